@@ -0,0 +1,516 @@
+// Package buffer computes buffer polygons of planar geometries.
+//
+// The buffer is computed by generating the raw offset curve of every input component,
+// noding all curves together and keeping the noded edges which separate
+// a region covered by the curves (winding number >= 1) from an uncovered one.
+// The kept edges are finally assembled into shells and holes.
+package buffer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+	"github.com/spatial-go/geoos/algorithm/topology"
+	"github.com/spatial-go/geoos/common"
+)
+
+// DefaultQuadSegs is the default number of segments used to approximate a quarter circle.
+const DefaultQuadSegs = common.DefaultQuadSegs
+
+// Builder computes the buffer of a set of geometry components.
+type Builder struct {
+	distance float64
+	params   Params
+
+	curves [][]matrix.LineMatrix
+	extent float64
+}
+
+// NewBuilder returns a Builder which buffers by distance using quadsegs
+// segments to approximate a quarter circle.
+func NewBuilder(distance float64, quadsegs int) *Builder {
+	return NewBuilderWithParams(distance, DefaultParams(quadsegs))
+}
+
+// NewBuilderWithParams returns a Builder which buffers by distance according to params,
+// of which the zero values are replaced by the defaults.
+func NewBuilderWithParams(distance float64, params Params) *Builder {
+	return &Builder{distance: distance, params: params.Normalize()}
+}
+
+// AddPoint adds a point to buffer.
+func (b *Builder) AddPoint(p matrix.Matrix) {
+	if b.distance <= 0 || len(p) < 2 {
+		return
+	}
+	gen := newOffsetGenerator(b.distance, b.params)
+	curve := gen.pointCurve(p)
+	if curve == nil {
+		return
+	}
+	b.extendTo(matrix.LineMatrix{p})
+	b.curves = append(b.curves, []matrix.LineMatrix{curve})
+}
+
+// AddLine adds a linestring to buffer.
+// With single sided buffers the line is buffered on its left side for a positive distance
+// and on its right side for a negative one.
+func (b *Builder) AddLine(line matrix.LineMatrix) {
+	if b.distance == 0 || (b.distance < 0 && !b.params.SingleSided) {
+		return
+	}
+	pts := removeRepeatedPoints(line)
+	if len(pts) == 0 {
+		return
+	}
+	if len(pts) == 1 {
+		b.AddPoint(pts[0])
+		return
+	}
+	b.extendTo(pts)
+	if b.params.SingleSided {
+		gen, side := newOffsetGenerator(b.distance, b.params), sideLeft
+		if b.distance < 0 {
+			gen, side = newOffsetGenerator(-b.distance, b.params), sideRight
+		}
+		b.curves = append(b.curves, []matrix.LineMatrix{gen.singleSidedLineCurve(pts, side)})
+		return
+	}
+	gen := newOffsetGenerator(b.distance, b.params)
+	b.curves = append(b.curves, []matrix.LineMatrix{gen.lineCurve(pts)})
+}
+
+// AddPolygon adds a polygon to buffer.
+func (b *Builder) AddPolygon(polygon matrix.PolygonMatrix) {
+	if len(polygon) == 0 {
+		return
+	}
+	offsetDistance, side := b.distance, sideRight
+	if b.distance < 0 {
+		offsetDistance, side = -b.distance, sideLeft
+	}
+
+	shell := removeRepeatedPoints(polygon[0])
+	// optimization - don't bother computing buffer if the polygon would be completely eroded
+	if b.distance < 0 && isErodedCompletely(shell, b.distance) {
+		return
+	}
+	if len(shell) < 4 {
+		// don't attempt to buffer a polygon with too few distinct vertices
+		if b.distance > 0 {
+			b.AddLine(shell)
+		}
+		return
+	}
+	b.extendTo(shell)
+	gen := newOffsetGenerator(offsetDistance, b.params)
+	// the shell is offset counter clockwise on its exterior side,
+	// the holes are offset clockwise on their interior side.
+	curves := []matrix.LineMatrix{gen.ringCurve(orientRing(shell, true), side)}
+	for _, h := range polygon[1:] {
+		hole := removeRepeatedPoints(h)
+		if len(hole) < 4 {
+			continue
+		}
+		if b.distance > 0 && isErodedCompletely(hole, -b.distance) {
+			continue
+		}
+		curves = append(curves, gen.ringCurve(orientRing(hole, false), side))
+	}
+	b.curves = append(b.curves, curves)
+}
+
+// Build computes the buffer of all added components.
+// Shells of the result are clockwise and holes counter clockwise.
+func (b *Builder) Build() matrix.MultiPolygonMatrix {
+	n := &topology.Noder{Tolerance: b.extent * topology.SnapToleranceFactor}
+	for _, curves := range b.curves {
+		for _, c := range curves {
+			addCurve(n, c)
+		}
+	}
+	edges := nodeEdges(n)
+	if len(edges) == 0 {
+		return nil
+	}
+	boundary := selectBoundary(edges)
+	rings := buildRings(boundary)
+	return assemblePolygons(rings)
+}
+
+// extendTo grows the extent of the input used to derive snapping tolerances.
+func (b *Builder) extendTo(pts matrix.LineMatrix) {
+	for _, p := range pts {
+		b.extent = math.Max(b.extent, math.Max(math.Abs(p[0]), math.Abs(p[1]))+math.Abs(b.distance))
+	}
+}
+
+// selectBoundary returns the edges separating covered from uncovered regions,
+// directed so that the covered region lies on their left.
+func selectBoundary(edges []edge) []edge {
+	idx := newWindingIndex(edges)
+	var boundary []edge
+	for i, e := range edges {
+		mx, my := (e.start[0]+e.end[0])/2, (e.start[1]+e.end[1])/2
+		dx, dy := e.end[0]-e.start[0], e.end[1]-e.start[1]
+		// the ray leaves the edge on its left side, along the axis closest to the left normal (-dy, dx)
+		var ray int
+		switch {
+		case math.Abs(dy) >= math.Abs(dx) && dy > 0:
+			ray = rayMinusX
+		case math.Abs(dy) >= math.Abs(dx):
+			ray = rayPlusX
+		case dx > 0:
+			ray = rayPlusY
+		default:
+			ray = rayMinusY
+		}
+		left := idx.winding(mx, my, i, ray)
+		right := left - e.weight
+		inLeft, inRight := left >= 1, right >= 1
+		if inLeft && !inRight {
+			boundary = append(boundary, edge{start: e.start, end: e.end})
+		} else if inRight && !inLeft {
+			boundary = append(boundary, edge{start: e.end, end: e.start})
+		}
+	}
+	return boundary
+}
+
+// directions of the rays used to compute winding numbers.
+const (
+	rayPlusX = iota
+	rayMinusX
+	rayPlusY
+	rayMinusY
+)
+
+// windingIndex buckets edges by x and by y to speed up winding number computation.
+type windingIndex struct {
+	edges    []edge
+	byX, byY *bucketIndex
+}
+
+// newWindingIndex creates a windingIndex over edges.
+func newWindingIndex(edges []edge) *windingIndex {
+	return &windingIndex{
+		edges: edges,
+		byX:   newBucketIndex(edges, 0),
+		byY:   newBucketIndex(edges, 1),
+	}
+}
+
+// winding computes the winding number of a point next to (x, y), by counting the signed
+// crossings of the edges other than skip with an axis parallel ray starting at (x, y).
+// Vertices lying on the line of the ray are taken to lie on its positive side.
+func (idx *windingIndex) winding(x, y float64, skip int, ray int) int {
+	axis, across := 1, y
+	buckets := idx.byY
+	if ray == rayPlusY || ray == rayMinusY {
+		axis, across = 0, x
+		buckets = idx.byX
+	}
+	p := topology.Vertex{x, y}
+	wn := 0
+	for _, i := range buckets.query(across) {
+		if i == skip {
+			continue
+		}
+		e := idx.edges[i]
+		if e.weight == 0 {
+			continue
+		}
+		lo, hi := e.start, e.end
+		if lo[axis] > hi[axis] {
+			lo, hi = hi, lo
+		}
+		if !(lo[axis] <= across && across < hi[axis]) {
+			continue
+		}
+		// o > 0 if p lies left of lo-hi, i.e. the crossing is in the positive direction
+		// of the other axis for an x ray and in the negative direction for a y ray.
+		o := topology.Orient(lo, hi, p)
+		var crosses bool
+		switch ray {
+		case rayPlusX, rayMinusY:
+			crosses = o > 0
+		default:
+			crosses = o < 0
+		}
+		if !crosses {
+			continue
+		}
+		// the sign of the crossing is the sign of the cross product of ray and edge directions
+		dx, dy := e.end[0]-e.start[0], e.end[1]-e.start[1]
+		var cross float64
+		switch ray {
+		case rayPlusX:
+			cross = dy
+		case rayMinusX:
+			cross = -dy
+		case rayPlusY:
+			cross = -dx
+		default:
+			cross = dx
+		}
+		if cross > 0 {
+			wn += e.weight
+		} else {
+			wn -= e.weight
+		}
+	}
+	return wn
+}
+
+// bucketIndex buckets edges by their extent along one axis.
+type bucketIndex struct {
+	min, width float64
+	buckets    [][]int
+}
+
+// newBucketIndex creates a bucketIndex over the extent of edges along axis.
+func newBucketIndex(edges []edge, axis int) *bucketIndex {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, e := range edges {
+		min = math.Min(min, math.Min(e.start[axis], e.end[axis]))
+		max = math.Max(max, math.Max(e.start[axis], e.end[axis]))
+	}
+	n := int(math.Sqrt(float64(len(edges)))) + 1
+	idx := &bucketIndex{min: min, width: (max - min) / float64(n)}
+	if !(idx.width > 0) {
+		n = 1
+		idx.width = 1
+	}
+	idx.buckets = make([][]int, n)
+	for i, e := range edges {
+		lo := idx.bucket(math.Min(e.start[axis], e.end[axis]))
+		hi := idx.bucket(math.Max(e.start[axis], e.end[axis]))
+		for k := lo; k <= hi; k++ {
+			idx.buckets[k] = append(idx.buckets[k], i)
+		}
+	}
+	return idx
+}
+
+// bucket returns the bucket containing v.
+func (idx *bucketIndex) bucket(v float64) int {
+	k := int((v - idx.min) / idx.width)
+	if k < 0 {
+		return 0
+	}
+	if k >= len(idx.buckets) {
+		return len(idx.buckets) - 1
+	}
+	return k
+}
+
+// query returns the edges whose extent may contain v.
+func (idx *bucketIndex) query(v float64) []int {
+	return idx.buckets[idx.bucket(v)]
+}
+
+// buildRings links boundary edges into closed rings, keeping the covered region on the left.
+// At nodes with several outgoing edges the tightest turn is taken, so that rings
+// touching at a point are kept apart.
+func buildRings(boundary []edge) []matrix.LineMatrix {
+	outgoing := map[topology.Vertex][]int{}
+	for i, e := range boundary {
+		outgoing[e.start] = append(outgoing[e.start], i)
+	}
+	used := make([]bool, len(boundary))
+	var rings []matrix.LineMatrix
+	for i := range boundary {
+		if used[i] {
+			continue
+		}
+		start := boundary[i].start
+		ring := matrix.LineMatrix{{start[0], start[1]}}
+		cur := i
+		closed := false
+		for steps := 0; steps <= len(boundary); steps++ {
+			used[cur] = true
+			e := boundary[cur]
+			ring = append(ring, matrix.Matrix{e.end[0], e.end[1]})
+			if e.end == start {
+				closed = true
+				break
+			}
+			next := nextEdge(boundary, outgoing[e.end], used, e)
+			if next < 0 {
+				break
+			}
+			cur = next
+		}
+		if closed && len(ring) >= 4 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// nextEdge returns the unused edge leaving the end of in which is
+// first clockwise from the reverse of in.
+func nextEdge(boundary []edge, candidates []int, used []bool, in edge) int {
+	back := math.Atan2(in.start[1]-in.end[1], in.start[0]-in.end[0])
+	best, bestAngle := -1, math.Inf(1)
+	for _, c := range candidates {
+		if used[c] {
+			continue
+		}
+		out := boundary[c]
+		angle := back - math.Atan2(out.end[1]-out.start[1], out.end[0]-out.start[0])
+		for angle <= 0 {
+			angle += 2 * math.Pi
+		}
+		for angle > 2*math.Pi {
+			angle -= 2 * math.Pi
+		}
+		if angle < bestAngle {
+			best, bestAngle = c, angle
+		}
+	}
+	return best
+}
+
+// assemblePolygons assigns every hole to the smallest shell containing it.
+// Rings are counter clockwise shells or clockwise holes on input and are reversed
+// on output.
+func assemblePolygons(rings []matrix.LineMatrix) matrix.MultiPolygonMatrix {
+	type shell struct {
+		ring  matrix.LineMatrix
+		area  float64
+		holes []matrix.LineMatrix
+	}
+	var shells []*shell
+	var holes []matrix.LineMatrix
+	for _, r := range rings {
+		area := measure.AreaDirection(r)
+		// AreaDirection is negative for counter clockwise rings
+		if area < 0 {
+			shells = append(shells, &shell{ring: r, area: -area})
+		} else if area > 0 {
+			holes = append(holes, r)
+		}
+	}
+	sort.Slice(shells, func(i, j int) bool { return shells[i].area < shells[j].area })
+	for _, h := range holes {
+		for _, s := range shells {
+			if ringContainsRing(s.ring, h) {
+				s.holes = append(s.holes, h)
+				break
+			}
+		}
+	}
+	// larger shells first
+	result := make(matrix.MultiPolygonMatrix, 0, len(shells))
+	for i := len(shells) - 1; i >= 0; i-- {
+		s := shells[i]
+		polygon := matrix.PolygonMatrix{reverse(s.ring)}
+		for _, h := range s.holes {
+			polygon = append(polygon, reverse(h))
+		}
+		result = append(result, polygon)
+	}
+	return result
+}
+
+// ringContainsRing returns true if the first vertex of inner not on the boundary of ring lies inside it.
+func ringContainsRing(ring, inner matrix.LineMatrix) bool {
+	for _, p := range inner {
+		switch locateInRing(ring, p) {
+		case locationInterior:
+			return true
+		case locationExterior:
+			return false
+		}
+	}
+	return false
+}
+
+// locations of a point relative to a ring.
+const (
+	locationInterior = iota
+	locationBoundary
+	locationExterior
+)
+
+// locateInRing determines the location of p relative to a closed ring.
+func locateInRing(ring matrix.LineMatrix, p matrix.Matrix) int {
+	crossings := 0
+	for i := 0; i < len(ring)-1; i++ {
+		a, b := ring[i], ring[i+1]
+		if measure.DistanceSegmentToPoint(p, a, b, measure.PlanarDistance) == 0 {
+			return locationBoundary
+		}
+		if (a[1] > p[1]) != (b[1] > p[1]) {
+			x := a[0] + (p[1]-a[1])*(b[0]-a[0])/(b[1]-a[1])
+			if x > p[0] {
+				crossings++
+			}
+		}
+	}
+	if crossings%2 == 1 {
+		return locationInterior
+	}
+	return locationExterior
+}
+
+// orientRing returns the ring oriented counter clockwise if ccw is true, else clockwise.
+func orientRing(ring matrix.LineMatrix, ccw bool) matrix.LineMatrix {
+	// AreaDirection is negative for counter clockwise rings
+	if (measure.AreaDirection(ring) < 0) == ccw {
+		return ring
+	}
+	return reverse(ring)
+}
+
+// reverse returns the points in reverse order.
+func reverse(pts matrix.LineMatrix) matrix.LineMatrix {
+	r := make(matrix.LineMatrix, len(pts))
+	for i, p := range pts {
+		r[len(pts)-1-i] = p
+	}
+	return r
+}
+
+// removeRepeatedPoints returns the points without consecutive duplicates.
+func removeRepeatedPoints(pts matrix.LineMatrix) matrix.LineMatrix {
+	var r matrix.LineMatrix
+	for _, p := range pts {
+		if len(r) > 0 && matrix.Equal(r[len(r)-1], p) {
+			continue
+		}
+		r = append(r, p)
+	}
+	return r
+}
+
+// isErodedCompletely tests whether a ring would be eroded completely by a negative buffer distance.
+func isErodedCompletely(ring matrix.LineMatrix, bufferDistance float64) bool {
+	if len(ring) < 4 {
+		return bufferDistance < 0
+	}
+	// triangles are eroded if the buffer distance exceeds their inradius
+	if len(ring) == 4 {
+		a, b, c := ring[0], ring[1], ring[2]
+		lenA, lenB, lenC := distance(b, c), distance(a, c), distance(a, b)
+		perimeter := lenA + lenB + lenC
+		if perimeter == 0 {
+			return bufferDistance < 0
+		}
+		inCentre := matrix.Matrix{
+			(lenA*a[0] + lenB*b[0] + lenC*c[0]) / perimeter,
+			(lenA*a[1] + lenB*b[1] + lenC*c[1]) / perimeter,
+		}
+		return measure.DistanceSegmentToPoint(inCentre, a, b, measure.PlanarDistance) < math.Abs(bufferDistance)
+	}
+	minX, minY, maxX, maxY := ring[0][0], ring[0][1], ring[0][0], ring[0][1]
+	for _, p := range ring {
+		minX, maxX = math.Min(minX, p[0]), math.Max(maxX, p[0])
+		minY, maxY = math.Min(minY, p[1]), math.Max(maxY, p[1])
+	}
+	envMinDimension := math.Min(maxX-minX, maxY-minY)
+	return bufferDistance < 0 && 2*math.Abs(bufferDistance) > envMinDimension
+}
@@ -0,0 +1,165 @@
+package buffer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+)
+
+// circleArea is the area of a unit circle approximated with 8 segments per quadrant.
+var circleArea = 16 * math.Sin(math.Pi/16)
+
+func TestBuilder_Build(t *testing.T) {
+	square := matrix.PolygonMatrix{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}
+	squareWithHole := matrix.PolygonMatrix{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{4, 4}, {4, 6}, {6, 6}, {6, 4}, {4, 4}},
+	}
+	zigzag := matrix.LineMatrix{}
+	for i := 0; i < 200; i++ {
+		zigzag = append(zigzag, []float64{float64(i), 0.3 * float64(i%2)})
+	}
+
+	type args struct {
+		distance float64
+		add      func(b *Builder)
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantArea  float64
+	}{
+		{name: "point", args: args{distance: 1, add: func(b *Builder) { b.AddPoint(matrix.Matrix{1, 1}) }},
+			wantCount: 1, wantArea: circleArea},
+		{name: "point negative", args: args{distance: -1, add: func(b *Builder) { b.AddPoint(matrix.Matrix{1, 1}) }},
+			wantCount: 0, wantArea: 0},
+		{name: "separate points", args: args{distance: 1, add: func(b *Builder) {
+			b.AddPoint(matrix.Matrix{0, 0})
+			b.AddPoint(matrix.Matrix{10, 0})
+		}}, wantCount: 2, wantArea: 2 * circleArea},
+		{name: "line", args: args{distance: 1, add: func(b *Builder) { b.AddLine(matrix.LineMatrix{{0, 0}, {10, 0}}) }},
+			wantCount: 1, wantArea: 20 + circleArea},
+		{name: "line repeated points", args: args{distance: 1, add: func(b *Builder) {
+			b.AddLine(matrix.LineMatrix{{0, 0}, {0, 0}, {10, 0}, {10, 0}})
+		}}, wantCount: 1, wantArea: 20 + circleArea},
+		{name: "zigzag line", args: args{distance: 2, add: func(b *Builder) { b.AddLine(zigzag) }},
+			wantCount: 1},
+		{name: "polygon", args: args{distance: 1, add: func(b *Builder) { b.AddPolygon(square) }},
+			wantCount: 1, wantArea: 140 + circleArea},
+		{name: "polygon negative", args: args{distance: -1, add: func(b *Builder) { b.AddPolygon(square) }},
+			wantCount: 1, wantArea: 64},
+		{name: "polygon eroded", args: args{distance: -6, add: func(b *Builder) { b.AddPolygon(square) }},
+			wantCount: 0, wantArea: 0},
+		{name: "polygon with hole", args: args{distance: 0.5, add: func(b *Builder) { b.AddPolygon(squareWithHole) }},
+			wantCount: 1, wantArea: 120 + circleArea/4 - 1},
+		{name: "polygon with filled hole", args: args{distance: 1, add: func(b *Builder) { b.AddPolygon(squareWithHole) }},
+			wantCount: 1, wantArea: 140 + circleArea},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder(tt.args.distance, DefaultQuadSegs)
+			tt.args.add(b)
+			got := b.Build()
+			if len(got) != tt.wantCount {
+				t.Fatalf("Build() got %d polygons, want %d", len(got), tt.wantCount)
+			}
+			for _, polygon := range got {
+				if measure.AreaDirection(polygon[0]) <= 0 {
+					t.Errorf("Build() shell %v is not clockwise", polygon[0])
+				}
+				for _, hole := range polygon[1:] {
+					if measure.AreaDirection(hole) >= 0 {
+						t.Errorf("Build() hole %v is not counter-clockwise", hole)
+					}
+				}
+			}
+			if tt.wantArea == 0 {
+				return
+			}
+			if area := measure.AreaOfMultiPolygon(got); math.Abs(area-tt.wantArea) > 1e-9 {
+				t.Errorf("Build() area = %v, want %v", area, tt.wantArea)
+			}
+		})
+	}
+}
+
+func TestBuilder_BuildWithParams(t *testing.T) {
+	line := matrix.LineMatrix{{0, 0}, {10, 0}}
+	corner := matrix.LineMatrix{{0, 0}, {10, 0}, {10, 10}}
+	square := matrix.PolygonMatrix{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}
+	params := func(endCapStyle, joinStyle int, mitreLimit float64, singleSided bool) Params {
+		return Params{QuadSegs: DefaultQuadSegs, EndCapStyle: endCapStyle, JoinStyle: joinStyle,
+			MitreLimit: mitreLimit, SingleSided: singleSided}
+	}
+	type args struct {
+		distance float64
+		params   Params
+		add      func(b *Builder)
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantArea  float64
+		wantMinY  float64
+		wantMaxY  float64
+	}{
+		{name: "point square cap", args: args{distance: 1, params: params(CapSquare, JoinRound, DefaultMitreLimit, false),
+			add: func(b *Builder) { b.AddPoint(matrix.Matrix{0, 0}) }}, wantCount: 1, wantArea: 4, wantMinY: -1, wantMaxY: 1},
+		{name: "point flat cap", args: args{distance: 1, params: params(CapFlat, JoinRound, DefaultMitreLimit, false),
+			add: func(b *Builder) { b.AddPoint(matrix.Matrix{0, 0}) }}, wantCount: 0},
+		{name: "line flat cap", args: args{distance: 1, params: params(CapFlat, JoinRound, DefaultMitreLimit, false),
+			add: func(b *Builder) { b.AddLine(line) }}, wantCount: 1, wantArea: 20, wantMinY: -1, wantMaxY: 1},
+		{name: "line square cap", args: args{distance: 1, params: params(CapSquare, JoinRound, DefaultMitreLimit, false),
+			add: func(b *Builder) { b.AddLine(line) }}, wantCount: 1, wantArea: 24, wantMinY: -1, wantMaxY: 1},
+		{name: "corner round join", args: args{distance: 1, params: params(CapFlat, JoinRound, DefaultMitreLimit, false),
+			add: func(b *Builder) { b.AddLine(corner) }}, wantCount: 1, wantArea: 39 + circleArea/4, wantMinY: -1, wantMaxY: 10},
+		{name: "corner mitre join", args: args{distance: 1, params: params(CapFlat, JoinMitre, DefaultMitreLimit, false),
+			add: func(b *Builder) { b.AddLine(corner) }}, wantCount: 1, wantArea: 40, wantMinY: -1, wantMaxY: 10},
+		{name: "corner limited mitre join", args: args{distance: 1, params: params(CapFlat, JoinMitre, 1.2, false),
+			add: func(b *Builder) { b.AddLine(corner) }}, wantCount: 1, wantArea: 40 - (math.Sqrt2-1.2)*(math.Sqrt2-1.2),
+			wantMinY: -1, wantMaxY: 10},
+		{name: "corner bevel join", args: args{distance: 1, params: params(CapFlat, JoinBevel, DefaultMitreLimit, false),
+			add: func(b *Builder) { b.AddLine(corner) }}, wantCount: 1, wantArea: 39.5, wantMinY: -1, wantMaxY: 10},
+		{name: "line left side", args: args{distance: 1, params: params(CapRound, JoinRound, DefaultMitreLimit, true),
+			add: func(b *Builder) { b.AddLine(line) }}, wantCount: 1, wantArea: 10, wantMinY: 0, wantMaxY: 1},
+		{name: "line right side", args: args{distance: -1, params: params(CapRound, JoinRound, DefaultMitreLimit, true),
+			add: func(b *Builder) { b.AddLine(line) }}, wantCount: 1, wantArea: 10, wantMinY: -1, wantMaxY: 0},
+		{name: "corner left side", args: args{distance: 1, params: params(CapRound, JoinRound, DefaultMitreLimit, true),
+			add: func(b *Builder) { b.AddLine(corner) }}, wantCount: 1, wantArea: 19, wantMinY: 0, wantMaxY: 10},
+		{name: "corner right side", args: args{distance: -1, params: params(CapRound, JoinRound, DefaultMitreLimit, true),
+			add: func(b *Builder) { b.AddLine(corner) }}, wantCount: 1, wantArea: 20 + circleArea/4, wantMinY: -1, wantMaxY: 10},
+		{name: "polygon mitre join", args: args{distance: 1, params: params(CapRound, JoinMitre, DefaultMitreLimit, false),
+			add: func(b *Builder) { b.AddPolygon(square) }}, wantCount: 1, wantArea: 144, wantMinY: -1, wantMaxY: 11},
+		{name: "polygon bevel join", args: args{distance: 1, params: params(CapRound, JoinBevel, DefaultMitreLimit, false),
+			add: func(b *Builder) { b.AddPolygon(square) }}, wantCount: 1, wantArea: 142, wantMinY: -1, wantMaxY: 11},
+		{name: "zero params", args: args{distance: 1, params: Params{},
+			add: func(b *Builder) { b.AddLine(line) }}, wantCount: 1, wantArea: 20 + circleArea, wantMinY: -1, wantMaxY: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilderWithParams(tt.args.distance, tt.args.params)
+			tt.args.add(b)
+			got := b.Build()
+			if len(got) != tt.wantCount {
+				t.Fatalf("Build() got %d polygons, want %d", len(got), tt.wantCount)
+			}
+			if tt.wantCount == 0 {
+				return
+			}
+			if area := measure.AreaOfMultiPolygon(got); math.Abs(area-tt.wantArea) > 1e-9 {
+				t.Errorf("Build() area = %v, want %v", area, tt.wantArea)
+			}
+			minY, maxY := math.Inf(1), math.Inf(-1)
+			for _, p := range got[0][0] {
+				minY, maxY = math.Min(minY, p[1]), math.Max(maxY, p[1])
+			}
+			if math.Abs(minY-tt.wantMinY) > 1e-9 || math.Abs(maxY-tt.wantMaxY) > 1e-9 {
+				t.Errorf("Build() y extent = [%v %v], want [%v %v]", minY, maxY, tt.wantMinY, tt.wantMaxY)
+			}
+		})
+	}
+}
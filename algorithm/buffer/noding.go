@@ -0,0 +1,48 @@
+package buffer
+
+import (
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/topology"
+)
+
+// edge is a directed segment of the noded curves, weighted with
+// the number of curves running along it in its direction.
+type edge struct {
+	start, end topology.Vertex
+	weight     int
+}
+
+// addCurve adds the segments of a closed curve to the noder.
+func addCurve(n *topology.Noder, pts matrix.LineMatrix) {
+	for i := 0; i < len(pts)-1; i++ {
+		n.AddSegment(topology.Vertex{pts[i][0], pts[i][1]}, topology.Vertex{pts[i+1][0], pts[i+1][1]}, 0, 0)
+	}
+}
+
+// nodeEdges returns the noded segments of the curves, merging coincident segments into one weighted edge.
+func nodeEdges(n *topology.Noder) []edge {
+	merged := map[[2]topology.Vertex]int{}
+	var keys [][2]topology.Vertex
+	for _, pts := range n.Node() {
+		for k := 0; k < len(pts)-1; k++ {
+			p, q := pts[k], pts[k+1]
+			if p == q {
+				continue
+			}
+			key, w := [2]topology.Vertex{p, q}, 1
+			if topology.Less(q, p) {
+				key, w = [2]topology.Vertex{q, p}, -1
+			}
+			if _, ok := merged[key]; !ok {
+				keys = append(keys, key)
+			}
+			merged[key] += w
+		}
+	}
+
+	edges := make([]edge, 0, len(keys))
+	for _, k := range keys {
+		edges = append(edges, edge{start: k[0], end: k[1], weight: merged[k]})
+	}
+	return edges
+}
@@ -0,0 +1,448 @@
+package buffer
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+)
+
+const (
+	// offsetSegmentSeparationFactor is the factor which controls how close offset segments can be to
+	// skip adding a fillet or mitre.
+	offsetSegmentSeparationFactor = 1.0e-3
+
+	// insideTurnVertexSnapDistanceFactor is the factor which controls how close curve vertices
+	// on inside turns can be to be snapped.
+	insideTurnVertexSnapDistanceFactor = 1.0e-3
+)
+
+// orientation values of a turn.
+const (
+	clockwise        = -1
+	collinear        = 0
+	counterClockwise = 1
+)
+
+// side of a segment on which the offset curve is computed.
+const (
+	sideLeft  = 1
+	sideRight = -1
+)
+
+// orientationIndex returns the orientation of q relative to the directed segment p1-p2.
+func orientationIndex(p1, p2, q matrix.Matrix) int {
+	dx1 := p2[0] - p1[0]
+	dy1 := p2[1] - p1[1]
+	dx2 := q[0] - p2[0]
+	dy2 := q[1] - p2[1]
+	det := dx1*dy2 - dy1*dx2
+	if det > 0 {
+		return counterClockwise
+	}
+	if det < 0 {
+		return clockwise
+	}
+	return collinear
+}
+
+// segment is a line segment of the offset curve.
+type segment struct {
+	p0, p1 matrix.Matrix
+}
+
+// offsetSegment computes the segment parallel to p0-p1 at distance on the given side.
+func offsetSegment(p0, p1 matrix.Matrix, side int, distance float64) segment {
+	dx := p1[0] - p0[0]
+	dy := p1[1] - p0[1]
+	length := math.Sqrt(dx*dx + dy*dy)
+	// u is the vector that is the length of the offset, in the direction of the segment
+	ux := float64(side) * distance * dx / length
+	uy := float64(side) * distance * dy / length
+	return segment{
+		p0: matrix.Matrix{p0[0] - uy, p0[1] + ux},
+		p1: matrix.Matrix{p1[0] - uy, p1[1] + ux},
+	}
+}
+
+// offsetGenerator generates the raw offset curve of a sequence of points.
+type offsetGenerator struct {
+	distance           float64
+	params             Params
+	filletAngleQuantum float64
+
+	pts matrix.LineMatrix
+
+	side               int
+	s0, s1, s2         matrix.Matrix
+	offset0, offset1   segment
+	minimumVertexDelta float64
+}
+
+// newOffsetGenerator returns a offsetGenerator which offsets at distance,
+// shaping caps and joins according to params.
+func newOffsetGenerator(distance float64, params Params) *offsetGenerator {
+	return &offsetGenerator{
+		distance:           distance,
+		params:             params,
+		filletAngleQuantum: math.Pi / 2.0 / float64(params.QuadSegs),
+		minimumVertexDelta: distance * 1.0e-6,
+	}
+}
+
+// addPt appends a point to the curve, skipping points which repeat the previous one.
+func (o *offsetGenerator) addPt(p matrix.Matrix) {
+	pt := matrix.Matrix{p[0], p[1]}
+	if n := len(o.pts); n > 0 {
+		last := o.pts[n-1]
+		if math.Abs(last[0]-pt[0]) <= o.minimumVertexDelta && math.Abs(last[1]-pt[1]) <= o.minimumVertexDelta {
+			return
+		}
+	}
+	o.pts = append(o.pts, pt)
+}
+
+// closeRing closes the generated curve.
+func (o *offsetGenerator) closeRing() {
+	if len(o.pts) < 1 {
+		return
+	}
+	first, last := o.pts[0], o.pts[len(o.pts)-1]
+	if first[0] == last[0] && first[1] == last[1] {
+		return
+	}
+	o.pts = append(o.pts, matrix.Matrix{first[0], first[1]})
+}
+
+// curve returns the generated curve and resets the generator.
+func (o *offsetGenerator) curve() matrix.LineMatrix {
+	pts := o.pts
+	o.pts = nil
+	return pts
+}
+
+// initSideSegments starts a new side with the segment s1-s2.
+func (o *offsetGenerator) initSideSegments(s1, s2 matrix.Matrix, side int) {
+	o.s1 = s1
+	o.s2 = s2
+	o.side = side
+	o.offset1 = offsetSegment(s1, s2, side, o.distance)
+}
+
+// addFirstSegment adds the start point of the current offset segment.
+func (o *offsetGenerator) addFirstSegment() {
+	o.addPt(o.offset1.p0)
+}
+
+// addLastSegment adds the end point of the current offset segment.
+func (o *offsetGenerator) addLastSegment() {
+	o.addPt(o.offset1.p1)
+}
+
+// addNextSegment adds the offset points for the join at s2 and advances to the segment s2-p.
+func (o *offsetGenerator) addNextSegment(p matrix.Matrix, addStartPoint bool) {
+	o.s0 = o.s1
+	o.s1 = o.s2
+	o.s2 = p
+	o.offset0 = offsetSegment(o.s0, o.s1, o.side, o.distance)
+	o.offset1 = offsetSegment(o.s1, o.s2, o.side, o.distance)
+
+	if matrix.Equal(o.s1, o.s2) {
+		return
+	}
+	orientation := orientationIndex(o.s0, o.s1, o.s2)
+	outsideTurn := (orientation == clockwise && o.side == sideLeft) ||
+		(orientation == counterClockwise && o.side == sideRight)
+
+	if orientation == collinear {
+		o.addCollinear(addStartPoint)
+	} else if outsideTurn {
+		o.addOutsideTurn(orientation, addStartPoint)
+	} else {
+		o.addInsideTurn()
+	}
+}
+
+// addCollinear adds the points for a join between collinear segments.
+// Only segments which reverse direction need a join.
+func (o *offsetGenerator) addCollinear(addStartPoint bool) {
+	dx0, dy0 := o.s1[0]-o.s0[0], o.s1[1]-o.s0[1]
+	dx1, dy1 := o.s2[0]-o.s1[0], o.s2[1]-o.s1[1]
+	if dx0*dx1+dy0*dy1 >= 0 {
+		return
+	}
+	if addStartPoint {
+		o.addPt(o.offset0.p1)
+	}
+	if o.params.JoinStyle == JoinBevel || o.params.JoinStyle == JoinMitre {
+		o.addPt(o.offset1.p0)
+		return
+	}
+	direction := clockwise
+	if o.side == sideRight {
+		direction = counterClockwise
+	}
+	o.addCornerFillet(o.s1, o.offset0.p1, o.offset1.p0, direction, o.distance)
+	o.addPt(o.offset1.p0)
+}
+
+// addOutsideTurn adds the points for a join on the convex side of a turn.
+func (o *offsetGenerator) addOutsideTurn(orientation int, addStartPoint bool) {
+	// Heuristic: If offset endpoints are very close together,
+	// just use one of them as the corner vertex.
+	if distance(o.offset0.p1, o.offset1.p0) < o.distance*offsetSegmentSeparationFactor {
+		o.addPt(o.offset0.p1)
+		return
+	}
+	switch o.params.JoinStyle {
+	case JoinMitre:
+		o.addMitreJoin(o.s1, o.offset0, o.offset1)
+	case JoinBevel:
+		o.addPt(o.offset0.p1)
+		o.addPt(o.offset1.p0)
+	default:
+		if addStartPoint {
+			o.addPt(o.offset0.p1)
+		}
+		o.addCornerFillet(o.s1, o.offset0.p1, o.offset1.p0, orientation, o.distance)
+		o.addPt(o.offset1.p0)
+	}
+}
+
+// addMitreJoin adds the points for a mitre join at the corner p.
+// Mitres longer than the mitre limit are cut off perpendicular to the bisector of the corner.
+func (o *offsetGenerator) addMitreJoin(p matrix.Matrix, offset0, offset1 segment) {
+	mitreLimitDistance := o.params.MitreLimit * o.distance
+	if ok, ip := lineIntersection(offset0.p0, offset0.p1, offset1.p0, offset1.p1); ok && distance(ip, p) <= mitreLimitDistance {
+		o.addPt(ip)
+		return
+	}
+	// b is the unit bisector of the corner, pointing away from it
+	bx := offset0.p1[0] + offset1.p0[0] - 2*p[0]
+	by := offset0.p1[1] + offset1.p0[1] - 2*p[1]
+	length := math.Sqrt(bx*bx + by*by)
+	u0x, u0y := offset0.p1[0]-offset0.p0[0], offset0.p1[1]-offset0.p0[1]
+	u1x, u1y := offset1.p1[0]-offset1.p0[0], offset1.p1[1]-offset1.p0[1]
+	if length == 0 {
+		bx, by, length = u0x, u0y, distance(offset0.p0, offset0.p1)
+	}
+	bx, by = bx/length, by/length
+
+	// the points where the offset lines cross the line cutting off the mitre
+	along0 := u0x*bx + u0y*by
+	along1 := u1x*bx + u1y*by
+	t0 := (mitreLimitDistance - (offset0.p1[0]-p[0])*bx - (offset0.p1[1]-p[1])*by) / along0
+	t1 := (mitreLimitDistance - (offset1.p0[0]-p[0])*bx - (offset1.p0[1]-p[1])*by) / -along1
+	o.addPt(offset0.p1)
+	if along0 > 0 && t0 > 0 && along1 < 0 && t1 > 0 {
+		o.addPt(matrix.Matrix{offset0.p1[0] + t0*u0x, offset0.p1[1] + t0*u0y})
+		o.addPt(matrix.Matrix{offset1.p0[0] - t1*u1x, offset1.p0[1] - t1*u1y})
+	}
+	o.addPt(offset1.p0)
+}
+
+// addInsideTurn adds the points for a join on the concave side of a turn.
+func (o *offsetGenerator) addInsideTurn() {
+	if ok, ip := segmentIntersection(o.offset0.p0, o.offset0.p1, o.offset1.p0, o.offset1.p1); ok {
+		o.addPt(ip)
+		return
+	}
+	// The offset segments do not intersect, so the turn is very narrow.
+	// The curve is routed through the vertex itself, which produces
+	// a loop that is removed by the winding rule.
+	if distance(o.offset0.p1, o.offset1.p0) < o.distance*insideTurnVertexSnapDistanceFactor {
+		o.addPt(o.offset0.p1)
+		return
+	}
+	o.addPt(o.offset0.p1)
+	o.addPt(o.s1)
+	o.addPt(o.offset1.p0)
+}
+
+// addCornerFillet adds points for a circular fillet around a reflex corner.
+// Adds the start and end points
+func (o *offsetGenerator) addCornerFillet(p, p0, p1 matrix.Matrix, direction int, radius float64) {
+	startAngle := math.Atan2(p0[1]-p[1], p0[0]-p[0])
+	endAngle := math.Atan2(p1[1]-p[1], p1[0]-p[0])
+	if direction == clockwise {
+		if startAngle <= endAngle {
+			startAngle += 2.0 * math.Pi
+		}
+	} else {
+		if startAngle >= endAngle {
+			startAngle -= 2.0 * math.Pi
+		}
+	}
+	o.addPt(p0)
+	o.addDirectedFillet(p, startAngle, endAngle, direction, radius)
+	o.addPt(p1)
+}
+
+// addDirectedFillet adds points for a circular fillet arc between two specified angles.
+// The start and end point for the fillet are not added - the caller must add them if required.
+func (o *offsetGenerator) addDirectedFillet(p matrix.Matrix, startAngle, endAngle float64, direction int, radius float64) {
+	directionFactor := 1.0
+	if direction == clockwise {
+		directionFactor = -1.0
+	}
+	totalAngle := math.Abs(startAngle - endAngle)
+	nSegs := int(totalAngle/o.filletAngleQuantum + 0.5)
+	if nSegs < 1 {
+		// no segments because angle is less than increment - nothing to do!
+		return
+	}
+	// choose angle increment so that each segment has equal length
+	angleInc := totalAngle / float64(nSegs)
+	for i := 0; i < nSegs; i++ {
+		angle := startAngle + directionFactor*float64(i)*angleInc
+		o.addPt(matrix.Matrix{p[0] + radius*math.Cos(angle), p[1] + radius*math.Sin(angle)})
+	}
+}
+
+// addLineEndCap adds an end cap at p1 for the line p0-p1.
+// The cap runs counter clockwise from the right side to the left side of the line.
+func (o *offsetGenerator) addLineEndCap(p0, p1 matrix.Matrix) {
+	offsetL := offsetSegment(p0, p1, sideLeft, o.distance)
+	offsetR := offsetSegment(p0, p1, sideRight, o.distance)
+	angle := math.Atan2(p1[1]-p0[1], p1[0]-p0[0])
+	switch o.params.EndCapStyle {
+	case CapFlat:
+		// only offset segment points are added
+		o.addPt(offsetR.p1)
+		o.addPt(offsetL.p1)
+	case CapSquare:
+		// add a square defined by extensions of the offset segment endpoints
+		dx, dy := o.distance*math.Cos(angle), o.distance*math.Sin(angle)
+		o.addPt(matrix.Matrix{offsetR.p1[0] + dx, offsetR.p1[1] + dy})
+		o.addPt(matrix.Matrix{offsetL.p1[0] + dx, offsetL.p1[1] + dy})
+	default:
+		o.addPt(offsetR.p1)
+		o.addDirectedFillet(p1, angle-math.Pi/2, angle+math.Pi/2, counterClockwise, o.distance)
+		o.addPt(offsetL.p1)
+	}
+}
+
+// pointCurve returns the closed curve around p, a circle for round end caps
+// and a square for square end caps. Points have no buffer with flat end caps.
+func (o *offsetGenerator) pointCurve(p matrix.Matrix) matrix.LineMatrix {
+	switch o.params.EndCapStyle {
+	case CapFlat:
+		return nil
+	case CapSquare:
+		o.addPt(matrix.Matrix{p[0] + o.distance, p[1] - o.distance})
+		o.addPt(matrix.Matrix{p[0] + o.distance, p[1] + o.distance})
+		o.addPt(matrix.Matrix{p[0] - o.distance, p[1] + o.distance})
+		o.addPt(matrix.Matrix{p[0] - o.distance, p[1] - o.distance})
+	default:
+		// add start point
+		o.addPt(matrix.Matrix{p[0] + o.distance, p[1]})
+		o.addDirectedFillet(p, 0.0, 2.0*math.Pi, counterClockwise, o.distance)
+	}
+	o.closeRing()
+	return o.curve()
+}
+
+// lineCurve returns the closed curve around the line, running along its right side
+// and back along its left side, so the curve is counter clockwise.
+func (o *offsetGenerator) lineCurve(pts matrix.LineMatrix) matrix.LineMatrix {
+	n := len(pts) - 1
+
+	// compute points for right side of line
+	o.initSideSegments(pts[0], pts[1], sideRight)
+	o.addFirstSegment()
+	for i := 2; i <= n; i++ {
+		o.addNextSegment(pts[i], true)
+	}
+	o.addLastSegment()
+	// add line cap for end of line
+	o.addLineEndCap(pts[n-1], pts[n])
+
+	// compute points for left side of line
+	o.initSideSegments(pts[n], pts[n-1], sideRight)
+	for i := n - 2; i >= 0; i-- {
+		o.addNextSegment(pts[i], true)
+	}
+	o.addLastSegment()
+	// add line cap for start of line
+	o.addLineEndCap(pts[1], pts[0])
+
+	o.closeRing()
+	return o.curve()
+}
+
+// singleSidedLineCurve returns the closed curve bounding the area between the line
+// and its offset on the given side, so the curve is counter clockwise.
+func (o *offsetGenerator) singleSidedLineCurve(pts matrix.LineMatrix, side int) matrix.LineMatrix {
+	n := len(pts) - 1
+	if side == sideLeft {
+		// add the line, then its left side backwards
+		for i := 0; i <= n; i++ {
+			o.addPt(pts[i])
+		}
+		o.initSideSegments(pts[n], pts[n-1], sideRight)
+		o.addFirstSegment()
+		for i := n - 2; i >= 0; i-- {
+			o.addNextSegment(pts[i], true)
+		}
+	} else {
+		// add the line backwards, then its right side
+		for i := n; i >= 0; i-- {
+			o.addPt(pts[i])
+		}
+		o.initSideSegments(pts[0], pts[1], sideRight)
+		o.addFirstSegment()
+		for i := 2; i <= n; i++ {
+			o.addNextSegment(pts[i], true)
+		}
+	}
+	o.addLastSegment()
+	o.closeRing()
+	return o.curve()
+}
+
+// ringCurve returns the offset curve of a closed ring on the given side.
+func (o *offsetGenerator) ringCurve(pts matrix.LineMatrix, side int) matrix.LineMatrix {
+	n := len(pts) - 1
+	o.initSideSegments(pts[n-1], pts[0], side)
+	for i := 1; i <= n; i++ {
+		o.addNextSegment(pts[i], i != 1)
+	}
+	o.closeRing()
+	return o.curve()
+}
+
+// distance returns the planar distance between p and q.
+func distance(p, q matrix.Matrix) float64 {
+	dx := p[0] - q[0]
+	dy := p[1] - q[1]
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// lineIntersection returns the intersection point of the lines through p1-p2 and q1-q2, if any.
+func lineIntersection(p1, p2, q1, q2 matrix.Matrix) (bool, matrix.Matrix) {
+	rx, ry := p2[0]-p1[0], p2[1]-p1[1]
+	sx, sy := q2[0]-q1[0], q2[1]-q1[1]
+	denom := rx*sy - ry*sx
+	if denom == 0 {
+		return false, nil
+	}
+	t := ((q1[0]-p1[0])*sy - (q1[1]-p1[1])*sx) / denom
+	return true, matrix.Matrix{p1[0] + t*rx, p1[1] + t*ry}
+}
+
+// segmentIntersection returns the intersection point of the segments p1-p2 and q1-q2, if any.
+// Collinear segments are reported as not intersecting.
+func segmentIntersection(p1, p2, q1, q2 matrix.Matrix) (bool, matrix.Matrix) {
+	rx, ry := p2[0]-p1[0], p2[1]-p1[1]
+	sx, sy := q2[0]-q1[0], q2[1]-q1[1]
+	denom := rx*sy - ry*sx
+	if denom == 0 {
+		return false, nil
+	}
+	qpx, qpy := q1[0]-p1[0], q1[1]-p1[1]
+	t := (qpx*sy - qpy*sx) / denom
+	u := (qpx*ry - qpy*rx) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return false, nil
+	}
+	return true, matrix.Matrix{p1[0] + t*rx, p1[1] + t*ry}
+}
@@ -0,0 +1,30 @@
+package buffer
+
+import "github.com/spatial-go/geoos/common"
+
+// End cap styles, specifying the shape of the buffer at the ends of lines.
+const (
+	CapRound  = common.CapRound
+	CapFlat   = common.CapFlat
+	CapSquare = common.CapSquare
+)
+
+// Join styles, specifying the shape of the buffer at the outside corners of lines and rings.
+const (
+	JoinRound = common.JoinRound
+	JoinMitre = common.JoinMitre
+	JoinBevel = common.JoinBevel
+)
+
+// DefaultMitreLimit is the default ratio of the mitre length to the buffer distance.
+const DefaultMitreLimit = common.DefaultMitreLimit
+
+// Params contains the parameters which control how a buffer is computed.
+// It is common.BufferParams, which the GEOS buffer takes as well.
+type Params = common.BufferParams
+
+// DefaultParams returns the default buffer parameters,
+// with round end caps and joins approximated by quadsegs segments per quarter circle.
+func DefaultParams(quadsegs int) Params {
+	return common.DefaultBufferParams(quadsegs)
+}
@@ -18,15 +18,10 @@ const (
 type Distance func(from, to matrix.Matrix) float64
 
 // SpheroidDistance Calculate distance, return unit: meter
+// It is the great circle distance on the sphere of radius R, by the haversine formula,
+// which is 0 for identical points and keeps its precision for close ones.
 func SpheroidDistance(from, to matrix.Matrix) float64 {
-	rad := math.Pi / 180.0
-	lat0 := from[1] * rad
-	lng0 := from[0] * rad
-	lat1 := to[1] * rad
-	lng1 := to[0] * rad
-	theta := lng1 - lng0
-	dist := math.Acos(math.Sin(lat0)*math.Sin(lat1) + math.Cos(lat0)*math.Cos(lat1)*math.Cos(theta))
-	return dist * R
+	return haversine(from, to, R)
 }
 
 // MercatorDistance scale factor is changed along the meridians as a function of latitude
@@ -90,12 +85,15 @@ func DistanceSegmentToPoint(p, a, b matrix.Matrix, f Distance) float64 {
 
 // DistanceLineToPoint Returns Distance of p,line
 func DistanceLineToPoint(line matrix.LineMatrix, pt matrix.Matrix, f Distance) (dist float64) {
-	for i, v := range line {
-		tmpDist := 0.0
-		if i < len(line)-1 {
-			tmpDist = DistanceSegmentToPoint(pt, v, line[i+1], f)
-		}
-		if dist > tmpDist {
+	switch len(line) {
+	case 0:
+		return 0
+	case 1:
+		return f(pt, line[0])
+	}
+	dist = math.MaxFloat64
+	for i := 0; i < len(line)-1; i++ {
+		if tmpDist := DistanceSegmentToPoint(pt, line[i], line[i+1], f); dist > tmpDist {
 			dist = tmpDist
 		}
 	}
@@ -104,7 +102,10 @@ func DistanceLineToPoint(line matrix.LineMatrix, pt matrix.Matrix, f Distance) (
 
 // DistancePolygonToPoint Returns Distance of p,polygon
 func DistancePolygonToPoint(poly matrix.PolygonMatrix, pt matrix.Matrix, f Distance) (dist float64) {
-
+	if len(poly) == 0 {
+		return 0
+	}
+	dist = math.MaxFloat64
 	for _, v := range poly {
 		tmpDist := DistanceLineToPoint(v, pt, f)
 		if dist > tmpDist {
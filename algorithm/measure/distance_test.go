@@ -1,6 +1,7 @@
 package measure
 
 import (
+	"math"
 	"testing"
 
 	"github.com/spatial-go/geoos/algorithm/matrix"
@@ -11,6 +12,7 @@ func TestDistance(t *testing.T) {
 	toPoint := matrix.Matrix{13, 15}
 
 	wantResult := 107405.96007592858
+	beijing := matrix.Matrix{116.4, 39.9}
 	type args struct {
 		fromPoint matrix.Matrix
 		toPoint   matrix.Matrix
@@ -21,15 +23,27 @@ func TestDistance(t *testing.T) {
 		want float64
 	}{
 		{name: "testDistance", args: args{fromPoint: fromPoint, toPoint: toPoint}, want: wantResult},
+		{name: "identical points", args: args{fromPoint: beijing, toPoint: beijing}, want: 0},
+		{name: "short distance", args: args{fromPoint: matrix.Matrix{10, 0}, toPoint: matrix.Matrix{10, 1e-6}},
+			want: R * math.Pi / 180 * 1e-6},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := SpheroidDistance(tt.args.fromPoint, tt.args.toPoint); got != tt.want {
+			if got := SpheroidDistance(tt.args.fromPoint, tt.args.toPoint); math.Abs(got-tt.want) > 1e-6 {
 				t.Errorf("Distance() = %v, want %v", got, tt.want)
 			}
 		})
 	}
+
+	for lon := -180.0; lon <= 180; lon += 0.5 {
+		for lat := -90.0; lat <= 90; lat += 0.5 {
+			if got := SpheroidDistance(matrix.Matrix{lon, lat}, matrix.Matrix{lon, lat}); got != 0 {
+				t.Fatalf("SpheroidDistance() of identical points %v %v = %v, want 0", lon, lat, got)
+			}
+		}
+	}
 }
+
 func TestMercatorDistance(t *testing.T) {
 	type args struct {
 		dis float64
@@ -0,0 +1,123 @@
+package measure
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+)
+
+const (
+	// WGS84SemiMajorAxis is the semi-major axis of the WGS84 ellipsoid, in meters.
+	WGS84SemiMajorAxis = 6378137.0
+	// WGS84Flattening is the flattening of the WGS84 ellipsoid.
+	WGS84Flattening = 1 / 298.257223563
+
+	// meanRadius is the mean radius of the WGS84 ellipsoid, in meters.
+	meanRadius = 6371008.8
+	// geodesicTolerance is the tolerance in radians of the iterations of the direct geodesic solution, about 6 um.
+	geodesicTolerance = 1e-12
+	// geodesicIterations is the maximum number of iterations of the direct geodesic solution.
+	geodesicIterations = 200
+)
+
+// GeodesicInverse returns the length in meters of the geodesic between two points on the WGS84 ellipsoid,
+// given by their longitudes and latitudes in degrees, its azimuth at the first point and its back azimuth,
+// the azimuth at the second point towards the first one. The azimuths are in degrees clockwise from the north,
+// in [0, 360), and 0 for coincident points.
+// It is solved by the method of Karney, accurate to 15 nanometers, which converges for any two points,
+// antipodal ones included.
+func GeodesicInverse(from, to matrix.Matrix) (distance, azimuth, backAzimuth float64) {
+	distance, azi1, azi2 := karneyInverse(from[1], from[0], to[1], to[0])
+	if distance == 0 {
+		return 0, 0, 0
+	}
+	return distance, normalizeAzimuth(azi1), normalizeAzimuth(azi2 + 180)
+}
+
+// GeodesicDirect returns the point reached on the WGS84 ellipsoid from a point, given by its longitude and
+// latitude in degrees, along the geodesic of an azimuth in degrees clockwise from the north for a distance
+// in meters, and the back azimuth at that point towards the first one.
+// It is solved by the iterations of Vincenty, which converge for any distance.
+func GeodesicDirect(from matrix.Matrix, azimuth, distance float64) (to matrix.Matrix, backAzimuth float64) {
+	const a, f = WGS84SemiMajorAxis, WGS84Flattening
+	const b = a * (1 - f)
+	rad := math.Pi / 180
+	sinAlpha1, cosAlpha1 := math.Sincos(azimuth * rad)
+	sinU1, cosU1 := reducedLatitude(from[1] * rad)
+
+	sigma1 := math.Atan2(sinU1, cosU1*cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cos2Alpha := 1 - sinAlpha*sinAlpha
+	u2 := cos2Alpha * (a*a - b*b) / (b * b)
+	bigA, bigB := vincentyCoefficients(u2)
+
+	sigma := distance / (b * bigA)
+	var sinSigma, cosSigma, cos2SigmaM float64
+	for i := 0; i < geodesicIterations; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+		prev := sigma
+		sigma = distance/(b*bigA) + vincentyDeltaSigma(bigB, sinSigma, cosSigma, cos2SigmaM)
+		if math.Abs(sigma-prev) < geodesicTolerance {
+			break
+		}
+	}
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+	sinSigma, cosSigma = math.Sincos(sigma)
+
+	x := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-f)*math.Hypot(sinAlpha, x))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	c := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+	l := lambda - (1-c)*f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+	lon := math.Remainder(from[0]*rad+l, 2*math.Pi)
+	finalAzimuth := math.Atan2(sinAlpha, -x)
+	return matrix.Matrix{lon / rad, lat / rad}, normalizeAzimuth(finalAzimuth/rad + 180)
+}
+
+// GeodesicDistance returns the length in meters of the geodesic between two points on the WGS84 ellipsoid.
+func GeodesicDistance(from, to matrix.Matrix) float64 {
+	distance, _, _ := GeodesicInverse(from, to)
+	return distance
+}
+
+// reducedLatitude returns the sine and the cosine of the reduced latitude of a latitude in radians.
+func reducedLatitude(lat float64) (sinU, cosU float64) {
+	tanU := (1 - WGS84Flattening) * math.Tan(lat)
+	cosU = 1 / math.Sqrt(1+tanU*tanU)
+	return tanU * cosU, cosU
+}
+
+// vincentyCoefficients returns the coefficients A and B of Vincenty for the square u2 of the second
+// eccentricity scaled by the azimuth of the geodesic at the equator.
+func vincentyCoefficients(u2 float64) (bigA, bigB float64) {
+	bigA = 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	bigB = u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+	return bigA, bigB
+}
+
+// vincentyDeltaSigma returns the difference between the arc length on the auxiliary sphere and
+// the length of the geodesic scaled to it.
+func vincentyDeltaSigma(bigB, sinSigma, cosSigma, cos2SigmaM float64) float64 {
+	return bigB * sinSigma * (cos2SigmaM + bigB/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+}
+
+// haversine returns the great circle distance between two points on a sphere of a radius.
+func haversine(from, to matrix.Matrix, radius float64) float64 {
+	rad := math.Pi / 180
+	sinLat := math.Sin((to[1] - from[1]) * rad / 2)
+	sinLon := math.Sin((to[0] - from[0]) * rad / 2)
+	h := sinLat*sinLat + math.Cos(from[1]*rad)*math.Cos(to[1]*rad)*sinLon*sinLon
+	return 2 * radius * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// normalizeAzimuth returns an azimuth in degrees in the range [0, 360).
+func normalizeAzimuth(azimuth float64) float64 {
+	azimuth = math.Mod(azimuth, 360)
+	if azimuth < 0 {
+		azimuth += 360
+	}
+	return azimuth
+}
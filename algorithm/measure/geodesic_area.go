@@ -0,0 +1,108 @@
+package measure
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+)
+
+// SphericalArea returns the area in square meters of a ring of longitudes and latitudes in degrees,
+// of which the edges are great circles of the mean sphere of WGS84.
+func SphericalArea(ring matrix.LineMatrix) float64 {
+	return sphericalExcess(ring, func(lat float64) float64 { return lat }) * meanRadius * meanRadius
+}
+
+// SphericalAreaOfPolygon returns the area in square meters of a polygon on the mean sphere of WGS84,
+// the areas of its holes being subtracted from the area of its shell.
+func SphericalAreaOfPolygon(polygon matrix.PolygonMatrix) float64 {
+	return areaOfPolygonWithFunc(polygon, SphericalArea)
+}
+
+// SphericalAreaOfMultiPolygon returns the area in square meters of a multi polygon on the mean sphere of WGS84.
+func SphericalAreaOfMultiPolygon(mp matrix.MultiPolygonMatrix) float64 {
+	area := 0.0
+	for _, polygon := range mp {
+		area += SphericalAreaOfPolygon(polygon)
+	}
+	return area
+}
+
+// SpheroidArea returns the area in square meters of a ring of longitudes and latitudes in degrees on the WGS84 ellipsoid.
+// It is computed on the authalic sphere, of the same area as the ellipsoid, to which the latitudes are mapped
+// preserving areas. Its edges are then great circles of that sphere, which are within a few millimeters of the
+// geodesics for edges shorter than 10 km, so that the area of a land parcel is exact to a few parts per million.
+func SpheroidArea(ring matrix.LineMatrix) float64 {
+	return sphericalExcess(ring, authalicLatitude) * authalicRadius * authalicRadius
+}
+
+// SpheroidAreaOfPolygon returns the area in square meters of a polygon on the WGS84 ellipsoid,
+// the areas of its holes being subtracted from the area of its shell.
+func SpheroidAreaOfPolygon(polygon matrix.PolygonMatrix) float64 {
+	return areaOfPolygonWithFunc(polygon, SpheroidArea)
+}
+
+// SpheroidAreaOfMultiPolygon returns the area in square meters of a multi polygon on the WGS84 ellipsoid.
+func SpheroidAreaOfMultiPolygon(mp matrix.MultiPolygonMatrix) float64 {
+	area := 0.0
+	for _, polygon := range mp {
+		area += SpheroidAreaOfPolygon(polygon)
+	}
+	return area
+}
+
+// areaOfPolygonWithFunc returns the area of a polygon, the area of its shell less those of its holes.
+func areaOfPolygonWithFunc(polygon matrix.PolygonMatrix, area func(ring matrix.LineMatrix) float64) float64 {
+	sum := 0.0
+	for i, ring := range polygon {
+		if i == 0 {
+			sum += area(ring)
+		} else {
+			sum -= area(ring)
+		}
+	}
+	return sum
+}
+
+// sphericalExcess returns the spherical excess in steradians of a ring on the unit sphere, the area it bounds
+// not containing the south pole, the latitudes being mapped by a function. It is the sum of the signed areas between
+// the edges and the equator, from which the area of the hemisphere is taken when the ring goes round a pole.
+func sphericalExcess(ring matrix.LineMatrix, latitude func(lat float64) float64) float64 {
+	if len(ring) < 3 {
+		return 0.0
+	}
+	rad := math.Pi / 180
+	excess, turn := 0.0, 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		dLon := math.Remainder((ring[i+1][0]-ring[i][0])*rad, 2*math.Pi)
+		t1 := math.Tan(latitude(ring[i][1]*rad) / 2)
+		t2 := math.Tan(latitude(ring[i+1][1]*rad) / 2)
+		excess += 2 * math.Atan2(math.Tan(dLon/2)*(t1+t2), 1+t1*t2)
+		turn += dLon
+	}
+	if math.Abs(turn) > math.Pi {
+		return 2*math.Pi - math.Abs(excess)
+	}
+	return math.Abs(excess)
+}
+
+var (
+	// eccentricity is the eccentricity of the WGS84 ellipsoid.
+	eccentricity = math.Sqrt(WGS84Flattening * (2 - WGS84Flattening))
+	// authalicQPole is the authalic function q at the pole.
+	authalicQPole = authalicQ(1)
+	// authalicRadius is the radius of the sphere of the same area as the WGS84 ellipsoid.
+	authalicRadius = WGS84SemiMajorAxis * math.Sqrt(authalicQPole/2)
+)
+
+// authalicQ returns the authalic function q of the sine of a latitude.
+func authalicQ(sinLat float64) float64 {
+	e := eccentricity
+	esin := e * sinLat
+	return (1 - e*e) * (sinLat/(1-esin*esin) + math.Atanh(esin)/e)
+}
+
+// authalicLatitude returns the authalic latitude of a latitude in radians, the latitude on the authalic sphere
+// of the same area between it and the equator.
+func authalicLatitude(lat float64) float64 {
+	return math.Asin(math.Max(-1, math.Min(1, authalicQ(math.Sin(lat))/authalicQPole)))
+}
@@ -0,0 +1,76 @@
+package measure
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+)
+
+func TestSpheroidArea(t *testing.T) {
+	square := matrix.LineMatrix{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	var cap matrix.LineMatrix
+	for lon := 0.0; lon <= 360; lon++ {
+		cap = append(cap, matrix.Matrix{lon, 80})
+	}
+	tests := []struct {
+		name      string
+		ring      matrix.LineMatrix
+		want      float64
+		tolerance float64
+	}{
+		// The area of the geodesic square, by GeographicLib.
+		{name: "one degree square", ring: square, want: 12308778361.469, tolerance: 1e-6},
+		{name: "reversed", ring: matrix.LineMatrix{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}, want: 12308778361.469, tolerance: 1e-6},
+		{name: "parcel", ring: matrix.LineMatrix{{116.4, 39.9}, {116.4001, 39.9}, {116.4001, 39.9001}, {116.4, 39.9001}, {116.4, 39.9}},
+			want: 94.953212, tolerance: 1e-6},
+		{name: "hemisphere", ring: matrix.LineMatrix{{0, 0}, {90, 0}, {180, 0}, {-90, 0}, {0, 0}},
+			want: 2 * math.Pi * authalicRadius * authalicRadius, tolerance: 1e-12},
+		{name: "polar cap", ring: cap, want: 2 * math.Pi * authalicRadius * authalicRadius * (1 - math.Sin(authalicLatitude(80*math.Pi/180))),
+			tolerance: 1e-4},
+		{name: "too few points", ring: matrix.LineMatrix{{0, 0}, {1, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SpheroidArea(tt.ring); math.Abs(got-tt.want) > tt.tolerance*tt.want {
+				t.Errorf("SpheroidArea() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	polygon := matrix.PolygonMatrix{square, {{0.25, 0.25}, {0.75, 0.25}, {0.75, 0.75}, {0.25, 0.75}, {0.25, 0.25}}}
+	hole := SpheroidArea(polygon[1])
+	if got := SpheroidAreaOfPolygon(polygon); math.Abs(got-(SpheroidArea(square)-hole)) > 1e-3 || hole < 0.24*12308778361.469 {
+		t.Errorf("SpheroidAreaOfPolygon() = %v, hole %v", got, hole)
+	}
+	if got := SpheroidAreaOfMultiPolygon(matrix.MultiPolygonMatrix{polygon, polygon}); got != 2*SpheroidAreaOfPolygon(polygon) {
+		t.Errorf("SpheroidAreaOfMultiPolygon() = %v", got)
+	}
+}
+
+func TestSphericalArea(t *testing.T) {
+	square := matrix.LineMatrix{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	octant := matrix.LineMatrix{{0, 0}, {90, 0}, {0, 90}, {0, 0}}
+	if got, want := SphericalArea(octant), math.Pi/2*meanRadius*meanRadius; math.Abs(got-want) > 1e-9*want {
+		t.Errorf("SphericalArea() = %v, want %v", got, want)
+	}
+	if got := SphericalAreaOfPolygon(matrix.PolygonMatrix{square, square}); got != 0 {
+		t.Errorf("SphericalAreaOfPolygon() = %v, want 0", got)
+	}
+	if got := SphericalAreaOfMultiPolygon(matrix.MultiPolygonMatrix{{square}}); got != SphericalArea(square) {
+		t.Errorf("SphericalAreaOfMultiPolygon() = %v, want %v", got, SphericalArea(square))
+	}
+}
+
+func TestSpheroidOfLine(t *testing.T) {
+	line := matrix.LineMatrix{{-73.8, 40.6}, {-0.5, 51.6}, {-0.5, 51.6}}
+	if got := SpheroidOfLine(line); math.Abs(got-5551759.400) > 1e-3 {
+		t.Errorf("SpheroidOfLine() = %v, want %v", got, 5551759.400)
+	}
+	if got := SphericalOfLine(matrix.LineMatrix{{0, 0}, {90, 0}}); math.Abs(got-math.Pi/2*meanRadius) > 1e-6 {
+		t.Errorf("SphericalOfLine() = %v, want %v", got, math.Pi/2*meanRadius)
+	}
+	if got := SpheroidOfLine(matrix.LineMatrix{{0, 0}}); got != 0 {
+		t.Errorf("SpheroidOfLine() = %v, want 0", got)
+	}
+}
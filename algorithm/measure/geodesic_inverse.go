@@ -0,0 +1,473 @@
+package measure
+
+import "math"
+
+// The inverse geodesic problem is solved after C. F. F. Karney, Algorithms for geodesics, J. Geod. 87, 43-55 (2013),
+// with the series of GeographicLib to the sixth order in the third flattening n, accurate to 15 nanometers.
+
+const (
+	// geodesicF1 is one less the flattening of the WGS84 ellipsoid.
+	geodesicF1 = 1 - WGS84Flattening
+	// geodesicEp2 is the square of the second eccentricity of the WGS84 ellipsoid.
+	geodesicEp2 = WGS84Flattening * (2 - WGS84Flattening) / (geodesicF1 * geodesicF1)
+	// geodesicN is the third flattening of the WGS84 ellipsoid.
+	geodesicN = WGS84Flattening / (2 - WGS84Flattening)
+	// geodesicB is the semi-minor axis of the WGS84 ellipsoid, in meters.
+	geodesicB = WGS84SemiMajorAxis * geodesicF1
+
+	// geodesicNewtonIterations is the number of iterations of Newton's method before bisecting.
+	geodesicNewtonIterations = 20
+	// geodesicMaxIterations is the maximum number of iterations of the azimuth, enough for the bisection
+	// to reach the precision of a float64.
+	geodesicMaxIterations = geodesicNewtonIterations + 53 + 10
+)
+
+var (
+	geodesicTiny    = math.Sqrt(2.2250738585072014e-308)
+	geodesicTol0    = math.Nextafter(1, 2) - 1
+	geodesicTol1    = 200 * geodesicTol0
+	geodesicTol2    = math.Sqrt(geodesicTol0)
+	geodesicTolB    = geodesicTol0 * geodesicTol2
+	geodesicXThresh = 1000 * geodesicTol2
+	geodesicETol2   = 0.1 * geodesicTol2 /
+		math.Sqrt(math.Max(0.001, WGS84Flattening)*math.Min(1, 1-WGS84Flattening/2)/2)
+
+	// geodesicA3 are the coefficients of the series A3 in eps.
+	geodesicA3 = [6]float64{
+		1,
+		(geodesicN - 1) / 2,
+		(3*geodesicN*geodesicN - geodesicN - 2) / 8,
+		(-geodesicN*geodesicN - 3*geodesicN - 1) / 16,
+		(-2*geodesicN - 3) / 64,
+		-3.0 / 128,
+	}
+	// geodesicC3 are the coefficients in eps of the terms of the series C3, from sin(2 sigma) on.
+	geodesicC3 = [5][6]float64{
+		{0, (1 - geodesicN) / 4, (1 - geodesicN*geodesicN) / 8, (3 + 3*geodesicN - geodesicN*geodesicN) / 64,
+			(5 + 2*geodesicN) / 128, 3.0 / 128},
+		{0, 0, (2 - 3*geodesicN + geodesicN*geodesicN) / 32, (3 - 2*geodesicN - 3*geodesicN*geodesicN) / 64,
+			(3 + geodesicN) / 128, 5.0 / 256},
+		{0, 0, 0, (5 - 9*geodesicN + 5*geodesicN*geodesicN) / 192, (9 - 10*geodesicN) / 384, 7.0 / 512},
+		{0, 0, 0, 0, (7 - 14*geodesicN) / 512, 7.0 / 512},
+		{0, 0, 0, 0, 0, 21.0 / 2560},
+	}
+)
+
+// geodesicArc describes a geodesic on the auxiliary sphere between its points of reduced latitudes beta1 and beta2.
+type geodesicArc struct {
+	salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps float64
+}
+
+// karneyInverse returns the length in meters of the geodesic between two points on the WGS84 ellipsoid,
+// given by their latitudes and longitudes in degrees, and its azimuths in degrees at both points,
+// in the direction from the first point to the second one.
+func karneyInverse(lat1, lon1, lat2, lon2 float64) (s12, azi1, azi2 float64) {
+	// make lon12 non-negative and the first point the farthest from the equator, in the southern hemisphere
+	lon12 := angRound(math.Remainder(lon2-lon1, 360))
+	lonsign := 1.0
+	if math.Signbit(lon12) {
+		lonsign = -1
+	}
+	lon12 *= lonsign
+	lam12 := lon12 * math.Pi / 180
+	slam12, clam12 := sincosd(lon12)
+
+	lat1, lat2 = angRound(latFix(lat1)), angRound(latFix(lat2))
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1
+		lonsign = -lonsign
+		lat1, lat2 = lat2, lat1
+	}
+	latsign := -1.0
+	if math.Signbit(lat1) {
+		latsign = 1
+	}
+	lat1 *= latsign
+	lat2 *= latsign
+
+	sbet1, cbet1 := sincosd(lat1)
+	sbet1, cbet1 = norm2(geodesicF1*sbet1, cbet1)
+	cbet1 = math.Max(geodesicTiny, cbet1)
+	sbet2, cbet2 := sincosd(lat2)
+	sbet2, cbet2 = norm2(geodesicF1*sbet2, cbet2)
+	cbet2 = math.Max(geodesicTiny, cbet2)
+
+	// make the latitudes of symmetric points exactly opposite or equal
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+	dn1 := math.Sqrt(1 + geodesicEp2*sbet1*sbet1)
+	dn2 := math.Sqrt(1 + geodesicEp2*sbet2*sbet2)
+
+	var salp1, calp1, salp2, calp2, s12x float64
+	meridian := lat1 == -90 || slam12 == 0
+	if meridian {
+		// the geodesic runs along a meridian, unless it goes round a pole and is longer than the one through the other
+		salp1, calp1 = slam12, clam12
+		salp2, calp2 = 0, 1
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+		sig12 := math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		s12b, m12b := geodesicLengths(geodesicN, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2)
+		if sig12 < 1 || m12b >= 0 {
+			if sig12 < 3*geodesicTiny || (sig12 < geodesicTol0 && (s12b < 0 || m12b < 0)) {
+				s12b = 0
+			}
+			s12x = s12b * geodesicB
+		} else {
+			meridian = false
+		}
+	}
+
+	if !meridian && sbet1 == 0 && 180-lon12 >= WGS84Flattening*180 {
+		// the geodesic runs along the equator
+		salp1, calp1, salp2, calp2 = 1, 0, 1, 0
+		s12x = WGS84SemiMajorAxis * lam12
+	} else if !meridian {
+		sig12, s1, c1, s2, c2, dnm := geodesicInverseStart(sbet1, cbet1, sbet2, cbet2, lam12, slam12, clam12)
+		salp1, calp1, salp2, calp2 = s1, c1, s2, c2
+		if sig12 >= 0 {
+			// short lines are solved on a sphere of the radius of curvature at their middle
+			s12x = sig12 * geodesicB * dnm
+		} else {
+			// Newton's method on the azimuth at the first point, falling back to bisection
+			var arc geodesicArc
+			tripn, tripb := false, false
+			salp1a, calp1a := geodesicTiny, 1.0
+			salp1b, calp1b := geodesicTiny, -1.0
+			for numit := 0; numit < geodesicMaxIterations; numit++ {
+				var v, dv float64
+				v, dv, arc = geodesicLambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12,
+					numit < geodesicNewtonIterations)
+				tol := geodesicTol0
+				if tripn {
+					tol *= 8
+				}
+				if tripb || !(math.Abs(v) >= tol) {
+					break
+				}
+				if v > 0 && (numit > geodesicNewtonIterations || calp1/salp1 > calp1b/salp1b) {
+					salp1b, calp1b = salp1, calp1
+				} else if v < 0 && (numit > geodesicNewtonIterations || calp1/salp1 < calp1a/salp1a) {
+					salp1a, calp1a = salp1, calp1
+				}
+				if numit < geodesicNewtonIterations && dv > 0 {
+					if dalp1 := -v / dv; math.Abs(dalp1) < math.Pi {
+						sdalp1, cdalp1 := math.Sincos(dalp1)
+						if nsalp1 := salp1*cdalp1 + calp1*sdalp1; nsalp1 > 0 {
+							salp1, calp1 = norm2(nsalp1, calp1*cdalp1-salp1*sdalp1)
+							tripn = math.Abs(v) <= 16*geodesicTol0
+							continue
+						}
+					}
+				}
+				salp1, calp1 = norm2((salp1a+salp1b)/2, (calp1a+calp1b)/2)
+				tripn = false
+				tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < geodesicTolB ||
+					math.Abs(salp1-salp1b)+(calp1-calp1b) < geodesicTolB
+			}
+			salp2, calp2 = arc.salp2, arc.calp2
+			s12b, _ := geodesicLengths(arc.eps, arc.sig12, arc.ssig1, arc.csig1, dn1, arc.ssig2, arc.csig2, dn2)
+			s12x = s12b * geodesicB
+		}
+	}
+
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+	}
+	salp1 *= swapp * lonsign
+	calp1 *= swapp * latsign
+	salp2 *= swapp * lonsign
+	calp2 *= swapp * latsign
+	return 0 + s12x, math.Atan2(salp1, calp1) * 180 / math.Pi, math.Atan2(salp2, calp2) * 180 / math.Pi
+}
+
+// geodesicInverseStart returns the arc length on the auxiliary sphere of a short geodesic and its azimuths,
+// or a negative arc length and the first estimate of the azimuth at the first point of a longer one.
+func geodesicInverseStart(sbet1, cbet1, sbet2, cbet2, lam12, slam12, clam12 float64) (
+	sig12, salp1, calp1, salp2, calp2, dnm float64) {
+	sig12 = -1
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+	somg12, comg12 := slam12, clam12
+	if shortline {
+		sbetm2 := (sbet1 + sbet2) * (sbet1 + sbet2)
+		sbetm2 /= sbetm2 + (cbet1+cbet2)*(cbet1+cbet2)
+		dnm = math.Sqrt(1 + geodesicEp2*sbetm2)
+		somg12, comg12 = math.Sincos(lam12 / (geodesicF1 * dnm))
+	}
+
+	salp1 = cbet2 * somg12
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*somg12*somg12/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+	}
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	if shortline && ssig12 < geodesicETol2 {
+		salp2 = cbet1 * somg12
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*somg12*somg12/(1+comg12)
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+		salp2, calp2 = norm2(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	} else if geodesicN > 0.1 || csig12 >= 0 || ssig12 >= 6*geodesicN*math.Pi*cbet1*cbet1 {
+		// the spherical estimate is good enough
+	} else {
+		// nearly antipodal points: scale the longitude and the latitude to x and y around the antipode
+		// of the first point, where the cut of the geodesics from it starts at x = -1, y = 0
+		lam12x := math.Atan2(-slam12, -clam12)
+		k2 := sbet1 * sbet1 * geodesicEp2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		lamscale := WGS84Flattening * cbet1 * geodesicA3f(eps) * math.Pi
+		betscale := lamscale * cbet1
+		x, y := lam12x/lamscale, sbet12a/betscale
+		if y > -geodesicTol1 && x > -1-geodesicXThresh {
+			salp1 = math.Min(1, -x)
+			calp1 = -math.Sqrt(1 - salp1*salp1)
+		} else {
+			k := astroid(x, y)
+			omg12a := lamscale * -x * k / (1 + k)
+			somg12, comg12 = math.Sin(omg12a), -math.Cos(omg12a)
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+		}
+	}
+	if !(salp1 <= 0) {
+		salp1, calp1 = norm2(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+	return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+// geodesicLambda12 returns the difference between the longitude reached by the geodesic of an azimuth
+// at the first point and the longitude of the second point, its derivative with respect to the azimuth,
+// and the arc of that geodesic.
+func geodesicLambda12(sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64, diffp bool) (
+	lam12, dlam12 float64, arc geodesicArc) {
+	if sbet1 == 0 && calp1 == 0 {
+		// break the degeneracy of the equatorial line
+		calp1 = -geodesicTiny
+	}
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	somg1, comg1 := salp0*sbet1, calp1*cbet1
+	arc.ssig1, arc.csig1 = norm2(sbet1, calp1*cbet1)
+	arc.salp2 = salp1
+	if cbet2 != cbet1 {
+		arc.salp2 = salp0 / cbet2
+	}
+	arc.calp2 = math.Abs(calp1)
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		d := (sbet1 - sbet2) * (sbet1 + sbet2)
+		if cbet1 < -sbet1 {
+			d = (cbet2 - cbet1) * (cbet1 + cbet2)
+		}
+		arc.calp2 = math.Sqrt(calp1*cbet1*calp1*cbet1+d) / cbet2
+	}
+	somg2, comg2 := salp0*sbet2, arc.calp2*cbet2
+	arc.ssig2, arc.csig2 = norm2(sbet2, arc.calp2*cbet2)
+
+	arc.sig12 = math.Atan2(math.Max(0, arc.csig1*arc.ssig2-arc.ssig1*arc.csig2), arc.csig1*arc.csig2+arc.ssig1*arc.ssig2)
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := calp0 * calp0 * geodesicEp2
+	arc.eps = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+	c3 := geodesicC3f(arc.eps)
+	b312 := sinSeries(arc.ssig2, arc.csig2, c3[:]) - sinSeries(arc.ssig1, arc.csig1, c3[:])
+	lam12 = eta - WGS84Flattening*geodesicA3f(arc.eps)*salp0*(arc.sig12+b312)
+
+	if diffp {
+		if arc.calp2 == 0 {
+			dlam12 = -2 * geodesicF1 * dn1 / sbet1
+		} else {
+			_, m12b := geodesicLengths(arc.eps, arc.sig12, arc.ssig1, arc.csig1, dn1, arc.ssig2, arc.csig2, dn2)
+			dlam12 = m12b * geodesicF1 / (arc.calp2 * cbet2)
+		}
+	}
+	return lam12, dlam12, arc
+}
+
+// geodesicLengths returns the length and the reduced length of a geodesic, in units of the semi-minor axis.
+func geodesicLengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64) (s12b, m12b float64) {
+	c1, c2 := geodesicC1f(eps), geodesicC2f(eps)
+	a1, a2 := geodesicA1m1f(eps), geodesicA2m1f(eps)
+	m0 := a1 - a2
+	a1, a2 = 1+a1, 1+a2
+	b1 := sinSeries(ssig2, csig2, c1[:]) - sinSeries(ssig1, csig1, c1[:])
+	b2 := sinSeries(ssig2, csig2, c2[:]) - sinSeries(ssig1, csig1, c2[:])
+	s12b = a1 * (sig12 + b1)
+	j12 := m0*sig12 + (a1*b1 - a2*b2)
+	m12b = dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+	return s12b, m12b
+}
+
+// geodesicA1m1f returns A1 - 1, A1 being the scale of the distance along the geodesic.
+func geodesicA1m1f(eps float64) float64 {
+	e2 := eps * eps
+	t := e2 * (64 + e2*(4+e2)) / 256
+	return (t + eps) / (1 - eps)
+}
+
+// geodesicA2m1f returns A2 - 1, A2 being the scale of the reduced length.
+func geodesicA2m1f(eps float64) float64 {
+	e2 := eps * eps
+	t := -e2 * (192 + e2*(28+11*e2)) / 256
+	return (t - eps) / (1 + eps)
+}
+
+// geodesicA3f returns A3, the scale of the difference of the longitudes on the ellipsoid and on the auxiliary sphere.
+func geodesicA3f(eps float64) float64 {
+	return polyval(eps, geodesicA3[:])
+}
+
+// geodesicC1f returns the coefficients of the series C1 of the distance along the geodesic.
+func geodesicC1f(eps float64) [6]float64 {
+	e2 := eps * eps
+	e3, e4 := e2*eps, e2*e2
+	return [6]float64{
+		eps * (-16 + e2*(6-e2)) / 32,
+		e2 * (-128 + e2*(64-9*e2)) / 2048,
+		e3 * (-16 + 9*e2) / 768,
+		e4 * (-5 + 3*e2) / 512,
+		-7 * e4 * eps / 1280,
+		-7 * e4 * e2 / 2048,
+	}
+}
+
+// geodesicC2f returns the coefficients of the series C2 of the reduced length.
+func geodesicC2f(eps float64) [6]float64 {
+	e2 := eps * eps
+	e3, e4 := e2*eps, e2*e2
+	return [6]float64{
+		eps * (16 + e2*(2+e2)) / 32,
+		e2 * (384 + e2*(64+35*e2)) / 2048,
+		e3 * (80 + 15*e2) / 768,
+		e4 * (35 + 7*e2) / 512,
+		63 * e4 * eps / 1280,
+		77 * e4 * e2 / 2048,
+	}
+}
+
+// geodesicC3f returns the coefficients of the series C3 of the difference of the longitudes.
+func geodesicC3f(eps float64) [5]float64 {
+	var c [5]float64
+	for l := range c {
+		c[l] = polyval(eps, geodesicC3[l][:])
+	}
+	return c
+}
+
+// polyval returns the value at x of the polynomial of the coefficients c, in increasing powers.
+func polyval(x float64, c []float64) float64 {
+	y := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		y = y*x + c[i]
+	}
+	return y
+}
+
+// sinSeries returns the sum of c[l] sin(2 (l + 1) x) by the method of Clenshaw, given the sine and the cosine of x.
+func sinSeries(sinx, cosx float64, c []float64) float64 {
+	ar := 2 * (cosx - sinx) * (cosx + sinx)
+	y0, y1 := 0.0, 0.0
+	for l := len(c) - 1; l >= 0; l-- {
+		y0, y1 = ar*y0-y1+c[l], y0
+	}
+	return 2 * sinx * cosx * y0
+}
+
+// astroid returns the positive root k of k^4 + 2 k^3 - (x^2 + y^2 - 1) k^2 - 2 y^2 k - y^2 = 0,
+// which estimates the azimuth of the geodesics between nearly antipodal points.
+func astroid(x, y float64) float64 {
+	p, q := x*x, y*y
+	r := (p + q - 1) / 6
+	if q == 0 && r <= 0 {
+		return 0
+	}
+	s := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := s * (s + 2*r3)
+	u := r
+	if disc >= 0 {
+		t3 := s + r3
+		if t3 < 0 {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+		t := math.Cbrt(t3)
+		if t != 0 {
+			u += t + r2/t
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+	v := math.Sqrt(u*u + q)
+	uv := u + v
+	if u < 0 {
+		uv = q / (v - u)
+	}
+	w := (uv - q) / (2 * v)
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+// sincosd returns the sine and the cosine of an angle in degrees, exact for the multiples of 90 degrees.
+func sincosd(x float64) (sinx, cosx float64) {
+	r := math.Remainder(x, 360)
+	q := math.Round(r / 90)
+	s, c := math.Sincos((r - 90*q) * math.Pi / 180)
+	switch int(q) & 3 {
+	case 0:
+		sinx, cosx = s, c
+	case 1:
+		sinx, cosx = c, -s
+	case 2:
+		sinx, cosx = -s, -c
+	default:
+		sinx, cosx = -c, s
+	}
+	return sinx, cosx + 0
+}
+
+// angRound rounds an angle in degrees so that tiny angles are zero, to keep the symmetries of the geodesics.
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+	y := math.Abs(x)
+	if w := z - y; w > 0 {
+		y = z - w
+	}
+	return math.Copysign(y, x)
+}
+
+// latFix returns a latitude in degrees, or NaN beyond the poles.
+func latFix(lat float64) float64 {
+	if math.Abs(lat) > 90 {
+		return math.NaN()
+	}
+	return lat
+}
+
+// norm2 returns a vector scaled to unit length.
+func norm2(x, y float64) (float64, float64) {
+	h := math.Hypot(x, y)
+	return x / h, y / h
+}
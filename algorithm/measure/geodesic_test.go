@@ -0,0 +1,73 @@
+package measure
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+)
+
+// dms returns the degrees of an angle in degrees, minutes and seconds.
+func dms(d, m, s float64) float64 {
+	return d + m/60 + s/3600
+}
+
+func TestGeodesicInverse(t *testing.T) {
+	tests := []struct {
+		name                           string
+		from, to                       matrix.Matrix
+		distance, azimuth, backAzimuth float64
+	}{
+		{name: "flinders peak to buninyong", from: matrix.Matrix{dms(144, 25, 29.52440), -dms(37, 57, 3.72030)},
+			to:       matrix.Matrix{dms(143, 55, 35.38390), -dms(37, 39, 10.15610)},
+			distance: 54972.271, azimuth: dms(306, 52, 5.37), backAzimuth: dms(127, 10, 25.07)},
+		{name: "jfk to lhr", from: matrix.Matrix{-73.8, 40.6}, to: matrix.Matrix{-0.5, 51.6},
+			distance: 5551759.400, azimuth: 51.198882845579824, backAzimuth: 287.821776735514248},
+		{name: "equator to pole", from: matrix.Matrix{0, 0}, to: matrix.Matrix{0, 90},
+			distance: 10001965.729, azimuth: 0, backAzimuth: 180},
+		{name: "antimeridian", from: matrix.Matrix{179.9, 10}, to: matrix.Matrix{-179.9, 10},
+			distance: 21927.872, azimuth: 89.98263516502294, backAzimuth: 270.01736483497706},
+		{name: "coincident", from: matrix.Matrix{12, 15}, to: matrix.Matrix{12, 15}},
+		{name: "antipodal on the equator", from: matrix.Matrix{0, 0}, to: matrix.Matrix{180, 0},
+			distance: 20003931.459, azimuth: 0, backAzimuth: 0},
+		{name: "nearly antipodal", from: matrix.Matrix{0, -30}, to: matrix.Matrix{179.8, 29.9},
+			distance: 19989832.828, azimuth: 161.890524736, backAzimuth: 198.090737245},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, azimuth, backAzimuth := GeodesicInverse(tt.from, tt.to)
+			if math.Abs(distance-tt.distance) > 1e-3 || math.Abs(azimuth-tt.azimuth) > 1e-5 || math.Abs(backAzimuth-tt.backAzimuth) > 1e-5 {
+				t.Errorf("GeodesicInverse() = %v %v %v, want %v %v %v", distance, azimuth, backAzimuth,
+					tt.distance, tt.azimuth, tt.backAzimuth)
+			}
+		})
+	}
+
+	// the geodesics between nearly antipodal points are found again by the direct solution
+	for _, to := range []matrix.Matrix{{179.7, 0.3}, {179.5, 0}, {179.9, 0.001}, {-179.99, -0.2}} {
+		distance, azimuth, _ := GeodesicInverse(matrix.Matrix{0, 0}, to)
+		if got, _ := GeodesicDirect(matrix.Matrix{0, 0}, azimuth, distance); math.Abs(got[0]-to[0]) > 1e-8 ||
+			math.Abs(got[1]-to[1]) > 1e-8 {
+			t.Errorf("GeodesicInverse() to %v = %v %v, reaching %v", to, distance, azimuth, got)
+		}
+	}
+}
+
+func TestGeodesicDirect(t *testing.T) {
+	from := matrix.Matrix{-73.8, 40.6}
+	to, backAzimuth := GeodesicDirect(from, 51.198882845579824, 5551759.400)
+	if math.Abs(to[0]+0.5) > 1e-8 || math.Abs(to[1]-51.6) > 1e-8 || math.Abs(backAzimuth-287.821776735514248) > 1e-5 {
+		t.Errorf("GeodesicDirect() = %v %v", to, backAzimuth)
+	}
+
+	for _, azimuth := range []float64{0, 45, 90, 135, 180, 270} {
+		to, _ := GeodesicDirect(matrix.Matrix{116.4, 39.9}, azimuth, 1000)
+		if distance, got, _ := GeodesicInverse(matrix.Matrix{116.4, 39.9}, to); math.Abs(distance-1000) > 1e-6 ||
+			math.Abs(math.Remainder(got-azimuth, 360)) > 1e-9 {
+			t.Errorf("GeodesicDirect() azimuth %v got %v, distance %v", azimuth, got, distance)
+		}
+	}
+	if to, _ := GeodesicDirect(matrix.Matrix{179.9, 10}, 90, 30000); to[0] > -179 {
+		t.Errorf("GeodesicDirect() got = %v, want across the antimeridian", to)
+	}
+}
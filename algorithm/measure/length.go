@@ -25,3 +25,37 @@ func OfLine(pts matrix.LineMatrix) float64 {
 	}
 	return 0.0
 }
+
+// OfLine3D Computes the length of a linestring in 3D, using the Z of the points when they have one.
+func OfLine3D(pts matrix.LineMatrix) float64 {
+	length := 0.0
+	for i := 0; i < len(pts)-1; i++ {
+		dx, dy := pts[i+1][0]-pts[i][0], pts[i+1][1]-pts[i][1]
+		dz := 0.0
+		if len(pts[i]) > 2 && len(pts[i+1]) > 2 && !math.IsNaN(pts[i][2]) && !math.IsNaN(pts[i+1][2]) {
+			dz = pts[i+1][2] - pts[i][2]
+		}
+		length += math.Sqrt(dx*dx + dy*dy + dz*dz)
+	}
+	return length
+}
+
+// SphericalOfLine Computes the length in meters of a linestring of longitudes and latitudes in degrees,
+// along the great circles of the mean sphere of WGS84.
+func SphericalOfLine(pts matrix.LineMatrix) float64 {
+	length := 0.0
+	for i := 0; i < len(pts)-1; i++ {
+		length += haversine(pts[i], pts[i+1], meanRadius)
+	}
+	return length
+}
+
+// SpheroidOfLine Computes the length in meters of a linestring of longitudes and latitudes in degrees,
+// along the geodesics of the WGS84 ellipsoid.
+func SpheroidOfLine(pts matrix.LineMatrix) float64 {
+	length := 0.0
+	for i := 0; i < len(pts)-1; i++ {
+		length += GeodesicDistance(pts[i], pts[i+1])
+	}
+	return length
+}
@@ -0,0 +1,77 @@
+package overlay
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/topology"
+)
+
+// EvenOdd returns the polygons covering the points which lie inside an odd number of the rings,
+// which may cross themselves and each other, like the rings of an invalid polygon.
+// The rings are noded together, and the noded edges covered an odd number of times bound the result.
+func EvenOdd(rings []matrix.LineMatrix) matrix.MultiPolygonMatrix {
+	n := &topology.Noder{}
+	extent := 0.0
+	for _, ring := range rings {
+		for j := 0; j < len(ring)-1; j++ {
+			extent = math.Max(extent, math.Max(math.Abs(ring[j][0]), math.Abs(ring[j][1])))
+			n.AddSegment(vertex(ring[j]), vertex(ring[j+1]), 0, roleArea)
+		}
+	}
+	n.Tolerance = extent * topology.SnapToleranceFactor
+
+	odd := map[[2]topology.Vertex]bool{}
+	var keys [][2]topology.Vertex
+	for _, pts := range n.Node() {
+		for j := 0; j < len(pts)-1; j++ {
+			p, q := pts[j], pts[j+1]
+			if p == q {
+				continue
+			}
+			key := [2]topology.Vertex{p, q}
+			if topology.Less(q, p) {
+				key = [2]topology.Vertex{q, p}
+			}
+			if _, ok := odd[key]; !ok {
+				keys = append(keys, key)
+			}
+			odd[key] = !odd[key]
+		}
+	}
+
+	// the edges bounding the result are located with rays in the x direction, and in the y direction
+	// for horizontal edges, which the rays in the x direction do not cross.
+	var boundary, transposed matrix.PolygonMatrix
+	for _, key := range keys {
+		if odd[key] {
+			boundary = append(boundary, matrix.LineMatrix{{key[0][0], key[0][1]}, {key[1][0], key[1][1]}})
+			transposed = append(transposed, matrix.LineMatrix{{key[0][1], key[0][0]}, {key[1][1], key[1][0]}})
+		}
+	}
+	locator := topology.NewAreaLocator([]matrix.PolygonMatrix{boundary})
+	transposedLocator := topology.NewAreaLocator([]matrix.PolygonMatrix{transposed})
+
+	var edges []*directedEdge
+	for _, key := range keys {
+		if !odd[key] {
+			continue
+		}
+		start, end := key[0], key[1]
+		mid := topology.Vertex{(start[0] + end[0]) / 2, (start[1] + end[1]) / 2}
+		var leftInside bool
+		if start[1] == end[1] {
+			// start lies left of end, so the side of the greater y is on the left.
+			leftInside = transposedLocator.Contains(topology.Vertex{mid[1], mid[0]})
+		} else {
+			// the side of the greater x is on the left of the edge going downwards.
+			leftInside = locator.Contains(mid) == (start[1] > end[1])
+		}
+		if leftInside {
+			edges = append(edges, &directedEdge{from: start, to: end})
+		} else {
+			edges = append(edges, &directedEdge{from: end, to: start})
+		}
+	}
+	return buildPolygons(linkRings(edges))
+}
@@ -0,0 +1,52 @@
+package overlay
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+)
+
+func TestEvenOdd(t *testing.T) {
+	tests := []struct {
+		name     string
+		rings    []matrix.LineMatrix
+		area     float64
+		polygons int
+		holes    int
+	}{
+		{name: "square", rings: []matrix.LineMatrix{square(0, 0, 10)}, area: 100, polygons: 1},
+		{name: "bow tie", rings: []matrix.LineMatrix{{{0, 0}, {10, 10}, {10, 0}, {0, 10}, {0, 0}}}, area: 50, polygons: 2},
+		{name: "hole", rings: []matrix.LineMatrix{square(0, 0, 10), square(2, 2, 6)}, area: 64, polygons: 1, holes: 1},
+		{name: "hole outside shell", rings: []matrix.LineMatrix{square(0, 0, 10), square(20, 0, 5)}, area: 125, polygons: 2},
+		{name: "overlapping hole", rings: []matrix.LineMatrix{square(0, 0, 10), square(5, 5, 10)}, area: 150, polygons: 2},
+		{name: "nested holes", rings: []matrix.LineMatrix{square(0, 0, 10), square(2, 2, 6), square(4, 4, 2)}, area: 68, polygons: 2, holes: 1},
+		{name: "same rings", rings: []matrix.LineMatrix{square(0, 0, 10), square(0, 0, 10)}},
+		{name: "collapsed", rings: []matrix.LineMatrix{{{0, 0}, {10, 0}, {0, 0}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvenOdd(tt.rings)
+			area, holes := 0.0, 0
+			for _, polygon := range got {
+				for i, ring := range polygon {
+					direction := measure.AreaDirection(ring)
+					if (direction > 0) != (i == 0) {
+						t.Errorf("EvenOdd() ring %v has a wrong orientation", ring)
+					}
+					if i > 0 {
+						holes++
+						area -= math.Abs(direction)
+					} else {
+						area += math.Abs(direction)
+					}
+				}
+			}
+			if len(got) != tt.polygons || holes != tt.holes || math.Abs(area-tt.area) > 1e-9 {
+				t.Errorf("EvenOdd() got %v polygons, %v holes, area %v, want %v, %v, %v: %v",
+					len(got), holes, area, tt.polygons, tt.holes, tt.area, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package overlay
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+	"github.com/spatial-go/geoos/algorithm/topology"
+)
+
+// Overlay operations.
+const (
+	OpIntersection = iota
+	OpUnion
+	OpDifference
+	OpSymDifference
+)
+
+// Roles of the noded linework in an operand, combined as bit flags.
+const (
+	roleArea = 1 << iota
+	roleLine
+)
+
+// Operand is an input of an overlay, made of points, lines and polygons.
+// The polygons must have disjoint interiors.
+type Operand struct {
+	Points   []matrix.Matrix
+	Lines    []matrix.LineMatrix
+	Polygons []matrix.PolygonMatrix
+}
+
+// Result is the result of an overlay. Its polygons have clockwise shells and counter-clockwise holes,
+// its lines are merged at the nodes where they do not branch, and its points lie on no line or polygon of the result.
+type Result struct {
+	Points   []matrix.Matrix
+	Lines    []matrix.LineMatrix
+	Polygons matrix.MultiPolygonMatrix
+}
+
+// IsEmpty returns true if the result has no component.
+func (r Result) IsEmpty() bool {
+	return len(r.Points) == 0 && len(r.Lines) == 0 && len(r.Polygons) == 0
+}
+
+// overlayEdge is a noded edge of the linework of two operands.
+// The sides of the edge are relative to the direction start-end.
+type overlayEdge struct {
+	start, end          topology.Vertex
+	roles               [2]int
+	areaLeft, areaRight [2]bool
+
+	// forward is the direction of the first input line the edge lies on,
+	// or of the first ring if it lies on no line.
+	forward bool
+
+	inLeft, inRight, inResult bool
+}
+
+// directedEdge is an edge of the result, directed so that the interior of the result lies on its left
+// for area edges, and along the input line for line edges.
+type directedEdge struct {
+	from, to topology.Vertex
+	used     bool
+}
+
+// Compute computes the overlay of two operands, following the semantics of the GEOS overlay:
+// the result of an intersection contains the lines and points where the operands only touch,
+// while the other operations keep the components of the operands down to their dimension.
+//
+// The linework of both operands is noded together. Every noded edge is located in each operand,
+// and is kept as the boundary of the result area if the operation puts the result interior on
+// one side of it only, or as a result line if it is in the result and not covered by its area.
+// Nodes and input points covered by no result edge are kept as result points.
+func Compute(a, b Operand, op int) Result {
+	operands := [2]Operand{a, b}
+	n := &topology.Noder{}
+	extent := 0.0
+	grow := func(p matrix.Matrix) {
+		extent = math.Max(extent, math.Max(math.Abs(p[0]), math.Abs(p[1])))
+	}
+	for k, operand := range operands {
+		for _, polygon := range operand.Polygons {
+			for i, ring := range polygon {
+				// orient rings so that the interior of the polygon lies on their left
+				if (measure.AreaDirection(ring) > 0) == (i == 0) {
+					ring = reverseRing(ring)
+				}
+				for j := 0; j < len(ring)-1; j++ {
+					grow(ring[j])
+					n.AddSegment(vertex(ring[j]), vertex(ring[j+1]), k, roleArea)
+				}
+			}
+		}
+		for _, line := range operand.Lines {
+			for j := 0; j < len(line)-1; j++ {
+				grow(line[j])
+				grow(line[j+1])
+				n.AddSegment(vertex(line[j]), vertex(line[j+1]), k, roleLine)
+			}
+		}
+		for _, p := range operand.Points {
+			grow(p)
+		}
+	}
+	n.Tolerance = extent * topology.SnapToleranceFactor
+
+	edges := map[[2]topology.Vertex]*overlayEdge{}
+	nodes := map[topology.Vertex][]*overlayEdge{}
+	var keys [][2]topology.Vertex
+	for i, pts := range n.Node() {
+		s := n.Segs[i]
+		for j := 0; j < len(pts)-1; j++ {
+			p, q := pts[j], pts[j+1]
+			if p == q {
+				continue
+			}
+			key, forward := [2]topology.Vertex{p, q}, true
+			if topology.Less(q, p) {
+				key, forward = [2]topology.Vertex{q, p}, false
+			}
+			e, ok := edges[key]
+			if !ok {
+				e = &overlayEdge{start: key[0], end: key[1]}
+				edges[key] = e
+				keys = append(keys, key)
+				nodes[e.start] = append(nodes[e.start], e)
+				nodes[e.end] = append(nodes[e.end], e)
+			}
+			if !ok || s.Role == roleLine && (e.roles[0]|e.roles[1])&roleLine == 0 {
+				e.forward = forward
+			}
+			e.roles[s.Geom] |= s.Role
+			if s.Role == roleArea {
+				if forward {
+					e.areaLeft[s.Geom] = true
+				} else {
+					e.areaRight[s.Geom] = true
+				}
+			}
+		}
+	}
+
+	var locators [2]*topology.AreaLocator
+	for k, operand := range operands {
+		locators[k] = topology.NewAreaLocator(operand.Polygons)
+	}
+
+	var areaEdges, lineEdges []*directedEdge
+	for _, key := range keys {
+		e := edges[key]
+		var left, right, in [2]bool
+		for k := range operands {
+			if e.roles[k]&roleArea != 0 {
+				left[k], right[k] = e.areaLeft[k], e.areaRight[k]
+			} else {
+				mid := topology.Vertex{(e.start[0] + e.end[0]) / 2, (e.start[1] + e.end[1]) / 2}
+				inside := locators[k].Contains(mid)
+				left[k], right[k] = inside, inside
+			}
+			in[k] = e.roles[k] != 0 || left[k]
+		}
+		e.inLeft, e.inRight = isInResult(op, left), isInResult(op, right)
+		switch {
+		case e.inLeft && !e.inRight:
+			areaEdges = append(areaEdges, &directedEdge{from: e.start, to: e.end})
+		case e.inRight && !e.inLeft:
+			areaEdges = append(areaEdges, &directedEdge{from: e.end, to: e.start})
+		case e.inLeft && e.inRight:
+			continue
+		case isLineInResult(op, [2]bool{e.roles[0]&roleLine != 0, e.roles[1]&roleLine != 0}, in):
+			if e.forward {
+				lineEdges = append(lineEdges, &directedEdge{from: e.start, to: e.end})
+			} else {
+				lineEdges = append(lineEdges, &directedEdge{from: e.end, to: e.start})
+			}
+		default:
+			continue
+		}
+		e.inResult = true
+	}
+
+	// lines are split where the linework branches, where edges of different roles meet,
+	// and at the endpoints of the input lines, as in GEOS.
+	lineEnds := map[topology.Vertex]bool{}
+	for _, operand := range operands {
+		for _, line := range operand.Lines {
+			if len(line) > 1 {
+				lineEnds[vertex(line[0])], lineEnds[vertex(line[len(line)-1])] = true, true
+			}
+		}
+	}
+	isNode := func(v topology.Vertex) bool {
+		at := nodes[v]
+		return lineEnds[v] || len(at) != 2 || at[0].roles != at[1].roles
+	}
+
+	result := Result{
+		Polygons: buildPolygons(linkRings(areaEdges)),
+		Lines:    buildLines(lineEdges, isNode),
+	}
+
+	// points are located against the noded edges rather than noded with them,
+	// so that they do not add vertices to the result lines and polygons.
+	var points [2]map[topology.Vertex]bool
+	var candidates []topology.Vertex
+	for k, operand := range operands {
+		points[k] = map[topology.Vertex]bool{}
+		for _, p := range operand.Points {
+			points[k][vertex(p)] = true
+			candidates = append(candidates, vertex(p))
+		}
+	}
+	for _, key := range keys {
+		candidates = append(candidates, key[0], key[1])
+	}
+	seen := map[topology.Vertex]bool{}
+	for _, v := range candidates {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		at, ok := nodes[v]
+		if !ok {
+			at = edgesThrough(v, edges, keys)
+		}
+		var in, inArea, isPoint [2]bool
+		covered, interior := false, len(at) > 0
+		for _, e := range at {
+			covered = covered || e.inResult
+			interior = interior && e.inLeft && e.inRight
+			for k := range operands {
+				in[k] = in[k] || e.roles[k] != 0
+			}
+		}
+		if covered {
+			continue
+		}
+		for k := range operands {
+			isPoint[k] = points[k][v]
+			inArea[k] = !in[k] && locators[k].Contains(v)
+			in[k] = in[k] || isPoint[k] || inArea[k]
+		}
+		if len(at) == 0 {
+			interior = isInResult(op, inArea)
+		}
+		if isPointInResult(op, isPoint, in) && !interior {
+			result.Points = append(result.Points, matrix.Matrix{v[0], v[1]})
+		}
+	}
+	return result
+}
+
+// isLineInResult returns true if an edge is a line of the result of op, given whether it lies on
+// a line of each operand and whether it lies in each operand.
+func isLineInResult(op int, line, in [2]bool) bool {
+	switch op {
+	case OpIntersection:
+		return in[0] && in[1]
+	case OpUnion:
+		return line[0] || line[1]
+	case OpDifference:
+		return line[0] && !in[1]
+	case OpSymDifference:
+		return (line[0] && !in[1]) || (line[1] && !in[0])
+	}
+	return false
+}
+
+// isPointInResult returns true if a node is a point of the result of op, given whether it is
+// a point of each operand and whether it lies in each operand.
+func isPointInResult(op int, point, in [2]bool) bool {
+	switch op {
+	case OpIntersection:
+		return in[0] && in[1]
+	case OpUnion:
+		return point[0] || point[1]
+	case OpDifference:
+		return point[0] && !in[1]
+	case OpSymDifference:
+		return (point[0] && !in[1]) || (point[1] && !in[0])
+	}
+	return false
+}
+
+// edgesThrough returns the edges passing through v.
+func edgesThrough(v topology.Vertex, edges map[[2]topology.Vertex]*overlayEdge, keys [][2]topology.Vertex) []*overlayEdge {
+	var through []*overlayEdge
+	for _, key := range keys {
+		if topology.Orient(key[0], key[1], v) == 0 && topology.Between(key[0], key[1], v) {
+			through = append(through, edges[key])
+		}
+	}
+	return through
+}
+
+// buildLines links the line edges into lines, merging them at the vertices which are not nodes
+// and where exactly two of them meet. Each line follows the direction of the input line of the
+// edge it is started from.
+func buildLines(edges []*directedEdge, isNode func(v topology.Vertex) bool) []matrix.LineMatrix {
+	incident := map[topology.Vertex][]*directedEdge{}
+	for _, e := range edges {
+		incident[e.from] = append(incident[e.from], e)
+		incident[e.to] = append(incident[e.to], e)
+	}
+	// next returns the other edge at v if v joins exactly two edges and it is unused, with its other end.
+	next := func(v topology.Vertex, e *directedEdge) (*directedEdge, topology.Vertex, bool) {
+		at := incident[v]
+		if len(at) != 2 || isNode(v) {
+			return nil, v, false
+		}
+		other := at[0]
+		if other == e {
+			other = at[1]
+		}
+		if other.used {
+			return nil, v, false
+		}
+		other.used = true
+		if other.from == v {
+			return other, other.to, true
+		}
+		return other, other.from, true
+	}
+	var lines []matrix.LineMatrix
+	for _, first := range edges {
+		if first.used {
+			continue
+		}
+		first.used = true
+		forward := []topology.Vertex{first.from, first.to}
+		for e, v, ok := next(first.to, first); ok; e, v, ok = next(v, e) {
+			forward = append(forward, v)
+		}
+		var backward []topology.Vertex
+		for e, v, ok := next(first.from, first); ok; e, v, ok = next(v, e) {
+			backward = append(backward, v)
+		}
+		line := make(matrix.LineMatrix, 0, len(backward)+len(forward))
+		for i := len(backward) - 1; i >= 0; i-- {
+			line = append(line, matrix.Matrix{backward[i][0], backward[i][1]})
+		}
+		for _, v := range forward {
+			line = append(line, matrix.Matrix{v[0], v[1]})
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// vertex returns the vertex of a point.
+func vertex(p matrix.Matrix) topology.Vertex {
+	return topology.Vertex{p[0], p[1]}
+}
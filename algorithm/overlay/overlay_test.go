@@ -0,0 +1,73 @@
+package overlay
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+)
+
+func TestCompute(t *testing.T) {
+	polygon := Operand{Polygons: []matrix.PolygonMatrix{{square(0, 0, 10)}}}
+	adjacent := Operand{Polygons: []matrix.PolygonMatrix{{square(10, 0, 10)}}}
+	corner := Operand{Polygons: []matrix.PolygonMatrix{{square(10, 10, 10)}}}
+	line := Operand{Lines: []matrix.LineMatrix{{{50, 100}, {50, 200}}}}
+	overlapping := Operand{Lines: []matrix.LineMatrix{{{50, 50}, {50, 150}}}}
+	crossing := Operand{Lines: []matrix.LineMatrix{{{-5, 5}, {5, 5}, {15, 5}}}}
+	cross := Operand{Lines: []matrix.LineMatrix{{{0, 0}, {2, 2}}, {{0, 2}, {2, 0}}}}
+	points := Operand{Points: []matrix.Matrix{{0, 0}, {5, 5}, {20, 20}}}
+
+	tests := []struct {
+		name string
+		a, b Operand
+		op   int
+		want Result
+	}{
+		{name: "line difference", a: line, b: overlapping, op: OpDifference,
+			want: Result{Lines: []matrix.LineMatrix{{{50, 150}, {50, 200}}}}},
+		{name: "line sym difference", a: line, b: overlapping, op: OpSymDifference,
+			want: Result{Lines: []matrix.LineMatrix{{{50, 150}, {50, 200}}, {{50, 50}, {50, 100}}}}},
+		{name: "line intersection", a: line, b: overlapping, op: OpIntersection,
+			want: Result{Lines: []matrix.LineMatrix{{{50, 100}, {50, 150}}}}},
+		{name: "line union", a: line, b: overlapping, op: OpUnion,
+			want: Result{Lines: []matrix.LineMatrix{{{50, 100}, {50, 150}}, {{50, 150}, {50, 200}}, {{50, 50}, {50, 100}}}}},
+		{name: "crossing lines", a: cross, b: Operand{Lines: cross.Lines[1:]}, op: OpDifference,
+			want: Result{Lines: []matrix.LineMatrix{{{0, 0}, {1, 1}}, {{1, 1}, {2, 2}}}}},
+		{name: "crossing point", a: Operand{Lines: cross.Lines[:1]}, b: Operand{Lines: cross.Lines[1:]}, op: OpIntersection,
+			want: Result{Points: []matrix.Matrix{{1, 1}}}},
+		{name: "line in polygon", a: crossing, b: polygon, op: OpIntersection,
+			want: Result{Lines: []matrix.LineMatrix{{{0, 5}, {5, 5}, {10, 5}}}}},
+		{name: "line out of polygon", a: crossing, b: polygon, op: OpDifference,
+			want: Result{Lines: []matrix.LineMatrix{{{-5, 5}, {0, 5}}, {{10, 5}, {15, 5}}}}},
+		{name: "polygon minus line", a: polygon, b: crossing, op: OpDifference,
+			want: Result{Polygons: matrix.MultiPolygonMatrix{{{{0, 0}, {0, 5}, {0, 10}, {10, 10}, {10, 5}, {10, 0}, {0, 0}}}}}},
+		{name: "shared edge", a: polygon, b: adjacent, op: OpIntersection,
+			want: Result{Lines: []matrix.LineMatrix{{{10, 0}, {10, 10}}}}},
+		{name: "touching vertex", a: polygon, b: corner, op: OpIntersection,
+			want: Result{Points: []matrix.Matrix{{10, 10}}}},
+		{name: "points in polygon", a: points, b: polygon, op: OpIntersection,
+			want: Result{Points: []matrix.Matrix{{0, 0}, {5, 5}}}},
+		{name: "points out of polygon", a: points, b: polygon, op: OpDifference,
+			want: Result{Points: []matrix.Matrix{{20, 20}}}},
+		{name: "points and polygon", a: points, b: polygon, op: OpSymDifference,
+			want: Result{Polygons: matrix.MultiPolygonMatrix{{{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}}}},
+				Points: []matrix.Matrix{{20, 20}}}},
+		{name: "point on line", a: Operand{Points: []matrix.Matrix{{50, 120}}}, b: line, op: OpIntersection,
+			want: Result{Points: []matrix.Matrix{{50, 120}}}},
+		{name: "disjoint", a: line, b: polygon, op: OpIntersection, want: Result{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compute(tt.a, tt.b, tt.op)
+			if !equalResult(got, tt.want) {
+				t.Errorf("Compute() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func equalResult(r1, r2 Result) bool {
+	return len(r1.Points) == len(r2.Points) && (len(r1.Points) == 0 || reflect.DeepEqual(r1.Points, r2.Points)) &&
+		len(r1.Lines) == len(r2.Lines) && (len(r1.Lines) == 0 || reflect.DeepEqual(r1.Lines, r2.Lines)) &&
+		len(r1.Polygons) == len(r2.Polygons) && (len(r1.Polygons) == 0 || reflect.DeepEqual(r1.Polygons, r2.Polygons))
+}
@@ -0,0 +1,169 @@
+package overlay
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+	"github.com/spatial-go/geoos/algorithm/topology"
+)
+
+// PolygonOverlay computes the overlay of two polygonal geometries given as polygons with
+// disjoint interiors. Polygons may have holes, and may touch at vertices or share edges.
+// Lines and points where the polygons only touch are not part of the result.
+func PolygonOverlay(m0, m1 matrix.MultiPolygonMatrix, op int) matrix.MultiPolygonMatrix {
+	return Compute(polygonOperand(m0), polygonOperand(m1), op).Polygons
+}
+
+// polygonOperand returns an operand made of the polygons of m.
+func polygonOperand(m matrix.MultiPolygonMatrix) Operand {
+	operand := Operand{Polygons: make([]matrix.PolygonMatrix, len(m))}
+	for i, polygon := range m {
+		operand.Polygons[i] = polygon
+	}
+	return operand
+}
+
+// isInResult returns true if a point with the given locations in both geometries is in the result of op.
+func isInResult(op int, in [2]bool) bool {
+	switch op {
+	case OpIntersection:
+		return in[0] && in[1]
+	case OpUnion:
+		return in[0] || in[1]
+	case OpDifference:
+		return in[0] && !in[1]
+	case OpSymDifference:
+		return in[0] != in[1]
+	}
+	return false
+}
+
+// linkRings links the directed edges into closed rings. At a node reached by several rings,
+// the edge leaving it is the first one clockwise from the edge arriving, so that every ring
+// bounds a single face of the result. Rings touching themselves are split into simple rings.
+func linkRings(edges []*directedEdge) []matrix.LineMatrix {
+	outgoing := map[topology.Vertex][]*directedEdge{}
+	for _, e := range edges {
+		outgoing[e.from] = append(outgoing[e.from], e)
+	}
+	var rings []matrix.LineMatrix
+	for _, first := range edges {
+		if first.used {
+			continue
+		}
+		walk := []topology.Vertex{first.from}
+		closed := false
+		for e := first; ; {
+			e.used = true
+			next := nextEdge(e, outgoing[e.to], first)
+			if next == nil {
+				break
+			}
+			if next == first {
+				closed = true
+				break
+			}
+			walk = append(walk, next.from)
+			e = next
+		}
+		if closed {
+			rings = append(rings, splitWalk(walk)...)
+		}
+	}
+	return rings
+}
+
+// nextEdge returns the unused edge leaving the end of e which is the first one clockwise from e reversed.
+// The first edge of the ring being linked is a candidate too, to close it.
+func nextEdge(e *directedEdge, candidates []*directedEdge, first *directedEdge) *directedEdge {
+	back := math.Atan2(e.from[1]-e.to[1], e.from[0]-e.to[0])
+	var next *directedEdge
+	best := math.Inf(1)
+	for _, c := range candidates {
+		if c.used && c != first {
+			continue
+		}
+		turn := back - math.Atan2(c.to[1]-c.from[1], c.to[0]-c.from[0])
+		for turn <= 0 {
+			turn += 2 * math.Pi
+		}
+		for turn > 2*math.Pi {
+			turn -= 2 * math.Pi
+		}
+		if turn < best {
+			next, best = c, turn
+		}
+	}
+	return next
+}
+
+// splitWalk splits a closed walk of vertices at the vertices it visits twice into closed simple rings.
+func splitWalk(walk []topology.Vertex) []matrix.LineMatrix {
+	var rings []matrix.LineMatrix
+	var stack []topology.Vertex
+	index := map[topology.Vertex]int{}
+	for _, v := range append(walk, walk[0]) {
+		if i, ok := index[v]; ok {
+			ring := make(matrix.LineMatrix, 0, len(stack)-i+1)
+			for _, p := range stack[i:] {
+				ring = append(ring, matrix.Matrix{p[0], p[1]})
+				delete(index, p)
+			}
+			ring = append(ring, matrix.Matrix{v[0], v[1]})
+			if len(ring) >= 4 {
+				rings = append(rings, ring)
+			}
+			stack = stack[:i]
+		}
+		index[v] = len(stack)
+		stack = append(stack, v)
+	}
+	return rings
+}
+
+// buildPolygons assigns the holes to the shells containing them. Rings have the interior of the
+// result on their left, so shells are counter-clockwise and holes clockwise: they are reversed
+// to follow the orientation of the polygons computed by GEOS.
+func buildPolygons(rings []matrix.LineMatrix) matrix.MultiPolygonMatrix {
+	var shells, holes []matrix.LineMatrix
+	for _, ring := range rings {
+		switch direction := measure.AreaDirection(ring); {
+		case direction < 0:
+			shells = append(shells, reverseRing(ring))
+		case direction > 0:
+			holes = append(holes, reverseRing(ring))
+		}
+	}
+	result := make(matrix.MultiPolygonMatrix, len(shells))
+	areas := make([]float64, len(shells))
+	locators := make([]*topology.AreaLocator, len(shells))
+	for i, shell := range shells {
+		result[i] = matrix.PolygonMatrix{shell}
+		areas[i] = math.Abs(measure.AreaDirection(shell))
+		locators[i] = topology.NewAreaLocator([]matrix.PolygonMatrix{{shell}})
+	}
+	for _, hole := range holes {
+		// the midpoint of an edge of the hole does not lie on another ring
+		p := topology.Vertex{(hole[0][0] + hole[1][0]) / 2, (hole[0][1] + hole[1][1]) / 2}
+		owner := -1
+		for i := range shells {
+			if locators[i].Contains(p) && (owner < 0 || areas[i] < areas[owner]) {
+				owner = i
+			}
+		}
+		if owner >= 0 {
+			result[owner] = append(result[owner], hole)
+		}
+	}
+	return result
+}
+
+// reverseRing returns the points of the ring in reverse order.
+func reverseRing(ring matrix.LineMatrix) matrix.LineMatrix {
+	reversed := make(matrix.LineMatrix, len(ring))
+	for i, p := range ring {
+		reversed[len(ring)-1-i] = p
+	}
+	return reversed
+}
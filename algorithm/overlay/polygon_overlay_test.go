@@ -0,0 +1,102 @@
+package overlay
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+)
+
+func square(x, y, size float64) matrix.LineMatrix {
+	return matrix.LineMatrix{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}, {x, y}}
+}
+
+func TestPolygonOverlay(t *testing.T) {
+	a := matrix.MultiPolygonMatrix{{square(0, 0, 10)}}
+	b := matrix.MultiPolygonMatrix{{square(5, 5, 10)}}
+	withHole := matrix.MultiPolygonMatrix{{square(0, 0, 10), square(2, 2, 6)}}
+	inHole := matrix.MultiPolygonMatrix{{square(4, 4, 2)}}
+	adjacent := matrix.MultiPolygonMatrix{{square(10, 0, 10)}}
+	corner := matrix.MultiPolygonMatrix{{square(10, 10, 10)}}
+	triangle := matrix.MultiPolygonMatrix{{{{0, 5}, {5, 2}, {5, 8}, {0, 5}}}}
+	multi := matrix.MultiPolygonMatrix{{square(0, 0, 4)}, {square(6, 6, 4)}}
+
+	tests := []struct {
+		name      string
+		m0, m1    matrix.MultiPolygonMatrix
+		op        int
+		area      float64
+		polygons  int
+		holes     int
+		wantEmpty bool
+	}{
+		{name: "union", m0: a, m1: b, op: OpUnion, area: 175, polygons: 1},
+		{name: "intersection", m0: a, m1: b, op: OpIntersection, area: 25, polygons: 1},
+		{name: "difference", m0: a, m1: b, op: OpDifference, area: 75, polygons: 1},
+		{name: "sym difference", m0: a, m1: b, op: OpSymDifference, area: 150, polygons: 2},
+		{name: "difference creates hole", m0: a, m1: matrix.MultiPolygonMatrix{{square(2, 2, 6)}}, op: OpDifference, area: 64, polygons: 1, holes: 1},
+		{name: "union with hole", m0: withHole, m1: b, op: OpUnion, area: 175 - 27, polygons: 1, holes: 1},
+		{name: "intersection with hole", m0: withHole, m1: b, op: OpIntersection, area: 25 - 9, polygons: 1},
+		{name: "polygon in hole", m0: withHole, m1: inHole, op: OpUnion, area: 68, polygons: 2, holes: 1},
+		{name: "polygon in hole intersection", m0: withHole, m1: inHole, op: OpIntersection, wantEmpty: true},
+		{name: "shared edge", m0: a, m1: adjacent, op: OpUnion, area: 200, polygons: 1},
+		{name: "shared edge intersection", m0: a, m1: adjacent, op: OpIntersection, wantEmpty: true},
+		{name: "touching vertex", m0: a, m1: corner, op: OpUnion, area: 200, polygons: 2},
+		{name: "hole touching shell", m0: a, m1: triangle, op: OpDifference, area: 85, polygons: 1, holes: 1},
+		{name: "multipolygon", m0: multi, m1: b, op: OpIntersection, area: 16, polygons: 1},
+		{name: "multipolygon union", m0: multi, m1: b, op: OpUnion, area: 16 + 100, polygons: 2},
+		{name: "same polygon", m0: withHole, m1: withHole, op: OpUnion, area: 64, polygons: 1, holes: 1},
+		{name: "same polygon difference", m0: withHole, m1: withHole, op: OpDifference, wantEmpty: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PolygonOverlay(tt.m0, tt.m1, tt.op)
+			if tt.wantEmpty {
+				if len(got) != 0 {
+					t.Errorf("PolygonOverlay() got = %v, want empty", got)
+				}
+				return
+			}
+			area, holes := 0.0, 0
+			for _, polygon := range got {
+				for i, ring := range polygon {
+					direction := measure.AreaDirection(ring)
+					if (direction > 0) != (i == 0) {
+						t.Errorf("PolygonOverlay() ring %v has a wrong orientation", ring)
+					}
+					if i > 0 {
+						holes++
+						area -= math.Abs(direction)
+					} else {
+						area += math.Abs(direction)
+					}
+				}
+			}
+			if len(got) != tt.polygons || holes != tt.holes || math.Abs(area-tt.area) > 1e-9 {
+				t.Errorf("PolygonOverlay() got %v polygons, %v holes, area %v, want %v, %v, %v: %v",
+					len(got), holes, area, tt.polygons, tt.holes, tt.area, got)
+			}
+		})
+	}
+}
+
+func TestUnaryUnionPolygons(t *testing.T) {
+	polygons := matrix.MultiPolygonMatrix{{square(0, 0, 10)}, {square(5, 5, 10)}, {square(20, 20, 1)}, {square(14, 14, 2)}}
+	got := UnaryUnionPolygons(polygons)
+	if len(got) != 2 {
+		t.Fatalf("UnaryUnionPolygons() got %v polygons, want 2", len(got))
+	}
+	area := math.Abs(measure.AreaDirection(got[0][0])) + math.Abs(measure.AreaDirection(got[1][0]))
+	if area != 175+3+1 {
+		t.Errorf("UnaryUnionPolygons() area got = %v, want %v", area, 179)
+	}
+
+	if rings := UnaryUnion(polygons); len(rings) != 2 {
+		t.Errorf("UnaryUnion() got %v rings, want 2", len(rings))
+	}
+	union := Union(matrix.PolygonMatrix{square(0, 0, 10), square(2, 2, 2)}, matrix.PolygonMatrix{square(5, 5, 10)})
+	if len(union) != 2 || math.Abs(measure.AreaDirection(union[0]))-math.Abs(measure.AreaDirection(union[1])) != 171 {
+		t.Errorf("Union() got = %v, want the union with the hole", union)
+	}
+}
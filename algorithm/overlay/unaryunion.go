@@ -1,85 +1,77 @@
 package overlay
 
 import (
-	"github.com/spatial-go/geoos/algorithm"
 	"github.com/spatial-go/geoos/algorithm/matrix"
 )
 
 // UnaryUnion returns a Geometry containing the union.
-//	or an empty atomic geometry, or an empty GEOMETRYCOLLECTION
+// The rings of all the polygons of the union are returned in one PolygonMatrix.
+//
+// Deprecated: use UnaryUnionPolygons, which returns each polygon of the union separately.
 func UnaryUnion(matrix4 matrix.MultiPolygonMatrix) matrix.PolygonMatrix {
 	return UnaryUnionByHalf(matrix4, 0, len(matrix4))
 }
 
 // UnaryUnionByHalf returns Unions a section of a list using a recursive binary union on each half of the section.
+// The rings of all the polygons of the union are returned in one PolygonMatrix.
+//
+// Deprecated: use UnaryUnionPolygons, which returns each polygon of the union separately.
 func UnaryUnionByHalf(matrix4 matrix.MultiPolygonMatrix, start, end int) matrix.PolygonMatrix {
 	if matrix4 == nil {
 		return nil
 	}
-	if end-start <= 1 {
-		return Union(matrix4[start], nil)
-	} else if end-start == 2 {
-		return Union(matrix4[start], matrix4[start+1])
-	} else {
-		mid := (end + start) / 2
-		g0 := UnaryUnionByHalf(matrix4, start, mid)
-		g1 := UnaryUnionByHalf(matrix4, mid, end)
-		return Union(g0, g1)
-	}
+	return flattenPolygons(unaryUnionByHalf(matrix4, start, end))
 }
 
-// Computes the Union of two geometries,either or both of which may be null.
+// Union Computes the Union of two geometries,either or both of which may be null.
+// The rings of all the polygons of the union are returned in one PolygonMatrix.
+//
+// Deprecated: use UnionPolygons, which returns each polygon of the union separately.
 func Union(m0, m1 matrix.PolygonMatrix) matrix.PolygonMatrix {
-
 	if m0 == nil && m1 == nil {
 		return nil
 	}
 	if m0 == nil {
 		return m1
 	}
-
 	if m1 == nil {
 		return m0
 	}
-
-	return unionActual(m0, m1)
+	return flattenPolygons(UnionPolygons(matrix.MultiPolygonMatrix{m0}, matrix.MultiPolygonMatrix{m1}))
 }
 
-// unionActual the actual unioning of two polygonal geometries.
-func unionActual(m0, m1 matrix.PolygonMatrix) matrix.PolygonMatrix {
-
-	subject := &algorithm.Plane{}
-	for _, v2 := range m0 {
-		for i, v1 := range v2 {
-			if i < len(v2)-1 {
-				subject.AddPoint(&algorithm.Vertex{Matrix: matrix.Matrix(v1)})
-			}
-		}
-		subject.CloseRing()
-		subject.Rank = algorithm.MAIN
+// UnaryUnionPolygons returns the union of the polygons, which may overlap.
+func UnaryUnionPolygons(matrix4 matrix.MultiPolygonMatrix) matrix.MultiPolygonMatrix {
+	if matrix4 == nil {
+		return nil
 	}
-	clipping := &algorithm.Plane{}
-	for _, v2 := range m1 {
-		for i, v1 := range v2 {
-			if i < len(v2)-1 {
-				clipping.AddPoint(&algorithm.Vertex{Matrix: matrix.Matrix(v1)})
-			}
-		}
-		clipping.CloseRing()
-		clipping.Rank = algorithm.CUT
+	return unaryUnionByHalf(matrix4, 0, len(matrix4))
+}
+
+// unaryUnionByHalf unions a section of a list using a recursive binary union on each half of the section.
+func unaryUnionByHalf(matrix4 matrix.MultiPolygonMatrix, start, end int) matrix.MultiPolygonMatrix {
+	if end-start <= 1 {
+		return UnionPolygons(matrix4[start:end], nil)
+	} else if end-start == 2 {
+		return UnionPolygons(matrix4[start:start+1], matrix4[start+1:end])
 	}
-	poly := Weiler(subject, clipping, Merge)
-	var result matrix.PolygonMatrix
-	for _, v2 := range poly.Rings {
-		var edge matrix.LineMatrix
-		for _, v1 := range v2.Vertexs {
-			edge = append(edge, v1.Matrix)
-		}
-		if !matrix.Equal(edge[len(edge)-1], edge[0]) {
-			edge = append(edge, edge[0])
-		}
-		result = append(result, edge)
+	mid := (end + start) / 2
+	return UnionPolygons(unaryUnionByHalf(matrix4, start, mid), unaryUnionByHalf(matrix4, mid, end))
+}
+
+// UnionPolygons computes the union of two polygonal geometries, either or both of which may be null.
+func UnionPolygons(m0, m1 matrix.MultiPolygonMatrix) matrix.MultiPolygonMatrix {
+	if m0 == nil && m1 == nil {
+		return nil
 	}
+	return PolygonOverlay(m0, m1, OpUnion)
+}
 
-	return result
+// flattenPolygons returns the rings of all the polygons as one PolygonMatrix.
+func flattenPolygons(polygons matrix.MultiPolygonMatrix) matrix.PolygonMatrix {
+	var rings matrix.PolygonMatrix
+	for _, polygon := range polygons {
+		rings = append(rings, polygon...)
+	}
+	return rings
 }
@@ -35,16 +35,22 @@ func InLine(spot, a, b *algorithm.Vertex) bool {
 }
 
 // Merge merge polygon.
+//
+// Deprecated: use UnionPolygons or UnaryUnionPolygons, which handle holes, shared edges and touching polygons.
 func Merge(walkings []*algorithm.Edge, pol *algorithm.Plane, start *algorithm.Vertex, which bool) *algorithm.Vertex {
 	return Overlay(walkings, pol, start, which, algorithm.MERGE)
 }
 
 // Clip clip polygon.
+//
+// Deprecated: use PolygonOverlay with OpIntersection, which handles holes, shared edges and touching polygons.
 func Clip(walkings []*algorithm.Edge, pol *algorithm.Plane, start *algorithm.Vertex, which bool) *algorithm.Vertex {
 	return Overlay(walkings, pol, start, which, algorithm.CLIP)
 }
 
 // Overlay overlay polygon.
+//
+// Deprecated: use PolygonOverlay, or UnionPolygons and UnaryUnionPolygons for unions.
 func Overlay(walkings []*algorithm.Edge, pol *algorithm.Plane, start *algorithm.Vertex, which bool, kind int) *algorithm.Vertex {
 	// find in each edge
 	for _, w := range walkings {
@@ -153,6 +159,9 @@ func IntersectionEdge(aLine, bLine algorithm.Edge) (mark bool, ps []*algorithm.V
 }
 
 // Weiler Weiler overlay.
+// The Weiler-Atherton walk only handles simple polygons without holes which cross at proper intersections.
+//
+// Deprecated: use PolygonOverlay, or UnionPolygons and UnaryUnionPolygons for unions.
 func Weiler(subject, clipping *algorithm.Plane, ath Atherton) *algorithm.Plane {
 	var pol *algorithm.Plane = &algorithm.Plane{}
 
@@ -0,0 +1,173 @@
+package relate
+
+import (
+	"errors"
+	"strings"
+)
+
+// Locations of a point relative to a geometry, used as indexes of the intersection matrix.
+const (
+	Interior = 0
+	Boundary = 1
+	Exterior = 2
+)
+
+// Dimension values of the intersection matrix.
+const (
+	// DimFalse is the dimension of the empty set.
+	DimFalse = -1
+	// DimPoint is the dimension of a point set.
+	DimPoint = 0
+	// DimLine is the dimension of a curve set.
+	DimLine = 1
+	// DimArea is the dimension of a surface set.
+	DimArea = 2
+)
+
+// ErrInvalidPattern is returned by Matches for patterns which are not 9 characters of "TF*012".
+var ErrInvalidPattern = errors.New("invalid intersection matrix pattern")
+
+// IntersectionMatrix is a Dimensionally Extended Nine-Intersection Model (DE-9IM) matrix.
+// Element [i][j] is the dimension of the intersection of location i of the first geometry
+// with location j of the second geometry.
+type IntersectionMatrix [3][3]int
+
+// NewIntersectionMatrix returns an intersection matrix with all elements set to DimFalse.
+func NewIntersectionMatrix() IntersectionMatrix {
+	var im IntersectionMatrix
+	for i := range im {
+		for j := range im[i] {
+			im[i][j] = DimFalse
+		}
+	}
+	return im
+}
+
+// setAtLeast raises the element [i][j] to dim.
+func (im *IntersectionMatrix) setAtLeast(i, j, dim int) {
+	if im[i][j] < dim {
+		im[i][j] = dim
+	}
+}
+
+// String returns the matrix as a 9 character string, such as "212101212".
+func (im IntersectionMatrix) String() string {
+	var b strings.Builder
+	for i := range im {
+		for j := range im[i] {
+			b.WriteByte(dimensionSymbol(im[i][j]))
+		}
+	}
+	return b.String()
+}
+
+// dimensionSymbol returns the character representing dim.
+func dimensionSymbol(dim int) byte {
+	if dim == DimFalse {
+		return 'F'
+	}
+	return byte('0' + dim)
+}
+
+// Matches returns true if the matrix matches the pattern, a 9 character string
+// of 'T' (any non empty dimension), 'F' (empty), '*' (any dimension) or '0', '1', '2'.
+func (im IntersectionMatrix) Matches(pattern string) (bool, error) {
+	if len(pattern) != 9 {
+		return false, ErrInvalidPattern
+	}
+	match := true
+	for k := 0; k < 9; k++ {
+		dim := im[k/3][k%3]
+		switch pattern[k] {
+		case '*':
+		case 'T', 't':
+			match = match && dim != DimFalse
+		case 'F', 'f':
+			match = match && dim == DimFalse
+		case '0', '1', '2':
+			match = match && dim == int(pattern[k]-'0')
+		default:
+			return false, ErrInvalidPattern
+		}
+	}
+	return match, nil
+}
+
+// IsDisjoint returns true if the geometries have no point in common.
+func (im IntersectionMatrix) IsDisjoint() bool {
+	return im[Interior][Interior] == DimFalse && im[Interior][Boundary] == DimFalse &&
+		im[Boundary][Interior] == DimFalse && im[Boundary][Boundary] == DimFalse
+}
+
+// IsIntersects returns true if the geometries have at least one point in common.
+func (im IntersectionMatrix) IsIntersects() bool {
+	return !im.IsDisjoint()
+}
+
+// IsTouches returns true if the geometries have at least one point in common,
+// but their interiors do not intersect. dimA and dimB are the dimensions of the geometries.
+func (im IntersectionMatrix) IsTouches(dimA, dimB int) bool {
+	if dimA == DimPoint && dimB == DimPoint {
+		// points have only interiors, so they cannot touch
+		return false
+	}
+	return im[Interior][Interior] == DimFalse &&
+		(im[Interior][Boundary] != DimFalse || im[Boundary][Interior] != DimFalse || im[Boundary][Boundary] != DimFalse)
+}
+
+// IsCrosses returns true if the geometries have some but not all interior points in common.
+// dimA and dimB are the dimensions of the geometries.
+func (im IntersectionMatrix) IsCrosses(dimA, dimB int) bool {
+	switch {
+	case dimA < dimB:
+		return im[Interior][Interior] != DimFalse && im[Interior][Exterior] != DimFalse
+	case dimA > dimB:
+		return im[Interior][Interior] != DimFalse && im[Exterior][Interior] != DimFalse
+	case dimA == DimLine:
+		return im[Interior][Interior] == DimPoint
+	}
+	return false
+}
+
+// IsWithin returns true if the first geometry is within the second.
+func (im IntersectionMatrix) IsWithin() bool {
+	return im[Interior][Interior] != DimFalse && im[Interior][Exterior] == DimFalse && im[Boundary][Exterior] == DimFalse
+}
+
+// IsContains returns true if the first geometry contains the second.
+func (im IntersectionMatrix) IsContains() bool {
+	return im[Interior][Interior] != DimFalse && im[Exterior][Interior] == DimFalse && im[Exterior][Boundary] == DimFalse
+}
+
+// IsCovers returns true if no point of the second geometry lies in the exterior of the first.
+func (im IntersectionMatrix) IsCovers() bool {
+	hasPointInCommon := im[Interior][Interior] != DimFalse || im[Interior][Boundary] != DimFalse ||
+		im[Boundary][Interior] != DimFalse || im[Boundary][Boundary] != DimFalse
+	return hasPointInCommon && im[Exterior][Interior] == DimFalse && im[Exterior][Boundary] == DimFalse
+}
+
+// IsCoveredBy returns true if no point of the first geometry lies in the exterior of the second.
+func (im IntersectionMatrix) IsCoveredBy() bool {
+	hasPointInCommon := im[Interior][Interior] != DimFalse || im[Interior][Boundary] != DimFalse ||
+		im[Boundary][Interior] != DimFalse || im[Boundary][Boundary] != DimFalse
+	return hasPointInCommon && im[Interior][Exterior] == DimFalse && im[Boundary][Exterior] == DimFalse
+}
+
+// IsEquals returns true if the geometries are topologically equal.
+func (im IntersectionMatrix) IsEquals() bool {
+	return im[Interior][Interior] != DimFalse && im[Interior][Exterior] == DimFalse && im[Boundary][Exterior] == DimFalse &&
+		im[Exterior][Interior] == DimFalse && im[Exterior][Boundary] == DimFalse
+}
+
+// IsOverlaps returns true if the geometries have the same dimension, their interiors intersect
+// in that dimension and each has at least one point not in the other.
+// dimA and dimB are the dimensions of the geometries.
+func (im IntersectionMatrix) IsOverlaps(dimA, dimB int) bool {
+	if dimA != dimB {
+		return false
+	}
+	if dimA == DimLine {
+		return im[Interior][Interior] == DimLine && im[Interior][Exterior] != DimFalse && im[Exterior][Interior] != DimFalse
+	}
+	return im[Interior][Interior] != DimFalse && im[Interior][Exterior] != DimFalse && im[Exterior][Interior] != DimFalse
+}
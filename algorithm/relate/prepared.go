@@ -0,0 +1,374 @@
+package relate
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/topology"
+)
+
+// Prepared is a geometry whose linework is indexed once,
+// for repeated evaluation of predicates against other geometries.
+type Prepared struct {
+	comps *Components
+	area  *topology.AreaLocator
+	segs  *segmentIndex
+
+	lineBoundaries map[topology.Vertex]bool
+	minX, minY     float64
+	maxX, maxY     float64
+}
+
+// NewPrepared prepares the components c.
+func NewPrepared(c *Components) *Prepared {
+	p := &Prepared{
+		comps:          c,
+		area:           topology.NewAreaLocator(c.Polygons),
+		lineBoundaries: map[topology.Vertex]bool{},
+		minX:           math.Inf(1),
+		minY:           math.Inf(1),
+		maxX:           math.Inf(-1),
+		maxY:           math.Inf(-1),
+	}
+	var segs []indexedSegment
+	for _, pt := range c.Points {
+		// points are indexed as segments with equal endpoints
+		segs = append(segs, indexedSegment{start: topology.Vertex{pt[0], pt[1]}, end: topology.Vertex{pt[0], pt[1]}, role: rolePoint})
+		p.extend(pt)
+	}
+	endpoints := map[topology.Vertex]int{}
+	for _, line := range c.Lines {
+		for i := 0; i < len(line)-1; i++ {
+			segs = append(segs, indexedSegment{start: topology.Vertex{line[i][0], line[i][1]}, end: topology.Vertex{line[i+1][0], line[i+1][1]}, role: roleLine})
+		}
+		for _, pt := range line {
+			p.extend(pt)
+		}
+		if len(line) < 2 {
+			continue
+		}
+		endpoints[topology.Vertex{line[0][0], line[0][1]}]++
+		endpoints[topology.Vertex{line[len(line)-1][0], line[len(line)-1][1]}]++
+	}
+	for v, count := range endpoints {
+		if count%2 == 1 {
+			p.lineBoundaries[v] = true
+		}
+	}
+	for _, polygon := range c.Polygons {
+		for _, ring := range polygon {
+			for i := 0; i < len(ring)-1; i++ {
+				segs = append(segs, indexedSegment{start: topology.Vertex{ring[i][0], ring[i][1]}, end: topology.Vertex{ring[i+1][0], ring[i+1][1]}, role: roleArea})
+			}
+			for _, pt := range ring {
+				p.extend(pt)
+			}
+		}
+	}
+	p.segs = newSegmentIndex(segs)
+	return p
+}
+
+// extend grows the envelope of the prepared geometry to include pt.
+func (p *Prepared) extend(pt []float64) {
+	p.minX, p.minY = math.Min(p.minX, pt[0]), math.Min(p.minY, pt[1])
+	p.maxX, p.maxY = math.Max(p.maxX, pt[0]), math.Max(p.maxY, pt[1])
+}
+
+// Locate returns the location of the point pt in the prepared geometry.
+func (p *Prepared) Locate(pt []float64) int {
+	v := topology.Vertex{pt[0], pt[1]}
+	if v[0] < p.minX || v[0] > p.maxX || v[1] < p.minY || v[1] > p.maxY {
+		return Exterior
+	}
+	roles := p.segs.rolesAt(v)
+	switch {
+	case roles&roleArea != 0:
+		return Boundary
+	case p.area.Contains(v):
+		return Interior
+	case p.lineBoundaries[v]:
+		return Boundary
+	case roles&(roleLine|rolePoint) != 0:
+		return Interior
+	}
+	return Exterior
+}
+
+// Intersects returns true if the prepared geometry and c have at least one point in common.
+func (p *Prepared) Intersects(c *Components) bool {
+	if !p.envelopeIntersects(c) {
+		return false
+	}
+	for _, pt := range c.Points {
+		if p.Locate(pt) != Exterior {
+			return true
+		}
+	}
+	for _, line := range c.Lines {
+		if p.intersectsLinework(line) || (len(line) > 0 && p.Locate(line[0]) != Exterior) {
+			return true
+		}
+	}
+	for _, polygon := range c.Polygons {
+		for _, ring := range polygon {
+			if p.intersectsLinework(ring) {
+				return true
+			}
+		}
+		if len(polygon) > 0 && len(polygon[0]) > 0 && p.Locate(polygon[0][0]) != Exterior {
+			return true
+		}
+	}
+	if len(c.Polygons) == 0 {
+		return false
+	}
+	// the prepared geometry may lie inside the polygons of c
+	other := topology.NewAreaLocator(c.Polygons)
+	for _, s := range p.segs.segs {
+		if other.Contains(s.start) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns true if the prepared geometry contains c.
+func (p *Prepared) Contains(c *Components) bool {
+	if ok, result := p.locateComponents(c, func(interior, boundary int) bool {
+		return interior > 0
+	}); ok {
+		return result
+	}
+	return p.relate(c).IsContains()
+}
+
+// Covers returns true if no point of c lies in the exterior of the prepared geometry.
+func (p *Prepared) Covers(c *Components) bool {
+	if ok, result := p.locateComponents(c, func(interior, boundary int) bool {
+		return interior+boundary > 0
+	}); ok {
+		return result
+	}
+	return p.relate(c).IsCovers()
+}
+
+// ContainsProperly returns true if c lies in the interior of the prepared geometry.
+func (p *Prepared) ContainsProperly(c *Components) bool {
+	if ok, result := p.locateComponents(c, func(interior, boundary int) bool {
+		return interior > 0 && boundary == 0
+	}); ok {
+		return result
+	}
+	match, _ := p.relate(c).Matches("T**FF*FF*")
+	return match
+}
+
+// locateComponents evaluates a containment predicate without computing the intersection matrix,
+// by counting the components of c lying in the interior and on the boundary of the prepared geometry.
+// A line or polygon of c whose linework does not touch the linework of the prepared geometry lies
+// entirely in its interior or its exterior, and is located by one of its vertices.
+// It returns false as its first result if the linework of c touches the linework of the prepared geometry,
+// so that the predicate has to be evaluated on the intersection matrix.
+func (p *Prepared) locateComponents(c *Components, predicate func(interior, boundary int) bool) (bool, bool) {
+	if (len(c.Lines) > 0 || len(c.Polygons) > 0) && !p.envelopeCovers(c) {
+		return true, false
+	}
+	interior, boundary := 0, 0
+	for _, pt := range c.Points {
+		switch p.Locate(pt) {
+		case Interior:
+			interior++
+		case Boundary:
+			boundary++
+		default:
+			return true, false
+		}
+	}
+	// locate returns false as its first result if the linework touches the prepared geometry.
+	locate := func(linework [][]float64) (bool, bool) {
+		if len(linework) == 0 {
+			return true, true
+		}
+		if p.intersectsLinework(linework) {
+			return false, false
+		}
+		switch p.Locate(linework[0]) {
+		case Interior:
+			interior++
+			return true, true
+		case Exterior:
+			return true, false
+		}
+		return false, false
+	}
+	for _, line := range c.Lines {
+		if ok, in := locate(line); !ok || !in {
+			return ok, false
+		}
+	}
+	for _, polygon := range c.Polygons {
+		for _, ring := range polygon {
+			if ok, in := locate(ring); !ok || !in {
+				return ok, false
+			}
+		}
+	}
+	if len(c.Polygons) > 0 {
+		// the boundary of an area of the prepared geometry inside the polygons of c has exterior points next to it
+		other := topology.NewAreaLocator(c.Polygons)
+		for _, s := range p.segs.segs {
+			if s.role == roleArea && other.Contains(s.start) {
+				return true, false
+			}
+		}
+	}
+	return true, predicate(interior, boundary)
+}
+
+// relate computes the full intersection matrix of the prepared geometry and c.
+func (p *Prepared) relate(c *Components) IntersectionMatrix {
+	return Relate(p.comps, c)
+}
+
+// envelopeIntersects returns true if the envelopes of the prepared geometry and c intersect.
+func (p *Prepared) envelopeIntersects(c *Components) bool {
+	minX, minY, maxX, maxY := envelope(c)
+	return !(minX > p.maxX || maxX < p.minX || minY > p.maxY || maxY < p.minY)
+}
+
+// envelopeCovers returns true if the envelope of the prepared geometry covers the envelope of c.
+func (p *Prepared) envelopeCovers(c *Components) bool {
+	minX, minY, maxX, maxY := envelope(c)
+	return minX >= p.minX && maxX <= p.maxX && minY >= p.minY && maxY <= p.maxY
+}
+
+// intersectsLinework returns true if a segment of the line intersects the linework of the prepared geometry.
+func (p *Prepared) intersectsLinework(line [][]float64) bool {
+	for i := 0; i < len(line)-1; i++ {
+		if p.segs.intersects(topology.Vertex{line[i][0], line[i][1]}, topology.Vertex{line[i+1][0], line[i+1][1]}) {
+			return true
+		}
+	}
+	return false
+}
+
+// envelope returns the envelope of the components.
+func envelope(c *Components) (minX, minY, maxX, maxY float64) {
+	minX, minY, maxX, maxY = math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	extend := func(pt []float64) {
+		minX, minY = math.Min(minX, pt[0]), math.Min(minY, pt[1])
+		maxX, maxY = math.Max(maxX, pt[0]), math.Max(maxY, pt[1])
+	}
+	for _, pt := range c.Points {
+		extend(pt)
+	}
+	for _, line := range c.Lines {
+		for _, pt := range line {
+			extend(pt)
+		}
+	}
+	for _, polygon := range c.Polygons {
+		for _, ring := range polygon {
+			for _, pt := range ring {
+				extend(pt)
+			}
+		}
+	}
+	return
+}
+
+// indexedSegment is a segment of the linework of a prepared geometry.
+type indexedSegment struct {
+	start, end topology.Vertex
+	role       int
+}
+
+// segmentIndex buckets segments by y to speed up intersection and location queries.
+type segmentIndex struct {
+	segs         []indexedSegment
+	minY, height float64
+	buckets      [][]int
+}
+
+// newSegmentIndex creates a segmentIndex over segs.
+func newSegmentIndex(segs []indexedSegment) *segmentIndex {
+	idx := &segmentIndex{segs: segs}
+	if len(segs) == 0 {
+		return idx
+	}
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, s := range segs {
+		minY, maxY = math.Min(minY, math.Min(s.start[1], s.end[1])), math.Max(maxY, math.Max(s.start[1], s.end[1]))
+	}
+	n := int(math.Sqrt(float64(len(segs)))) + 1
+	idx.minY, idx.height = minY, (maxY-minY)/float64(n)
+	if !(idx.height > 0) {
+		n, idx.height = 1, 1
+	}
+	idx.buckets = make([][]int, n)
+	for i, s := range segs {
+		lo, hi := idx.bucket(math.Min(s.start[1], s.end[1])), idx.bucket(math.Max(s.start[1], s.end[1]))
+		for k := lo; k <= hi; k++ {
+			idx.buckets[k] = append(idx.buckets[k], i)
+		}
+	}
+	return idx
+}
+
+// bucket returns the bucket containing y.
+func (idx *segmentIndex) bucket(y float64) int {
+	k := int((y - idx.minY) / idx.height)
+	if k < 0 {
+		return 0
+	}
+	if k >= len(idx.buckets) {
+		return len(idx.buckets) - 1
+	}
+	return k
+}
+
+// rolesAt returns the roles of the segments passing through v.
+func (idx *segmentIndex) rolesAt(v topology.Vertex) int {
+	if len(idx.segs) == 0 {
+		return 0
+	}
+	roles := 0
+	for _, i := range idx.buckets[idx.bucket(v[1])] {
+		s := idx.segs[i]
+		if v == s.start || v == s.end || (topology.Orient(s.start, s.end, v) == 0 && topology.Between(s.start, s.end, v)) {
+			roles |= s.role
+		}
+	}
+	return roles
+}
+
+// intersects returns true if the segment a-b intersects one of the indexed segments.
+func (idx *segmentIndex) intersects(a, b topology.Vertex) bool {
+	if len(idx.segs) == 0 {
+		return false
+	}
+	lo, hi := idx.bucket(math.Min(a[1], b[1])), idx.bucket(math.Max(a[1], b[1]))
+	for k := lo; k <= hi; k++ {
+		for _, i := range idx.buckets[k] {
+			if segmentsIntersect(a, b, idx.segs[i].start, idx.segs[i].end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// segmentsIntersect returns true if the closed segments p1-p2 and q1-q2 have a point in common.
+func segmentsIntersect(p1, p2, q1, q2 topology.Vertex) bool {
+	if math.Max(p1[0], p2[0]) < math.Min(q1[0], q2[0]) || math.Min(p1[0], p2[0]) > math.Max(q1[0], q2[0]) ||
+		math.Max(p1[1], p2[1]) < math.Min(q1[1], q2[1]) || math.Min(p1[1], p2[1]) > math.Max(q1[1], q2[1]) {
+		return false
+	}
+	o1, o2 := topology.Orient(p1, p2, q1), topology.Orient(p1, p2, q2)
+	o3, o4 := topology.Orient(q1, q2, p1), topology.Orient(q1, q2, p2)
+	if o1*o2 > 0 || o3*o4 > 0 {
+		return false
+	}
+	// collinear segments overlap if their envelopes do, which has been checked above
+	return true
+}
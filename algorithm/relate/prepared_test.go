@@ -0,0 +1,130 @@
+package relate
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+)
+
+func TestPrepared(t *testing.T) {
+	squareWithHole := append(square(0, 0, 10, 10), matrix.LineMatrix{{2, 2}, {2, 8}, {8, 8}, {8, 2}, {2, 2}})
+	prepared := []*Components{
+		{Polygons: []matrix.PolygonMatrix{squareWithHole}},
+		{Lines: []matrix.LineMatrix{{{0, 0}, {5, 0}}, {{5, 0}, {10, 0}}, {{0, 5}, {10, 5}}}},
+		{Points: []matrix.Matrix{{1, 1}, {5, 0}}},
+		{Polygons: []matrix.PolygonMatrix{squareWithHole, square(3, 3, 7, 7)}, Lines: []matrix.LineMatrix{{{0.2, 1}, {0.2, 9}}}},
+	}
+	candidates := []*Components{
+		{Points: []matrix.Matrix{{1, 1}}},
+		{Points: []matrix.Matrix{{5, 5}}},
+		{Points: []matrix.Matrix{{0, 5}}},
+		{Points: []matrix.Matrix{{5, 0}, {1, 1}}},
+		{Points: []matrix.Matrix{{20, 20}}},
+		{Lines: []matrix.LineMatrix{{{0.5, 0.5}, {1.5, 9.5}}}},
+		{Lines: []matrix.LineMatrix{{{-5, 1}, {1, 1}}}},
+		{Lines: []matrix.LineMatrix{{{0, 0}, {10, 0}}}},
+		{Lines: []matrix.LineMatrix{{{3, 3}, {7, 7}}}},
+		{Polygons: []matrix.PolygonMatrix{square(3, 3, 7, 7)}},
+		{Polygons: []matrix.PolygonMatrix{square(0, 0, 1, 1)}},
+		{Polygons: []matrix.PolygonMatrix{square(0.5, 0.5, 1.5, 1.5)}},
+		{Polygons: []matrix.PolygonMatrix{square(-1, -1, 11, 11)}},
+		{Polygons: []matrix.PolygonMatrix{square(20, 20, 30, 30)}},
+		{Polygons: []matrix.PolygonMatrix{square(1, 1, 9, 9)}},
+		{Polygons: []matrix.PolygonMatrix{append(square(1, 1, 9, 9), square(1.5, 1.5, 8.5, 8.5)[0])}},
+		{Lines: []matrix.LineMatrix{{{0.5, 0.5}, {0.5, 9.5}}}, Points: []matrix.Matrix{{0, 5}}},
+		{Lines: []matrix.LineMatrix{{{0.5, 0.5}, {0.5, 9.5}}, {{3, 3}, {7, 3}}}},
+		{Lines: []matrix.LineMatrix{{{0.5, 0.5}, {1.5, 0.5}}}, Polygons: []matrix.PolygonMatrix{square(8.5, 0.5, 9.5, 9.5)}},
+		{},
+	}
+	for i, a := range prepared {
+		p := NewPrepared(a)
+		for j, b := range candidates {
+			im := Relate(a, b)
+			containsProperly, _ := im.Matches("T**FF*FF*")
+			if got, want := p.Intersects(b), im.IsIntersects(); got != want {
+				t.Errorf("Intersects(%d, %d) = %v, want %v", i, j, got, want)
+			}
+			if got, want := p.Contains(b), im.IsContains(); got != want {
+				t.Errorf("Contains(%d, %d) = %v, want %v", i, j, got, want)
+			}
+			if got, want := p.Covers(b), im.IsCovers(); got != want {
+				t.Errorf("Covers(%d, %d) = %v, want %v", i, j, got, want)
+			}
+			if got, want := p.ContainsProperly(b), containsProperly; got != want {
+				t.Errorf("ContainsProperly(%d, %d) = %v, want %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestPrepared_Locate(t *testing.T) {
+	p := NewPrepared(&Components{
+		Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)},
+		Lines:    []matrix.LineMatrix{{{10, 5}, {20, 5}}},
+		Points:   []matrix.Matrix{{30, 30}},
+	})
+	tests := []struct {
+		name string
+		pt   matrix.Matrix
+		want int
+	}{
+		{name: "interior", pt: matrix.Matrix{5, 5}, want: Interior},
+		{name: "area boundary", pt: matrix.Matrix{0, 5}, want: Boundary},
+		{name: "line interior", pt: matrix.Matrix{15, 5}, want: Interior},
+		{name: "line boundary", pt: matrix.Matrix{20, 5}, want: Boundary},
+		{name: "point", pt: matrix.Matrix{30, 30}, want: Interior},
+		{name: "exterior", pt: matrix.Matrix{15, 15}, want: Exterior},
+		{name: "outside envelope", pt: matrix.Matrix{-1, 5}, want: Exterior},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Locate(tt.pt); got != tt.want {
+				t.Errorf("Locate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepared_Concurrent(t *testing.T) {
+	squareWithHole := append(square(0, 0, 10, 10), matrix.LineMatrix{{2, 2}, {2, 8}, {8, 8}, {8, 2}, {2, 2}})
+	p := NewPrepared(&Components{Polygons: []matrix.PolygonMatrix{squareWithHole, square(20, 0, 30, 10)}})
+	candidates := []*Components{
+		{Points: []matrix.Matrix{{1, 1}}},
+		{Points: []matrix.Matrix{{5, 5}}},
+		{Points: []matrix.Matrix{{25, 5}, {1, 5}}},
+		{Lines: []matrix.LineMatrix{{{0.5, 0.5}, {1.5, 9.5}}}},
+		{Polygons: []matrix.PolygonMatrix{square(21, 1, 29, 9)}},
+	}
+	want := make([]bool, len(candidates))
+	for i, c := range candidates {
+		want[i] = Relate(p.comps, c).IsContains()
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 8*len(candidates))
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				for i, c := range candidates {
+					if got := p.Contains(c); got != want[i] {
+						errs <- fmt.Sprintf("Contains(%d) = %v, want %v", i, got, want[i])
+						return
+					}
+					if got := p.Locate(matrix.Matrix{25, 5}); got != Interior {
+						errs <- fmt.Sprintf("Locate() = %v, want %v", got, Interior)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
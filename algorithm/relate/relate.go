@@ -0,0 +1,275 @@
+// Package relate computes the Dimensionally Extended Nine-Intersection Model (DE-9IM)
+// matrix of two planar geometries.
+//
+// The linework of both geometries is noded together. Every node, every noded edge
+// and both sides of every area boundary edge are then located in the two geometries,
+// and each pair of locations raises the matrix element it belongs to up to the
+// dimension of the located element.
+package relate
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+	"github.com/spatial-go/geoos/algorithm/topology"
+)
+
+// Components are the points, lines and polygons making up a geometry.
+type Components struct {
+	Points   []matrix.Matrix
+	Lines    []matrix.LineMatrix
+	Polygons []matrix.PolygonMatrix
+}
+
+// Dimension returns the largest dimension of the components, or DimFalse if there are none.
+func (c *Components) Dimension() int {
+	switch {
+	case len(c.Polygons) > 0:
+		return DimArea
+	case len(c.Lines) > 0:
+		return DimLine
+	case len(c.Points) > 0:
+		return DimPoint
+	}
+	return DimFalse
+}
+
+// Relate computes the intersection matrix of a and b.
+func Relate(a, b *Components) IntersectionMatrix {
+	g := newGraph([2]*Components{a, b})
+	return g.intersectionMatrix()
+}
+
+// RelatePattern returns true if the intersection matrix of a and b matches pattern.
+func RelatePattern(a, b *Components, pattern string) (bool, error) {
+	return Relate(a, b).Matches(pattern)
+}
+
+// roles of an edge or node in a geometry.
+const (
+	rolePoint = 1 << iota
+	roleLine
+	roleLineBoundary
+	roleArea
+)
+
+// edgeLabel records the roles of a noded edge in both geometries.
+// An area boundary edge also records on which sides the area interior lies.
+type edgeLabel struct {
+	roles               [2]int
+	areaLeft, areaRight [2]bool
+	start, end          topology.Vertex
+}
+
+// graph is the noded linework of two geometries.
+type graph struct {
+	geoms    [2]*Components
+	areas    [2]*topology.AreaLocator
+	edges    map[[2]topology.Vertex]*edgeLabel
+	edgeKeys [][2]topology.Vertex
+	nodes    map[topology.Vertex]*[2]int
+}
+
+// newGraph nodes the linework of geoms and labels the resulting edges and nodes.
+func newGraph(geoms [2]*Components) *graph {
+	g := &graph{
+		geoms: geoms,
+		edges: map[[2]topology.Vertex]*edgeLabel{},
+		nodes: map[topology.Vertex]*[2]int{},
+	}
+	n := &topology.Noder{}
+	extent := 0.0
+	extend := func(p []float64) {
+		extent = math.Max(extent, math.Max(math.Abs(p[0]), math.Abs(p[1])))
+	}
+	for k, c := range geoms {
+		for _, p := range c.Points {
+			extend(p)
+			n.AddPoint(topology.Vertex{p[0], p[1]}, k, rolePoint)
+		}
+		for _, line := range c.Lines {
+			for i := 0; i < len(line)-1; i++ {
+				extend(line[i])
+				n.AddSegment(topology.Vertex{line[i][0], line[i][1]}, topology.Vertex{line[i+1][0], line[i+1][1]}, k, roleLine)
+			}
+			if isZeroLength(line) {
+				// a zero length line is closed, so it has the topology of a point
+				n.AddPoint(topology.Vertex{line[0][0], line[0][1]}, k, rolePoint)
+			}
+		}
+		for _, polygon := range c.Polygons {
+			for i, ring := range polygon {
+				// orient rings so that the interior of the polygon lies on their left
+				if (measure.AreaDirection(ring) > 0) == (i == 0) {
+					ring = reverse(ring)
+				}
+				for j := 0; j < len(ring)-1; j++ {
+					extend(ring[j])
+					n.AddSegment(topology.Vertex{ring[j][0], ring[j][1]}, topology.Vertex{ring[j+1][0], ring[j+1][1]}, k, roleArea)
+				}
+			}
+		}
+		g.areas[k] = topology.NewAreaLocator(c.Polygons)
+	}
+	n.Tolerance = extent * topology.SnapToleranceFactor
+
+	for i, pts := range n.Node() {
+		s := n.Segs[i]
+		for k := 0; k < len(pts)-1; k++ {
+			p, q := pts[k], pts[k+1]
+			if p == q {
+				continue
+			}
+			g.addEdge(p, q, s.Geom, s.Role)
+		}
+	}
+	for k, c := range geoms {
+		for _, p := range c.Points {
+			g.node(topology.Vertex{p[0], p[1]})[k] |= rolePoint
+		}
+		for _, line := range c.Lines {
+			if isZeroLength(line) {
+				g.node(topology.Vertex{line[0][0], line[0][1]})[k] |= rolePoint
+			}
+		}
+		// the boundary of lines follows the mod-2 rule
+		endpoints := map[topology.Vertex]int{}
+		for _, line := range c.Lines {
+			if len(line) < 2 {
+				continue
+			}
+			endpoints[topology.Vertex{line[0][0], line[0][1]}]++
+			endpoints[topology.Vertex{line[len(line)-1][0], line[len(line)-1][1]}]++
+		}
+		for v, count := range endpoints {
+			if count%2 == 1 {
+				g.node(v)[k] |= roleLineBoundary
+			}
+		}
+	}
+	return g
+}
+
+// addEdge labels the edge p-q with a role in the geometry geom.
+func (g *graph) addEdge(p, q topology.Vertex, geom, role int) {
+	key, forward := [2]topology.Vertex{p, q}, true
+	if topology.Less(q, p) {
+		key, forward = [2]topology.Vertex{q, p}, false
+	}
+	label, ok := g.edges[key]
+	if !ok {
+		label = &edgeLabel{start: key[0], end: key[1]}
+		g.edges[key] = label
+		g.edgeKeys = append(g.edgeKeys, key)
+	}
+	label.roles[geom] |= role
+	if role == roleArea {
+		if forward {
+			label.areaLeft[geom] = true
+		} else {
+			label.areaRight[geom] = true
+		}
+	}
+	g.node(p)[geom] |= role
+	g.node(q)[geom] |= role
+}
+
+// node returns the roles of the node at v, creating it if needed.
+func (g *graph) node(v topology.Vertex) *[2]int {
+	roles, ok := g.nodes[v]
+	if !ok {
+		roles = &[2]int{}
+		g.nodes[v] = roles
+	}
+	return roles
+}
+
+// intersectionMatrix computes the intersection matrix from the labelled graph.
+func (g *graph) intersectionMatrix() IntersectionMatrix {
+	im := NewIntersectionMatrix()
+	im.setAtLeast(Exterior, Exterior, DimArea)
+
+	for v, roles := range g.nodes {
+		im.setAtLeast(g.locateNode(0, v, roles[0]), g.locateNode(1, v, roles[1]), DimPoint)
+	}
+	for _, key := range g.edgeKeys {
+		e := g.edges[key]
+		mid := topology.Vertex{(e.start[0] + e.end[0]) / 2, (e.start[1] + e.end[1]) / 2}
+		im.setAtLeast(g.locateEdge(0, e, mid), g.locateEdge(1, e, mid), DimLine)
+		if e.roles[0]&roleArea == 0 && e.roles[1]&roleArea == 0 {
+			continue
+		}
+		im.setAtLeast(g.locateSide(0, e, mid, true), g.locateSide(1, e, mid, true), DimArea)
+		im.setAtLeast(g.locateSide(0, e, mid, false), g.locateSide(1, e, mid, false), DimArea)
+	}
+	return im
+}
+
+// locateNode returns the location of the node v with the given roles in the geometry geom.
+func (g *graph) locateNode(geom int, v topology.Vertex, roles int) int {
+	switch {
+	case roles&roleArea != 0:
+		return Boundary
+	case g.areas[geom].Contains(v):
+		return Interior
+	case roles&roleLineBoundary != 0:
+		return Boundary
+	case roles&(roleLine|rolePoint) != 0:
+		return Interior
+	}
+	return Exterior
+}
+
+// locateEdge returns the location of the edge e with midpoint mid in the geometry geom.
+func (g *graph) locateEdge(geom int, e *edgeLabel, mid topology.Vertex) int {
+	roles := e.roles[geom]
+	switch {
+	case roles&roleArea != 0:
+		if e.areaLeft[geom] && e.areaRight[geom] {
+			return Interior
+		}
+		return Boundary
+	case g.areas[geom].Contains(mid):
+		return Interior
+	case roles&roleLine != 0:
+		return Interior
+	}
+	return Exterior
+}
+
+// locateSide returns the location of the left or right side of the edge e in the geometry geom.
+func (g *graph) locateSide(geom int, e *edgeLabel, mid topology.Vertex, left bool) int {
+	if e.roles[geom]&roleArea != 0 {
+		if (left && e.areaLeft[geom]) || (!left && e.areaRight[geom]) {
+			return Interior
+		}
+		return Exterior
+	}
+	if g.areas[geom].Contains(mid) {
+		return Interior
+	}
+	return Exterior
+}
+
+// isZeroLength returns true if the line has points which are all equal.
+func isZeroLength(line matrix.LineMatrix) bool {
+	if len(line) == 0 {
+		return false
+	}
+	for _, p := range line[1:] {
+		if p[0] != line[0][0] || p[1] != line[0][1] {
+			return false
+		}
+	}
+	return true
+}
+
+// reverse returns the points in reverse order.
+func reverse(pts matrix.LineMatrix) matrix.LineMatrix {
+	reversed := make(matrix.LineMatrix, len(pts))
+	for i, p := range pts {
+		reversed[len(pts)-1-i] = p
+	}
+	return reversed
+}
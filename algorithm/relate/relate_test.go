@@ -0,0 +1,146 @@
+package relate
+
+import (
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+)
+
+func square(minX, minY, maxX, maxY float64) matrix.PolygonMatrix {
+	return matrix.PolygonMatrix{{{minX, minY}, {maxX, minY}, {maxX, maxY}, {minX, maxY}, {minX, minY}}}
+}
+
+func TestRelate(t *testing.T) {
+	squareWithHole := append(square(0, 0, 10, 10), matrix.LineMatrix{{2, 2}, {2, 8}, {8, 8}, {8, 2}, {2, 2}})
+	type args struct {
+		a, b *Components
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{name: "overlapping polygons", args: args{
+			a: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}},
+			b: &Components{Polygons: []matrix.PolygonMatrix{square(5, 5, 15, 15)}}}, want: "212101212"},
+		{name: "equal polygons", args: args{
+			a: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}},
+			b: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}}}, want: "2FFF1FFF2"},
+		{name: "adjacent polygons", args: args{
+			a: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}},
+			b: &Components{Polygons: []matrix.PolygonMatrix{square(10, 0, 20, 10)}}}, want: "FF2F11212"},
+		{name: "polygon in hole", args: args{
+			a: &Components{Polygons: []matrix.PolygonMatrix{squareWithHole}},
+			b: &Components{Polygons: []matrix.PolygonMatrix{square(3, 3, 7, 7)}}}, want: "FF2FF1212"},
+		{name: "polygon contains point", args: args{
+			a: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}},
+			b: &Components{Points: []matrix.Matrix{{5, 5}}}}, want: "0F2FF1FF2"},
+		{name: "point on polygon boundary", args: args{
+			a: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}},
+			b: &Components{Points: []matrix.Matrix{{5, 0}}}}, want: "FF20F1FF2"},
+		{name: "polygon contains line", args: args{
+			a: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}},
+			b: &Components{Lines: []matrix.LineMatrix{{{2, 2}, {8, 8}}}}}, want: "102FF1FF2"},
+		{name: "line touches polygon", args: args{
+			a: &Components{Lines: []matrix.LineMatrix{{{-5, 5}, {0, 5}}}},
+			b: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}}}, want: "FF1F00212"},
+		{name: "line on polygon boundary", args: args{
+			a: &Components{Lines: []matrix.LineMatrix{{{0, 0}, {10, 0}}}},
+			b: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}}}, want: "F1FF0F212"},
+		{name: "crossing lines", args: args{
+			a: &Components{Lines: []matrix.LineMatrix{{{0, 0}, {10, 10}}}},
+			b: &Components{Lines: []matrix.LineMatrix{{{0, 10}, {10, 0}}}}}, want: "0F1FF0102"},
+		{name: "mod-2 boundary", args: args{
+			a: &Components{Lines: []matrix.LineMatrix{{{0, 0}, {5, 0}}, {{5, 0}, {10, 0}}}},
+			b: &Components{Points: []matrix.Matrix{{5, 0}}}}, want: "0F1FF0FF2"},
+		{name: "disjoint points", args: args{
+			a: &Components{Points: []matrix.Matrix{{0, 0}}},
+			b: &Components{Points: []matrix.Matrix{{1, 1}}}}, want: "FF0FFF0F2"},
+		{name: "empty", args: args{
+			a: &Components{},
+			b: &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}}}, want: "FFFFFF212"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Relate(tt.args.a, tt.args.b).String(); got != tt.want {
+				t.Errorf("Relate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelatePattern(t *testing.T) {
+	a := &Components{Polygons: []matrix.PolygonMatrix{square(0, 0, 10, 10)}}
+	b := &Components{Points: []matrix.Matrix{{5, 5}}}
+	tests := []struct {
+		name    string
+		pattern string
+		want    bool
+		wantErr bool
+	}{
+		{name: "contains", pattern: "T*****FF*", want: true},
+		{name: "within", pattern: "T*F**F***", want: false},
+		{name: "exact", pattern: "0F2FF1FF2", want: true},
+		{name: "short", pattern: "T*****FF", wantErr: true},
+		{name: "invalid", pattern: "T*****FFX", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RelatePattern(a, b, tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RelatePattern() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RelatePattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersectionMatrix_Predicates(t *testing.T) {
+	tests := []struct {
+		name       string
+		im         string
+		dimA, dimB int
+		predicate  func(im IntersectionMatrix, dimA, dimB int) bool
+		want       bool
+	}{
+		{name: "contains", im: "0F2FF1FF2", dimA: DimArea, dimB: DimPoint,
+			predicate: func(im IntersectionMatrix, _, _ int) bool { return im.IsContains() }, want: true},
+		{name: "within", im: "0FFFFF212", dimA: DimPoint, dimB: DimArea,
+			predicate: func(im IntersectionMatrix, _, _ int) bool { return im.IsWithin() }, want: true},
+		{name: "covers boundary point", im: "FF20F1FF2", dimA: DimArea, dimB: DimPoint,
+			predicate: func(im IntersectionMatrix, _, _ int) bool { return im.IsCovers() }, want: true},
+		{name: "contains boundary point", im: "FF20F1FF2", dimA: DimArea, dimB: DimPoint,
+			predicate: func(im IntersectionMatrix, _, _ int) bool { return im.IsContains() }, want: false},
+		{name: "touches", im: "FF2F11212", dimA: DimArea, dimB: DimArea,
+			predicate: func(im IntersectionMatrix, _, _ int) bool { return im.IsTouches(DimArea, DimArea) }, want: true},
+		{name: "crosses lines", im: "0F1FF0102", dimA: DimLine, dimB: DimLine,
+			predicate: func(im IntersectionMatrix, a, b int) bool { return im.IsCrosses(a, b) }, want: true},
+		{name: "overlaps polygons", im: "212101212", dimA: DimArea, dimB: DimArea,
+			predicate: func(im IntersectionMatrix, a, b int) bool { return im.IsOverlaps(a, b) }, want: true},
+		{name: "disjoint", im: "FF0FFF0F2", dimA: DimPoint, dimB: DimPoint,
+			predicate: func(im IntersectionMatrix, _, _ int) bool { return im.IsDisjoint() }, want: true},
+		{name: "equals", im: "2FFF1FFF2", dimA: DimArea, dimB: DimArea,
+			predicate: func(im IntersectionMatrix, _, _ int) bool { return im.IsEquals() }, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			im := parseIntersectionMatrix(tt.im)
+			if got := tt.predicate(im, tt.dimA, tt.dimB); got != tt.want {
+				t.Errorf("%s(%s) = %v, want %v", tt.name, tt.im, got, tt.want)
+			}
+		})
+	}
+}
+
+func parseIntersectionMatrix(s string) IntersectionMatrix {
+	im := NewIntersectionMatrix()
+	for k := 0; k < 9; k++ {
+		if s[k] != 'F' {
+			im[k/3][k%3] = int(s[k] - '0')
+		}
+	}
+	return im
+}
@@ -0,0 +1,116 @@
+package topology
+
+import (
+	"math"
+	"sync"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+)
+
+// areaSegment is a segment of a polygon ring.
+type areaSegment struct {
+	lo, hi  Vertex
+	polygon int
+}
+
+// AreaLocator tests whether points lie in the interior of a set of polygons.
+// Points are assumed not to lie on the boundary of the polygons.
+// It is safe for concurrent use by multiple goroutines.
+type AreaLocator struct {
+	segs     []areaSegment
+	polygons int
+
+	minY, height float64
+	buckets      [][]int
+
+	// scratch pools the crossing parities of the polygons, reset after each call to Contains.
+	scratch sync.Pool
+}
+
+// crossings is the scratch space of a call to Contains: the crossing parity of each polygon,
+// and the polygons of which the parity has been set.
+type crossings struct {
+	parity  []bool
+	touched []int
+}
+
+// NewAreaLocator creates an AreaLocator for polygons, bucketing their segments by y.
+func NewAreaLocator(polygons []matrix.PolygonMatrix) *AreaLocator {
+	l := &AreaLocator{polygons: len(polygons)}
+	l.scratch.New = func() interface{} {
+		return &crossings{parity: make([]bool, l.polygons)}
+	}
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for k, polygon := range polygons {
+		for _, ring := range polygon {
+			for i := 0; i < len(ring)-1; i++ {
+				lo, hi := Vertex{ring[i][0], ring[i][1]}, Vertex{ring[i+1][0], ring[i+1][1]}
+				if lo[1] == hi[1] {
+					// horizontal segments are never crossed by the ray
+					continue
+				}
+				if lo[1] > hi[1] {
+					lo, hi = hi, lo
+				}
+				l.segs = append(l.segs, areaSegment{lo: lo, hi: hi, polygon: k})
+				minY, maxY = math.Min(minY, lo[1]), math.Max(maxY, hi[1])
+			}
+		}
+	}
+	if len(l.segs) == 0 {
+		return l
+	}
+	n := int(math.Sqrt(float64(len(l.segs)))) + 1
+	l.minY, l.height = minY, (maxY-minY)/float64(n)
+	if !(l.height > 0) {
+		n, l.height = 1, 1
+	}
+	l.buckets = make([][]int, n)
+	for i, s := range l.segs {
+		for k := l.bucket(s.lo[1]); k <= l.bucket(s.hi[1]); k++ {
+			l.buckets[k] = append(l.buckets[k], i)
+		}
+	}
+	return l
+}
+
+// bucket returns the bucket containing y.
+func (l *AreaLocator) bucket(y float64) int {
+	k := int((y - l.minY) / l.height)
+	if k < 0 {
+		return 0
+	}
+	if k >= len(l.buckets) {
+		return len(l.buckets) - 1
+	}
+	return k
+}
+
+// Contains returns true if p lies in the interior of one of the polygons.
+// It counts the crossings of each polygon's rings with a ray from p in the positive x direction.
+func (l *AreaLocator) Contains(p Vertex) bool {
+	if len(l.segs) == 0 || p[1] < l.minY || p[1] > l.minY+l.height*float64(len(l.buckets)) {
+		return false
+	}
+	c := l.scratch.Get().(*crossings)
+	defer l.scratch.Put(c)
+	for _, i := range l.buckets[l.bucket(p[1])] {
+		s := l.segs[i]
+		if !(s.lo[1] <= p[1] && p[1] < s.hi[1]) {
+			continue
+		}
+		if Orient(s.lo, s.hi, p) > 0 {
+			if !c.parity[s.polygon] {
+				c.touched = append(c.touched, s.polygon)
+			}
+			c.parity[s.polygon] = !c.parity[s.polygon]
+		}
+	}
+	inside := false
+	for _, k := range c.touched {
+		inside = inside || c.parity[k]
+		c.parity[k] = false
+	}
+	c.touched = c.touched[:0]
+	return inside
+}
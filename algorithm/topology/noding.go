@@ -0,0 +1,212 @@
+// Package topology provides the noding and point location shared by the algorithms
+// computing the topology of geometries, such as relate, overlay and buffer.
+package topology
+
+import (
+	"math"
+	"sort"
+)
+
+// SnapToleranceFactor scales the extent of the input into the tolerance used to snap intersection points.
+const SnapToleranceFactor = 1.0e-12
+
+// Vertex is a node of the noded graph.
+type Vertex [2]float64
+
+// Segment is a segment of the linework of a geometry, with a role in the geometry Geom.
+// Points are added as segments with equal endpoints, so that segments passing through them are split.
+type Segment struct {
+	Start, End Vertex
+	Geom, Role int
+}
+
+// Noder splits segments at their intersections with other segments and points.
+// Computed intersection points lying within Tolerance of a segment endpoint are snapped to it.
+type Noder struct {
+	Segs      []Segment
+	Tolerance float64
+}
+
+// AddSegment adds the segment s-e with a role in the geometry geom.
+func (n *Noder) AddSegment(s, e Vertex, geom, role int) {
+	if s == e {
+		return
+	}
+	n.Segs = append(n.Segs, Segment{Start: s, End: e, Geom: geom, Role: role})
+}
+
+// AddPoint adds a point with a role in the geometry geom, which splits the segments passing through it.
+func (n *Noder) AddPoint(p Vertex, geom, role int) {
+	n.Segs = append(n.Segs, Segment{Start: p, End: p, Geom: geom, Role: role})
+}
+
+// Node returns for every segment its endpoints and split points, sorted along the segment.
+func (n *Noder) Node() [][]Vertex {
+	splits := make([][]Vertex, len(n.Segs))
+
+	order := make([]int, len(n.Segs))
+	for i := range order {
+		order[i] = i
+	}
+	minX := func(i int) float64 { return math.Min(n.Segs[i].Start[0], n.Segs[i].End[0]) }
+	maxX := func(i int) float64 { return math.Max(n.Segs[i].Start[0], n.Segs[i].End[0]) }
+	sort.Slice(order, func(i, j int) bool { return minX(order[i]) < minX(order[j]) })
+
+	for oi, i := range order {
+		aMaxX := maxX(i)
+		for _, j := range order[oi+1:] {
+			if minX(j) > aMaxX {
+				break
+			}
+			n.intersect(n.Segs[i], n.Segs[j], &splits[i], &splits[j])
+		}
+	}
+
+	result := make([][]Vertex, len(n.Segs))
+	for i, s := range n.Segs {
+		pts := append([]Vertex{s.Start}, splits[i]...)
+		pts = append(pts, s.End)
+		SortAlong(s.Start, pts)
+		result[i] = pts
+	}
+	return result
+}
+
+// intersect records the points where a and b intersect as split points of the segments.
+func (n *Noder) intersect(a, b Segment, splitsA, splitsB *[]Vertex) {
+	if math.Max(a.Start[1], a.End[1]) < math.Min(b.Start[1], b.End[1]) ||
+		math.Min(a.Start[1], a.End[1]) > math.Max(b.Start[1], b.End[1]) {
+		return
+	}
+	aIsPoint, bIsPoint := a.Start == a.End, b.Start == b.End
+	switch {
+	case aIsPoint && bIsPoint:
+		return
+	case aIsPoint:
+		if Orient(b.Start, b.End, a.Start) == 0 && Between(b.Start, b.End, a.Start) {
+			*splitsB = append(*splitsB, a.Start)
+		}
+		return
+	case bIsPoint:
+		if Orient(a.Start, a.End, b.Start) == 0 && Between(a.Start, a.End, b.Start) {
+			*splitsA = append(*splitsA, b.Start)
+		}
+		return
+	}
+
+	o1 := Orient(a.Start, a.End, b.Start)
+	o2 := Orient(a.Start, a.End, b.End)
+	o3 := Orient(b.Start, b.End, a.Start)
+	o4 := Orient(b.Start, b.End, a.End)
+
+	if o1 == 0 && o2 == 0 && o3 == 0 && o4 == 0 {
+		// collinear, split each segment at the endpoints of the other lying within it.
+		for _, p := range []Vertex{b.Start, b.End} {
+			if Between(a.Start, a.End, p) {
+				*splitsA = append(*splitsA, p)
+			}
+		}
+		for _, p := range []Vertex{a.Start, a.End} {
+			if Between(b.Start, b.End, p) {
+				*splitsB = append(*splitsB, p)
+			}
+		}
+		return
+	}
+	if o1*o2 > 0 || o3*o4 > 0 {
+		return
+	}
+	switch {
+	case o1 == 0:
+		if Between(a.Start, a.End, b.Start) {
+			*splitsA = append(*splitsA, b.Start)
+		}
+	case o2 == 0:
+		if Between(a.Start, a.End, b.End) {
+			*splitsA = append(*splitsA, b.End)
+		}
+	case o3 == 0:
+		if Between(b.Start, b.End, a.Start) {
+			*splitsB = append(*splitsB, a.Start)
+		}
+	case o4 == 0:
+		if Between(b.Start, b.End, a.End) {
+			*splitsB = append(*splitsB, a.End)
+		}
+	default:
+		p := n.snap(intersectionPoint(a, b), a, b)
+		*splitsA = append(*splitsA, p)
+		*splitsB = append(*splitsB, p)
+	}
+}
+
+// snap moves a computed intersection point onto a segment endpoint lying within tolerance.
+func (n *Noder) snap(p Vertex, a, b Segment) Vertex {
+	for _, q := range []Vertex{a.Start, a.End, b.Start, b.End} {
+		if math.Abs(p[0]-q[0]) <= n.Tolerance && math.Abs(p[1]-q[1]) <= n.Tolerance {
+			return q
+		}
+	}
+	return p
+}
+
+// intersectionPoint computes the intersection point of two properly crossing segments.
+// The segments are put in a canonical order first, so that the same pair of segments
+// always yields the same point.
+func intersectionPoint(a, b Segment) Vertex {
+	if Less(a.End, a.Start) {
+		a.Start, a.End = a.End, a.Start
+	}
+	if Less(b.End, b.Start) {
+		b.Start, b.End = b.End, b.Start
+	}
+	if Less(b.Start, a.Start) || (b.Start == a.Start && Less(b.End, a.End)) {
+		a, b = b, a
+	}
+	rx, ry := a.End[0]-a.Start[0], a.End[1]-a.Start[1]
+	sx, sy := b.End[0]-b.Start[0], b.End[1]-b.Start[1]
+	denom := rx*sy - ry*sx
+	t := ((b.Start[0]-a.Start[0])*sy - (b.Start[1]-a.Start[1])*sx) / denom
+	t = math.Max(0, math.Min(1, t))
+	return Vertex{a.Start[0] + t*rx, a.Start[1] + t*ry}
+}
+
+// Orient returns the sign of the orientation of q relative to p1-p2.
+func Orient(p1, p2, q Vertex) int {
+	det := (p2[0]-p1[0])*(q[1]-p1[1]) - (p2[1]-p1[1])*(q[0]-p1[0])
+	if det > 0 {
+		return 1
+	}
+	if det < 0 {
+		return -1
+	}
+	return 0
+}
+
+// Between returns true if p lies strictly within the collinear segment s-e.
+func Between(s, e, p Vertex) bool {
+	if p == s || p == e {
+		return false
+	}
+	if s[0] != e[0] {
+		return (p[0] > s[0]) != (p[0] > e[0])
+	}
+	return (p[1] > s[1]) != (p[1] > e[1])
+}
+
+// Less orders vertices by x, then y.
+func Less(p, q Vertex) bool {
+	if p[0] != q[0] {
+		return p[0] < q[0]
+	}
+	return p[1] < q[1]
+}
+
+// SortAlong sorts points by distance from start.
+func SortAlong(start Vertex, pts []Vertex) {
+	sort.SliceStable(pts, func(i, j int) bool {
+		di := (pts[i][0]-start[0])*(pts[i][0]-start[0]) + (pts[i][1]-start[1])*(pts[i][1]-start[1])
+		dj := (pts[j][0]-start[0])*(pts[j][0]-start[0]) + (pts[j][1]-start[1])*(pts[j][1]-start[1])
+		return di < dj
+	})
+}
@@ -7,6 +7,44 @@ import (
 	"github.com/spatial-go/geoos/space"
 )
 
+// Metric is the distance used by nearest neighbour queries.
+type Metric int
+
+// Metrics of nearest neighbour queries.
+const (
+	// MetricEuclidean is the planar distance between coordinates.
+	MetricEuclidean Metric = iota
+	// MetricSpherical is the distance computed by DistanceSpherical, in kilometers.
+	MetricSpherical
+)
+
+// distance returns the distance between p1 and p2.
+func (m Metric) distance(p1, p2 space.Point) float64 {
+	if m == MetricSpherical {
+		return DistanceSpherical(p1, p2)
+	}
+	return math.Hypot(p1[0]-p2[0], p1[1]-p2[1])
+}
+
+// lineDistance returns a lower bound of the distance between pt and the points lying
+// on the line where the coordinate split equals value, which are nearer than maxDist.
+func (m Metric) lineDistance(pt space.Point, split int, value, maxDist float64) float64 {
+	delta := math.Abs(pt[split] - value)
+	if m != MetricSpherical {
+		return delta
+	}
+	if split == 1 {
+		return common.EarthR * delta * common.DegreeRad
+	}
+	// a point nearer than maxDist has a latitude within maxDist of pt,
+	// which bounds the scale factor of longitudes at their mean latitude
+	lat := math.Abs(pt[1]) + maxDist/common.EarthR/common.DegreeRad/2
+	if lat >= 90 {
+		return 0
+	}
+	return common.EarthR * delta * common.DegreeRad * math.Cos(lat*common.DegreeRad)
+}
+
 // DistanceSpherical is a spherical (optimized) distance between two points
 //
 // Result is distance in kilometers
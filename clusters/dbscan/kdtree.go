@@ -6,6 +6,7 @@ package dbscan
 // Author: Ethan Burns <burns.ethan@gmail.com>
 
 import (
+	"container/heap"
 	"sort"
 
 	"github.com/spatial-go/geoos/clusters"
@@ -101,6 +102,122 @@ func (tree *KDTree) inRange(t *T, pt space.Point, r float64, nodes []int) []int
 	return nodes
 }
 
+// KNearest returns the indices of the k points nearest to pt, nearest first,
+// with distances measured by metric. Points at the same distance are ordered by index.
+func (tree *KDTree) KNearest(pt space.Point, k int, metric Metric) []int {
+	if k <= 0 || pt.IsEmpty() {
+		return nil
+	}
+	neighbours := &neighbourHeap{}
+	tree.kNearest(tree.Root, pt, k, metric, neighbours)
+	sort.Sort(byDistance(*neighbours))
+	result := make([]int, len(*neighbours))
+	for i, n := range *neighbours {
+		result[i] = n.pointID
+	}
+	return result
+}
+
+// Nearest returns the index of the point nearest to pt with distances measured by metric,
+// and false if the tree is empty.
+func (tree *KDTree) Nearest(pt space.Point, metric Metric) (int, bool) {
+	nearest := tree.KNearest(pt, 1, metric)
+	if len(nearest) == 0 {
+		return 0, false
+	}
+	return nearest[0], true
+}
+
+func (tree *KDTree) kNearest(t *T, pt space.Point, k int, metric Metric, neighbours *neighbourHeap) {
+	if t == nil {
+		return
+	}
+
+	value := tree.Points[t.PointID][t.split]
+	thisSide, otherSide := t.right, t.left
+	if pt[t.split] < value {
+		thisSide, otherSide = t.left, t.right
+	}
+
+	tree.kNearest(thisSide, pt, k, metric, neighbours)
+	dist := metric.distance(pt, tree.Points[t.PointID])
+	neighbours.offer(neighbour{pointID: t.PointID, dist: dist}, k)
+	for _, id := range t.EqualIDs {
+		neighbours.offer(neighbour{pointID: id, dist: dist}, k)
+	}
+	// the other side can only hold nearer points if the splitting line is nearer than the farthest neighbour
+	if neighbours.Len() < k || metric.lineDistance(pt, t.split, value, (*neighbours)[0].dist) <= (*neighbours)[0].dist {
+		tree.kNearest(otherSide, pt, k, metric, neighbours)
+	}
+}
+
+// neighbour is a point found by a nearest neighbour query.
+type neighbour struct {
+	pointID int
+	dist    float64
+}
+
+// nearer returns true if n is nearer than o, or at the same distance with a lower index.
+func (n neighbour) nearer(o neighbour) bool {
+	if n.dist == o.dist {
+		return n.pointID < o.pointID
+	}
+	return n.dist < o.dist
+}
+
+// byDistance sorts neighbours nearest first, then by index.
+type byDistance []neighbour
+
+func (n byDistance) Len() int {
+	return len(n)
+}
+
+func (n byDistance) Swap(i, j int) {
+	n[i], n[j] = n[j], n[i]
+}
+
+func (n byDistance) Less(i, j int) bool {
+	return n[i].nearer(n[j])
+}
+
+// neighbourHeap is a max-heap of neighbours, holding the farthest one at its root.
+type neighbourHeap []neighbour
+
+func (h neighbourHeap) Len() int {
+	return len(h)
+}
+
+func (h neighbourHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h neighbourHeap) Less(i, j int) bool {
+	return h[j].nearer(h[i])
+}
+
+func (h *neighbourHeap) Push(x interface{}) {
+	*h = append(*h, x.(neighbour))
+}
+
+func (h *neighbourHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// offer adds n to the heap if it holds less than k neighbours, or if n is nearer than the farthest one.
+func (h *neighbourHeap) offer(n neighbour, k int) {
+	if h.Len() < k {
+		heap.Push(h, n)
+		return
+	}
+	if n.nearer((*h)[0]) {
+		(*h)[0] = n
+		heap.Fix(h, 0)
+	}
+}
+
 // Height returns the height of the K-D tree.
 func (tree *KDTree) Height() int {
 	return tree.Root.height()
@@ -4,6 +4,7 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
+	"sort"
 	"testing"
 	"testing/quick"
 
@@ -87,6 +88,70 @@ func TestInRange(t *testing.T) {
 	}
 }
 
+// TestKNearest tests the KNearest function, ensuring that the reported points
+// are the k nearest ones, in order of distance, for both metrics.
+func TestKNearest(t *testing.T) {
+	for _, metric := range []Metric{MetricEuclidean, MetricSpherical} {
+		if err := quick.Check(func(pts pointSlice, pt space.Point, k uint8) bool {
+			if pt.IsEmpty() {
+				pt = space.Point{0, 0}
+			}
+			pt = space.Point{math.Mod(pt[0], 1), math.Mod(pt[1], 1)}
+			n := int(k%10) + 1
+			tree := NewKDTree(clusters.PointList(pts))
+			got := tree.KNearest(pt, n, metric)
+
+			want := make([]int, len(pts))
+			for i := range want {
+				want[i] = i
+			}
+			sort.SliceStable(want, func(i, j int) bool {
+				return metric.distance(pt, pts[want[i]]) < metric.distance(pt, pts[want[j]])
+			})
+			if len(want) > n {
+				want = want[:n]
+			}
+			if len(got) != len(want) {
+				return false
+			}
+			for i := range got {
+				if metric.distance(pt, pts[got[i]]) != metric.distance(pt, pts[want[i]]) {
+					return false
+				}
+			}
+			return true
+		}, nil); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	stations := clusters.PointList{{116.39, 39.91}, {121.47, 31.23}, {113.26, 23.13}, {104.07, 30.67}}
+	tree := NewKDTree(stations)
+	tests := []struct {
+		name string
+		ping space.Point
+		want int
+	}{
+		{name: "beijing", ping: space.Point{116.40, 39.90}, want: 0},
+		{name: "shanghai", ping: space.Point{120.9, 31.5}, want: 1},
+		{name: "guangzhou", ping: space.Point{113.0, 22.5}, want: 2},
+		{name: "chengdu", ping: space.Point{104.5, 30.0}, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tree.Nearest(tt.ping, MetricSpherical)
+			if !ok || got != tt.want {
+				t.Errorf("Nearest() got = %v, %v, want %v", got, ok, tt.want)
+			}
+		})
+	}
+	if _, ok := NewKDTree(nil).Nearest(space.Point{0, 0}, MetricEuclidean); ok {
+		t.Errorf("Nearest() on an empty tree got = true, want false")
+	}
+}
+
 // InvariantHolds returns the points in this subtree, and a bool
 // that is true if the K-D tree invariant holds.  The K-D tree invariant
 // states that all points in the left subtree have values less than that
@@ -0,0 +1,77 @@
+package common
+
+// End cap styles of buffers, specifying the shape of the buffer at the ends of lines.
+// The values match the GEOS end cap styles.
+const (
+	// CapRound specifies a semi-circle end cap.
+	CapRound = 1
+	// CapFlat specifies a flat end cap ending at the end points of the line.
+	CapFlat = 2
+	// CapSquare specifies a square end cap extending beyond the end points of the line by the buffer distance.
+	CapSquare = 3
+)
+
+// Join styles of buffers, specifying the shape of the buffer at the outside corners of lines and rings.
+// The values match the GEOS join styles.
+const (
+	// JoinRound specifies a circular arc join.
+	JoinRound = 1
+	// JoinMitre specifies a sharp join, limited by the mitre limit.
+	JoinMitre = 2
+	// JoinBevel specifies a straight line join.
+	JoinBevel = 3
+)
+
+const (
+	// DefaultQuadSegs is the default number of segments used to approximate a quarter circle.
+	DefaultQuadSegs = 8
+	// DefaultMitreLimit is the default ratio of the mitre length to the buffer distance.
+	DefaultMitreLimit = 5.0
+)
+
+// BufferParams contains the parameters which control how a buffer is computed,
+// shared by the pure Go and the GEOS buffers.
+type BufferParams struct {
+	// QuadSegs is the number of segments used to approximate a quarter circle.
+	QuadSegs int
+	// EndCapStyle is the end cap style of lines, one of CapRound, CapFlat or CapSquare.
+	EndCapStyle int
+	// JoinStyle is the join style of corners, one of JoinRound, JoinMitre or JoinBevel.
+	JoinStyle int
+	// MitreLimit limits the length of mitre joins, as a ratio of the buffer distance.
+	// Mitres longer than the limit are cut off.
+	MitreLimit float64
+	// SingleSided computes the buffer of lines on one side only,
+	// the left side for a positive distance and the right side for a negative one.
+	// End caps are always flat. It has no effect on points and polygons.
+	SingleSided bool
+}
+
+// DefaultBufferParams returns the default buffer parameters,
+// with round end caps and joins approximated by quadsegs segments per quarter circle.
+func DefaultBufferParams(quadsegs int) BufferParams {
+	return BufferParams{
+		QuadSegs:    quadsegs,
+		EndCapStyle: CapRound,
+		JoinStyle:   JoinRound,
+		MitreLimit:  DefaultMitreLimit,
+	}
+}
+
+// Normalize returns the parameters with their zero or out of range values replaced by the defaults,
+// so that the zero value of BufferParams buffers the same way as DefaultBufferParams(DefaultQuadSegs).
+func (p BufferParams) Normalize() BufferParams {
+	if p.QuadSegs < 1 {
+		p.QuadSegs = DefaultQuadSegs
+	}
+	if p.EndCapStyle < CapRound || p.EndCapStyle > CapSquare {
+		p.EndCapStyle = CapRound
+	}
+	if p.JoinStyle < JoinRound || p.JoinStyle > JoinBevel {
+		p.JoinStyle = JoinRound
+	}
+	if p.MitreLimit <= 0 {
+		p.MitreLimit = DefaultMitreLimit
+	}
+	return p
+}
@@ -31,7 +31,7 @@ func readCollection(r io.Reader, order byteOrder, buf []byte) (space.Collection,
 }
 
 func (e *Encoder) writeCollection(c space.Collection) error {
-	e.order.PutUint32(e.buf, geometryCollectionType)
+	e.order.PutUint32(e.buf, e.layoutType(geometryCollectionType, space.CoordLayout(c)))
 	e.order.PutUint32(e.buf[4:], uint32(len(c)))
 	_, err := e.w.Write(e.buf[:8])
 	if err != nil {
@@ -39,7 +39,7 @@ func (e *Encoder) writeCollection(c space.Collection) error {
 	}
 
 	for _, geom := range c {
-		err := e.Encode(geom)
+		err := e.write(geom)
 		if err != nil {
 			return err
 		}
@@ -3,7 +3,6 @@ package wkb
 import (
 	"errors"
 	"io"
-	"math"
 
 	"github.com/spatial-go/geoos/space"
 )
@@ -20,7 +19,7 @@ func unmarshalLineString(order byteOrder, data []byte) (space.LineString, error)
 	return line, nil
 }
 
-func readLineString(r io.Reader, order byteOrder, buf []byte) (space.LineString, error) {
+func readLineString(r io.Reader, order byteOrder, buf []byte, layout space.Layout) (space.LineString, error) {
 	num, err := readUint32(r, order, buf[:4])
 	if err != nil {
 		return nil, err
@@ -34,7 +33,7 @@ func readLineString(r io.Reader, order byteOrder, buf []byte) (space.LineString,
 	result := make(space.LineString, 0, alloc)
 
 	for i := 0; i < int(num); i++ {
-		p, err := readPoint(r, order, buf)
+		p, err := readPoint(r, order, buf, layout)
 		if err != nil {
 			return nil, err
 		}
@@ -46,7 +45,8 @@ func readLineString(r io.Reader, order byteOrder, buf []byte) (space.LineString,
 }
 
 func (e *Encoder) writeLineString(ls space.LineString) error {
-	e.order.PutUint32(e.buf, lineStringType)
+	layout := space.CoordLayout(ls)
+	e.order.PutUint32(e.buf, e.layoutType(lineStringType, layout))
 	e.order.PutUint32(e.buf[4:], uint32(len(ls)))
 	_, err := e.w.Write(e.buf[:8])
 	if err != nil {
@@ -54,9 +54,7 @@ func (e *Encoder) writeLineString(ls space.LineString) error {
 	}
 
 	for _, p := range ls {
-		e.order.PutUint64(e.buf, math.Float64bits(p[0]))
-		e.order.PutUint64(e.buf[8:], math.Float64bits(p[1]))
-		_, err = e.w.Write(e.buf)
+		err = e.writeCoord(p, layout)
 		if err != nil {
 			return err
 		}
@@ -106,7 +104,7 @@ func readMultiLineString(r io.Reader, order byteOrder, buf []byte) (space.MultiL
 	result := make(space.MultiLineString, 0, alloc)
 
 	for i := 0; i < int(num); i++ {
-		lOrder, typ, err := readByteOrderType(r, buf)
+		lOrder, typ, h, err := readByteOrderType(r, buf)
 		if err != nil {
 			return nil, err
 		}
@@ -115,7 +113,7 @@ func readMultiLineString(r io.Reader, order byteOrder, buf []byte) (space.MultiL
 			return nil, errors.New("expect multilines to contains lines, did not find a line")
 		}
 
-		ls, err := readLineString(r, lOrder, buf)
+		ls, err := readLineString(r, lOrder, buf, h.layout())
 		if err != nil {
 			return nil, err
 		}
@@ -127,7 +125,7 @@ func readMultiLineString(r io.Reader, order byteOrder, buf []byte) (space.MultiL
 }
 
 func (e *Encoder) writeMultiLineString(mls space.MultiLineString) error {
-	e.order.PutUint32(e.buf, multiLineStringType)
+	e.order.PutUint32(e.buf, e.layoutType(multiLineStringType, space.CoordLayout(mls)))
 	e.order.PutUint32(e.buf[4:], uint32(len(mls)))
 	_, err := e.w.Write(e.buf[:8])
 	if err != nil {
@@ -135,7 +133,7 @@ func (e *Encoder) writeMultiLineString(mls space.MultiLineString) error {
 	}
 
 	for _, ls := range mls {
-		err := e.Encode(ls)
+		err := e.write(ls)
 		if err != nil {
 			return err
 		}
@@ -61,13 +61,24 @@ func unmarshalPoint(order byteOrder, buf []byte) (space.Point, error) {
 	return p, nil
 }
 
-func readPoint(r io.Reader, order byteOrder, buf []byte) (space.Point, error) {
-	var p space.Point
+func readPoint(r io.Reader, order byteOrder, buf []byte, layout space.Layout) (space.Point, error) {
+	p := make(space.Point, 0, layout.Stride())
 
-	for i := 0; i < 2; i++ {
+	values := 2
+	if layout.HasZ() {
+		values++
+	}
+	if layout.HasM() {
+		values++
+	}
+	for i := 0; i < values; i++ {
 		if _, err := io.ReadFull(r, buf); err != nil {
 			return space.Point{}, err
 		}
+		if i == 2 && layout == space.XYM {
+			// the M is the fourth value of the coordinate, after a NaN Z
+			p = append(p, math.NaN())
+		}
 		if order == littleEndian {
 			p = append(p, math.Float64frombits(binary.LittleEndian.Uint64(buf)))
 		} else {
@@ -79,16 +90,17 @@ func readPoint(r io.Reader, order byteOrder, buf []byte) (space.Point, error) {
 }
 
 func (e *Encoder) writePoint(p space.Point) error {
-	e.order.PutUint32(e.buf, pointType)
+	if len(p) < 2 {
+		p = space.Point{math.NaN(), math.NaN()}
+	}
+	layout := p.Layout()
+	e.order.PutUint32(e.buf, e.layoutType(pointType, layout))
 	_, err := e.w.Write(e.buf[:4])
 	if err != nil {
 		return err
 	}
 
-	e.order.PutUint64(e.buf, math.Float64bits(p[0]))
-	e.order.PutUint64(e.buf[8:], math.Float64bits(p[1]))
-	_, err = e.w.Write(e.buf)
-	return err
+	return e.writeCoord(p, layout)
 }
 
 func unmarshalMultiPoint(order byteOrder, data []byte) (space.MultiPoint, error) {
@@ -132,7 +144,7 @@ func readMultiPoint(r io.Reader, order byteOrder, buf []byte) (space.MultiPoint,
 	result := make(space.MultiPoint, 0, alloc)
 
 	for i := 0; i < int(num); i++ {
-		pOrder, typ, err := readByteOrderType(r, buf)
+		pOrder, typ, h, err := readByteOrderType(r, buf)
 		if err != nil {
 			return nil, err
 		}
@@ -141,7 +153,7 @@ func readMultiPoint(r io.Reader, order byteOrder, buf []byte) (space.MultiPoint,
 			return nil, errors.New("expect multipoint to contains points, did not find a point")
 		}
 
-		p, err := readPoint(r, pOrder, buf)
+		p, err := readPoint(r, pOrder, buf, h.layout())
 		if err != nil {
 			return nil, err
 		}
@@ -153,7 +165,7 @@ func readMultiPoint(r io.Reader, order byteOrder, buf []byte) (space.MultiPoint,
 }
 
 func (e *Encoder) writeMultiPoint(mp space.MultiPoint) error {
-	e.order.PutUint32(e.buf, multiPointType)
+	e.order.PutUint32(e.buf, e.layoutType(multiPointType, space.CoordLayout(mp)))
 	e.order.PutUint32(e.buf[4:], uint32(len(mp)))
 	_, err := e.w.Write(e.buf[:8])
 	if err != nil {
@@ -161,7 +173,7 @@ func (e *Encoder) writeMultiPoint(mp space.MultiPoint) error {
 	}
 
 	for _, p := range mp {
-		err := e.Encode(space.Point(p))
+		err := e.write(space.Point(p))
 		if err != nil {
 			return err
 		}
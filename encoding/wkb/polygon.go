@@ -3,7 +3,6 @@ package wkb
 import (
 	"errors"
 	"io"
-	"math"
 
 	"github.com/spatial-go/geoos/space"
 )
@@ -40,7 +39,7 @@ func unmarshalPolygon(order byteOrder, data []byte) (space.Polygon, error) {
 	return result, nil
 }
 
-func readPolygon(r io.Reader, order byteOrder, buf []byte) (space.Polygon, error) {
+func readPolygon(r io.Reader, order byteOrder, buf []byte, layout space.Layout) (space.Polygon, error) {
 	num, err := readUint32(r, order, buf[:4])
 	if err != nil {
 		return nil, err
@@ -54,7 +53,7 @@ func readPolygon(r io.Reader, order byteOrder, buf []byte) (space.Polygon, error
 	result := make(space.Polygon, 0, alloc)
 
 	for i := 0; i < int(num); i++ {
-		ls, err := readLineString(r, order, buf)
+		ls, err := readLineString(r, order, buf, layout)
 		if err != nil {
 			return nil, err
 		}
@@ -66,7 +65,8 @@ func readPolygon(r io.Reader, order byteOrder, buf []byte) (space.Polygon, error
 }
 
 func (e *Encoder) writePolygon(p space.Polygon) error {
-	e.order.PutUint32(e.buf, polygonType)
+	layout := space.CoordLayout(p)
+	e.order.PutUint32(e.buf, e.layoutType(polygonType, layout))
 	e.order.PutUint32(e.buf[4:], uint32(len(p)))
 	_, err := e.w.Write(e.buf[:8])
 	if err != nil {
@@ -79,9 +79,7 @@ func (e *Encoder) writePolygon(p space.Polygon) error {
 			return err
 		}
 		for _, p := range r {
-			e.order.PutUint64(e.buf, math.Float64bits(p[0]))
-			e.order.PutUint64(e.buf[8:], math.Float64bits(p[1]))
-			_, err = e.w.Write(e.buf)
+			err = e.writeCoord(p, layout)
 			if err != nil {
 				return err
 			}
@@ -136,7 +134,7 @@ func readMultiPolygon(r io.Reader, order byteOrder, buf []byte) (space.MultiPoly
 	result := make(space.MultiPolygon, 0, alloc)
 
 	for i := 0; i < int(num); i++ {
-		pOrder, typ, err := readByteOrderType(r, buf)
+		pOrder, typ, h, err := readByteOrderType(r, buf)
 		if err != nil {
 			return nil, err
 		}
@@ -145,7 +143,7 @@ func readMultiPolygon(r io.Reader, order byteOrder, buf []byte) (space.MultiPoly
 			return nil, errors.New("expect multipolygons to contains polygons, did not find a polygon")
 		}
 
-		p, err := readPolygon(r, pOrder, buf)
+		p, err := readPolygon(r, pOrder, buf, h.layout())
 		if err != nil {
 			return nil, err
 		}
@@ -157,7 +155,7 @@ func readMultiPolygon(r io.Reader, order byteOrder, buf []byte) (space.MultiPoly
 }
 
 func (e *Encoder) writeMultiPolygon(mp space.MultiPolygon) error {
-	e.order.PutUint32(e.buf, multiPolygonType)
+	e.order.PutUint32(e.buf, e.layoutType(multiPolygonType, space.CoordLayout(mp)))
 	e.order.PutUint32(e.buf[4:], uint32(len(mp)))
 	_, err := e.w.Write(e.buf[:8])
 	if err != nil {
@@ -165,7 +163,7 @@ func (e *Encoder) writeMultiPolygon(mp space.MultiPolygon) error {
 	}
 
 	for _, p := range mp {
-		err := e.Encode(p)
+		err := e.write(p)
 		if err != nil {
 			return err
 		}
@@ -49,6 +49,9 @@ type GeometryScanner struct {
 	g        interface{}
 	Geometry space.Geometry
 	Valid    bool // Valid is true if the geometry is not NULL
+
+	// Header is the SRID and the Z and M flags of the EWKB scanned.
+	Header Header
 }
 
 // Scanner will return a GeometryScanner that can scan sql query results.
@@ -78,6 +81,15 @@ type GeometryScanner struct {
 // data as WKB but prefixed with a 4 byte SRID. To support this, if the data is not
 // valid WKB, the code will strip the first 4 bytes and try again.
 // This works for most use cases.
+//
+// PostGIS geometry columns can be scanned without ST_AsBinary: they are returned as
+// EWKB, or hex encoded EWKB in text mode, whose SRID is set to the Header attribute.
+//
+//	var p space.Point
+//	s := wkb.Scanner(&p)
+//	err := db.QueryRow("SELECT geom FROM foo WHERE id=$1", id).Scan(s)
+//	...
+//	// use p and s.Header.SRID
 func Scanner(g interface{}) *GeometryScanner {
 	return &GeometryScanner{g: g}
 }
@@ -88,13 +100,19 @@ func Scanner(g interface{}) *GeometryScanner {
 func (s *GeometryScanner) Scan(d interface{}) error {
 	s.Geometry = nil
 	s.Valid = false
+	s.Header = Header{}
 
 	if d == nil {
 		return nil
 	}
 
-	data, ok := d.([]byte)
-	if !ok {
+	var data []byte
+	switch v := d.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
 		return ErrUnsupportedDataType
 	}
 
@@ -113,6 +131,24 @@ func (s *GeometryScanner) Scan(d interface{}) error {
 		data = data[:n]
 	}
 
+	// PostGIS returns geometry columns as hex encoded EWKB in text mode,
+	// starting with the byte order 00 or 01.
+	if len(data) > 9 && len(data)%2 == 0 && data[0] == '0' && (data[1] == '0' || data[1] == '1') {
+		n, err := hex.Decode(data, data)
+		if err != nil {
+			return fmt.Errorf("thought the data was hex, but it is not: %v", err)
+		}
+		data = data[:n]
+	}
+
+	if _, typ, _, err := unmarshalByteOrderType(data); err == nil && typ > geometryCollectionType {
+		m, h, err := UnmarshalEWKB(data)
+		if err != nil {
+			return err
+		}
+		return s.scanGeometry(m, h)
+	}
+
 	switch g := s.g.(type) {
 	case nil:
 		m, err := Unmarshal(data)
@@ -223,6 +259,108 @@ func (s *GeometryScanner) Scan(d interface{}) error {
 	return ErrIncorrectGeometry
 }
 
+// scanGeometry sets the decoded geometry m to the scanner, converting it to the type of the destination.
+func (s *GeometryScanner) scanGeometry(m space.Geometry, h Header) error {
+	var geom space.Geometry
+	switch g := s.g.(type) {
+	case nil:
+		geom = m
+	case *space.Point:
+		switch p := m.(type) {
+		case space.Point:
+			*g = p
+		case space.MultiPoint:
+			if len(p) != 1 {
+				return ErrIncorrectGeometry
+			}
+			*g = p[0]
+		default:
+			return ErrIncorrectGeometry
+		}
+		geom = *g
+	case *space.MultiPoint:
+		switch p := m.(type) {
+		case space.Point:
+			*g = space.MultiPoint{p}
+		case space.MultiPoint:
+			*g = p
+		default:
+			return ErrIncorrectGeometry
+		}
+		geom = *g
+	case *space.LineString:
+		switch p := m.(type) {
+		case space.LineString:
+			*g = p
+		case space.MultiLineString:
+			if len(p) != 1 {
+				return ErrIncorrectGeometry
+			}
+			*g = p[0]
+		default:
+			return ErrIncorrectGeometry
+		}
+		geom = *g
+	case *space.MultiLineString:
+		switch p := m.(type) {
+		case space.LineString:
+			*g = space.MultiLineString{p}
+		case space.MultiLineString:
+			*g = p
+		default:
+			return ErrIncorrectGeometry
+		}
+		geom = *g
+	case *space.Ring:
+		p, ok := m.(space.Polygon)
+		if !ok || len(p) != 1 {
+			return ErrIncorrectGeometry
+		}
+		*g = p.ToRingArray()[0]
+		geom = *g
+	case *space.Polygon:
+		switch p := m.(type) {
+		case space.Polygon:
+			*g = p
+		case space.MultiPolygon:
+			if len(p) != 1 {
+				return ErrIncorrectGeometry
+			}
+			*g = p[0]
+		default:
+			return ErrIncorrectGeometry
+		}
+		geom = *g
+	case *space.MultiPolygon:
+		switch p := m.(type) {
+		case space.Polygon:
+			*g = space.MultiPolygon{p}
+		case space.MultiPolygon:
+			*g = p
+		default:
+			return ErrIncorrectGeometry
+		}
+		geom = *g
+	case *space.Collection:
+		p, ok := m.(space.Collection)
+		if !ok {
+			return ErrIncorrectGeometry
+		}
+		*g = p
+		geom = *g
+	case *space.Bound:
+		*g = m.Bound()
+		geom = *g
+	default:
+		return ErrIncorrectGeometry
+	}
+
+	s.Geometry = geom
+	s.Header = h
+	s.Valid = true
+	return nil
+}
+
 func scanPoint(data []byte) (space.Point, error) {
 	order, typ, data, err := unmarshalByteOrderType(data)
 	if err != nil {
@@ -365,7 +503,8 @@ func scanCollection(data []byte) (space.Collection, error) {
 }
 
 type value struct {
-	v space.Geometry
+	v    space.Geometry
+	srid int
 }
 
 // Value will create a driver.Valuer that will WKB the geometry
@@ -375,10 +514,36 @@ func Value(g space.Geometry) driver.Valuer {
 
 }
 
+// ValueEWKB will create a driver.Valuer that will write the geometry with the SRID
+// into the database query, as hex encoded EWKB. PostGIS reads it directly into
+// geometry columns with a SRID constraint, such as geometry(Point, 4326).
+func ValueEWKB(g space.Geometry, srid int) driver.Valuer {
+	return value{v: g, srid: srid}
+}
+
 func (v value) Value() (driver.Value, error) {
+	if v.srid != 0 {
+		return marshalEWKBHex(v.v, v.srid)
+	}
+
 	val, err := Marshal(v.v)
 	if val == nil {
 		return nil, err
 	}
 	return val, err
 }
+
+// marshalEWKBHex encodes the geometry with the SRID as hex encoded EWKB.
+func marshalEWKBHex(g space.Geometry, srid int) (driver.Value, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, geomLength(g)+4))
+	e := NewEncoder(buf)
+	e.SetSRID(srid)
+	if err := e.Encode(g); err != nil {
+		return nil, err
+	}
+
+	if buf.Len() == 0 {
+		return nil, nil
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
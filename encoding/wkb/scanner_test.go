@@ -2,6 +2,7 @@ package wkb
 
 import (
 	"bytes"
+	"encoding/hex"
 	"reflect"
 	"testing"
 
@@ -83,6 +84,34 @@ func TestScanHexData(t *testing.T) {
 	}
 }
 
+func TestScanEWKB(t *testing.T) {
+	ewkb := "0101000020E61000008EAF3DB324E05C40DC12B9E00C704340"
+	data, _ := hex.DecodeString(ewkb)
+	expected := space.Point{115.50224, 38.875393}
+
+	for _, d := range []interface{}{data, []byte(ewkb), ewkb, []byte(`\x` + ewkb)} {
+		var p space.Point
+		s := Scanner(&p)
+		if err := s.Scan(d); err != nil {
+			t.Fatalf("scan error: %v", err)
+		}
+		if !p.Equal(expected) || !s.Valid || s.Header.SRID != 4326 {
+			t.Errorf("Scan() got = %v, %v", p, s.Header)
+		}
+	}
+
+	var mp space.MultiPoint
+	s := Scanner(&mp)
+	if err := s.Scan(data); err != nil || len(mp) != 1 || !mp[0].Equal(expected) {
+		t.Errorf("Scan() into multi point got = %v, %v", mp, err)
+	}
+
+	var ls space.LineString
+	if err := Scanner(&ls).Scan(data); err != ErrIncorrectGeometry {
+		t.Errorf("incorrect error: %v != %v", err, ErrIncorrectGeometry)
+	}
+}
+
 func TestScanPoint(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -968,6 +997,22 @@ func TestValue(t *testing.T) {
 	})
 }
 
+func TestValueEWKB(t *testing.T) {
+	val, err := ValueEWKB(space.Point{115.50224, 38.875393}, 4326).Value()
+	if err != nil {
+		t.Errorf("value error: %v", err)
+	}
+
+	if val != "0101000020e61000008eaf3db324e05c40dc12b9e00c704340" {
+		t.Errorf("incorrect marshal: %v", val)
+	}
+
+	val, err = ValueEWKB(nil, 4326).Value()
+	if err != nil || val != nil {
+		t.Errorf("should be nil value: %[1]T, %[1]v", val)
+	}
+}
+
 func TestValue_nil(t *testing.T) {
 	var (
 		mp    space.MultiPoint
@@ -1,14 +1,15 @@
-// Package wkb is for decoding ESRI's Well Known Binary (WKB) format
+// Package wkb is for decoding ESRI's Well Known Binary (WKB) format, and the PostGIS EWKB and ISO WKB
+// extensions carrying a SRID and Z and M values.
 // sepcification at https://en.wikipedia.org/wiki/Well-known_text_representation_of_geometry#Well-known_binary
 package wkb
 
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"io"
+	"math"
 
-	"github.com/spatial-go/geoos/encoding/wkt"
-	"github.com/spatial-go/geoos/geo"
 	"github.com/spatial-go/geoos/space"
 )
 
@@ -30,6 +31,100 @@ const (
 	geometryCollectionType uint32 = 7
 )
 
+// Flags of the PostGIS extended WKB (EWKB) type.
+const (
+	ewkbZFlag    uint32 = 0x80000000
+	ewkbMFlag    uint32 = 0x40000000
+	ewkbSRIDFlag uint32 = 0x20000000
+)
+
+// Header is the extended information carried by the type of an EWKB or ISO WKB geometry:
+// the SRID, 0 if there is none, and whether the coordinates have Z and M values.
+// Z and M values are kept in the coordinates as described by space.Layout.
+type Header struct {
+	SRID int
+	HasZ bool
+	HasM bool
+}
+
+// layout returns the layout of the coordinates.
+func (h Header) layout() space.Layout {
+	switch {
+	case h.HasZ && h.HasM:
+		return space.XYZM
+	case h.HasZ:
+		return space.XYZ
+	case h.HasM:
+		return space.XYM
+	}
+	return space.XY
+}
+
+// layoutType returns the EWKB or ISO WKB type of a geometry type with coordinates in the layout.
+func (e *Encoder) layoutType(typ uint32, layout space.Layout) uint32 {
+	if e.iso {
+		switch layout {
+		case space.XYZ:
+			return typ + 1000
+		case space.XYM:
+			return typ + 2000
+		case space.XYZM:
+			return typ + 3000
+		}
+		return typ
+	}
+	if layout.HasZ() {
+		typ |= ewkbZFlag
+	}
+	if layout.HasM() {
+		typ |= ewkbMFlag
+	}
+	return typ
+}
+
+// writeCoord writes the values of the coordinate in the layout.
+func (e *Encoder) writeCoord(p []float64, layout space.Layout) error {
+	e.order.PutUint64(e.buf, math.Float64bits(p[0]))
+	e.order.PutUint64(e.buf[8:], math.Float64bits(p[1]))
+	if _, err := e.w.Write(e.buf); err != nil {
+		return err
+	}
+
+	n := 0
+	if layout.HasZ() {
+		e.order.PutUint64(e.buf, math.Float64bits(space.Point(p).Z()))
+		n += 8
+	}
+	if layout.HasM() {
+		e.order.PutUint64(e.buf[n:], math.Float64bits(space.Point(p).M()))
+		n += 8
+	}
+	if n == 0 {
+		return nil
+	}
+	_, err := e.w.Write(e.buf[:n])
+	return err
+}
+
+// splitType splits an EWKB or ISO WKB type into the geometry type and the Z and M flags,
+// and returns whether an SRID follows the type.
+func splitType(typ uint32) (uint32, Header, bool) {
+	h := Header{HasZ: typ&ewkbZFlag != 0, HasM: typ&ewkbMFlag != 0}
+	hasSRID := typ&ewkbSRIDFlag != 0
+	typ &^= ewkbZFlag | ewkbMFlag | ewkbSRIDFlag
+
+	// ISO WKB adds 1000 for Z, 2000 for M and 3000 for ZM to the type.
+	switch typ / 1000 {
+	case 1:
+		h.HasZ = true
+	case 2:
+		h.HasM = true
+	case 3:
+		h.HasZ, h.HasM = true, true
+	}
+	return typ % 1000, h, hasSRID
+}
+
 const (
 	// limits so that bad data can't come in and preallocate tons of memory.
 	// Well formed data with less elements will allocate the correct amount just fine.
@@ -48,6 +143,8 @@ type Encoder struct {
 
 	w     io.Writer
 	order binary.ByteOrder
+	srid  int
+	iso   bool
 }
 
 // MustMarshal will encode the geometry and panic on error.
@@ -96,9 +193,24 @@ func (e *Encoder) SetByteOrder(bo binary.ByteOrder) {
 	e.order = bo
 }
 
+// SetSRID makes the encoder write PostGIS EWKB with the given SRID.
+// The SRID is written with the outer geometry only. A zero SRID writes WKB.
+func (e *Encoder) SetSRID(srid int) {
+	e.srid = srid
+}
+
+// SetISO makes the encoder write the Z and M values of the coordinates with the ISO WKB types,
+// adding 1000, 2000 or 3000 to the geometry types, instead of the EWKB flags.
+// It has no effect on the EWKB written with a SRID.
+func (e *Encoder) SetISO(iso bool) {
+	e.iso = iso
+}
+
 // Encode will write the geometry encoded as WKB to the given writer.
+// An empty point is written with NaN coordinates, like PostGIS does, and the other empty
+// geometries with no elements.
 func (e *Encoder) Encode(geom space.Geometry) error {
-	if geom == nil || geom.IsEmpty() {
+	if geom == nil {
 		return nil
 	}
 
@@ -129,23 +241,47 @@ func (e *Encoder) Encode(geom space.Geometry) error {
 		if g == nil {
 			return nil
 		}
-	// deal with types that are not supported by wkb
 	case space.Ring:
 		if g == nil {
 			return nil
 		}
-		geom = space.Polygon{g}
 	case space.Bound:
 		if g.Max == nil || g.Min == nil {
 			return nil
 		}
-		geom = g.ToPolygon()
 	case space.Point:
 		if g == nil {
 			return nil
 		}
 	}
 
+	if e.srid != 0 {
+		return e.writeEWKB(geom)
+	}
+	return e.write(geom)
+}
+
+// write writes the geometry with its byte order. Nil geometries are written as empty ones,
+// so that the elements of a multi geometry or a collection are all written.
+func (e *Encoder) write(geom space.Geometry) error {
+	switch g := geom.(type) {
+	case nil:
+		geom = space.Collection{}
+	// deal with types that are not supported by wkb
+	case space.Ring:
+		if len(g) == 0 {
+			geom = space.Polygon{}
+		} else {
+			geom = space.Polygon{g}
+		}
+	case space.Bound:
+		if g.IsEmpty() {
+			geom = space.Polygon{}
+		} else {
+			geom = g.ToPolygon()
+		}
+	}
+
 	var b []byte
 	if e.order == binary.LittleEndian {
 		b = []byte{1}
@@ -182,18 +318,47 @@ func (e *Encoder) Encode(geom space.Geometry) error {
 	panic("unsupported type")
 }
 
+// writeEWKB writes the geometry as EWKB, the SRID following the type of the outer geometry.
+func (e *Encoder) writeEWKB(geom space.Geometry) error {
+	buf := bytes.NewBuffer(make([]byte, 0, geomLength(geom)))
+	wkb := NewEncoder(buf)
+	wkb.SetByteOrder(e.order)
+	if err := wkb.Encode(geom); err != nil {
+		return err
+	}
+
+	data := buf.Bytes()
+	header := make([]byte, 9)
+	header[0] = data[0]
+	e.order.PutUint32(header[1:], e.order.Uint32(data[1:])|ewkbSRIDFlag)
+	e.order.PutUint32(header[5:], uint32(e.srid))
+	if _, err := e.w.Write(header); err != nil {
+		return err
+	}
+	_, err := e.w.Write(data[5:])
+	return err
+}
+
 // Decoder can decoder WKB geometry off of the stream.
+// It also decodes PostGIS EWKB and ISO WKB, see Header.
 type Decoder struct {
-	r io.Reader
+	r      io.Reader
+	header Header
 }
 
 // Unmarshal will decode the type into a Geometry.
+// EWKB and ISO WKB data are decoded too, see UnmarshalEWKB to get their SRID.
 func Unmarshal(data []byte) (space.Geometry, error) {
 	order, typ, data, err := unmarshalByteOrderType(data)
 	if err != nil {
 		return nil, err
 	}
 
+	if typ > geometryCollectionType {
+		g, _, err := UnmarshalEWKB(data)
+		return g, err
+	}
+
 	switch typ {
 	case pointType:
 		return unmarshalPoint(order, data[5:])
@@ -219,6 +384,21 @@ func Unmarshal(data []byte) (space.Geometry, error) {
 	return nil, ErrUnsupportedGeometry
 }
 
+// UnmarshalEWKB will decode PostGIS EWKB, ISO WKB or WKB data into a Geometry,
+// and returns the header of the data with its SRID and Z and M flags.
+func UnmarshalEWKB(data []byte) (space.Geometry, Header, error) {
+	d := NewDecoder(bytes.NewReader(data))
+	g, err := d.Decode()
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, Header{}, ErrNotWKB
+	}
+	if err != nil {
+		return nil, Header{}, err
+	}
+
+	return g, d.Header(), nil
+}
+
 // NewDecoder will create a new WKB decoder.
 func NewDecoder(r io.Reader) *Decoder {
 	return &Decoder{
@@ -226,25 +406,32 @@ func NewDecoder(r io.Reader) *Decoder {
 	}
 }
 
+// Header returns the header of the last geometry decoded.
+func (d *Decoder) Header() Header {
+	return d.header
+}
+
 // Decode will decode the next geometry off of the stream.
 func (d *Decoder) Decode() (space.Geometry, error) {
 	buf := make([]byte, 8)
-	order, typ, err := readByteOrderType(d.r, buf)
+	order, typ, h, err := readByteOrderType(d.r, buf)
 	if err != nil {
 		return nil, err
 	}
+	d.header = h
 
+	layout := h.layout()
 	switch typ {
 	case pointType:
-		return readPoint(d.r, order, buf)
+		return readPoint(d.r, order, buf, layout)
 	case multiPointType:
 		return readMultiPoint(d.r, order, buf)
 	case lineStringType:
-		return readLineString(d.r, order, buf)
+		return readLineString(d.r, order, buf, layout)
 	case multiLineStringType:
 		return readMultiLineString(d.r, order, buf)
 	case polygonType:
-		return readPolygon(d.r, order, buf)
+		return readPolygon(d.r, order, buf, layout)
 	case multiPolygonType:
 		return readMultiPolygon(d.r, order, buf)
 	case geometryCollectionType:
@@ -254,23 +441,20 @@ func (d *Decoder) Decode() (space.Geometry, error) {
 	return nil, ErrUnsupportedGeometry
 }
 
-// GeoFromWKBHexStr convert hex string to geometry
+// GeoFromWKBHexStr convert hex string to geometry.
+// The hex string may hold WKB, EWKB or ISO WKB data, see Unmarshal.
 func GeoFromWKBHexStr(wkbHex string) (geometry space.Geometry, err error) {
-	geom, err := geo.GeomFromWKBHexStr(wkbHex)
+	data, err := hex.DecodeString(wkbHex)
 	if err != nil {
-		return
-	}
-	geoWek, err := geo.ToWKTStr(geom)
-	if err != nil {
-		return
+		return nil, err
 	}
-	return wkt.UnmarshalString(geoWek)
+	return Unmarshal(data)
 }
 
-func readByteOrderType(r io.Reader, buf []byte) (byteOrder, uint32, error) {
+func readByteOrderType(r io.Reader, buf []byte) (byteOrder, uint32, Header, error) {
 	// the byte order is the first byte
 	if _, err := r.Read(buf[:1]); err != nil {
-		return 0, 0, err
+		return 0, 0, Header{}, err
 	}
 
 	var order byteOrder
@@ -279,16 +463,25 @@ func readByteOrderType(r io.Reader, buf []byte) (byteOrder, uint32, error) {
 	} else if buf[0] == 1 {
 		order = littleEndian
 	} else {
-		return 0, 0, ErrNotWKB
+		return 0, 0, Header{}, ErrNotWKB
 	}
 
 	// the type which is 4 bytes
 	typ, err := readUint32(r, order, buf[:4])
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, Header{}, err
 	}
 
-	return order, typ, nil
+	typ, h, hasSRID := splitType(typ)
+	if hasSRID {
+		srid, err := readUint32(r, order, buf[:4])
+		if err != nil {
+			return 0, 0, Header{}, err
+		}
+		h.SRID = int(int32(srid))
+	}
+
+	return order, typ, h, nil
 }
 
 func readUint32(r io.Reader, order byteOrder, buf []byte) (uint32, error) {
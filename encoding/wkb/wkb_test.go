@@ -3,9 +3,13 @@ package wkb
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"io/ioutil"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/spatial-go/geoos/encoding/wkt"
 	"github.com/spatial-go/geoos/space"
 )
 
@@ -57,6 +61,148 @@ func TestGeoFromWKBHexStr(t *testing.T) {
 		t.Errorf("GeoFromWKBHexStr() got = %v, want %v", g0, g1)
 	}
 }
+func TestUnmarshalEWKB(t *testing.T) {
+	cases := []struct {
+		name   string
+		data   string
+		geom   space.Geometry
+		header Header
+	}{
+		{
+			name:   "point with srid",
+			data:   "0101000020E61000008EAF3DB324E05C40DC12B9E00C704340",
+			geom:   space.Point{115.50224, 38.875393},
+			header: Header{SRID: 4326},
+		},
+		{
+			name:   "point z with srid",
+			data:   "01010000A0E6100000000000000000F03F00000000000000400000000000000840",
+			geom:   space.Point{1, 2, 3},
+			header: Header{SRID: 4326, HasZ: true},
+		},
+		{
+			name:   "iso line string zm",
+			data:   "01BA0B000002000000000000000000F03F000000000000004000000000000008400000000000001040000000000000144000000000000018400000000000001C400000000000002040",
+			geom:   space.LineString{{1, 2, 3, 4}, {5, 6, 7, 8}},
+			header: Header{HasZ: true, HasM: true},
+		},
+		{
+			name:   "multi point z",
+			data:   "0104000080020000000101000080000000000000F03F000000000000004000000000000008400101000080000000000000104000000000000014400000000000001840",
+			geom:   space.MultiPoint{{1, 2, 3}, {4, 5, 6}},
+			header: Header{HasZ: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tc.data)
+			geom, header, err := UnmarshalEWKB(data)
+			if err != nil {
+				t.Fatalf("unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(geom, tc.geom) || header != tc.header {
+				t.Errorf("UnmarshalEWKB() got = %v, %v, want %v, %v", geom, header, tc.geom, tc.header)
+			}
+
+			geom, err = Unmarshal(data)
+			if err != nil || !reflect.DeepEqual(geom, tc.geom) {
+				t.Errorf("Unmarshal() got = %v, %v, want %v", geom, err, tc.geom)
+			}
+		})
+	}
+}
+
+func TestMarshal_ZM(t *testing.T) {
+	geoms := []space.Geometry{
+		space.Point{1, 2, 3},
+		space.NewPointM(1, 2, 4),
+		space.LineString{{1, 2, 3, 4}, {5, 6, 7, 8}},
+		space.MultiPoint{space.NewPointM(1, 2, 3), space.NewPointM(4, 5, 6)},
+		space.MultiPolygon{{{{0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 0, 1}}}},
+		space.Collection{space.Point{1, 2, 3}, space.LineString{{1, 2, 3}, {3, 4, 5}}},
+	}
+	for _, g := range geoms {
+		data, err := Marshal(g)
+		if err != nil {
+			t.Fatalf("marshal error: %v", err)
+		}
+
+		geom, header, err := UnmarshalEWKB(data)
+		if err != nil {
+			t.Fatalf("unmarshal error: %v", err)
+		}
+		layout := space.CoordLayout(g)
+		if !geom.Equal(g) || space.CoordLayout(geom) != layout || header.HasZ != layout.HasZ() || header.HasM != layout.HasM() {
+			t.Errorf("round trip got = %v, %v, want %v", geom, header, g)
+		}
+	}
+
+	data, _ := hex.DecodeString("0101000040000000000000F03F00000000000000400000000000001040")
+	geom, err := Unmarshal(data)
+	if err != nil || !geom.Equal(space.NewPointM(1, 2, 4)) {
+		t.Errorf("Unmarshal() of a point m got = %v, %v", geom, err)
+	}
+}
+
+func TestEncoder_SetISO(t *testing.T) {
+	cases := []struct {
+		geom space.Geometry
+		data string
+	}{
+		{geom: space.LineString{{1, 2, 3, 4}, {5, 6, 7, 8}}, data: "01BA0B000002000000000000000000F03F000000000000004000000000000008400000000000001040000000000000144000000000000018400000000000001C400000000000002040"},
+		{geom: space.NewPointM(1, 2, 4), data: "01D1070000000000000000F03F00000000000000400000000000001040"},
+		{geom: space.Point{1, 2}, data: "0101000000000000000000F03F0000000000000040"},
+	}
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		e := NewEncoder(&buf)
+		e.SetISO(true)
+		if err := e.Encode(tc.geom); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+		if got := strings.ToUpper(hex.EncodeToString(buf.Bytes())); got != tc.data {
+			t.Errorf("Encode() of %v got = %v, want %v", tc.geom, got, tc.data)
+		}
+	}
+}
+
+func TestEncoder_SetSRID(t *testing.T) {
+	geoms := []space.Geometry{
+		space.Point{115.50224, 38.875393},
+		space.LineString{{1, 2}, {3, 4}},
+		space.MultiPolygon{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}},
+		space.Collection{space.Point{1, 2}, space.LineString{{1, 2}, {3, 4}}},
+	}
+	for _, g := range geoms {
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			var buf bytes.Buffer
+			e := NewEncoder(&buf)
+			e.SetByteOrder(order)
+			e.SetSRID(4326)
+			if err := e.Encode(g); err != nil {
+				t.Fatalf("encode error: %v", err)
+			}
+
+			geom, header, err := UnmarshalEWKB(buf.Bytes())
+			if err != nil {
+				t.Fatalf("unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(geom, g) || header.SRID != 4326 {
+				t.Errorf("round trip got = %v, %v, want %v", geom, header, g)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	e.SetSRID(4326)
+	e.Encode(space.Point{115.50224, 38.875393})
+	if got := strings.ToUpper(hex.EncodeToString(buf.Bytes())); got != "0101000020E61000008EAF3DB324E05C40DC12B9E00C704340" {
+		t.Errorf("Encode() got = %v", got)
+	}
+}
+
 func BenchmarkEncode_Point(b *testing.B) {
 	g := space.Point{1, 2}
 	e := NewEncoder(ioutil.Discard)
@@ -173,3 +319,45 @@ func compare(t testing.TB, e space.Geometry, b []byte) {
 		t.Errorf("scan: incorrect geometry: %v != %v", sg, e)
 	}
 }
+
+func TestMarshal_Empty(t *testing.T) {
+	cases := []string{
+		"POINT EMPTY",
+		"POINT Z EMPTY",
+		"LINESTRING EMPTY",
+		"POLYGON EMPTY",
+		"MULTIPOINT EMPTY",
+		"MULTILINESTRING EMPTY",
+		"MULTIPOLYGON EMPTY",
+		"GEOMETRYCOLLECTION EMPTY",
+		"MULTIPOINT((1 2),EMPTY)",
+		"MULTILINESTRING((1 2,3 4),EMPTY)",
+		"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),EMPTY)",
+		"GEOMETRYCOLLECTION(POINT EMPTY,POINT(1 2))",
+		"GEOMETRYCOLLECTION(LINESTRING EMPTY,POLYGON EMPTY,GEOMETRYCOLLECTION EMPTY,POINT(1 2))",
+	}
+	for _, s := range cases {
+		t.Run(s, func(t *testing.T) {
+			geom, err := wkt.UnmarshalString(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+				data, err := Marshal(geom, order)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(data) == 0 {
+					t.Fatalf("Marshal() wrote no data")
+				}
+				got, err := Unmarshal(data)
+				if err != nil {
+					t.Fatalf("Unmarshal() error = %v, data %x", err, data)
+				}
+				if out := wkt.MarshalString(got); out != s {
+					t.Errorf("round trip = %v, want %v", out, s)
+				}
+			}
+		})
+	}
+}
@@ -10,7 +10,7 @@ import (
 
 // UnmarshalString encode to geom
 func UnmarshalString(s string) (space.Geometry, error) {
-	p := Parser{NewLexer(strings.NewReader(s))}
+	p := Parser{Lexer: NewLexer(strings.NewReader(s))}
 	return p.Parse()
 }
 
@@ -21,22 +21,45 @@ func MarshalString(geom space.Geometry) string {
 	return buf.String()
 }
 
+// layoutTags are the tags of the layouts of coordinates, written after the geometry type.
+var layoutTags = map[space.Layout]string{
+	space.XY:   "",
+	space.XYZ:  " Z ",
+	space.XYM:  " M ",
+	space.XYZM: " ZM ",
+}
+
 func wkt(buf *bytes.Buffer, geom space.Geometry) {
+	layout := space.CoordLayout(geom)
+	tag := layoutTags[layout]
 	switch g := geom.(type) {
 	case space.Point:
-		_, _ = fmt.Fprintf(buf, "POINT(%g %g)", g.Lon(), g.Lat())
+		if g.IsEmpty() {
+			buf.WriteString("POINT" + strings.TrimSuffix(tag, " ") + " EMPTY")
+			return
+		}
+
+		buf.WriteString("POINT" + tag + "(")
+		writeCoord(buf, g, layout)
+		buf.WriteByte(')')
 	case space.MultiPoint:
 		if len(g) == 0 {
 			buf.Write([]byte(`MULTIPOINT EMPTY`))
 			return
 		}
 
-		buf.Write([]byte(`MULTIPOINT(`))
+		buf.WriteString("MULTIPOINT" + tag + "(")
 		for i, p := range g.ToPointArray() {
 			if i != 0 {
 				buf.WriteByte(',')
 			}
-			_, _ = fmt.Fprintf(buf, "(%g %g)", p.Lon(), p.Lat())
+			if p.IsEmpty() {
+				buf.Write([]byte(`EMPTY`))
+				continue
+			}
+			buf.WriteByte('(')
+			writeCoord(buf, p, layout)
+			buf.WriteByte(')')
 		}
 		buf.WriteByte(')')
 	case space.LineString:
@@ -45,20 +68,20 @@ func wkt(buf *bytes.Buffer, geom space.Geometry) {
 			return
 		}
 
-		buf.Write([]byte(`LINESTRING`))
-		writeLineString(buf, g)
+		buf.WriteString("LINESTRING" + tag)
+		writeLineString(buf, g, layout)
 	case space.MultiLineString:
 		if len(g) == 0 {
 			buf.Write([]byte(`MULTILINESTRING EMPTY`))
 			return
 		}
 
-		buf.Write([]byte(`MULTILINESTRING(`))
+		buf.WriteString("MULTILINESTRING" + tag + "(")
 		for i, ls := range g {
 			if i != 0 {
 				buf.WriteByte(',')
 			}
-			writeLineString(buf, ls)
+			writeLineString(buf, ls, layout)
 		}
 		buf.WriteByte(')')
 	case space.Ring:
@@ -69,12 +92,12 @@ func wkt(buf *bytes.Buffer, geom space.Geometry) {
 			return
 		}
 
-		buf.Write([]byte(`POLYGON(`))
+		buf.WriteString("POLYGON" + tag + "(")
 		for i, r := range g {
 			if i != 0 {
 				buf.WriteByte(',')
 			}
-			writeLineString(buf, space.LineString(r))
+			writeLineString(buf, space.LineString(r), layout)
 		}
 		buf.WriteByte(')')
 	case space.MultiPolygon:
@@ -83,17 +106,21 @@ func wkt(buf *bytes.Buffer, geom space.Geometry) {
 			return
 		}
 
-		buf.Write([]byte(`MULTIPOLYGON(`))
+		buf.WriteString("MULTIPOLYGON" + tag + "(")
 		for i, p := range g {
 			if i != 0 {
 				buf.WriteByte(',')
 			}
+			if len(p) == 0 {
+				buf.Write([]byte(`EMPTY`))
+				continue
+			}
 			buf.WriteByte('(')
 			for j, r := range p {
 				if j != 0 {
 					buf.WriteByte(',')
 				}
-				writeLineString(buf, space.LineString(r))
+				writeLineString(buf, space.LineString(r), layout)
 			}
 			buf.WriteByte(')')
 		}
@@ -104,7 +131,7 @@ func wkt(buf *bytes.Buffer, geom space.Geometry) {
 			buf.Write([]byte(`GEOMETRYCOLLECTION EMPTY`))
 			return
 		}
-		buf.Write([]byte(`GEOMETRYCOLLECTION(`))
+		buf.WriteString("GEOMETRYCOLLECTION" + tag + "(")
 		for i, c := range g {
 			if i != 0 {
 				buf.WriteByte(',')
@@ -117,14 +144,29 @@ func wkt(buf *bytes.Buffer, geom space.Geometry) {
 	}
 }
 
-func writeLineString(buf *bytes.Buffer, ls space.LineString) {
+func writeLineString(buf *bytes.Buffer, ls space.LineString, layout space.Layout) {
+	if len(ls) == 0 {
+		buf.Write([]byte(`EMPTY`))
+		return
+	}
 	buf.WriteByte('(')
 	for i, p := range ls.ToPointArray() {
 		if i != 0 {
 			buf.WriteByte(',')
 		}
 
-		_, _ = fmt.Fprintf(buf, "%g %g", p.Lon(), p.Lat())
+		writeCoord(buf, p, layout)
 	}
 	buf.WriteByte(')')
 }
+
+// writeCoord writes the values of the coordinate in the layout.
+func writeCoord(buf *bytes.Buffer, p space.Point, layout space.Layout) {
+	_, _ = fmt.Fprintf(buf, "%g %g", p.Lon(), p.Lat())
+	if layout.HasZ() {
+		_, _ = fmt.Fprintf(buf, " %g", p.Z())
+	}
+	if layout.HasM() {
+		_, _ = fmt.Fprintf(buf, " %g", p.M())
+	}
+}
@@ -30,6 +30,7 @@ const (
 	Multipoint
 	MultilineString
 	MultiPolygonEnum
+	GeometryCollectionEnum
 
 	// Values
 	Float
@@ -89,6 +90,18 @@ func (l *Lexer) peek() rune {
 	return ch
 }
 
+// peekFloat skips the spaces and returns true if the next lexeme is a float.
+func (l *Lexer) peekFloat() bool {
+	for {
+		r := l.peek()
+		if !unicode.IsSpace(r) {
+			return beginFloat(r)
+		}
+		l.read()
+		l.pos++
+	}
+}
+
 // scanToLowerWord scan a word and returns its value in lower letters
 func (l *Lexer) scanToLowerWord(r rune) string {
 	var buf bytes.Buffer
@@ -153,6 +166,8 @@ func (l *Lexer) scanToken() (Token, error) {
 			return l.getToken(MultilineString, "multilinestring"), nil
 		case "multipolygon":
 			return l.getToken(MultiPolygonEnum, "multipolygon"), nil
+		case "geometrycollection":
+			return l.getToken(GeometryCollectionEnum, "geometrycollection"), nil
 		default:
 			return Token{}, fmt.Errorf("Unexpected word %s on character %d", w, l.pos)
 		}
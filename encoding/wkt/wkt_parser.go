@@ -10,208 +10,150 @@ import (
 // Parser ...
 type Parser struct {
 	*Lexer
+
+	// dims is the number of values of the first coordinate parsed, which all the coordinates must have.
+	dims int
 }
 
 // Parse ...
+// On error, it returns the geometry parsed so far.
 func (p *Parser) Parse() (space.Geometry, error) {
+	geom, err := p.parseGeometry(LeftParen)
+	if err != nil {
+		return geom, err
+	}
+
+	t, err := p.scanToken()
+	if err != nil {
+		return geom, err
+	}
+	if t.ttype != EOF {
+		return geom, fmt.Errorf("unexpected token %s on pos %d, expected EOF", t.lexeme, t.pos)
+	}
+	return geom, nil
+}
+
+// parseGeometry parses a geometry, from its type to its closing parenthesis.
+// inherit is the layout token of the enclosing collection, used when the geometry has none.
+func (p *Parser) parseGeometry(inherit tokenType) (space.Geometry, error) {
 	t, err := p.scanToken()
 	if err != nil {
 		return nil, err
 	}
 	switch t.ttype {
 	case PointEnum:
-		return p.parsePoint()
+		return p.parsePoint(inherit)
 	case Linestring:
-		return p.parseLineString()
+		return p.parseLineString(inherit)
 	case PolygonEnum:
-		return p.parsePolygon()
+		return p.parsePolygon(inherit)
 	case Multipoint:
-		line, err := p.parseLineString()
-		return space.MultiPoint(line.ToPointArray()), err
+		return p.parseMultiPoint(inherit)
 	case MultilineString:
-		poly, err := p.parsePolygon()
-		multiline := make(space.MultiLineString, 0, len(poly))
-		for _, ring := range poly {
-			multiline = append(multiline, space.LineString(ring))
-		}
-		return multiline, err
+		return p.parseMultiLineString(inherit)
 	case MultiPolygonEnum:
-		return p.parseMultiPolygon()
+		return p.parseMultiPolygon(inherit)
+	case GeometryCollectionEnum:
+		return p.parseCollection(inherit)
 	default:
 		return nil, fmt.Errorf("Parse unexpected token %s on pos %d expected geometry type", t.lexeme, t.pos)
 	}
 }
 
-func (p *Parser) parsePoint() (point space.Point, err error) {
+// parseTag parses the optional layout token following the geometry type, then EMPTY or the opening parenthesis.
+// It returns the layout token, inherit if there is none, and true if the geometry is empty.
+// Only empty points keep the layout, the other empty geometries have no coordinate to carry it.
+func (p *Parser) parseTag(inherit tokenType) (ttype tokenType, empty bool, err error) {
 	t, err := p.scanToken()
 	if err != nil {
-		return point, err
+		return inherit, false, err
+	}
+	ttype = inherit
+	if t.ttype == Z || t.ttype == M || t.ttype == ZM {
+		ttype = t.ttype
+		if t, err = p.scanToken(); err != nil {
+			return ttype, false, err
+		}
 	}
 	switch t.ttype {
 	case Empty:
-		point = space.Point{0, 0}
-	case Z, M, ZM:
-		t1, err := p.scanToken()
-		if err != nil {
-			return point, err
-		}
-		if t1.ttype == Empty {
-			point = space.Point{0, 0}
-			break
-		}
-		if t1.ttype != LeftParen {
-			return point, fmt.Errorf("parse point unexpected token %s on pos %d", t.lexeme, t.pos)
-		}
-		fallthrough
+		return ttype, true, nil
 	case LeftParen:
-		switch t.ttype { // reswitch on the type because of the fallthrough
-		case Z, M:
-			point, err = p.parseCoordDrop1()
-		case ZM:
-			point, err = p.parseCoordDrop2()
-		default:
-			point, err = p.parseCoord()
-		}
-		if err != nil {
-			return point, err
-		}
-
-		t, err = p.scanToken()
-		if err != nil {
-			return point, err
-		}
-
-		if t.ttype != RightParen {
-			return point, fmt.Errorf("parse point unexpected token %s on pos %d expected )", t.lexeme, t.pos)
-		}
+		return ttype, false, nil
 	default:
-		return point, fmt.Errorf("parse point unexpected token %s on pos %d", t.lexeme, t.pos)
-	}
-
-	t, err = p.scanToken()
-	if err != nil {
-		return point, err
-	}
-	if t.ttype != EOF {
-		return point, fmt.Errorf("parse point unexpected token %s on pos %d", t.lexeme, t.pos)
+		return ttype, false, fmt.Errorf("unexpected token %s on pos %d expected '(' or EMPTY", t.lexeme, t.pos)
 	}
-
-	return point, nil
 }
 
-func (p *Parser) parseLineString() (line space.LineString, err error) {
-	line = make([][]float64, 0)
+// parseEnd parses the separator after an element of a list, and returns true if it closes the list.
+func (p *Parser) parseEnd() (bool, error) {
 	t, err := p.scanToken()
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 	switch t.ttype {
-	case Empty:
-	case Z, M, ZM:
-		t1, err := p.scanToken()
-		if err != nil {
-			return line, err
-		}
-		if t1.ttype == Empty {
-			break
-		}
-		if t1.ttype != LeftParen {
-			return line, fmt.Errorf("unexpected token %s on pos %d expected '('", t.lexeme, t.pos)
-		}
-		fallthrough
-	case LeftParen:
-		line, err = p.parseLineStringText(t.ttype)
-		if err != nil {
-			return line, err
-		}
+	case RightParen:
+		return true, nil
+	case Comma:
+		return false, nil
 	default:
-		return line, fmt.Errorf("unexpected token %s on pos %d", t.lexeme, t.pos)
+		return false, fmt.Errorf("unexpected token %s on pos %d expected ','", t.lexeme, t.pos)
+	}
+}
+
+func (p *Parser) parsePoint(inherit tokenType) (point space.Point, err error) {
+	ttype, empty, err := p.parseTag(inherit)
+	if err != nil || empty {
+		return space.NewEmptyPoint(tagLayouts[ttype]), err
 	}
 
-	t, err = p.scanToken()
+	point, err = p.parseCoordLayout(ttype)
 	if err != nil {
-		return line, err
+		return point, err
 	}
-	if t.ttype != EOF {
-		return line, fmt.Errorf("unexpected token %s on pos %d, expected EOF", t.lexeme, t.pos)
+	t, err := p.scanToken()
+	if err != nil {
+		return point, err
+	}
+	if t.ttype != RightParen {
+		return point, fmt.Errorf("parse point unexpected token %s on pos %d expected )", t.lexeme, t.pos)
 	}
+	return point, nil
+}
 
-	return line, nil
+func (p *Parser) parseLineString(inherit tokenType) (line space.LineString, err error) {
+	ttype, empty, err := p.parseTag(inherit)
+	if err != nil || empty {
+		return space.LineString{}, err
+	}
+	return p.parseLineStringText(ttype)
 }
 
 func (p *Parser) parseLineStringText(ttype tokenType) (line space.LineString, err error) {
 	line = make([][]float64, 0)
 	for {
-		var point space.Point
-		switch ttype {
-		case Z, M:
-			point, err = p.parseCoordDrop1()
-		case ZM:
-			point, err = p.parseCoordDrop2()
-		default:
-			point, err = p.parseCoord()
-		}
+		point, err := p.parseCoordLayout(ttype)
 		if err != nil {
 			return line, err
 		}
 		line = append(line, point)
-		t, err := p.scanToken()
-		if err != nil {
+		if end, err := p.parseEnd(); err != nil || end {
 			return line, err
 		}
-		if t.ttype == RightParen {
-			break
-		} else if t.ttype != Comma {
-			return line, fmt.Errorf("unexpected token %s on pos %d expected ','", t.lexeme, t.pos)
-		}
 	}
-	return line, nil
 }
 
-func (p *Parser) parsePolygon() (poly space.Polygon, err error) {
-	poly = make([][][]float64, 0)
-	t, err := p.scanToken()
-	if err != nil {
-		return poly, err
+func (p *Parser) parsePolygon(inherit tokenType) (poly space.Polygon, err error) {
+	ttype, empty, err := p.parseTag(inherit)
+	if err != nil || empty {
+		return space.Polygon{}, err
 	}
-	switch t.ttype {
-	case Empty:
-	case Z, M, ZM:
-		t1, err := p.scanToken()
-		if err != nil {
-			return poly, err
-		}
-		if t1.ttype == Empty {
-			break
-		}
-		if t1.ttype != LeftParen {
-			return poly, fmt.Errorf("unexpected token %s on pos %d expected '('", t.lexeme, t.pos)
-		}
-		fallthrough
-	case LeftParen:
-		poly, err = p.parsePolygonText(t.ttype)
-		if err != nil {
-			return poly, err
-		}
-	default:
-		return poly, fmt.Errorf("unexpected token %s on pos %d", t.lexeme, t.pos)
-	}
-
-	t, err = p.scanToken()
-	if err != nil {
-		return poly, err
-	}
-	if t.ttype != EOF {
-		return poly, fmt.Errorf("unexpected token %s on pos %d, expected EOF", t.lexeme, t.pos)
-	}
-
-	return poly, nil
+	return p.parsePolygonText(ttype)
 }
 
 func (p *Parser) parsePolygonText(ttype tokenType) (poly space.Polygon, err error) {
 	poly = make([][][]float64, 0)
 	for {
-		var line space.LineString
 		t, err := p.scanToken()
 		if err != nil {
 			return poly, err
@@ -219,91 +161,136 @@ func (p *Parser) parsePolygonText(ttype tokenType) (poly space.Polygon, err erro
 		if t.ttype != LeftParen {
 			return poly, fmt.Errorf("unexpected token %s on pos %d expected '('", t.lexeme, t.pos)
 		}
-		line, err = p.parseLineStringText(ttype)
+		line, err := p.parseLineStringText(ttype)
 		if err != nil {
 			return poly, err
 		}
 		poly = append(poly, space.Ring(line))
-		t, err = p.scanToken()
-		if err != nil {
+		if end, err := p.parseEnd(); err != nil || end {
 			return poly, err
 		}
-		if t.ttype == RightParen {
-			break
-		} else if t.ttype != Comma {
-			return poly, fmt.Errorf("unexpected token %s on pos %d expected ','", t.lexeme, t.pos)
+	}
+}
+
+// parseMultiPoint parses the points of a multi point, each one in parentheses or not.
+func (p *Parser) parseMultiPoint(inherit tokenType) (multi space.MultiPoint, err error) {
+	ttype, empty, err := p.parseTag(inherit)
+	if err != nil || empty {
+		return space.MultiPoint{}, err
+	}
+
+	multi = make(space.MultiPoint, 0)
+	for {
+		var point space.Point
+		if p.peekFloat() {
+			if point, err = p.parseCoordLayout(ttype); err != nil {
+				return multi, err
+			}
+		} else {
+			t, err := p.scanToken()
+			if err != nil {
+				return multi, err
+			}
+			switch t.ttype {
+			case Empty:
+				point = space.NewEmptyPoint(tagLayouts[ttype])
+			case LeftParen:
+				if point, err = p.parseCoordLayout(ttype); err != nil {
+					return multi, err
+				}
+				if t, err = p.scanToken(); err != nil {
+					return multi, err
+				}
+				if t.ttype != RightParen {
+					return multi, fmt.Errorf("unexpected token %s on pos %d expected ')'", t.lexeme, t.pos)
+				}
+			default:
+				return multi, fmt.Errorf("unexpected token %s on pos %d expected '('", t.lexeme, t.pos)
+			}
+		}
+		multi = append(multi, point)
+		if end, err := p.parseEnd(); err != nil || end {
+			return multi, err
 		}
 	}
-	return poly, nil
 }
 
-func (p *Parser) parseMultiPolygon() (multi space.MultiPolygon, err error) {
-	multi = make([]space.Polygon, 0)
-	t, err := p.scanToken()
-	if err != nil {
-		return multi, err
+func (p *Parser) parseMultiLineString(inherit tokenType) (multi space.MultiLineString, err error) {
+	ttype, empty, err := p.parseTag(inherit)
+	if err != nil || empty {
+		return space.MultiLineString{}, err
 	}
-	switch t.ttype {
-	case Empty:
-	case Z, M, ZM:
-		t1, err := p.scanToken()
+
+	multi = make(space.MultiLineString, 0)
+	for {
+		t, err := p.scanToken()
 		if err != nil {
 			return multi, err
 		}
-		if t1.ttype == Empty {
-			break
-		}
-		if t1.ttype != LeftParen {
+		line := space.LineString{}
+		switch t.ttype {
+		case Empty:
+		case LeftParen:
+			if line, err = p.parseLineStringText(ttype); err != nil {
+				return multi, err
+			}
+		default:
 			return multi, fmt.Errorf("unexpected token %s on pos %d expected '('", t.lexeme, t.pos)
 		}
-		fallthrough
-	case LeftParen:
-		multi, err = p.parseMultiPolygonText(t.ttype)
-		if err != nil {
+		multi = append(multi, line)
+		if end, err := p.parseEnd(); err != nil || end {
 			return multi, err
 		}
-	default:
-		return multi, fmt.Errorf("unexpected token %s on pos %d", t.lexeme, t.pos)
 	}
+}
 
-	t, err = p.scanToken()
-	if err != nil {
-		return multi, err
+func (p *Parser) parseMultiPolygon(inherit tokenType) (multi space.MultiPolygon, err error) {
+	ttype, empty, err := p.parseTag(inherit)
+	if err != nil || empty {
+		return space.MultiPolygon{}, err
 	}
-	if t.ttype != EOF {
-		return multi, fmt.Errorf("unexpected token %s on pos %d, expected EOF", t.lexeme, t.pos)
-	}
-
-	return multi, nil
-}
 
-func (p *Parser) parseMultiPolygonText(ttype tokenType) (multi space.MultiPolygon, err error) {
 	multi = make([]space.Polygon, 0)
 	for {
-		var poly space.Polygon
 		t, err := p.scanToken()
 		if err != nil {
 			return multi, err
 		}
-		if t.ttype != LeftParen {
+		poly := space.Polygon{}
+		switch t.ttype {
+		case Empty:
+		case LeftParen:
+			if poly, err = p.parsePolygonText(ttype); err != nil {
+				return multi, err
+			}
+		default:
 			return multi, fmt.Errorf("unexpected token %s on pos %d expected '('", t.lexeme, t.pos)
 		}
-		poly, err = p.parsePolygonText(ttype)
-		if err != nil {
+		multi = append(multi, poly)
+		if end, err := p.parseEnd(); err != nil || end {
 			return multi, err
 		}
-		multi = append(multi, poly)
-		t, err = p.scanToken()
+	}
+}
+
+// parseCollection parses the geometries of a collection, which may be collections too.
+func (p *Parser) parseCollection(inherit tokenType) (collection space.Collection, err error) {
+	ttype, empty, err := p.parseTag(inherit)
+	if err != nil || empty {
+		return space.Collection{}, err
+	}
+
+	collection = make(space.Collection, 0)
+	for {
+		geom, err := p.parseGeometry(ttype)
 		if err != nil {
-			return multi, err
+			return collection, err
 		}
-		if t.ttype == RightParen {
-			break
-		} else if t.ttype != Comma {
-			return multi, fmt.Errorf("unexpected token %s on pos %d expected ','", t.lexeme, t.pos)
+		collection = append(collection, geom)
+		if end, err := p.parseEnd(); err != nil || end {
+			return collection, err
 		}
 	}
-	return multi, nil
 }
 
 func (p *Parser) parseCoord() (point space.Point, err error) {
@@ -334,41 +321,57 @@ func (p *Parser) parseCoord() (point space.Point, err error) {
 	return space.Point{c1, c2}, nil
 }
 
-func (p *Parser) parseCoordDrop1() (point space.Point, err error) {
-	point, err = p.parseCoord()
-	if err != nil {
-		return point, err
-	}
-
-	// drop the last value Z or M coordinates are not really supported
-	t, err := p.scanToken()
-	if err != nil {
-		return point, err
-	}
-	if t.ttype != Float {
-		return point, fmt.Errorf("parseCoordDrop1 unexpected token %s on pos %d expected Float", t.lexeme, t.pos)
-	}
-
-	return point, nil
+// tagLayouts are the layouts given by the Z, M and ZM tokens.
+var tagLayouts = map[tokenType]space.Layout{
+	Z:  space.XYZ,
+	M:  space.XYM,
+	ZM: space.XYZM,
 }
 
-func (p *Parser) parseCoordDrop2() (point space.Point, err error) {
+// parseCoordLayout parses a coordinate with the values of the layout given by the Z, M or ZM token ttype.
+// Without layout token, a coordinate of three values has a Z, and a coordinate of four values a Z and a M.
+// A coordinate with a M but no Z has a NaN Z, see space.Layout.
+// It returns an error if the coordinate has not as many values as the first one of the geometry.
+func (p *Parser) parseCoordLayout(ttype tokenType) (point space.Point, err error) {
 	point, err = p.parseCoord()
 	if err != nil {
 		return point, err
 	}
 
-	// drop the last value M values
-	// and Z coordinates are not really supported
-	for i := 0; i < 2; i++ {
+	n, tagged := 2, true
+	switch ttype {
+	case Z, M:
+		n = 1
+	case ZM:
+		n = 2
+	default:
+		tagged = false
+	}
+	for i := 0; i < n; i++ {
+		if !tagged && !p.peekFloat() {
+			break
+		}
 		t, err := p.scanToken()
 		if err != nil {
 			return point, err
 		}
 		if t.ttype != Float {
-			return point, fmt.Errorf("parseCoordDrop2 unexpected token %s on pos %d expected Float", t.lexeme, t.pos)
+			return point, fmt.Errorf("parse coordinates unexpected token %s on pos %d expected Float", t.lexeme, t.pos)
 		}
+		c, err := strconv.ParseFloat(t.lexeme, 64)
+		if err != nil {
+			return point, fmt.Errorf("invalid lexeme %s for token on pos %d", t.lexeme, t.pos)
+		}
+		point = append(point, c)
 	}
 
+	if p.dims == 0 {
+		p.dims = len(point)
+	} else if len(point) != p.dims {
+		return point, fmt.Errorf("coordinate before pos %d has %d values, expected %d", p.pos, len(point), p.dims)
+	}
+	if ttype == M {
+		point = space.NewPointM(point[0], point[1], point[2])
+	}
 	return point, nil
 }
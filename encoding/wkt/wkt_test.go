@@ -0,0 +1,150 @@
+package wkt
+
+import (
+	"testing"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestUnmarshalString_ZM(t *testing.T) {
+	tests := []struct {
+		name string
+		wkt  string
+		want space.Geometry
+		out  string
+	}{
+		{name: "point z", wkt: "POINT Z (1 2 3)", want: space.Point{1, 2, 3}, out: "POINT Z (1 2 3)"},
+		{name: "point m", wkt: "POINT M(1 2 4)", want: space.NewPointM(1, 2, 4), out: "POINT M (1 2 4)"},
+		{name: "point zm", wkt: "POINT ZM (1 2 3 4)", want: space.Point{1, 2, 3, 4}, out: "POINT ZM (1 2 3 4)"},
+		{name: "point without tag", wkt: "POINT(1 2 3)", want: space.Point{1, 2, 3}, out: "POINT Z (1 2 3)"},
+		{name: "point xy", wkt: "POINT(1 2)", want: space.Point{1, 2}, out: "POINT(1 2)"},
+		{name: "line string z", wkt: "LINESTRING Z (1 2 3, 4 5 6)", want: space.LineString{{1, 2, 3}, {4, 5, 6}},
+			out: "LINESTRING Z (1 2 3,4 5 6)"},
+		{name: "line string without tag", wkt: "LINESTRING(1 2 3 4, 5 6 7 8)", want: space.LineString{{1, 2, 3, 4}, {5, 6, 7, 8}},
+			out: "LINESTRING ZM (1 2 3 4,5 6 7 8)"},
+		{name: "multi point m", wkt: "MULTIPOINT M (1 2 3, 4 5 6)", want: space.MultiPoint{space.NewPointM(1, 2, 3), space.NewPointM(4, 5, 6)},
+			out: "MULTIPOINT M ((1 2 3),(4 5 6))"},
+		{name: "polygon z", wkt: "POLYGON Z ((0 0 1, 1 0 1, 1 1 1, 0 0 1))", want: space.Polygon{{{0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 0, 1}}},
+			out: "POLYGON Z ((0 0 1,1 0 1,1 1 1,0 0 1))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalString(tt.wkt)
+			if err != nil {
+				t.Fatalf("UnmarshalString() error = %v", err)
+			}
+			if !got.Equal(tt.want) || space.CoordLayout(got) != space.CoordLayout(tt.want) {
+				t.Errorf("UnmarshalString() = %v, want %v", got, tt.want)
+			}
+			if out := MarshalString(got); out != tt.out {
+				t.Errorf("MarshalString() = %v, want %v", out, tt.out)
+			}
+		})
+	}
+
+	if _, err := UnmarshalString("POINT Z (1 2)"); err == nil {
+		t.Errorf("UnmarshalString() of a point z without z got no error")
+	}
+}
+
+func TestUnmarshalString_Collection(t *testing.T) {
+	tests := []struct {
+		name string
+		wkt  string
+		want space.Geometry
+		out  string
+	}{
+		{name: "collection", wkt: "GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(1 2, 3 4))",
+			want: space.Collection{space.Point{1, 2}, space.LineString{{1, 2}, {3, 4}}},
+			out:  "GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(1 2,3 4))"},
+		{name: "nested collection", wkt: "GEOMETRYCOLLECTION(POLYGON((0 0, 1 0, 1 1, 0 0)), GEOMETRYCOLLECTION(MULTIPOINT(1 2, 3 4), POINT EMPTY))",
+			want: space.Collection{space.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+				space.Collection{space.MultiPoint{{1, 2}, {3, 4}}, space.Point{}}},
+			out: "GEOMETRYCOLLECTION(POLYGON((0 0,1 0,1 1,0 0)),GEOMETRYCOLLECTION(MULTIPOINT((1 2),(3 4)),POINT EMPTY))"},
+		{name: "collection z", wkt: "GEOMETRYCOLLECTION Z (POINT Z (1 2 3), LINESTRING Z (1 2 3, 4 5 6))",
+			want: space.Collection{space.Point{1, 2, 3}, space.LineString{{1, 2, 3}, {4, 5, 6}}},
+			out:  "GEOMETRYCOLLECTION Z (POINT Z (1 2 3),LINESTRING Z (1 2 3,4 5 6))"},
+		{name: "collection m inherited", wkt: "GEOMETRYCOLLECTION M (POINT(1 2 4))",
+			want: space.Collection{space.NewPointM(1, 2, 4)},
+			out:  "GEOMETRYCOLLECTION M (POINT M (1 2 4))"},
+		{name: "multi point in parentheses", wkt: "MULTIPOINT((1 2), (3 4))", want: space.MultiPoint{{1, 2}, {3, 4}},
+			out: "MULTIPOINT((1 2),(3 4))"},
+		{name: "multi line string with empty", wkt: "MULTILINESTRING((1 2, 3 4), EMPTY)",
+			want: space.MultiLineString{{{1, 2}, {3, 4}}, {}},
+			out:  "MULTILINESTRING((1 2,3 4),EMPTY)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalString(tt.wkt)
+			if err != nil {
+				t.Fatalf("UnmarshalString() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("UnmarshalString() = %v, want %v", got, tt.want)
+			}
+			if out := MarshalString(got); out != tt.out {
+				t.Errorf("MarshalString() = %v, want %v", out, tt.out)
+			}
+		})
+	}
+
+	for _, s := range []string{"GEOMETRYCOLLECTION(POINT(1 2)", "GEOMETRYCOLLECTION(POINT(1 2) POINT(3 4))", "GEOMETRYCOLLECTION(1 2)"} {
+		if _, err := UnmarshalString(s); err == nil {
+			t.Errorf("UnmarshalString(%v) got no error", s)
+		}
+	}
+}
+
+func TestUnmarshalString_Empty(t *testing.T) {
+	tests := []struct {
+		wkt  string
+		want space.Geometry
+		out  string
+	}{
+		{wkt: "POINT EMPTY", want: space.Point{}, out: "POINT EMPTY"},
+		{wkt: "POINT Z EMPTY", want: space.NewEmptyPoint(space.XYZ), out: "POINT Z EMPTY"},
+		{wkt: "POINT M EMPTY", want: space.NewEmptyPoint(space.XYM), out: "POINT M EMPTY"},
+		{wkt: "POINT ZM EMPTY", want: space.NewEmptyPoint(space.XYZM), out: "POINT ZM EMPTY"},
+		{wkt: "MULTIPOINT Z (EMPTY, 1 2 3)", want: space.MultiPoint{space.NewEmptyPoint(space.XYZ), {1, 2, 3}},
+			out: "MULTIPOINT Z (EMPTY,(1 2 3))"},
+		{wkt: "LINESTRING EMPTY", want: space.LineString{}, out: "LINESTRING EMPTY"},
+		{wkt: "LINESTRING Z EMPTY", want: space.LineString{}, out: "LINESTRING EMPTY"},
+		{wkt: "POLYGON EMPTY", want: space.Polygon{}, out: "POLYGON EMPTY"},
+		{wkt: "MULTIPOINT EMPTY", want: space.MultiPoint{}, out: "MULTIPOINT EMPTY"},
+		{wkt: "MULTILINESTRING EMPTY", want: space.MultiLineString{}, out: "MULTILINESTRING EMPTY"},
+		{wkt: "MULTIPOLYGON EMPTY", want: space.MultiPolygon{}, out: "MULTIPOLYGON EMPTY"},
+		{wkt: "GEOMETRYCOLLECTION EMPTY", want: space.Collection{}, out: "GEOMETRYCOLLECTION EMPTY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wkt, func(t *testing.T) {
+			got, err := UnmarshalString(tt.wkt)
+			if err != nil {
+				t.Fatalf("UnmarshalString() error = %v", err)
+			}
+			if !got.Equal(tt.want) || space.CoordLayout(got) != space.CoordLayout(tt.want) {
+				t.Errorf("UnmarshalString() = %#v, want %#v", got, tt.want)
+			}
+			if out := MarshalString(got); out != tt.out {
+				t.Errorf("MarshalString() = %v, want %v", out, tt.out)
+			}
+		})
+	}
+	if !space.NewEmptyPoint(space.XYZ).IsEmpty() {
+		t.Errorf("IsEmpty() of an empty point z got = false, want true")
+	}
+}
+
+func TestUnmarshalString_MixedDimensions(t *testing.T) {
+	for _, s := range []string{
+		"LINESTRING(0 0 1, 1 1)",
+		"LINESTRING(0 0, 1 1 1)",
+		"POLYGON((0 0 1, 1 0 1, 1 1 1, 0 0 1), (0.2 0.2, 0.4 0.2, 0.4 0.4, 0.2 0.2))",
+		"MULTIPOINT(1 2, 3 4 5 6)",
+		"GEOMETRYCOLLECTION(POINT(1 2 3), POINT(1 2))",
+		"GEOMETRYCOLLECTION(POINT Z (1 2 3), POINT ZM (1 2 3 4))",
+	} {
+		if _, err := UnmarshalString(s); err == nil {
+			t.Errorf("UnmarshalString(%v) got no error", s)
+		}
+	}
+}
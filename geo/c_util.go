@@ -15,23 +15,23 @@ func GoByteArrayToCCharArray(array []byte) []C.uchar {
 	return cArray
 }
 
-func boolFromC(c C.char) (bool, error) {
+func (ctx *Context) boolFromC(c C.char) (bool, error) {
 	if c == 2 {
-		return false, Error()
+		return false, ctx.Error()
 	}
 	return c == 1, nil
 }
 
-func intFromC(i C.int, exception C.int) (int, error) {
+func (ctx *Context) intFromC(i C.int, exception C.int) (int, error) {
 	if i == exception {
-		return 0, Error()
+		return 0, ctx.Error()
 	}
 	return int(i), nil
 }
 
-func float64FromC(c C.int, d C.double) (float64, error) {
+func (ctx *Context) float64FromC(c C.int, d C.double) (float64, error) {
 	if c == 0 {
-		return 0.0, Error()
+		return 0.0, ctx.Error()
 	}
 	return float64(d), nil
 }
@@ -0,0 +1,123 @@
+package geo
+
+/*
+#cgo LDFLAGS: -lgeos_c
+#include "geos.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// ErrContext is returned when a GEOS context cannot be created.
+var ErrContext = errors.New("geo: failed to create GEOS context")
+
+// Context is a GEOS context handle, which captures the errors raised by the operations run in it.
+// The methods of a Context mirror the package functions.
+//
+// A Context must not be used by several goroutines at the same time. The package functions
+// take a Context from a pool for every call, so they are safe for concurrent use.
+// The GEOS handles created in a Context, like the geometries returned by GeomFromSpace,
+// have to be used and destroyed in the same Context.
+type Context struct {
+	handle C.GEOSContextHandle_t
+	errBuf *C.char
+}
+
+// contextPool holds the contexts used by the package functions.
+// A context dropped by the pool is finished by its finalizer.
+var contextPool = sync.Pool{
+	New: func() interface{} {
+		ctx, err := NewContext()
+		if err != nil {
+			return nil
+		}
+		return ctx
+	},
+}
+
+// NewContext creates a GEOS context.
+// It is released by Close, or when it is garbage collected.
+func NewContext() (*Context, error) {
+	errBuf := (*C.char)(C.calloc(C.ERRLEN, 1))
+	if errBuf == nil {
+		return nil, ErrContext
+	}
+	handle := C.geos_context_create(errBuf)
+	if handle == nil {
+		C.free(unsafe.Pointer(errBuf))
+		return nil, ErrContext
+	}
+	ctx := &Context{handle: handle, errBuf: errBuf}
+	runtime.SetFinalizer(ctx, (*Context).Close)
+	return ctx, nil
+}
+
+// Close finishes the GEOS context handle. The context must not be used afterwards.
+func (ctx *Context) Close() {
+	if ctx.handle != nil {
+		C.GEOS_finish_r(ctx.handle)
+		ctx.handle = nil
+	}
+	if ctx.errBuf != nil {
+		C.free(unsafe.Pointer(ctx.errBuf))
+		ctx.errBuf = nil
+	}
+	runtime.SetFinalizer(ctx, nil)
+}
+
+// Error returns the last error raised by GEOS in the context, and clears it.
+func (ctx *Context) Error() error {
+	msg := C.GoString(ctx.errBuf)
+	*ctx.errBuf = 0
+	if msg == "" {
+		msg = "unknown error"
+	}
+	return fmt.Errorf("geo: %s", msg)
+}
+
+// getContext takes a context from the pool, creating one if needed.
+func getContext() (*Context, error) {
+	ctx, _ := contextPool.Get().(*Context)
+	if ctx == nil {
+		return nil, ErrContext
+	}
+	return ctx, nil
+}
+
+// putContext returns a context to the pool.
+func putContext(ctx *Context) {
+	contextPool.Put(ctx)
+}
+
+// defaultContext is the context of the package functions which take or return GEOS handles, such as
+// GeomFromSpace and DestroyGeometry, so that the handles are created, used and destroyed in the same context.
+// defaultMu serializes its use.
+var (
+	defaultMu      sync.Mutex
+	defaultContext *Context
+)
+
+// lockDefaultContext locks the default context, creating it if needed. It is unlocked by unlockDefaultContext.
+func lockDefaultContext() (*Context, error) {
+	defaultMu.Lock()
+	if defaultContext == nil {
+		ctx, err := NewContext()
+		if err != nil {
+			defaultMu.Unlock()
+			return nil, err
+		}
+		defaultContext = ctx
+	}
+	return defaultContext, nil
+}
+
+// unlockDefaultContext unlocks the default context.
+func unlockDefaultContext() {
+	defaultMu.Unlock()
+}
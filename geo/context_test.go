@@ -0,0 +1,67 @@
+package geo
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestContext(t *testing.T) {
+	ctx, err := NewContext()
+	if err != nil {
+		t.Fatalf("NewContext() error = %v", err)
+	}
+	defer ctx.Close()
+	area, err := ctx.Area(space.Polygon{{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}})
+	if err != nil {
+		t.Fatalf("Area() error = %v", err)
+	}
+	if area != 4 {
+		t.Errorf("Area() got = %v, want 4", area)
+	}
+}
+
+func TestContext_Concurrent(t *testing.T) {
+	polygon := space.Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}
+	invalid := space.LineString{{0, 0}}
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			switch i % 3 {
+			case 0:
+				area, err := Area(polygon)
+				if err != nil {
+					errs <- err
+				} else if area != 100 {
+					errs <- fmt.Errorf("Area() got = %v, want 100", area)
+				}
+			case 1:
+				// a failure in one goroutine must not leak into the others
+				if _, err := Length(invalid); err == nil {
+					errs <- fmt.Errorf("Length() of an invalid line got no error")
+				}
+			default:
+				// the handles of the package functions are created, used and destroyed in the default context
+				g, err := GeomFromSpace(polygon)
+				if err != nil {
+					errs <- err
+					return
+				}
+				defer DestroyGeometry(g)
+				if got, err := ToWKTStr(g); err != nil || got == "" {
+					errs <- fmt.Errorf("ToWKTStr() got = %v, %v", got, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
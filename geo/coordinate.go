@@ -21,7 +21,12 @@ func (c Coordinate) String() string {
 }
 
 // PrepareGeometry ...
-func PrepareGeometry(g GEOSGeometry) GEOSPreparedGeometry {
-	ptr := C.GEOSPrepare_r(geosContext, g)
+func (ctx *Context) PrepareGeometry(g GEOSGeometry) GEOSPreparedGeometry {
+	ptr := C.GEOSPrepare_r(ctx.handle, g)
 	return ptr
 }
+
+// DestroyGeometry releases a GEOSGeometry created by GeomFromSpace, GeomFromWKTStr or GeomFromWKBStr.
+func (ctx *Context) DestroyGeometry(g GEOSGeometry) {
+	C.GEOSGeom_destroy_r(ctx.handle, g)
+}
@@ -0,0 +1,760 @@
+package geo
+
+/*
+#cgo LDFLAGS: -lgeos_c
+#include "geos.h"
+*/
+import "C"
+
+import (
+	"github.com/spatial-go/geoos/common"
+	"github.com/spatial-go/geoos/space"
+)
+
+// The package functions mirror the methods of Context. Each call takes a Context from a pool,
+// so they are safe for concurrent use. The functions which take or return GEOS handles, from
+// GeomFromSpace on, run in a single default context instead, one call at a time, so that a handle
+// is created, used and destroyed in the same context.
+
+// Area returns the area of a polygonal geometry
+func Area(geom space.Geometry) (float64, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return 0, err
+	}
+	defer putContext(ctx)
+	return ctx.Area(geom)
+}
+
+// Boundary returns the closure of the combinatorial boundary of this Geometry
+func Boundary(geom space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.Boundary(geom)
+}
+
+// Buffer returns a geometry that represents all points whose distance from
+// this Geometry is less than or equal to distance.
+func Buffer(g space.Geometry, width float64, quadsegs int32) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.Buffer(g, width, quadsegs)
+}
+
+// BufferWithParams returns a geometry that represents all points whose distance from
+// this Geometry is less than or equal to distance, using the end cap style, join style,
+// mitre limit and single sidedness of params.
+func BufferWithParams(g space.Geometry, width float64, params common.BufferParams) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.BufferWithParams(g, width, params)
+}
+
+// Centroid Computes the geometric center of a geometry, or equivalently, the center of mass of the geometry as a POINT.
+// For [MULTI]POINTs, this is computed as the arithmetic mean of the input coordinates.
+// For [MULTI]LINESTRINGs, this is computed as the weighted length of each line segment.
+// For [MULTI]POLYGONs, "weight" is thought in terms of area.
+// If an empty geometry is supplied, an empty GEOMETRYCOLLECTION is returned.
+// If NULL is supplied, NULL is returned.
+// If CIRCULARSTRING or COMPOUNDCURVE are supplied, they are converted to linestring wtih CurveToLine first,
+// then same than for LINESTRING
+func Centroid(geom space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.Centroid(geom)
+}
+
+// Contains Geometry A contains Geometry B if and only if no points of B lie in the exterior of A,
+// and at least one point of the interior of B lies in the interior of A.
+// An important subtlety of this definition is that A does not contain its boundary, but A does contain itself.
+// Returns TRUE if geometry B is completely inside geometry A.
+// For this function to make sense, the source geometries must both be of the same coordinate projection,
+// having the same SRID.
+func Contains(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.Contains(g1, g2)
+}
+
+// ConvexHull computes the convex hull of a geometry. The convex hull is the smallest convex geometry
+// that encloses all geometries in the input.
+// In the general case the convex hull is a Polygon.
+// The convex hull of two or more collinear points is a two-point LineString.
+// The convex hull of one or more identical points is a Point.
+func ConvexHull(geom space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.ConvexHull(geom)
+}
+
+// Covers computes whether the prepared geometry covers the other.
+func Covers(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.Covers(g1, g2)
+}
+
+// CoversBy computes whether the prepared geometry is covered by the other.
+func CoversBy(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.CoversBy(g1, g2)
+}
+
+// Crosses takes two geometry objects and returns TRUE if their intersection "spatially cross",
+// that is, the geometries have some, but not all interior points in common.
+// The intersection of the interiors of the geometries must not be the empty set and must have a dimensionality
+// less than the maximum dimension of the two input geometries. Additionally, the intersection of the two geometries
+// must not equal either of the source geometries. Otherwise, it returns FALSE.
+func Crosses(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.Crosses(g1, g2)
+}
+
+// Difference returns a geometry that represents that part of geometry A that does not intersect with geometry B.
+// One can think of this as GeometryA - Intersection(A,B).
+// If A is completely contained in B then an empty geometry collection is returned.
+func Difference(g1, g2 space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.Difference(g1, g2)
+}
+
+// Disjoint Overlaps, Touches, Within all imply geometries are not spatially disjoint.
+// If any of the aforementioned returns true, then the geometries are not spatially disjoint.
+// Disjoint implies false for spatial intersection.
+func Disjoint(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.Disjoint(g1, g2)
+}
+
+// Distance returns the minimum 2D Cartesian (planar) distance between two geometries,
+// in projected units (spatial ref units).
+func Distance(g1, g2 space.Geometry) (float64, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return 0, err
+	}
+	defer putContext(ctx)
+	return ctx.Distance(g1, g2)
+}
+
+// Envelope returns the  minimum bounding box for the supplied geometry, as a geometry.
+// The polygon is defined by the corner points of the bounding box
+// ((MINX, MINY), (MINX, MAXY), (MAXX, MAXY), (MAXX, MINY), (MINX, MINY)).
+func Envelope(geom space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.Envelope(geom)
+}
+
+// Equals returns true if the two geometries have at least one point in common.
+func Equals(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.Equals(g1, g2)
+}
+
+// EqualsExact returns true if both geometries are Equal, as evaluated by their
+// points being within the given tolerance.
+func EqualsExact(g1, g2 space.Geometry, tolerance float64) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.EqualsExact(g1, g2, tolerance)
+}
+
+// HasZ returns true if the geometry is 3D
+func HasZ(geom space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.HasZ(geom)
+}
+
+// HausdorffDistance returns the Hausdorff distance between two geometries, a measure of how similar or
+// dissimilar 2 geometries are. Implements algorithm for computing a distance metric which can be
+// thought of as the "Discrete Hausdorff Distance". This is the Hausdorff distance restricted to discrete points
+// for one of the geometries
+func HausdorffDistance(g1, g2 space.Geometry) (float64, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return 0, err
+	}
+	defer putContext(ctx)
+	return ctx.HausdorffDistance(g1, g2)
+}
+
+// HausdorffDistanceDensify computes the Hausdorff distance with an additional densification fraction amount
+func HausdorffDistanceDensify(g1, g2 space.Geometry, densifyFrac float64) (float64, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return 0, err
+	}
+	defer putContext(ctx)
+	return ctx.HausdorffDistanceDensify(g1, g2, densifyFrac)
+}
+
+// Intersection returns a geometry that represents the point set intersection of the Geometries.
+func Intersection(g1, g2 space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.Intersection(g1, g2)
+}
+
+// Intersects If a geometry  shares any portion of space then they intersect
+func Intersects(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.Intersects(g1, g2)
+}
+
+// IsClosed returns true if the geometry is closed
+func IsClosed(geom space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.IsClosed(geom)
+}
+
+// IsEmpty returns true if this Geometry is an empty geometry.
+// If true, then this Geometry represents an empty geometry collection, polygon, point etc.
+func IsEmpty(geom space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.IsEmpty(geom)
+}
+
+// IsRing returns true if the lineal geometry has the ring property.
+func IsRing(geom space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.IsRing(geom)
+}
+
+// IsSimple returns true if this Geometry has no anomalous geometric points, such as self intersection or self tangency
+func IsSimple(geom space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.IsSimple(geom)
+}
+
+// IsValid returns true if the geometry is valid in the OGC sense.
+func IsValid(geom space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.IsValid(geom)
+}
+
+// IsValidReason returns the reason why the geometry is not valid, and its location.
+func IsValidReason(geom space.Geometry) (space.ValidReason, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return space.ValidReason{}, err
+	}
+	defer putContext(ctx)
+	return ctx.IsValidReason(geom)
+}
+
+// Length returns the 2D Cartesian length of the geometry if it is a LineString, MultiLineString
+func Length(geom space.Geometry) (float64, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return 0, err
+	}
+	defer putContext(ctx)
+	return ctx.Length(geom)
+}
+
+// LineMerge returns a (set of) LineString(s) formed by sewing together the constituent line work of a MULTILINESTRING.
+func LineMerge(geom space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.LineMerge(geom)
+}
+
+// MakeValid returns a valid geometry covering the points of the geometry, see Context.MakeValid.
+func MakeValid(geom space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.MakeValid(geom)
+}
+
+// NGeometry returns the number of component geometries.
+func NGeometry(g space.Geometry) (int, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return 0, err
+	}
+	defer putContext(ctx)
+	return ctx.NGeometry(g)
+}
+
+// Overlaps returns true if one geometry overlaps the other.
+func Overlaps(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.Overlaps(g1, g2)
+}
+
+// PointOnSurface Returns a POINT guaranteed to intersect a surface.
+func PointOnSurface(geom space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.PointOnSurface(geom)
+}
+
+// Relate computes the intersection matrix (Dimensionally Extended
+// Nine-Intersection Model (DE-9IM) matrix) for the spatial relationship between
+// the two geometries.
+func Relate(g1, g2 space.Geometry) (string, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return "", err
+	}
+	defer putContext(ctx)
+	return ctx.Relate(g1, g2)
+}
+
+// RelatePattern returns true if the DE-9IM matrix of the two geometries matches pattern.
+func RelatePattern(g1, g2 space.Geometry, pattern string) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.RelatePattern(g1, g2, pattern)
+}
+
+// SharedPaths returns a collection containing paths shared by the two input geometries.
+// Those going in the same direction are in the first element of the collection, those going in the opposite
+// direction are in the second element. The paths themselves are given in the direction of the first geometry.
+func SharedPaths(g1, g2 space.Geometry) (string, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return "", err
+	}
+	defer putContext(ctx)
+	return ctx.SharedPaths(g1, g2)
+}
+
+// Simplify returns a "simplified" version of the given geometry using the Douglas-Peucker algorithm,
+// May not preserve topology
+func Simplify(geom space.Geometry, tolerance float64) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.Simplify(geom, tolerance)
+}
+
+// SimplifyP returns a geometry simplified by amount given by tolerance.
+// Unlike Simplify, SimplifyP guarantees it will preserve topology.
+func SimplifyP(geom space.Geometry, tolerance float64) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.SimplifyP(geom, tolerance)
+}
+
+// Snap Snaps the vertices and segments of a geometry to another Geometry's vertices.
+// A snap distance tolerance is used to control where snapping is performed.
+// The result geometry is the input geometry with the vertices snapped.
+// If no snapping occurs then the input geometry is returned unchanged.
+func Snap(input, reference space.Geometry, tolerance float64) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.Snap(input, reference, tolerance)
+}
+
+// SymDifference returns a geometry that represents the portions of A and B that do not intersect.
+// It is called a symmetric difference because SymDifference(A,B) = SymDifference(B,A).
+// One can think of this as Union(geomA,geomB) - Intersection(A,B).
+func SymDifference(g1, g2 space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.SymDifference(g1, g2)
+}
+
+// Touches returns TRUE if the only points in common between g1 and g2 lie in the union of the boundaries of g1 and g2.
+// The touches relation applies to all Area/Area, Line/Line, Line/Area, Point/Area and Point/Line pairs of relationships,
+// but not to the Point/Point pair.
+func Touches(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.Touches(g1, g2)
+}
+
+// UnaryUnion does dissolve boundaries between components of a multipolygon (invalid) and does perform union
+// between the components of a geometrycollection
+func UnaryUnion(geom space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.UnaryUnion(geom)
+}
+
+// Union returns a new geometry representing all points in this geometry and the other.
+func Union(g1, g2 space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.Union(g1, g2)
+}
+
+// UniquePoints return all distinct vertices of input geometry as a MultiPoint.
+func UniquePoints(g space.Geometry) (space.Geometry, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return nil, err
+	}
+	defer putContext(ctx)
+	return ctx.UniquePoints(g)
+}
+
+// Within returns TRUE if geometry A is completely inside geometry B.
+// For this function to make sense, the source geometries must both be of the same coordinate projection,
+// having the same SRID.
+func Within(g1, g2 space.Geometry) (bool, error) {
+	ctx, err := getContext()
+	if err != nil {
+		return false, err
+	}
+	defer putContext(ctx)
+	return ctx.Within(g1, g2)
+}
+
+// GeomFromSpace converts a space.Geometry to a GEOSGeometry by copying its coordinates
+// into GEOS coordinate sequences. The caller owns the result and has to destroy it.
+func GeomFromSpace(geom space.Geometry) (GEOSGeometry, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockDefaultContext()
+	return ctx.GeomFromSpace(geom)
+}
+
+// ToSpace converts a GEOSGeometry to a space.Geometry by reading its coordinate sequences.
+// The GEOSGeometry is left untouched, the caller still has to destroy it.
+func ToSpace(g GEOSGeometry) (space.Geometry, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockDefaultContext()
+	return ctx.ToSpace(g)
+}
+
+// ToWKTStr convert GEOSGeometry to WKT string
+func ToWKTStr(g GEOSGeometry) (string, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return "", err
+	}
+	defer unlockDefaultContext()
+	return ctx.ToWKTStr(g)
+}
+
+// GeomFromWKTStr convert WKT string to GEOSGeometry
+func GeomFromWKTStr(wktstr string) GEOSGeometry {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil
+	}
+	defer unlockDefaultContext()
+	return ctx.GeomFromWKTStr(wktstr)
+}
+
+// WKTWriterFactory ...
+func WKTWriterFactory() GEOSWKTWriter {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil
+	}
+	defer unlockDefaultContext()
+	return ctx.WKTWriterFactory()
+}
+
+// WKTWriterDestroy ...
+func WKTWriterDestroy(writer GEOSWKTWriter) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return
+	}
+	defer unlockDefaultContext()
+	ctx.WKTWriterDestroy(writer)
+}
+
+// WKTReaderFactory ...
+func WKTReaderFactory() GEOSWKTReader {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil
+	}
+	defer unlockDefaultContext()
+	return ctx.WKTReaderFactory()
+}
+
+// WKTReaderDestroy ...
+func WKTReaderDestroy(reader GEOSWKTReader) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return
+	}
+	defer unlockDefaultContext()
+	ctx.WKTReaderDestroy(reader)
+}
+
+// DecodeWKTToStr decode GEOSGeometry to WKT string
+func DecodeWKTToStr(writer GEOSWKTWriter, g GEOSGeometry) (string, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return "", err
+	}
+	defer unlockDefaultContext()
+	return ctx.DecodeWKTToStr(writer, g)
+}
+
+// EncodeWKTToGeom encode WKT string to GEOSGeometry
+func EncodeWKTToGeom(reader GEOSWKTReader, wktStr string) GEOSGeometry {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil
+	}
+	defer unlockDefaultContext()
+	return ctx.EncodeWKTToGeom(reader, wktStr)
+}
+
+// GeomFromWKBStr convert wkb byte array to GEOSGeometry
+func GeomFromWKBStr(wkbByte []byte) (GEOSGeometry, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockDefaultContext()
+	return ctx.GeomFromWKBStr(wkbByte)
+}
+
+// ToWKB convert GEOSGeometry to wkb byte array
+func ToWKB(g GEOSGeometry) ([]byte, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockDefaultContext()
+	return ctx.ToWKB(g)
+}
+
+// ToWKBHex convert GEOSGeometry to hex string
+func ToWKBHex(g GEOSGeometry) (string, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return "", err
+	}
+	defer unlockDefaultContext()
+	return ctx.ToWKBHex(g)
+}
+
+// GeomFromWKBHexStr convert hex string to GEOSGeometry
+func GeomFromWKBHexStr(wkbHex string) (GEOSGeometry, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockDefaultContext()
+	return ctx.GeomFromWKBHexStr(wkbHex)
+}
+
+// EncodeWKBToGeom ...
+func EncodeWKBToGeom(reader GEOSWKBReader, cwkb []C.uchar) (GEOSGeometry, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockDefaultContext()
+	return ctx.EncodeWKBToGeom(reader, cwkb)
+}
+
+// EncodeHexToGeom ...
+func EncodeHexToGeom(reader GEOSWKBReader, cwkb []C.uchar) (GEOSGeometry, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockDefaultContext()
+	return ctx.EncodeHexToGeom(reader, cwkb)
+}
+
+// DecodeWKBToArray ...
+func DecodeWKBToArray(writer GEOSWKBWriter, g GEOSGeometry) ([]byte, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil, err
+	}
+	defer unlockDefaultContext()
+	return ctx.DecodeWKBToArray(writer, g)
+}
+
+// DecodeWKBToHexStr ...
+func DecodeWKBToHexStr(writer GEOSWKBWriter, g GEOSGeometry) (string, error) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return "", err
+	}
+	defer unlockDefaultContext()
+	return ctx.DecodeWKBToHexStr(writer, g)
+}
+
+// WKBReaderFactory ...
+func WKBReaderFactory() GEOSWKBReader {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil
+	}
+	defer unlockDefaultContext()
+	return ctx.WKBReaderFactory()
+}
+
+// WKBReaderDestroy ...
+func WKBReaderDestroy(reader GEOSWKBReader) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return
+	}
+	defer unlockDefaultContext()
+	ctx.WKBReaderDestroy(reader)
+}
+
+// WKBWriterFactory ...
+func WKBWriterFactory() GEOSWKBWriter {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil
+	}
+	defer unlockDefaultContext()
+	return ctx.WKBWriterFactory()
+}
+
+// WKBWriterDestroy ...
+func WKBWriterDestroy(writer GEOSWKBWriter) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return
+	}
+	defer unlockDefaultContext()
+	ctx.WKBWriterDestroy(writer)
+}
+
+// PrepareGeometry ...
+func PrepareGeometry(g GEOSGeometry) GEOSPreparedGeometry {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return nil
+	}
+	defer unlockDefaultContext()
+	return ctx.PrepareGeometry(g)
+}
+
+// DestroyGeometry releases a GEOSGeometry created by GeomFromSpace, GeomFromWKTStr or GeomFromWKBStr.
+func DestroyGeometry(g GEOSGeometry) {
+	ctx, err := lockDefaultContext()
+	if err != nil {
+		return
+	}
+	defer unlockDefaultContext()
+	ctx.DestroyGeometry(g)
+}
@@ -6,8 +6,13 @@
 
 void geos_notice_handler(const char *fmt, ...);
 void geos_error_handler(const char *fmt, ...);
+void geos_context_notice_handler(const char *message, void *userdata);
+void geos_context_error_handler(const char *message, void *userdata);
 char *geos_get_last_error(void);
 GEOSContextHandle_t geos_initGEOS();
+GEOSContextHandle_t geos_context_create(char *errbuf);
+GEOSCoordSequence *geos_coordseq_from_buffer(GEOSContextHandle_t handle, const double *buf, unsigned int size, unsigned int dims);
+int geos_coordseq_to_buffer(GEOSContextHandle_t handle, const GEOSCoordSequence *seq, double *buf, unsigned int size, unsigned int dims);
 
 void geos_notice_handler(const char *fmt, ...) {
     va_list ap;
@@ -35,3 +40,51 @@ char *geos_get_last_error(void) {
 GEOSContextHandle_t geos_initGEOS() {
     return initGEOS_r(geos_notice_handler, geos_error_handler);
 }
+
+void geos_context_notice_handler(const char *message, void *userdata) {
+    fprintf(stderr, "NOTICE: %s\n", message);
+}
+
+void geos_context_error_handler(const char *message, void *userdata) {
+    strncpy((char *) userdata, message, ERRLEN - 1);
+    ((char *) userdata)[ERRLEN - 1] = '\0';
+}
+
+GEOSContextHandle_t geos_context_create(char *errbuf) {
+    GEOSContextHandle_t handle = GEOS_init_r();
+    if (handle == NULL) {
+        return NULL;
+    }
+    GEOSContext_setNoticeMessageHandler_r(handle, geos_context_notice_handler, NULL);
+    GEOSContext_setErrorMessageHandler_r(handle, geos_context_error_handler, errbuf);
+    return handle;
+}
+
+GEOSCoordSequence *geos_coordseq_from_buffer(GEOSContextHandle_t handle, const double *buf, unsigned int size, unsigned int dims) {
+    unsigned int i;
+    GEOSCoordSequence *seq = GEOSCoordSeq_create_r(handle, size, dims);
+    if (seq == NULL) {
+        return NULL;
+    }
+    for (i = 0; i < size; i++) {
+        if (!GEOSCoordSeq_setX_r(handle, seq, i, buf[dims * i]) ||
+            !GEOSCoordSeq_setY_r(handle, seq, i, buf[dims * i + 1]) ||
+            (dims > 2 && !GEOSCoordSeq_setZ_r(handle, seq, i, buf[dims * i + 2]))) {
+            GEOSCoordSeq_destroy_r(handle, seq);
+            return NULL;
+        }
+    }
+    return seq;
+}
+
+int geos_coordseq_to_buffer(GEOSContextHandle_t handle, const GEOSCoordSequence *seq, double *buf, unsigned int size, unsigned int dims) {
+    unsigned int i;
+    for (i = 0; i < size; i++) {
+        if (!GEOSCoordSeq_getX_r(handle, seq, i, &buf[dims * i]) ||
+            !GEOSCoordSeq_getY_r(handle, seq, i, &buf[dims * i + 1]) ||
+            (dims > 2 && !GEOSCoordSeq_getZ_r(handle, seq, i, &buf[dims * i + 2]))) {
+            return 0;
+        }
+    }
+    return 1;
+}
@@ -12,6 +12,10 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"unsafe"
+
+	"github.com/spatial-go/geoos/common"
+	"github.com/spatial-go/geoos/space"
 )
 
 // GEOSContext ...
@@ -35,43 +39,81 @@ type GEOSSTRtree C.GEOSSTRtree
 // GEOSChar ...
 type GEOSChar *C.char
 
-var (
-	geosContext = InitGeosContext()
-)
-
 // Area returns the area of a polygonal geometry
-func Area(wkt string) (float64, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	defer C.GEOSGeom_destroy_r(geosContext, geoGeom)
+func (ctx *Context) Area(geom space.Geometry) (float64, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return 0.0, err
+	}
+	defer C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
 	var d C.double
-	i := C.GEOSArea_r(geosContext, geoGeom, &d)
+	i := C.GEOSArea_r(ctx.handle, geoGeom, &d)
 	if i == 0 {
-		return 0.0, Error()
+		return 0.0, ctx.Error()
 	}
 	return float64(d), nil
 }
 
 // Boundary returns the closure of the combinatorial boundary of this Geometry
-func Boundary(wkt string) (string, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	g := C.GEOSBoundary_r(geosContext, geoGeom)
+func (ctx *Context) Boundary(geom space.Geometry) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSBoundary_r(ctx.handle, geoGeom)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // Buffer returns a geometry that represents all points whose distance from
 // this Geometry is less than or equal to distance.
-func Buffer(g string, width float64, quadsegs int32) (string, error) {
-	geom := GeomFromWKTStr(g)
-	bufferGeom := C.GEOSBuffer_r(geosContext, geom, C.double(width), C.int(quadsegs))
+func (ctx *Context) Buffer(g space.Geometry, width float64, quadsegs int32) (space.Geometry, error) {
+	geom, err := ctx.GeomFromSpace(g)
+	if err != nil {
+		return nil, err
+	}
+	bufferGeom := C.GEOSBuffer_r(ctx.handle, geom, C.double(width), C.int(quadsegs))
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom)
-		C.GEOSGeom_destroy_r(geosContext, bufferGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, geom)
+		C.GEOSGeom_destroy_r(ctx.handle, bufferGeom)
 	}()
-	return ToWKTStr(bufferGeom)
+	return ctx.ToSpace(bufferGeom)
+}
+
+// BufferWithParams returns a geometry that represents all points whose distance from
+// this Geometry is less than or equal to distance, using the end cap style, join style,
+// mitre limit and single sidedness of params, of which the zero values are replaced by the defaults.
+func (ctx *Context) BufferWithParams(g space.Geometry, width float64, params common.BufferParams) (space.Geometry, error) {
+	params = params.Normalize()
+	bufferParams := C.GEOSBufferParams_create_r(ctx.handle)
+	if bufferParams == nil {
+		return nil, ctx.Error()
+	}
+	defer C.GEOSBufferParams_destroy_r(ctx.handle, bufferParams)
+	singleSided := 0
+	if params.SingleSided {
+		singleSided = 1
+	}
+	if C.GEOSBufferParams_setEndCapStyle_r(ctx.handle, bufferParams, C.int(params.EndCapStyle)) == 0 ||
+		C.GEOSBufferParams_setJoinStyle_r(ctx.handle, bufferParams, C.int(params.JoinStyle)) == 0 ||
+		C.GEOSBufferParams_setMitreLimit_r(ctx.handle, bufferParams, C.double(params.MitreLimit)) == 0 ||
+		C.GEOSBufferParams_setQuadrantSegments_r(ctx.handle, bufferParams, C.int(params.QuadSegs)) == 0 ||
+		C.GEOSBufferParams_setSingleSided_r(ctx.handle, bufferParams, C.int(singleSided)) == 0 {
+		return nil, ctx.Error()
+	}
+	geom, err := ctx.GeomFromSpace(g)
+	if err != nil {
+		return nil, err
+	}
+	bufferGeom := C.GEOSBufferWithParams_r(ctx.handle, geom, bufferParams, C.double(width))
+	defer func() {
+		C.GEOSGeom_destroy_r(ctx.handle, geom)
+		C.GEOSGeom_destroy_r(ctx.handle, bufferGeom)
+	}()
+	return ctx.ToSpace(bufferGeom)
 }
 
 // Centroid Computes the geometric center of a geometry, or equivalently, the center of mass of the geometry as a POINT.
@@ -82,14 +124,17 @@ func Buffer(g string, width float64, quadsegs int32) (string, error) {
 // If NULL is supplied, NULL is returned.
 // If CIRCULARSTRING or COMPOUNDCURVE are supplied, they are converted to linestring wtih CurveToLine first,
 // then same than for LINESTRING
-func Centroid(wkt string) (string, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	g := C.GEOSGetCentroid_r(geosContext, geoGeom)
+func (ctx *Context) Centroid(geom space.Geometry) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSGetCentroid_r(ctx.handle, geoGeom)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // Contains Geometry A contains Geometry B if and only if no points of B lie in the exterior of A,
@@ -98,21 +143,31 @@ func Centroid(wkt string) (string, error) {
 //Returns TRUE if geometry B is completely inside geometry A.
 // For this function to make sense, the source geometries must both be of the same coordinate projection,
 // having the same SRID.
-func Contains(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
-	c := C.GEOSContains_r(geosContext, geom1, geom2)
+func (ctx *Context) Contains(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
+	c := C.GEOSContains_r(ctx.handle, geom1, geom2)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
-	return boolFromC(c)
+	return ctx.boolFromC(c)
 }
 
-// convertWKTtoGEOSGeometry help to convert WKT string to GEOSGeometry
-func convertWKTtoGEOSGeometry(g1 string, g2 string) (GEOSGeometry, GEOSGeometry) {
-	geom1 := GeomFromWKTStr(g1)
-	geom2 := GeomFromWKTStr(g2)
-	return geom1, geom2
+// convertToGEOSGeometry help to convert two space.Geometry to GEOSGeometry
+func (ctx *Context) convertToGEOSGeometry(g1, g2 space.Geometry) (GEOSGeometry, GEOSGeometry, error) {
+	geom1, err := ctx.GeomFromSpace(g1)
+	if err != nil {
+		return nil, nil, err
+	}
+	geom2, err := ctx.GeomFromSpace(g2)
+	if err != nil {
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		return nil, nil, err
+	}
+	return geom1, geom2, nil
 }
 
 // ConvexHull computes the convex hull of a geometry. The convex hull is the smallest convex geometry
@@ -120,40 +175,49 @@ func convertWKTtoGEOSGeometry(g1 string, g2 string) (GEOSGeometry, GEOSGeometry)
 // In the general case the convex hull is a Polygon.
 // The convex hull of two or more collinear points is a two-point LineString.
 // The convex hull of one or more identical points is a Point.
-func ConvexHull(wkt string) (string, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	g := C.GEOSConvexHull_r(geosContext, geoGeom)
+func (ctx *Context) ConvexHull(geom space.Geometry) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSConvexHull_r(ctx.handle, geoGeom)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // Covers computes whether the prepared geometry covers the other.
-func Covers(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
-	pGeom := C.GEOSPrepare_r(geosContext, geom1)
+func (ctx *Context) Covers(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
+	pGeom := C.GEOSPrepare_r(ctx.handle, geom1)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
-		C.GEOSPreparedGeom_destroy_r(geosContext, pGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
+		C.GEOSPreparedGeom_destroy_r(ctx.handle, pGeom)
 	}()
-	c := C.GEOSPreparedCovers_r(geosContext, pGeom, geom2)
-	return boolFromC(c)
+	c := C.GEOSPreparedCovers_r(ctx.handle, pGeom, geom2)
+	return ctx.boolFromC(c)
 }
 
 // CoversBy computes whether the prepared geometry is covered by the other.
-func CoversBy(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
-	pGeom := C.GEOSPrepare_r(geosContext, geom1)
+func (ctx *Context) CoversBy(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
+	pGeom := C.GEOSPrepare_r(ctx.handle, geom1)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
-		C.GEOSPreparedGeom_destroy_r(geosContext, pGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
+		C.GEOSPreparedGeom_destroy_r(ctx.handle, pGeom)
 	}()
-	c := C.GEOSPreparedCoveredBy_r(geosContext, pGeom, geom2)
-	return boolFromC(c)
+	c := C.GEOSPreparedCoveredBy_r(ctx.handle, pGeom, geom2)
+	return ctx.boolFromC(c)
 }
 
 // Crosses takes two geometry objects and returns TRUE if their intersection "spatially cross",
@@ -161,55 +225,67 @@ func CoversBy(g1 string, g2 string) (bool, error) {
 // The intersection of the interiors of the geometries must not be the empty set and must have a dimensionality
 // less than the maximum dimension of the two input geometries. Additionally, the intersection of the two geometries
 // must not equal either of the source geometries. Otherwise, it returns FALSE.
-func Crosses(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) Crosses(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
-	c := C.GEOSCrosses_r(geosContext, geom1, geom2)
-	return boolFromC(c)
+	c := C.GEOSCrosses_r(ctx.handle, geom1, geom2)
+	return ctx.boolFromC(c)
 }
 
 // Difference returns a geometry that represents that part of geometry A that does not intersect with geometry B.
 // One can think of this as GeometryA - Intersection(A,B).
 // If A is completely contained in B then an empty geometry collection is returned.
-func Difference(g1 string, g2 string) (string, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
-	g := C.GEOSDifference_r(geosContext, geom1, geom2)
+func (ctx *Context) Difference(g1, g2 space.Geometry) (space.Geometry, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSDifference_r(ctx.handle, geom1, geom2)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // Disjoint Overlaps, Touches, Within all imply geometries are not spatially disjoint.
 // If any of the aforementioned returns true, then the geometries are not spatially disjoint.
 // Disjoint implies false for spatial intersection.
-func Disjoint(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) Disjoint(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
-	c := C.GEOSDisjoint_r(geosContext, geom1, geom2)
-	return boolFromC(c)
+	c := C.GEOSDisjoint_r(ctx.handle, geom1, geom2)
+	return ctx.boolFromC(c)
 }
 
 // Distance returns the minimum 2D Cartesian (planar) distance between two geometries,
 // in projected units (spatial ref units).
-func Distance(g1 string, g2 string) (float64, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) Distance(g1, g2 space.Geometry) (float64, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return 0.0, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
 	var distance C.double
-	i := C.GEOSDistance_r(geosContext, geom1, geom2, &distance)
+	i := C.GEOSDistance_r(ctx.handle, geom1, geom2, &distance)
 	if i == 0 {
-		return 0.0, Error()
+		return 0.0, ctx.Error()
 	}
 	return float64(distance), nil
 }
@@ -217,340 +293,497 @@ func Distance(g1 string, g2 string) (float64, error) {
 // Envelope returns the  minimum bounding box for the supplied geometry, as a geometry.
 // The polygon is defined by the corner points of the bounding box
 // ((MINX, MINY), (MINX, MAXY), (MAXX, MAXY), (MAXX, MINY), (MINX, MINY)).
-func Envelope(wkt string) (string, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	g := C.GEOSEnvelope_r(geosContext, geoGeom)
+func (ctx *Context) Envelope(geom space.Geometry) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSEnvelope_r(ctx.handle, geoGeom)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // Equals returns true if the two geometries have at least one point in common.
-func Equals(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) Equals(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
-	c := C.GEOSEquals_r(geosContext, geom1, geom2)
-	return boolFromC(c)
+	c := C.GEOSEquals_r(ctx.handle, geom1, geom2)
+	return ctx.boolFromC(c)
 }
 
 // EqualsExact returns true if both geometries are Equal, as evaluated by their
 // points being within the given tolerance.
-func EqualsExact(g1 string, g2 string, tolerance float64) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) EqualsExact(g1, g2 space.Geometry, tolerance float64) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
-	c := C.GEOSEqualsExact_r(geosContext, geom1, geom2, C.double(tolerance))
-	return boolFromC(c)
+	c := C.GEOSEqualsExact_r(ctx.handle, geom1, geom2, C.double(tolerance))
+	return ctx.boolFromC(c)
 }
 
-// Error ...
+// Error returns the last error raised in a context created by InitGeosContext.
+//
+// Deprecated: the package functions and the methods of a Context return the errors raised in their context.
 func Error() error {
 	return fmt.Errorf("geo: %s", C.GoString(C.geos_get_last_error()))
 }
 
 // FinishGeosContext release context
+//
+// Deprecated: contexts are created by NewContext and released by Context.Close.
 func FinishGeosContext(c GEOSContext) {
 	C.finishGEOS_r(c)
 }
 
 // HasZ returns true if the geometry is 3D
-func HasZ(g string) (bool, error) {
-	geoGeom := GeomFromWKTStr(g)
-	defer C.GEOSGeom_destroy_r(geosContext, geoGeom)
-	c := C.GEOSHasZ_r(geosContext, geoGeom)
-	return boolFromC(c)
+func (ctx *Context) HasZ(geom space.Geometry) (bool, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return false, err
+	}
+	defer C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+	c := C.GEOSHasZ_r(ctx.handle, geoGeom)
+	return ctx.boolFromC(c)
 }
 
 // HausdorffDistance returns the Hausdorff distance between two geometries, a measure of how similar or
 // dissimilar 2 geometries are. Implements algorithm for computing a distance metric which can be
 // thought of as the "Discrete Hausdorff Distance". This is the Hausdorff distance restricted to discrete points
 // for one of the geometries
-func HausdorffDistance(g1 string, g2 string) (float64, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) HausdorffDistance(g1, g2 space.Geometry) (float64, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return 0.0, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
 	var distance C.double
-	i := C.GEOSHausdorffDistance_r(geosContext, geom1, geom2, &distance)
+	i := C.GEOSHausdorffDistance_r(ctx.handle, geom1, geom2, &distance)
 	if i == 0 {
-		return 0.0, Error()
+		return 0.0, ctx.Error()
 	}
 	return float64(distance), nil
 }
 
 // HausdorffDistanceDensify computes the Hausdorff distance with an additional densification fraction amount
-func HausdorffDistanceDensify(g1 string, g2 string, densifyFrac float64) (float64, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) HausdorffDistanceDensify(g1, g2 space.Geometry, densifyFrac float64) (float64, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return 0.0, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
 	var distance C.double
-	c := C.GEOSHausdorffDistanceDensify_r(geosContext, geom1, geom2, C.double(densifyFrac), &distance)
-	return float64FromC(c, distance)
+	c := C.GEOSHausdorffDistanceDensify_r(ctx.handle, geom1, geom2, C.double(densifyFrac), &distance)
+	return ctx.float64FromC(c, distance)
 }
 
 // InitGeosContext  init context
+//
+// Deprecated: use NewContext, of which the errors are returned by its methods.
 func InitGeosContext() GEOSContext {
 	c := C.geos_initGEOS()
 	return GEOSContext(c)
 }
 
 // Intersection returns a geometry that represents the point set intersection of the Geometries.
-func Intersection(g1 string, g2 string) (string, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
-	g := C.GEOSIntersection_r(geosContext, geom1, geom2)
+func (ctx *Context) Intersection(g1, g2 space.Geometry) (space.Geometry, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSIntersection_r(ctx.handle, geom1, geom2)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 //Intersects If a geometry  shares any portion of space then they intersect
-func Intersects(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) Intersects(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
-	c := C.GEOSIntersects_r(geosContext, geom1, geom2)
-	return boolFromC(c)
+	c := C.GEOSIntersects_r(ctx.handle, geom1, geom2)
+	return ctx.boolFromC(c)
 }
 
 // IsClosed returns true if the geometry is closed
-func IsClosed(g string) (bool, error) {
-	geoGeom := GeomFromWKTStr(g)
-	defer C.GEOSGeom_destroy_r(geosContext, geoGeom)
-	c := C.GEOSisClosed_r(geosContext, geoGeom)
-	return boolFromC(c)
+func (ctx *Context) IsClosed(geom space.Geometry) (bool, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return false, err
+	}
+	defer C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+	c := C.GEOSisClosed_r(ctx.handle, geoGeom)
+	return ctx.boolFromC(c)
 }
 
 // IsEmpty returns true if this Geometry is an empty geometry.
 // If true, then this Geometry represents an empty geometry collection, polygon, point etc.
-func IsEmpty(g string) (bool, error) {
-	geoGeom := GeomFromWKTStr(g)
-	defer C.GEOSGeom_destroy_r(geosContext, geoGeom)
-	c := C.GEOSisEmpty_r(geosContext, geoGeom)
-	return boolFromC(c)
+func (ctx *Context) IsEmpty(geom space.Geometry) (bool, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return false, err
+	}
+	defer C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+	c := C.GEOSisEmpty_r(ctx.handle, geoGeom)
+	return ctx.boolFromC(c)
 }
 
 // IsRing returns true if the lineal geometry has the ring property.
-func IsRing(g string) (bool, error) {
-	geoGeom := GeomFromWKTStr(g)
-	defer C.GEOSGeom_destroy_r(geosContext, geoGeom)
-	c := C.GEOSisRing_r(geosContext, geoGeom)
-	return boolFromC(c)
+func (ctx *Context) IsRing(geom space.Geometry) (bool, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return false, err
+	}
+	defer C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+	c := C.GEOSisRing_r(ctx.handle, geoGeom)
+	return ctx.boolFromC(c)
 }
 
 // IsSimple returns true if this Geometry has no anomalous geometric points, such as self intersection or self tangency
-func IsSimple(wkt string) (bool, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	defer C.GEOSGeom_destroy_r(geosContext, geoGeom)
-	c := C.GEOSisSimple_r(geosContext, geoGeom)
-	return boolFromC(c)
+func (ctx *Context) IsSimple(geom space.Geometry) (bool, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return false, err
+	}
+	defer C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+	c := C.GEOSisSimple_r(ctx.handle, geoGeom)
+	return ctx.boolFromC(c)
+}
+
+// IsValid returns true if the geometry is valid in the OGC sense.
+func (ctx *Context) IsValid(geom space.Geometry) (bool, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return false, err
+	}
+	defer C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+	c := C.GEOSisValid_r(ctx.handle, geoGeom)
+	return ctx.boolFromC(c)
+}
+
+// IsValidReason returns the reason why the geometry is not valid, and its location.
+func (ctx *Context) IsValidReason(geom space.Geometry) (space.ValidReason, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return space.ValidReason{}, err
+	}
+	defer C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+	var reason *C.char
+	var location *C.GEOSGeometry
+	c := C.GEOSisValidDetail_r(ctx.handle, geoGeom, 0, &reason, &location)
+	if c == 2 {
+		return space.ValidReason{}, ctx.Error()
+	}
+	defer func() {
+		C.GEOSFree_r(ctx.handle, unsafe.Pointer(reason))
+		C.GEOSGeom_destroy_r(ctx.handle, location)
+	}()
+	if c == 1 {
+		return space.ValidReason{Kind: space.Valid}, nil
+	}
+	r := space.ValidReason{Kind: space.ValidKindOf(C.GoString(reason))}
+	if location != nil {
+		if p, err := ctx.ToSpace(location); err == nil {
+			r.Location, _ = p.(space.Point)
+		}
+	}
+	return r, nil
 }
 
 // Length returns the 2D Cartesian length of the geometry if it is a LineString, MultiLineString
-func Length(wkt string) (float64, error) {
-	geoGeom := GeomFromWKTStr(wkt)
+func (ctx *Context) Length(geom space.Geometry) (float64, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return 0.0, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
 	}()
 	var d C.double
-	i := C.GEOSLength_r(geosContext, geoGeom, &d)
+	i := C.GEOSLength_r(ctx.handle, geoGeom, &d)
 	if i == 0 {
-		return 0.0, Error()
+		return 0.0, ctx.Error()
 	}
 	return float64(d), nil
 
 }
 
 // LineMerge returns a (set of) LineString(s) formed by sewing together the constituent line work of a MULTILINESTRING.
-func LineMerge(wkt string) (string, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	g := C.GEOSLineMerge_r(geosContext, geoGeom)
+func (ctx *Context) LineMerge(geom space.Geometry) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSLineMerge_r(ctx.handle, geoGeom)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
+}
+
+// MakeValid returns a valid geometry covering the points of the geometry, without losing any of its vertices:
+// the parts which collapse are kept as lines or points.
+func (ctx *Context) MakeValid(geom space.Geometry) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSMakeValid_r(ctx.handle, geoGeom)
+	defer func() {
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
+	}()
+	return ctx.ToSpace(g)
 }
 
 // NGeometry returns the number of component geometries.
-func NGeometry(g string) (int, error) {
-	geom := GeomFromWKTStr(g)
-	defer C.GEOSGeom_destroy_r(geosContext, geom)
-	c := C.GEOSGetNumGeometries_r(geosContext, geom)
-	return intFromC(c, -1)
+func (ctx *Context) NGeometry(g space.Geometry) (int, error) {
+	geom, err := ctx.GeomFromSpace(g)
+	if err != nil {
+		return 0, err
+	}
+	defer C.GEOSGeom_destroy_r(ctx.handle, geom)
+	c := C.GEOSGetNumGeometries_r(ctx.handle, geom)
+	return ctx.intFromC(c, -1)
 }
 
 // Overlaps returns true if one geometry overlaps the other.
-func Overlaps(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
-	pGeom := C.GEOSPrepare_r(geosContext, geom1)
+func (ctx *Context) Overlaps(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
+	pGeom := C.GEOSPrepare_r(ctx.handle, geom1)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
-		C.GEOSPreparedGeom_destroy_r(geosContext, pGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
+		C.GEOSPreparedGeom_destroy_r(ctx.handle, pGeom)
 	}()
-	c := C.GEOSPreparedOverlaps_r(geosContext, pGeom, geom2)
-	return boolFromC(c)
+	c := C.GEOSPreparedOverlaps_r(ctx.handle, pGeom, geom2)
+	return ctx.boolFromC(c)
 }
 
 // PointOnSurface Returns a POINT guaranteed to intersect a surface.
-func PointOnSurface(wkt string) (string, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	g := C.GEOSPointOnSurface_r(geosContext, geoGeom)
+func (ctx *Context) PointOnSurface(geom space.Geometry) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSPointOnSurface_r(ctx.handle, geoGeom)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // Relate computes the intersection matrix (Dimensionally Extended
 // Nine-Intersection Model (DE-9IM) matrix) for the spatial relationship between
 // the two geometries.
-func Relate(g1 string, g2 string) (string, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) Relate(g1, g2 space.Geometry) (string, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return "", err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
-	c := C.GEOSRelate_r(geosContext, geom1, geom2)
+	c := C.GEOSRelate_r(ctx.handle, geom1, geom2)
 	if c == nil {
-		return "", Error()
+		return "", ctx.Error()
 	}
 	return C.GoString(c), nil
 }
 
+// RelatePattern returns true if the DE-9IM matrix of the two geometries matches pattern.
+func (ctx *Context) RelatePattern(g1, g2 space.Geometry, pattern string) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
+	cs := C.CString(pattern)
+	defer func() {
+		C.free(unsafe.Pointer(cs))
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
+	}()
+	c := C.GEOSRelatePattern_r(ctx.handle, geom1, geom2, cs)
+	return ctx.boolFromC(c)
+}
+
 // SharedPaths returns a collection containing paths shared by the two input geometries.
 // Those going in the same direction are in the first element of the collection, those going in the opposite
 // direction are in the second element. The paths themselves are given in the direction of the first geometry.
-func SharedPaths(g1 string, g2 string) (string, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
-	g := C.GEOSSharedPaths_r(geosContext, geom1, geom2)
+func (ctx *Context) SharedPaths(g1, g2 space.Geometry) (string, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return "", err
+	}
+	g := C.GEOSSharedPaths_r(ctx.handle, geom1, geom2)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToWKTStr(g)
 }
 
 // Simplify returns a "simplified" version of the given geometry using the Douglas-Peucker algorithm,
 // May not preserve topology
-func Simplify(wkt string, tolerance float64) (string, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	g := C.GEOSSimplify_r(geosContext, geoGeom, C.double(tolerance))
+func (ctx *Context) Simplify(geom space.Geometry, tolerance float64) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSSimplify_r(ctx.handle, geoGeom, C.double(tolerance))
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // SimplifyP returns a geometry simplified by amount given by tolerance.
 // Unlike Simplify, SimplifyP guarantees it will preserve topology.
-func SimplifyP(wkt string, tolerance float64) (string, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	g := C.GEOSTopologyPreserveSimplify_r(geosContext, geoGeom, C.double(tolerance))
+func (ctx *Context) SimplifyP(geom space.Geometry, tolerance float64) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSTopologyPreserveSimplify_r(ctx.handle, geoGeom, C.double(tolerance))
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // Snap Snaps the vertices and segments of a geometry to another Geometry's vertices.
 // A snap distance tolerance is used to control where snapping is performed.
 // The result geometry is the input geometry with the vertices snapped.
 // If no snapping occurs then the input geometry is returned unchanged.
-func Snap(input string, reference string, tolerance float64) (string, error) {
-	inputGeom := GeomFromWKTStr(input)
-	referenceGeom := GeomFromWKTStr(reference)
-	g := C.GEOSSnap_r(geosContext, inputGeom, referenceGeom, C.double(tolerance))
+func (ctx *Context) Snap(input, reference space.Geometry, tolerance float64) (space.Geometry, error) {
+	inputGeom, referenceGeom, err := ctx.convertToGEOSGeometry(input, reference)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSSnap_r(ctx.handle, inputGeom, referenceGeom, C.double(tolerance))
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, inputGeom)
-		C.GEOSGeom_destroy_r(geosContext, referenceGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, inputGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, referenceGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // SymDifference returns a geometry that represents the portions of A and B that do not intersect.
 // It is called a symmetric difference because SymDifference(A,B) = SymDifference(B,A).
 // One can think of this as Union(geomA,geomB) - Intersection(A,B).
-func SymDifference(g1 string, g2 string) (string, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
-	g := C.GEOSSymDifference_r(geosContext, geom1, geom2)
+func (ctx *Context) SymDifference(g1, g2 space.Geometry) (space.Geometry, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSSymDifference_r(ctx.handle, geom1, geom2)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // Touches returns TRUE if the only points in common between g1 and g2 lie in the union of the boundaries of g1 and g2.
 // The touches relation applies to all Area/Area, Line/Line, Line/Area, Point/Area and Point/Line pairs of relationships,
 // but not to the Point/Point pair.
-func Touches(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) Touches(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
-	c := C.GEOSTouches_r(geosContext, geom1, geom2)
-	return boolFromC(c)
+	c := C.GEOSTouches_r(ctx.handle, geom1, geom2)
+	return ctx.boolFromC(c)
 }
 
 // UnaryUnion does dissolve boundaries between components of a multipolygon (invalid) and does perform union
 // between the components of a geometrycollection
-func UnaryUnion(wkt string) (string, error) {
-	geoGeom := GeomFromWKTStr(wkt)
-	g := C.GEOSUnaryUnion_r(geosContext, geoGeom)
+func (ctx *Context) UnaryUnion(geom space.Geometry) (space.Geometry, error) {
+	geoGeom, err := ctx.GeomFromSpace(geom)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSUnaryUnion_r(ctx.handle, geoGeom)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geoGeom)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geoGeom)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // Union returns a new geometry representing all points in this geometry and the other.
-func Union(g1 string, g2 string) (string, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
-	g := C.GEOSUnion_r(geosContext, geom1, geom2)
+func (ctx *Context) Union(g1, g2 space.Geometry) (space.Geometry, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return nil, err
+	}
+	g := C.GEOSUnion_r(ctx.handle, geom1, geom2)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
-		C.GEOSGeom_destroy_r(geosContext, g)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, g)
 	}()
-	return ToWKTStr(g)
+	return ctx.ToSpace(g)
 }
 
 // UniquePoints return all distinct vertices of input geometry as a MultiPoint.
-func UniquePoints(g string) (string, error) {
-	geom := GeomFromWKTStr(g)
-	c := C.GEOSGeom_extractUniquePoints_r(geosContext, geom)
+func (ctx *Context) UniquePoints(g space.Geometry) (space.Geometry, error) {
+	geom, err := ctx.GeomFromSpace(g)
+	if err != nil {
+		return nil, err
+	}
+	c := C.GEOSGeom_extractUniquePoints_r(ctx.handle, geom)
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom)
-		C.GEOSGeom_destroy_r(geosContext, c)
+		C.GEOSGeom_destroy_r(ctx.handle, geom)
+		C.GEOSGeom_destroy_r(ctx.handle, c)
 	}()
 	if c == nil {
-		return "", errors.New("UniquePoints return null")
+		return nil, errors.New("UniquePoints return null")
 	}
-	return ToWKTStr(c)
+	return ctx.ToSpace(c)
 }
 
 // Version ...
@@ -561,12 +794,15 @@ func Version() string {
 // Within returns TRUE if geometry A is completely inside geometry B.
 // For this function to make sense, the source geometries must both be of the same coordinate projection,
 // having the same SRID.
-func Within(g1 string, g2 string) (bool, error) {
-	geom1, geom2 := convertWKTtoGEOSGeometry(g1, g2)
+func (ctx *Context) Within(g1, g2 space.Geometry) (bool, error) {
+	geom1, geom2, err := ctx.convertToGEOSGeometry(g1, g2)
+	if err != nil {
+		return false, err
+	}
 	defer func() {
-		C.GEOSGeom_destroy_r(geosContext, geom1)
-		C.GEOSGeom_destroy_r(geosContext, geom2)
+		C.GEOSGeom_destroy_r(ctx.handle, geom1)
+		C.GEOSGeom_destroy_r(ctx.handle, geom2)
 	}()
-	c := C.GEOSWithin_r(geosContext, geom1, geom2)
-	return boolFromC(c)
+	c := C.GEOSWithin_r(ctx.handle, geom1, geom2)
+	return ctx.boolFromC(c)
 }
@@ -4,7 +4,12 @@
 #include <stdarg.h>
 #include <string.h>
 
+#define ERRLEN 256
+
 void geos_notice_handler(const char *fmt, ...);
 void geos_error_handler(const char *fmt, ...);
 char *geos_get_last_error(void);
 GEOSContextHandle_t geos_initGEOS();
+GEOSContextHandle_t geos_context_create(char *errbuf);
+GEOSCoordSequence *geos_coordseq_from_buffer(GEOSContextHandle_t handle, const double *buf, unsigned int size, unsigned int dims);
+int geos_coordseq_to_buffer(GEOSContextHandle_t handle, const GEOSCoordSequence *seq, double *buf, unsigned int size, unsigned int dims);
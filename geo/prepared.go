@@ -0,0 +1,109 @@
+package geo
+
+/*
+#cgo LDFLAGS: -lgeos_c
+#include "geos.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+// ErrPreparedGeometry is returned when a geometry cannot be prepared.
+var ErrPreparedGeometry = errors.New("geo: failed to prepare geometry")
+
+// PreparedGeometry is a GEOS geometry prepared for repeated evaluation of predicates.
+// It has its own context, and is safe for concurrent use.
+// It is released when it is garbage collected, or explicitly by Destroy.
+type PreparedGeometry struct {
+	mu       sync.Mutex
+	ctx      *Context
+	geom     GEOSGeometry
+	prepared GEOSPreparedGeometry
+}
+
+// NewPreparedGeometry converts the geometry to GEOS and prepares it.
+func NewPreparedGeometry(g space.Geometry) (*PreparedGeometry, error) {
+	ctx, err := NewContext()
+	if err != nil {
+		return nil, err
+	}
+	geom, err := ctx.GeomFromSpace(g)
+	if err != nil {
+		ctx.Close()
+		return nil, err
+	}
+	prepared := ctx.PrepareGeometry(geom)
+	if prepared == nil {
+		C.GEOSGeom_destroy_r(ctx.handle, geom)
+		ctx.Close()
+		return nil, ErrPreparedGeometry
+	}
+	p := &PreparedGeometry{ctx: ctx, geom: geom, prepared: prepared}
+	runtime.SetFinalizer(p, (*PreparedGeometry).Destroy)
+	return p, nil
+}
+
+// Destroy releases the GEOS resources of the prepared geometry.
+func (p *PreparedGeometry) Destroy() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.prepared != nil {
+		C.GEOSPreparedGeom_destroy_r(p.ctx.handle, p.prepared)
+		p.prepared = nil
+	}
+	if p.geom != nil {
+		C.GEOSGeom_destroy_r(p.ctx.handle, p.geom)
+		p.geom = nil
+	}
+	p.ctx.Close()
+	runtime.SetFinalizer(p, nil)
+}
+
+// Contains returns true if the prepared geometry contains the geometry.
+func (p *PreparedGeometry) Contains(g space.Geometry) (bool, error) {
+	return p.predicate(g, func(g GEOSGeometry) C.char {
+		return C.GEOSPreparedContains_r(p.ctx.handle, p.prepared, g)
+	})
+}
+
+// ContainsProperly returns true if the geometry lies in the interior of the prepared geometry.
+func (p *PreparedGeometry) ContainsProperly(g space.Geometry) (bool, error) {
+	return p.predicate(g, func(g GEOSGeometry) C.char {
+		return C.GEOSPreparedContainsProperly_r(p.ctx.handle, p.prepared, g)
+	})
+}
+
+// Covers returns true if no point of the geometry is outside the prepared geometry.
+func (p *PreparedGeometry) Covers(g space.Geometry) (bool, error) {
+	return p.predicate(g, func(g GEOSGeometry) C.char {
+		return C.GEOSPreparedCovers_r(p.ctx.handle, p.prepared, g)
+	})
+}
+
+// Intersects returns true if the prepared geometry and the geometry have at least one point in common.
+func (p *PreparedGeometry) Intersects(g space.Geometry) (bool, error) {
+	return p.predicate(g, func(g GEOSGeometry) C.char {
+		return C.GEOSPreparedIntersects_r(p.ctx.handle, p.prepared, g)
+	})
+}
+
+// predicate evaluates a prepared predicate against the geometry g.
+func (p *PreparedGeometry) predicate(g space.Geometry, f func(g GEOSGeometry) C.char) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.prepared == nil {
+		return false, ErrPreparedGeometry
+	}
+	geom, err := p.ctx.GeomFromSpace(g)
+	if err != nil {
+		return false, err
+	}
+	defer C.GEOSGeom_destroy_r(p.ctx.handle, geom)
+	return p.ctx.boolFromC(f(geom))
+}
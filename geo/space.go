@@ -0,0 +1,310 @@
+package geo
+
+/*
+#cgo LDFLAGS: -lgeos_c
+#include "geos.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/space"
+)
+
+// ErrNilGeometry is returned when a nil geometry is converted to a GEOSGeometry.
+var ErrNilGeometry = errors.New("geo: geometry is nil")
+
+// GeomFromSpace converts a space.Geometry to a GEOSGeometry by copying its coordinates
+// into GEOS coordinate sequences, of three dimensions if the coordinates have a Z.
+// The M of the coordinates is dropped. The caller owns the result and has to destroy it.
+func (ctx *Context) GeomFromSpace(geom space.Geometry) (GEOSGeometry, error) {
+	switch g := geom.(type) {
+	case nil:
+		return nil, ErrNilGeometry
+	case space.Point:
+		if g.IsEmpty() {
+			return ctx.geomOrError(C.GEOSGeom_createEmptyPoint_r(ctx.handle))
+		}
+		seq, err := ctx.coordSeqFromLine(matrix.LineMatrix{matrix.Matrix(g)}, coordDims(g))
+		if err != nil {
+			return nil, err
+		}
+		return ctx.geomOrError(C.GEOSGeom_createPoint_r(ctx.handle, seq))
+	case space.MultiPoint:
+		return ctx.collectionFromSpace(C.GEOS_MULTIPOINT, len(g), func(i int) space.Geometry { return g[i] })
+	case space.LineString:
+		if g.IsEmpty() {
+			return ctx.geomOrError(C.GEOSGeom_createEmptyLineString_r(ctx.handle))
+		}
+		seq, err := ctx.coordSeqFromLine(matrix.LineMatrix(g), coordDims(g))
+		if err != nil {
+			return nil, err
+		}
+		return ctx.geomOrError(C.GEOSGeom_createLineString_r(ctx.handle, seq))
+	case space.MultiLineString:
+		return ctx.collectionFromSpace(C.GEOS_MULTILINESTRING, len(g), func(i int) space.Geometry { return g[i] })
+	case space.Ring:
+		return ctx.polygonFromSpace(matrix.PolygonMatrix{matrix.LineMatrix(g)}, coordDims(g))
+	case space.Polygon:
+		return ctx.polygonFromSpace(matrix.PolygonMatrix(g), coordDims(g))
+	case space.MultiPolygon:
+		return ctx.collectionFromSpace(C.GEOS_MULTIPOLYGON, len(g), func(i int) space.Geometry { return g[i] })
+	case space.Bound:
+		return ctx.polygonFromSpace(matrix.PolygonMatrix(g.ToPolygon()), 2)
+	case space.Collection:
+		return ctx.collectionFromSpace(C.GEOS_GEOMETRYCOLLECTION, len(g), func(i int) space.Geometry { return g[i] })
+	}
+	return nil, fmt.Errorf("geo: unsupported geometry type %T", geom)
+}
+
+// ToSpace converts a GEOSGeometry to a space.Geometry by reading its coordinate sequences.
+// The Z of the coordinates is kept if a coordinate of a sequence has one.
+// The GEOSGeometry is left untouched, the caller still has to destroy it.
+func (ctx *Context) ToSpace(g GEOSGeometry) (space.Geometry, error) {
+	if g == nil {
+		return nil, ctx.Error()
+	}
+	switch C.GEOSGeomTypeId_r(ctx.handle, g) {
+	case C.GEOS_POINT:
+		if C.GEOSisEmpty_r(ctx.handle, g) == 1 {
+			return space.Point{}, nil
+		}
+		line, err := ctx.lineFromGeom(g)
+		if err != nil {
+			return nil, err
+		}
+		return space.Point(line[0]), nil
+	case C.GEOS_LINESTRING, C.GEOS_LINEARRING:
+		line, err := ctx.lineFromGeom(g)
+		if err != nil {
+			return nil, err
+		}
+		return space.LineString(line), nil
+	case C.GEOS_POLYGON:
+		polygon, err := ctx.polygonFromGeom(g)
+		if err != nil {
+			return nil, err
+		}
+		return space.Polygon(polygon), nil
+	case C.GEOS_MULTIPOINT:
+		multiPoint := space.MultiPoint{}
+		err := ctx.eachGeometry(g, func(part GEOSGeometry) error {
+			line, err := ctx.lineFromGeom(part)
+			if err != nil {
+				return err
+			}
+			if len(line) > 0 {
+				multiPoint = append(multiPoint, space.Point(line[0]))
+			}
+			return nil
+		})
+		return multiPoint, err
+	case C.GEOS_MULTILINESTRING:
+		multiLine := space.MultiLineString{}
+		err := ctx.eachGeometry(g, func(part GEOSGeometry) error {
+			line, err := ctx.lineFromGeom(part)
+			if err != nil {
+				return err
+			}
+			multiLine = append(multiLine, space.LineString(line))
+			return nil
+		})
+		return multiLine, err
+	case C.GEOS_MULTIPOLYGON:
+		multiPolygon := space.MultiPolygon{}
+		err := ctx.eachGeometry(g, func(part GEOSGeometry) error {
+			polygon, err := ctx.polygonFromGeom(part)
+			if err != nil {
+				return err
+			}
+			multiPolygon = append(multiPolygon, space.Polygon(polygon))
+			return nil
+		})
+		return multiPolygon, err
+	case C.GEOS_GEOMETRYCOLLECTION:
+		collection := space.Collection{}
+		err := ctx.eachGeometry(g, func(part GEOSGeometry) error {
+			geom, err := ctx.ToSpace(part)
+			if err != nil {
+				return err
+			}
+			collection = append(collection, geom)
+			return nil
+		})
+		return collection, err
+	}
+	return nil, ctx.Error()
+}
+
+// geomOrError returns g, or the last GEOS error if g is nil.
+func (ctx *Context) geomOrError(g GEOSGeometry) (GEOSGeometry, error) {
+	if g == nil {
+		return nil, ctx.Error()
+	}
+	return g, nil
+}
+
+// coordDims returns the dimensions of the coordinate sequences of geom, 3 if its coordinates have a Z.
+func coordDims(geom space.Geometry) int {
+	if space.CoordLayout(geom).HasZ() {
+		return 3
+	}
+	return 2
+}
+
+// coordSeqFromLine copies the points of line into a new coordinate sequence of dims dimensions,
+// the x and y, and the z if dims is 3. A point without a Z is given a NaN z, as GEOS does.
+func (ctx *Context) coordSeqFromLine(line matrix.LineMatrix, dims int) (*C.GEOSCoordSequence, error) {
+	buf := make([]float64, dims*len(line))
+	for i, p := range line {
+		if len(p) < 2 {
+			return nil, fmt.Errorf("geo: point %v has less than two coordinates", p)
+		}
+		buf[dims*i], buf[dims*i+1] = p[0], p[1]
+		if dims == 3 {
+			buf[dims*i+2] = space.Point(p).Z()
+		}
+	}
+	var ptr *C.double
+	if len(buf) > 0 {
+		ptr = (*C.double)(unsafe.Pointer(&buf[0]))
+	}
+	seq := C.geos_coordseq_from_buffer(ctx.handle, ptr, C.uint(len(line)), C.uint(dims))
+	if seq == nil {
+		return nil, ctx.Error()
+	}
+	return seq, nil
+}
+
+// polygonFromSpace creates a GEOS polygon from the rings of polygon, the first being the shell,
+// with coordinate sequences of dims dimensions.
+func (ctx *Context) polygonFromSpace(polygon matrix.PolygonMatrix, dims int) (GEOSGeometry, error) {
+	if len(polygon) == 0 || len(polygon[0]) == 0 {
+		return ctx.geomOrError(C.GEOSGeom_createEmptyPolygon_r(ctx.handle))
+	}
+	rings := make([]*C.GEOSGeometry, 0, len(polygon))
+	for _, r := range polygon {
+		seq, err := ctx.coordSeqFromLine(r, dims)
+		if err == nil {
+			var ring GEOSGeometry
+			if ring, err = ctx.geomOrError(C.GEOSGeom_createLinearRing_r(ctx.handle, seq)); err == nil {
+				rings = append(rings, ring)
+				continue
+			}
+		}
+		for _, ring := range rings {
+			C.GEOSGeom_destroy_r(ctx.handle, ring)
+		}
+		return nil, err
+	}
+	var holes **C.GEOSGeometry
+	if len(rings) > 1 {
+		// the slice holds C pointers only, so it can be passed to C
+		holes = &rings[1]
+	}
+	return ctx.geomOrError(C.GEOSGeom_createPolygon_r(ctx.handle, rings[0], holes, C.uint(len(rings)-1)))
+}
+
+// collectionFromSpace creates a GEOS collection of the given type from n parts.
+func (ctx *Context) collectionFromSpace(typeID C.int, n int, part func(i int) space.Geometry) (GEOSGeometry, error) {
+	if n == 0 {
+		return ctx.geomOrError(C.GEOSGeom_createEmptyCollection_r(ctx.handle, typeID))
+	}
+	parts := make([]*C.GEOSGeometry, n)
+	for i := range parts {
+		g, err := ctx.GeomFromSpace(part(i))
+		if err != nil {
+			for _, p := range parts[:i] {
+				C.GEOSGeom_destroy_r(ctx.handle, p)
+			}
+			return nil, err
+		}
+		parts[i] = g
+	}
+	return ctx.geomOrError(C.GEOSGeom_createCollection_r(ctx.handle, typeID, &parts[0], C.uint(n)))
+}
+
+// lineFromGeom reads the coordinate sequence of a point, line string or linear ring.
+// The points have a Z if the sequence has three dimensions and one of its z is not NaN.
+func (ctx *Context) lineFromGeom(g GEOSGeometry) (matrix.LineMatrix, error) {
+	seq := C.GEOSGeom_getCoordSeq_r(ctx.handle, g)
+	if seq == nil {
+		return nil, ctx.Error()
+	}
+	var size C.uint
+	if C.GEOSCoordSeq_getSize_r(ctx.handle, seq, &size) == 0 {
+		return nil, ctx.Error()
+	}
+	if size == 0 {
+		return matrix.LineMatrix{}, nil
+	}
+	var dims C.uint
+	if C.GEOSCoordSeq_getDimensions_r(ctx.handle, seq, &dims) == 0 {
+		return nil, ctx.Error()
+	}
+	if dims > 3 {
+		// the M of the coordinates is dropped
+		dims = 3
+	}
+	d := int(dims)
+	buf := make([]float64, d*int(size))
+	if C.geos_coordseq_to_buffer(ctx.handle, seq, (*C.double)(unsafe.Pointer(&buf[0])), size, dims) == 0 {
+		return nil, ctx.Error()
+	}
+	// GEOS may give sequences three dimensions without any z
+	values := 2
+	for i := 0; d == 3 && i < int(size); i++ {
+		if !math.IsNaN(buf[d*i+2]) {
+			values = 3
+			break
+		}
+	}
+	line := make(matrix.LineMatrix, size)
+	for i := range line {
+		line[i] = buf[d*i : d*i+values : d*i+values]
+	}
+	return line, nil
+}
+
+// polygonFromGeom reads the shell and holes of a polygon.
+func (ctx *Context) polygonFromGeom(g GEOSGeometry) (matrix.PolygonMatrix, error) {
+	if C.GEOSisEmpty_r(ctx.handle, g) == 1 {
+		return matrix.PolygonMatrix{}, nil
+	}
+	shell, err := ctx.lineFromGeom(C.GEOSGetExteriorRing_r(ctx.handle, g))
+	if err != nil {
+		return nil, err
+	}
+	n := C.GEOSGetNumInteriorRings_r(ctx.handle, g)
+	if n < 0 {
+		return nil, ctx.Error()
+	}
+	polygon := matrix.PolygonMatrix{shell}
+	for i := C.int(0); i < n; i++ {
+		hole, err := ctx.lineFromGeom(C.GEOSGetInteriorRingN_r(ctx.handle, g, i))
+		if err != nil {
+			return nil, err
+		}
+		polygon = append(polygon, hole)
+	}
+	return polygon, nil
+}
+
+// eachGeometry calls f for every part of the collection g.
+func (ctx *Context) eachGeometry(g GEOSGeometry, f func(part GEOSGeometry) error) error {
+	n := C.GEOSGetNumGeometries_r(ctx.handle, g)
+	if n < 0 {
+		return ctx.Error()
+	}
+	for i := C.int(0); i < n; i++ {
+		if err := f(C.GEOSGetGeometryN_r(ctx.handle, g, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package geo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestGeomFromSpace(t *testing.T) {
+	tests := []struct {
+		name string
+		geom space.Geometry
+	}{
+		{name: "point", geom: space.Point{1.123456789012345, 2}},
+		{name: "line", geom: space.LineString{{0, 0}, {1, 1}, {2, 0}}},
+		{name: "point z", geom: space.Point{1, 2, 3}},
+		{name: "line z", geom: space.LineString{{0, 0, 1}, {1, 1, 2}, {2, 0, 3}}},
+		{name: "polygon with hole", geom: space.Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}, {{2, 2}, {2, 8}, {8, 8}, {8, 2}, {2, 2}}}},
+		{name: "multi point", geom: space.MultiPoint{{0, 0}, {1, 1}}},
+		{name: "multi line", geom: space.MultiLineString{{{0, 0}, {1, 1}}, {{2, 2}, {3, 3}}}},
+		{name: "multi polygon", geom: space.MultiPolygon{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, {{{5, 5}, {6, 5}, {6, 6}, {5, 5}}}}},
+		{name: "polygon z", geom: space.Polygon{{{0, 0, 1}, {10, 0, 2}, {10, 10, 3}, {0, 0, 1}}}},
+		{name: "collection", geom: space.Collection{space.Point{1, 2}, space.LineString{{0, 0}, {1, 1}}}},
+		{name: "empty collection", geom: space.Collection{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := GeomFromSpace(tt.geom)
+			if err != nil {
+				t.Fatalf("GeomFromSpace() error = %v", err)
+			}
+			defer DestroyGeometry(g)
+			got, err := ToSpace(g)
+			if err != nil {
+				t.Fatalf("ToSpace() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.geom) {
+				t.Errorf("ToSpace() got = %v, want %v", got, tt.geom)
+			}
+		})
+	}
+}
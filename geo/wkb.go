@@ -19,44 +19,44 @@ type GEOSWKBReader *C.GEOSWKBReader
 type GEOSWKBWriter *C.GEOSWKBWriter
 
 // GeomFromWKBStr convert wkb byte array to GEOSGeometry
-func GeomFromWKBStr(wkbByte []byte) (GEOSGeometry, error) {
+func (ctx *Context) GeomFromWKBStr(wkbByte []byte) (GEOSGeometry, error) {
 	cwkb := GoByteArrayToCCharArray(wkbByte)
-	reader := WKBReaderFactory()
-	defer WKBReaderDestroy(reader)
-	return EncodeWKBToGeom(reader, cwkb)
+	reader := ctx.WKBReaderFactory()
+	defer ctx.WKBReaderDestroy(reader)
+	return ctx.EncodeWKBToGeom(reader, cwkb)
 }
 
 // ToWKB convert GEOSGeometry to wkb byte array
-func ToWKB(g GEOSGeometry) ([]byte, error) {
-	writer := WKBWriterFactory()
-	defer WKBWriterDestroy(writer)
-	return DecodeWKBToArray(writer, g)
+func (ctx *Context) ToWKB(g GEOSGeometry) ([]byte, error) {
+	writer := ctx.WKBWriterFactory()
+	defer ctx.WKBWriterDestroy(writer)
+	return ctx.DecodeWKBToArray(writer, g)
 }
 
 // ToWKBHex convert GEOSGeometry to hex string
-func ToWKBHex(g GEOSGeometry) (string, error) {
-	w := WKBWriterFactory()
-	defer WKBWriterDestroy(w)
-	return DecodeWKBToHexStr(w, g)
+func (ctx *Context) ToWKBHex(g GEOSGeometry) (string, error) {
+	w := ctx.WKBWriterFactory()
+	defer ctx.WKBWriterDestroy(w)
+	return ctx.DecodeWKBToHexStr(w, g)
 }
 
 // GeomFromWKBHexStr convert hex string to GEOSGeometry
-func GeomFromWKBHexStr(wkbHex string) (GEOSGeometry, error) {
+func (ctx *Context) GeomFromWKBHexStr(wkbHex string) (GEOSGeometry, error) {
 	wkbstr, err := hex.DecodeString(wkbHex)
 	if err != nil {
 		return nil, err
 	}
 	array := GoByteArrayToCCharArray(wkbstr)
-	r := WKBReaderFactory()
-	defer WKBReaderDestroy(r)
-	return EncodeHexToGeom(r, array)
+	r := ctx.WKBReaderFactory()
+	defer ctx.WKBReaderDestroy(r)
+	return ctx.EncodeHexToGeom(r, array)
 
 }
 
 // EncodeWKBToGeom ...
-func EncodeWKBToGeom(reader GEOSWKBReader, cwkb []C.uchar) (GEOSGeometry, error) {
+func (ctx *Context) EncodeWKBToGeom(reader GEOSWKBReader, cwkb []C.uchar) (GEOSGeometry, error) {
 
-	g := C.GEOSWKBReader_read_r(geosContext, reader, &cwkb[0], C.size_t(len(cwkb)))
+	g := C.GEOSWKBReader_read_r(ctx.handle, reader, &cwkb[0], C.size_t(len(cwkb)))
 	if g == nil {
 		return nil, errors.New("C.GEOSGeometry is null")
 	}
@@ -64,8 +64,8 @@ func EncodeWKBToGeom(reader GEOSWKBReader, cwkb []C.uchar) (GEOSGeometry, error)
 }
 
 // EncodeHexToGeom ...
-func EncodeHexToGeom(reader GEOSWKBReader, cwkb []C.uchar) (GEOSGeometry, error) {
-	g := C.GEOSWKBReader_read_r(geosContext, reader, &cwkb[0], C.size_t(len(cwkb)))
+func (ctx *Context) EncodeHexToGeom(reader GEOSWKBReader, cwkb []C.uchar) (GEOSGeometry, error) {
+	g := C.GEOSWKBReader_read_r(ctx.handle, reader, &cwkb[0], C.size_t(len(cwkb)))
 	if g == nil {
 		return nil, errors.New("C.GEOSGeometry is null")
 	}
@@ -73,9 +73,9 @@ func EncodeHexToGeom(reader GEOSWKBReader, cwkb []C.uchar) (GEOSGeometry, error)
 }
 
 // DecodeWKBToArray ...
-func DecodeWKBToArray(writer GEOSWKBWriter, g GEOSGeometry) ([]byte, error) {
+func (ctx *Context) DecodeWKBToArray(writer GEOSWKBWriter, g GEOSGeometry) ([]byte, error) {
 	var size C.size_t
-	bytes := C.GEOSWKBWriter_write_r(geosContext, writer, g, &size)
+	bytes := C.GEOSWKBWriter_write_r(ctx.handle, writer, g, &size)
 	if bytes == nil {
 		return nil, errors.New("toWKBHex bytes is null")
 	}
@@ -91,9 +91,9 @@ func DecodeWKBToArray(writer GEOSWKBWriter, g GEOSGeometry) ([]byte, error) {
 }
 
 // DecodeWKBToHexStr ...
-func DecodeWKBToHexStr(writer GEOSWKBWriter, g GEOSGeometry) (string, error) {
+func (ctx *Context) DecodeWKBToHexStr(writer GEOSWKBWriter, g GEOSGeometry) (string, error) {
 	var size C.size_t
-	bytes := C.GEOSWKBWriter_writeHEX_r(geosContext, writer, g, &size)
+	bytes := C.GEOSWKBWriter_writeHEX_r(ctx.handle, writer, g, &size)
 	if bytes == nil {
 		return "", errors.New("toWKBHex bytes is null")
 	}
@@ -103,23 +103,23 @@ func DecodeWKBToHexStr(writer GEOSWKBWriter, g GEOSGeometry) (string, error) {
 }
 
 // WKBReaderFactory ...
-func WKBReaderFactory() GEOSWKBReader {
-	reader := C.GEOSWKBReader_create_r(geosContext)
+func (ctx *Context) WKBReaderFactory() GEOSWKBReader {
+	reader := C.GEOSWKBReader_create_r(ctx.handle)
 	return GEOSWKBReader(reader)
 }
 
 // WKBReaderDestroy ...
-func WKBReaderDestroy(reader GEOSWKBReader) {
-	C.GEOSWKBReader_destroy_r(geosContext, reader)
+func (ctx *Context) WKBReaderDestroy(reader GEOSWKBReader) {
+	C.GEOSWKBReader_destroy_r(ctx.handle, reader)
 }
 
 // WKBWriterFactory ...
-func WKBWriterFactory() GEOSWKBWriter {
-	writer := C.GEOSWKBWriter_create_r(geosContext)
+func (ctx *Context) WKBWriterFactory() GEOSWKBWriter {
+	writer := C.GEOSWKBWriter_create_r(ctx.handle)
 	return GEOSWKBWriter(writer)
 }
 
 // WKBWriterDestroy ...
-func WKBWriterDestroy(writer GEOSWKBWriter) {
-	C.GEOSWKBWriter_destroy_r(geosContext, writer)
+func (ctx *Context) WKBWriterDestroy(writer GEOSWKBWriter) {
+	C.GEOSWKBWriter_destroy_r(ctx.handle, writer)
 }
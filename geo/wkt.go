@@ -17,44 +17,44 @@ type GEOSWKTReader *C.GEOSWKTReader
 type GEOSWKTWriter *C.GEOSWKTWriter
 
 // ToWKTStr convert GEOSGeometry to WKT string
-func ToWKTStr(g GEOSGeometry) (string, error) {
-	w := WKTWriterFactory()
-	defer WKTWriterDestroy(w)
-	return DecodeWKTToStr(w, g)
+func (ctx *Context) ToWKTStr(g GEOSGeometry) (string, error) {
+	w := ctx.WKTWriterFactory()
+	defer ctx.WKTWriterDestroy(w)
+	return ctx.DecodeWKTToStr(w, g)
 }
 
 // GeomFromWKTStr convert WKT string to GEOSGeometry
-func GeomFromWKTStr(wktstr string) GEOSGeometry {
-	reader := WKTReaderFactory()
-	defer WKTReaderDestroy(reader)
-	return EncodeWKTToGeom(reader, wktstr)
+func (ctx *Context) GeomFromWKTStr(wktstr string) GEOSGeometry {
+	reader := ctx.WKTReaderFactory()
+	defer ctx.WKTReaderDestroy(reader)
+	return ctx.EncodeWKTToGeom(reader, wktstr)
 }
 
 // WKTWriterFactory ...
-func WKTWriterFactory() GEOSWKTWriter {
-	writer := C.GEOSWKTWriter_create_r(geosContext)
+func (ctx *Context) WKTWriterFactory() GEOSWKTWriter {
+	writer := C.GEOSWKTWriter_create_r(ctx.handle)
 	return GEOSWKTWriter(writer)
 }
 
 // WKTWriterDestroy ...
-func WKTWriterDestroy(writer GEOSWKTWriter) {
-	C.GEOSWKTWriter_destroy_r(geosContext, writer)
+func (ctx *Context) WKTWriterDestroy(writer GEOSWKTWriter) {
+	C.GEOSWKTWriter_destroy_r(ctx.handle, writer)
 }
 
 // WKTReaderFactory ...
-func WKTReaderFactory() GEOSWKTReader {
-	reader := C.GEOSWKTReader_create_r(geosContext)
+func (ctx *Context) WKTReaderFactory() GEOSWKTReader {
+	reader := C.GEOSWKTReader_create_r(ctx.handle)
 	return GEOSWKTReader(reader)
 }
 
 // WKTReaderDestroy ...
-func WKTReaderDestroy(reader GEOSWKTReader) {
-	C.GEOSWKTReader_destroy_r(geosContext, reader)
+func (ctx *Context) WKTReaderDestroy(reader GEOSWKTReader) {
+	C.GEOSWKTReader_destroy_r(ctx.handle, reader)
 }
 
 // DecodeWKTToStr decode GEOSGeometry to WKT string
-func DecodeWKTToStr(writer GEOSWKTWriter, g GEOSGeometry) (string, error) {
-	cstr := C.GEOSWKTWriter_write_r(geosContext, writer, g)
+func (ctx *Context) DecodeWKTToStr(writer GEOSWKTWriter, g GEOSGeometry) (string, error) {
+	cstr := C.GEOSWKTWriter_write_r(ctx.handle, writer, g)
 	defer C.free(unsafe.Pointer(cstr))
 	if cstr == nil {
 		return "", errors.New("writer to wkt is null")
@@ -63,9 +63,9 @@ func DecodeWKTToStr(writer GEOSWKTWriter, g GEOSGeometry) (string, error) {
 }
 
 // EncodeWKTToGeom encode WKT string to GEOSGeometry
-func EncodeWKTToGeom(reader GEOSWKTReader, wktStr string) GEOSGeometry {
+func (ctx *Context) EncodeWKTToGeom(reader GEOSWKTReader, wktStr string) GEOSGeometry {
 	cs := C.CString(wktStr)
 	defer C.free(unsafe.Pointer(cs))
-	g := C.GEOSWKTReader_read_r(geosContext, reader, cs)
+	g := C.GEOSWKTReader_read_r(ctx.handle, reader, cs)
 	return GEOSGeometry(g)
 }
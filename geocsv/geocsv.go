@@ -1,16 +1,20 @@
 /*
-Package geocsv is a library for read csv file with geospatial data.
+Package geocsv is a library for read and write csv file with geospatial data.
+Files can be loaded with Read, or read and written row by row with a Reader and a Writer.
 */
 package geocsv
 
 import (
 	"encoding/csv"
+	"encoding/hex"
 	"errors"
 	"io"
+	"math"
 	"os"
 	"strconv"
 	"strings"
 
+	"github.com/spatial-go/geoos/encoding/wkb"
 	"github.com/spatial-go/geoos/encoding/wkt"
 	"github.com/spatial-go/geoos/geojson"
 	"github.com/spatial-go/geoos/space"
@@ -31,12 +35,33 @@ type GeoCSV struct {
 
 // Options an options of GeoCSV
 type Options struct {
+	// Fields are the fields kept in the properties of the features, all of them if it is empty.
 	Fields   []string
 	XField   string
 	YField   string
 	WKTField string
+	// WKBField is the field of the geometries in hex WKB or EWKB.
+	WKBField string
+	// InferTypes reads the cells of the columns of numbers and of booleans as float64 and bool,
+	// and the empty cells as nil, instead of strings. A column is typed only if all its cells have the type,
+	// and numbers with leading zeros, such as codes, are strings.
+	InferTypes bool
+	// InferRows is the number of rows a Reader reads ahead to infer the types of the columns,
+	// DefaultInferRows if it is 0. The cells of the next rows which do not have the type of their column are strings.
+	InferRows int
 }
 
+// DefaultInferRows is the default number of rows a Reader reads ahead to infer the types of the columns.
+const DefaultInferRows = 1000
+
+// Types of the columns inferred with Options.InferTypes.
+const (
+	columnEmpty = iota
+	columnString
+	columnNumber
+	columnBool
+)
+
 // NewGeoCSV ...
 func NewGeoCSV() (gc *GeoCSV) {
 	gc = &GeoCSV{}
@@ -49,7 +74,6 @@ func (gc *GeoCSV) readRecords() (err error) {
 		return
 	}
 	headerRead := false
-	gbkDecoder := simplifiedchinese.GBK.NewDecoder()
 	reader := csv.NewReader(gc.file)
 	for {
 		record, readErr := reader.Read()
@@ -60,29 +84,10 @@ func (gc *GeoCSV) readRecords() (err error) {
 			err = readErr
 			return
 		}
-		encodeValues := make([]string, 0, len(record))
-		for _, value := range record {
-			var encodeValue string
-			coding := utils.GetStringEncoding(value)
-			switch coding {
-			case utils.UTF8:
-				encodeValue = value
-			case utils.GBK:
-				encodingString, _ := gbkDecoder.Bytes([]byte(value))
-				encodeValue = string(encodingString)
-			default:
-				if encodingString, decodeError := gbkDecoder.Bytes([]byte(value)); decodeError == nil {
-					encodeValue = string(encodingString)
-				} else {
-					err = errors.New("file encoding is not supported")
-					return
-				}
-			}
-			encodeValue = strings.TrimSpace(encodeValue)
-			// remove special characters, such as &#65279;
-			encodeValue = strings.ReplaceAll(encodeValue, "\uFEFF", "")
-			encodeValue = strings.TrimSpace(encodeValue)
-			encodeValues = append(encodeValues, encodeValue)
+		encodeValues, decodeErr := decodeRecord(record)
+		if decodeErr != nil {
+			err = decodeErr
+			return
 		}
 		if !headerRead {
 			headerRead = true
@@ -94,6 +99,35 @@ func (gc *GeoCSV) readRecords() (err error) {
 	return
 }
 
+// decodeRecord decodes the values of a record in UTF8 or GBK, and trims them.
+func decodeRecord(record []string) ([]string, error) {
+	gbkDecoder := simplifiedchinese.GBK.NewDecoder()
+	encodeValues := make([]string, 0, len(record))
+	for _, value := range record {
+		var encodeValue string
+		coding := utils.GetStringEncoding(value)
+		switch coding {
+		case utils.UTF8:
+			encodeValue = value
+		case utils.GBK:
+			encodingString, _ := gbkDecoder.Bytes([]byte(value))
+			encodeValue = string(encodingString)
+		default:
+			if encodingString, decodeError := gbkDecoder.Bytes([]byte(value)); decodeError == nil {
+				encodeValue = string(encodingString)
+			} else {
+				return nil, errors.New("file encoding is not supported")
+			}
+		}
+		encodeValue = strings.TrimSpace(encodeValue)
+		// remove special characters, such as &#65279;
+		encodeValue = strings.ReplaceAll(encodeValue, "\uFEFF", "")
+		encodeValue = strings.TrimSpace(encodeValue)
+		encodeValues = append(encodeValues, encodeValue)
+	}
+	return encodeValues, nil
+}
+
 // Read read csv file with options
 func Read(filePath string, options Options) (gc *GeoCSV, err error) {
 	gc = NewGeoCSV()
@@ -111,35 +145,124 @@ func Read(filePath string, options Options) (gc *GeoCSV, err error) {
 // ToGeoJSON export geojson
 func (gc *GeoCSV) ToGeoJSON() (features *geojson.FeatureCollection) {
 	features = geojson.NewFeatureCollection()
+	types := gc.options.columnTypes(gc.headers, gc.rows)
 	for _, row := range gc.rows {
-		var (
-			lng      = defaultCoordValue
-			lat      = defaultCoordValue
-			geometry *geojson.Geometry
-		)
-		properties := geojson.Properties{}
+		if feature := gc.options.feature(gc.headers, types, row); feature != nil {
+			features.Features = append(features.Features, feature)
+		}
+	}
+	return
+}
 
-		for j, cell := range row {
-			fieldName := gc.headers[j]
-			if len(gc.options.WKTField) > 0 && fieldName == gc.options.WKTField {
-				if wktGeometry, wktError := wkt.UnmarshalString(cell); wktError == nil {
-					geometry = geojson.NewGeometry(wktGeometry)
+// feature returns the feature of a row, or nil if it has no geometry.
+// types are the types of the columns, nil if the options do not infer them.
+func (options Options) feature(headers []string, types []int, row []string) *geojson.Feature {
+	var (
+		lng      = defaultCoordValue
+		lat      = defaultCoordValue
+		geometry *geojson.Geometry
+	)
+	properties := geojson.Properties{}
+
+	for j, cell := range row {
+		if j >= len(headers) {
+			break
+		}
+		fieldName := headers[j]
+		if len(options.WKTField) > 0 && fieldName == options.WKTField {
+			if wktGeometry, wktError := wkt.UnmarshalString(cell); wktError == nil {
+				geometry = geojson.NewGeometry(wktGeometry)
+			}
+		} else if len(options.WKBField) > 0 && fieldName == options.WKBField {
+			if data, hexError := hex.DecodeString(cell); hexError == nil {
+				if wkbGeometry, wkbError := wkb.Unmarshal(data); wkbError == nil {
+					geometry = geojson.NewGeometry(wkbGeometry)
 				}
-			} else if len(gc.options.XField) > 0 && fieldName == gc.options.XField {
-				lng, _ = strconv.ParseFloat(cell, 64)
-			} else if len(gc.options.YField) > 0 && fieldName == gc.options.YField {
-				lat, _ = strconv.ParseFloat(cell, 64)
 			}
-			properties[fieldName] = cell
+		} else if len(options.XField) > 0 && fieldName == options.XField {
+			lng, _ = strconv.ParseFloat(cell, 64)
+		} else if len(options.YField) > 0 && fieldName == options.YField {
+			lat, _ = strconv.ParseFloat(cell, 64)
 		}
-		if geometry == nil && lng != defaultCoordValue && lat != defaultCoordValue {
-			geometry = geojson.NewGeometry(space.Point{lng, lat})
+		if options.keeps(fieldName) {
+			if types == nil {
+				properties[fieldName] = cell
+			} else {
+				properties[fieldName] = value(cell, types[j])
+			}
 		}
-		if geometry != nil {
-			feature := geojson.NewFeature(*geometry)
-			feature.Properties = properties
-			features.Features = append(features.Features, feature)
+	}
+	if geometry == nil && lng != defaultCoordValue && lat != defaultCoordValue {
+		geometry = geojson.NewGeometry(space.Point{lng, lat})
+	}
+	if geometry == nil {
+		return nil
+	}
+	feature := geojson.NewFeature(*geometry)
+	feature.Properties = properties
+	return feature
+}
+
+// keeps returns true if the field is kept in the properties.
+func (options Options) keeps(fieldName string) bool {
+	return len(options.Fields) == 0 || contains(options.Fields, fieldName)
+}
+
+// columnTypes returns the types of the columns of the rows, or nil if the options do not infer them.
+func (options Options) columnTypes(headers []string, rows [][]string) []int {
+	if !options.InferTypes {
+		return nil
+	}
+	types := make([]int, len(headers))
+	for _, row := range rows {
+		for j, cell := range row {
+			if j >= len(types) {
+				break
+			}
+			if typ := cellType(cell); types[j] == columnEmpty {
+				types[j] = typ
+			} else if typ != columnEmpty && typ != types[j] {
+				types[j] = columnString
+			}
 		}
 	}
-	return
+	return types
+}
+
+// cellType returns the type of the value of a cell.
+func cellType(cell string) int {
+	if cell == "" {
+		return columnEmpty
+	}
+	if strings.EqualFold(cell, "true") || strings.EqualFold(cell, "false") {
+		return columnBool
+	}
+	// numbers with leading zeros are codes, 0.5 and 0 are not
+	digits := strings.TrimLeft(cell, "+-")
+	if len(digits) > 1 && digits[0] == '0' && digits[1] != '.' {
+		return columnString
+	}
+	// NaN and infinities are left as strings, since GeoJSON has no number for them.
+	if v, err := strconv.ParseFloat(cell, 64); err == nil && !math.IsNaN(v) && !math.IsInf(v, 0) {
+		return columnNumber
+	}
+	return columnString
+}
+
+// value returns the value of a cell in a column of the type, and the cell itself if it does not have the type.
+func value(cell string, typ int) interface{} {
+	if cell == "" {
+		return nil
+	}
+	if cellType(cell) != typ {
+		return cell
+	}
+	switch typ {
+	case columnBool:
+		return strings.EqualFold(cell, "true")
+	case columnNumber:
+		v, _ := strconv.ParseFloat(cell, 64)
+		return v
+	}
+	return cell
 }
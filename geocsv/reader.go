@@ -0,0 +1,90 @@
+package geocsv
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/spatial-go/geoos/geojson"
+)
+
+// Reader reads the features of a GeoCSV row by row, without loading the whole input in memory.
+type Reader struct {
+	reader  *csv.Reader
+	headers []string
+	options Options
+
+	// types are the types of the columns inferred from the rows read ahead in pending.
+	types   []int
+	pending [][]string
+}
+
+// NewReader returns a reader of the features of the GeoCSV read from r.
+func NewReader(r io.Reader, options Options) *Reader {
+	return &Reader{reader: csv.NewReader(r), options: options}
+}
+
+// Headers returns the headers of the GeoCSV, once the first feature has been read.
+func (r *Reader) Headers() []string {
+	return r.headers
+}
+
+// Read returns the feature of the next row with a geometry, or io.EOF when all the rows have been read.
+// With Options.InferTypes, the first call reads ahead the rows the types of the columns are inferred from.
+func (r *Reader) Read() (*geojson.Feature, error) {
+	if r.headers == nil {
+		values, err := r.readRow()
+		if err != nil {
+			return nil, err
+		}
+		r.headers = values
+		if err := r.inferTypes(); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		var values []string
+		if len(r.pending) > 0 {
+			values, r.pending = r.pending[0], r.pending[1:]
+		} else {
+			var err error
+			if values, err = r.readRow(); err != nil {
+				return nil, err
+			}
+		}
+		if feature := r.options.feature(r.headers, r.types, values); feature != nil {
+			return feature, nil
+		}
+	}
+}
+
+// readRow reads and decodes the next row.
+func (r *Reader) readRow() ([]string, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	return decodeRecord(record)
+}
+
+// inferTypes reads ahead the rows the types of the columns are inferred from, if the options infer them.
+func (r *Reader) inferTypes() error {
+	if !r.options.InferTypes {
+		return nil
+	}
+	n := r.options.InferRows
+	if n <= 0 {
+		n = DefaultInferRows
+	}
+	for len(r.pending) < n {
+		values, err := r.readRow()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		r.pending = append(r.pending, values)
+	}
+	r.types = r.options.columnTypes(r.headers, r.pending)
+	return nil
+}
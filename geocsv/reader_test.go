@@ -0,0 +1,126 @@
+package geocsv
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestReader_Read(t *testing.T) {
+	data := "id,name,area,capital,wkb\n" +
+		"1,北京,16410.54,TRUE,0101000000000000000000f03f0000000000000040\n" +
+		"2,上海,,false,\n" +
+		"3,广州,7434.4,false,01010000000000000000000840000000000000f03f\n"
+	reader := NewReader(strings.NewReader(data), Options{WKBField: "wkb", Fields: []string{"name", "area", "capital"}, InferTypes: true})
+
+	var points []space.Geometry
+	for {
+		feature, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		if len(feature.Properties) != 3 {
+			t.Errorf("Read() properties got = %v", feature.Properties)
+		}
+		points = append(points, feature.Geometry.Coordinates)
+		if len(points) == 1 && (feature.Properties["name"] != "北京" || feature.Properties["area"] != 16410.54 ||
+			feature.Properties["capital"] != true) {
+			t.Errorf("Read() properties got = %v", feature.Properties)
+		}
+	}
+	if len(reader.Headers()) != 5 {
+		t.Errorf("Headers() got = %v", reader.Headers())
+	}
+	// the row without a geometry is skipped.
+	if len(points) != 2 || !points[0].Equal(space.Point{1, 2}) || !points[1].Equal(space.Point{3, 1}) {
+		t.Errorf("Read() geometries got = %v", points)
+	}
+}
+
+func TestOptions_columnTypes(t *testing.T) {
+	headers := []string{"code", "zip", "area", "capital", "mixed", "empty"}
+	rows := [][]string{
+		{"001", "0012", "1.5", "True", "1", ""},
+		{"A01", "0013", "", "false", "x", ""},
+		{"002", "0014", "-1.5e3", "", "NaN", ""},
+	}
+	types := Options{InferTypes: true}.columnTypes(headers, rows)
+	want := []int{columnString, columnString, columnNumber, columnBool, columnString, columnEmpty}
+	for j := range want {
+		if types[j] != want[j] {
+			t.Errorf("columnTypes()[%v] got = %v, want %v", headers[j], types[j], want[j])
+		}
+	}
+	if got := (Options{}).columnTypes(headers, rows); got != nil {
+		t.Errorf("columnTypes() without InferTypes got = %v", got)
+	}
+
+	tests := []struct {
+		cell string
+		typ  int
+		want interface{}
+	}{
+		{cell: "", typ: columnNumber, want: nil},
+		{cell: "True", typ: columnBool, want: true},
+		{cell: "false", typ: columnBool, want: false},
+		{cell: "-1.5e3", typ: columnNumber, want: -1500.0},
+		{cell: "0.5", typ: columnNumber, want: 0.5},
+		{cell: "001", typ: columnString, want: "001"},
+		{cell: "1", typ: columnString, want: "1"},
+		{cell: "12a", typ: columnNumber, want: "12a"},
+	}
+	for _, tt := range tests {
+		if got := value(tt.cell, tt.typ); got != tt.want {
+			t.Errorf("value(%q) got = %v, want %v", tt.cell, got, tt.want)
+		}
+	}
+}
+
+func TestReader_InferRows(t *testing.T) {
+	data := "code,count,x,y\n" +
+		"1,1,0,0\n" +
+		"2,2,0,0\n" +
+		"A3,x,0,0\n"
+	reader := NewReader(strings.NewReader(data), Options{XField: "x", YField: "y", Fields: []string{"code", "count"},
+		InferTypes: true, InferRows: 2})
+	var got []interface{}
+	for {
+		feature, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		got = append(got, feature.Properties["code"], feature.Properties["count"])
+	}
+	// the third row is not read ahead, so its cells are kept as strings
+	want := []interface{}{1.0, 1.0, 2.0, 2.0, "A3", "x"}
+	if len(got) != len(want) {
+		t.Fatalf("Read() properties got = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Read() properties got = %v, want %v", got, want)
+		}
+	}
+
+	reader = NewReader(strings.NewReader(data), Options{XField: "x", YField: "y", InferTypes: true})
+	for {
+		feature, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		if _, ok := feature.Properties["code"].(string); !ok {
+			t.Errorf("Read() code got = %#v, want a string", feature.Properties["code"])
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package geocsv
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/spatial-go/geoos/encoding/wkb"
+	"github.com/spatial-go/geoos/encoding/wkt"
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+// ErrNotPoint is returned when a geometry written to the X and Y fields is not a point.
+var ErrNotPoint = errors.New("geocsv: X and Y fields need point geometries")
+
+// Writer writes features as the rows of a GeoCSV in UTF8.
+// The geometry is written to each field of the options it is given: WKTField, WKBField, or XField and YField.
+type Writer struct {
+	writer  *csv.Writer
+	options Options
+	fields  []string
+}
+
+// NewWriter returns a writer of the features to w.
+func NewWriter(w io.Writer, options Options) *Writer {
+	return &Writer{writer: csv.NewWriter(w), options: options}
+}
+
+// Write writes the feature as a row, after the headers for the first feature.
+// The properties written are the Fields of the options, or else the keys of the properties of the first
+// feature in order. Properties named like a geometry field are not written.
+func (w *Writer) Write(feature *geojson.Feature) error {
+	if w.fields == nil {
+		if err := w.writeHeaders(feature); err != nil {
+			return err
+		}
+	}
+
+	row, err := w.geometryCells(feature.Geometry)
+	if err != nil {
+		return err
+	}
+	for _, field := range w.fields {
+		cell, err := formatValue(feature.Properties[field])
+		if err != nil {
+			return err
+		}
+		row = append(row, cell)
+	}
+	return w.writer.Write(row)
+}
+
+// Flush writes the buffered rows to the underlying writer, and the headers if no feature has been written.
+func (w *Writer) Flush() error {
+	if w.fields == nil {
+		if err := w.writeHeaders(nil); err != nil {
+			return err
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// writeHeaders writes the headers of the geometry fields and of the properties of the first feature.
+func (w *Writer) writeHeaders(first *geojson.Feature) error {
+	fields := w.options.Fields
+	if len(fields) == 0 && first != nil {
+		fields = sortedKeys([]*geojson.Feature{first})
+	}
+	headers := w.geometryFields()
+	w.fields = make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !contains(headers, field) {
+			w.fields = append(w.fields, field)
+		}
+	}
+	return w.writer.Write(append(headers, w.fields...))
+}
+
+// geometryFields returns the fields of the geometry.
+func (w *Writer) geometryFields() []string {
+	var fields []string
+	if len(w.options.WKTField) > 0 {
+		fields = append(fields, w.options.WKTField)
+	}
+	if len(w.options.WKBField) > 0 {
+		fields = append(fields, w.options.WKBField)
+	}
+	if len(w.options.XField) > 0 && len(w.options.YField) > 0 {
+		fields = append(fields, w.options.XField, w.options.YField)
+	}
+	return fields
+}
+
+// geometryCells returns the cells of the geometry fields. A null geometry is written as empty cells.
+func (w *Writer) geometryCells(geometry geojson.Geometry) ([]string, error) {
+	var geom space.Geometry
+	if geometry.Coordinates != nil || len(geometry.Geometries) > 0 {
+		geom = geometry.Geometry()
+	}
+
+	var cells []string
+	if len(w.options.WKTField) > 0 {
+		cell := ""
+		if geom != nil {
+			cell = wkt.MarshalString(geom)
+		}
+		cells = append(cells, cell)
+	}
+	if len(w.options.WKBField) > 0 {
+		cell := ""
+		if geom != nil {
+			data, err := wkb.Marshal(geom)
+			if err != nil {
+				return nil, err
+			}
+			cell = hex.EncodeToString(data)
+		}
+		cells = append(cells, cell)
+	}
+	if len(w.options.XField) > 0 && len(w.options.YField) > 0 {
+		x, y := "", ""
+		if geom != nil {
+			point, ok := geom.(space.Point)
+			if !ok || point.IsEmpty() {
+				return nil, ErrNotPoint
+			}
+			x, y = strconv.FormatFloat(point.X(), 'f', -1, 64), strconv.FormatFloat(point.Y(), 'f', -1, 64)
+		}
+		cells = append(cells, x, y)
+	}
+	return cells, nil
+}
+
+// formatValue returns the cell of a property value. Values other than strings, numbers and booleans
+// are written as JSON.
+func formatValue(v interface{}) (string, error) {
+	switch x := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return x, nil
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// Write writes the feature collection as the GeoCSV file of filePath.
+// Without Fields in the options, the properties written are all the keys of the properties of the features.
+func Write(filePath string, fc *geojson.FeatureCollection, options Options) (err error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	if len(options.Fields) == 0 {
+		options.Fields = sortedKeys(fc.Features)
+	}
+	writer := NewWriter(file, options)
+	for _, feature := range fc.Features {
+		if err = writer.Write(feature); err != nil {
+			return
+		}
+	}
+	return writer.Flush()
+}
+
+// sortedKeys returns the keys of the properties of the features in order.
+func sortedKeys(features []*geojson.Feature) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, feature := range features {
+		for key := range feature.Properties {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package geocsv
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestWriter_Write(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	writer := NewWriter(buf, Options{XField: "x", YField: "y", WKBField: "wkb"})
+	feature := geojson.NewFeature(*geojson.NewGeometry(space.Point{1, 2}))
+	feature.Properties = geojson.Properties{"name": "a,b", "x": 5.0, "n": 1.5, "ok": true, "tags": []interface{}{"c"}}
+	if err := writer.Write(feature); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := writer.Write(geojson.NewFeature(geojson.Geometry{})); err != nil {
+		t.Fatalf("Write() of a null geometry error = %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	want := "wkb,x,y,n,name,ok,tags\n" +
+		"0101000000000000000000f03f0000000000000040,1,2,1.5,\"a,b\",true,\"[\"\"c\"\"]\"\n" +
+		",,,,,,\n"
+	if buf.String() != want {
+		t.Errorf("Write() got = %q, want %q", buf.String(), want)
+	}
+
+	line := geojson.NewFeature(*geojson.NewGeometry(space.LineString{{1, 2}, {3, 4}}))
+	if err := NewWriter(buf, Options{XField: "x", YField: "y"}).Write(line); err != ErrNotPoint {
+		t.Errorf("Write() of a line error = %v, want %v", err, ErrNotPoint)
+	}
+
+	buf.Reset()
+	writer = NewWriter(buf, Options{WKTField: "wkt", Fields: []string{"id"}})
+	if err := writer.Flush(); err != nil || buf.String() != "wkt,id\n" {
+		t.Errorf("Flush() without features got = %q, %v", buf.String(), err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	fc := geojson.NewFeatureCollection()
+	for i, geom := range []space.Geometry{space.Polygon{{{0, 0}, {0, 1}, {1, 1}, {0, 0}}}, space.Point{3, 50}} {
+		feature := geojson.NewFeature(*geojson.NewGeometry(geom))
+		feature.Properties = geojson.Properties{"id": float64(i)}
+		if i == 1 {
+			feature.Properties["name"] = "巴黎"
+		}
+		fc.Append(feature)
+	}
+	path := filepath.Join(t.TempDir(), "test.csv")
+	if err := Write(path, fc, Options{WKTField: "wkt"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	gc, err := Read(path, Options{WKTField: "wkt", InferTypes: true})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	got := gc.ToGeoJSON().Features
+	if len(got) != 2 || len(gc.headers) != 3 {
+		t.Fatalf("Read() got = %v, headers %v", got, gc.headers)
+	}
+	for i, feature := range got {
+		if !feature.Geometry.Coordinates.Equal(fc.Features[i].Geometry.Coordinates) {
+			t.Errorf("feature %d got = %v", i, feature.Geometry.Coordinates)
+		}
+		if feature.Properties["id"] != float64(i) {
+			t.Errorf("feature %d properties got = %v", i, feature.Properties)
+		}
+	}
+	if got[0].Properties["name"] != nil || got[1].Properties["name"] != "巴黎" {
+		t.Errorf("Read() names got = %v, %v", got[0].Properties, got[1].Properties)
+	}
+}
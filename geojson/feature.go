@@ -32,7 +32,10 @@ func (f Feature) MarshalJSON() ([]byte, error) {
 		Type:       "Feature",
 		Properties: f.Properties,
 		BBox:       f.BBox,
-		Geometry:   NewGeometry(f.Geometry.Geometry()),
+	}
+	if !f.Geometry.isNull() {
+		jf.Geometry = NewGeometry(f.Geometry.Geometry())
+		jf.Geometry.BBox = f.Geometry.BBox
 	}
 
 	if len(jf.Properties) == 0 {
@@ -2,6 +2,8 @@
 Package geojson is a library for encoding and decoding GeoJSON into Go structs using
 the geometries. Supports both the json.Marshaler and json.Unmarshaler
 interfaces as well as helper functions such as `UnmarshalFeatureCollection` and `UnmarshalFeature`.
+Large feature collections and GeoJSON text sequences can be streamed feature by feature
+with a `Decoder` and an `Encoder`.
 */
 package geojson
 
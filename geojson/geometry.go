@@ -11,8 +11,10 @@ import (
 var ErrInvalidGeometry = errors.New("geojson: invalid geometry")
 
 // A Geometry matches the structure of a GeoJSON Geometry.
+// A GeometryCollection has its members in Geometries, which may be collections too.
 type Geometry struct {
 	Type        string         `json:"type"`
+	BBox        BBox           `json:"bbox,omitempty"`
 	Coordinates space.Geometry `json:"coordinates,omitempty"`
 	Geometries  []*Geometry    `json:"geometries,omitempty"`
 }
@@ -25,6 +27,13 @@ func NewGeometry(g space.Geometry) *Geometry {
 	switch g := g.(type) {
 	case space.Ring:
 		jg.Coordinates = space.Polygon{g}
+	case space.Point:
+		if g.IsEmpty() {
+			// the NaN values of an empty point with a Z or a M have no JSON encoding
+			jg.Coordinates = space.Point{}
+		} else {
+			jg.Coordinates = g
+		}
 	case space.Bound:
 		if g.IsEmpty() {
 			jg.Coordinates = space.Polygon{{{0, 0}, {0, 0}, {0, 0}, {0, 0}}}
@@ -32,6 +41,7 @@ func NewGeometry(g space.Geometry) *Geometry {
 			jg.Coordinates = g.ToPolygon()
 		}
 	case space.Collection:
+		jg.Geometries = make([]*Geometry, 0, len(g))
 		for _, c := range g {
 			jg.Geometries = append(jg.Geometries, NewGeometry(c))
 		}
@@ -60,13 +70,23 @@ func (g Geometry) Geometry() space.Geometry {
 	return c
 }
 
+// isNull returns true if the geometry has neither coordinates nor geometries, and is not an empty collection.
+func (g Geometry) isNull() bool {
+	return g.Coordinates == nil && g.Geometries == nil && g.Type != space.TypeCollection
+}
+
 // MarshalJSON will marshal the geometry into the correct json structure.
+// An empty GeometryCollection is written with an empty geometries member.
 func (g Geometry) MarshalJSON() ([]byte, error) {
-	if g.Coordinates == nil && len(g.Geometries) == 0 {
+	if g.isNull() {
 		return []byte(`null`), nil
 	}
 
-	ng := &jsonGeometryMarshall{}
+	if len(g.Geometries) > 0 || g.Coordinates == nil {
+		return marshalCollection(g.BBox, g.Geometries)
+	}
+
+	ng := &jsonGeometryMarshall{BBox: g.BBox}
 	switch g := g.Coordinates.(type) {
 	case space.Ring:
 		ng.Coordinates = space.Polygon{g}
@@ -77,24 +97,25 @@ func (g Geometry) MarshalJSON() ([]byte, error) {
 			ng.Coordinates = g.ToPolygon()
 		}
 	case space.Collection:
-		ng.Geometries = make([]*Geometry, 0, len(g))
-		for _, c := range g {
-			ng.Geometries = append(ng.Geometries, NewGeometry(c))
-		}
-		ng.Type = g.GeoJSONType()
+		return marshalCollection(ng.BBox, NewGeometry(g).Geometries)
 	default:
 		ng.Coordinates = g
+		// positions have a Z as third value, so M without Z cannot be written.
+		if space.CoordLayout(g) == space.XYM {
+			ng.Coordinates = space.ForceLayout(g, space.XY)
+		}
 	}
+	ng.Type = ng.Coordinates.GeoJSONType()
+	return json.Marshal(ng)
+}
 
-	if ng.Coordinates != nil {
-		ng.Type = ng.Coordinates.GeoJSONType()
-	}
-
-	if len(g.Geometries) > 0 {
-		ng.Geometries = g.Geometries
-		ng.Type = space.Collection{}.GeoJSONType()
+// marshalCollection marshals a GeometryCollection, with its geometries member even if it is empty.
+func marshalCollection(bbox BBox, geometries []*Geometry) ([]byte, error) {
+	jc := &jsonCollectionMarshall{Type: space.TypeCollection, BBox: bbox, Geometries: geometries}
+	if jc.Geometries == nil {
+		jc.Geometries = []*Geometry{}
 	}
-	return json.Marshal(ng)
+	return json.Marshal(jc)
 }
 
 // UnmarshalGeometry decodes the data into a GeoJSON feature.
@@ -143,11 +164,21 @@ func (g *Geometry) UnmarshalJSON(data []byte) error {
 		err = json.Unmarshal(jg.Coordinates, &mp)
 		g.Coordinates = mp
 	case "GeometryCollection":
+		if jg.Geometries == nil {
+			return ErrInvalidGeometry
+		}
+		for _, member := range jg.Geometries {
+			if member == nil {
+				return ErrInvalidGeometry
+			}
+		}
+		g.Coordinates = nil
 		g.Geometries = jg.Geometries
 	default:
 		return ErrInvalidGeometry
 	}
 
+	g.BBox = jg.BBox
 	g.Type = g.Geometry().GeoJSONType()
 
 	return nil
@@ -333,14 +364,22 @@ func (mp *MultiPolygon) UnmarshalJSON(data []byte) error {
 
 type jsonGeometry struct {
 	Type        string           `json:"type"`
+	BBox        BBox             `json:"bbox"`
 	Coordinates nocopyRawMessage `json:"coordinates"`
 	Geometries  []*Geometry      `json:"geometries,omitempty"`
 }
 
 type jsonGeometryMarshall struct {
 	Type        string         `json:"type"`
+	BBox        BBox           `json:"bbox,omitempty"`
 	Coordinates space.Geometry `json:"coordinates,omitempty"`
-	Geometries  []*Geometry    `json:"geometries,omitempty"`
+}
+
+// jsonCollectionMarshall always writes the geometries member, which is required even when empty.
+type jsonCollectionMarshall struct {
+	Type       string      `json:"type"`
+	BBox       BBox        `json:"bbox,omitempty"`
+	Geometries []*Geometry `json:"geometries"`
 }
 
 type nocopyRawMessage []byte
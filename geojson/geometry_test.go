@@ -189,6 +189,105 @@ func TestGeometryUnmarshal(t *testing.T) {
 	}
 }
 
+func TestGeometryCollection(t *testing.T) {
+	collection := space.Collection{
+		space.Point{1, 2},
+		space.Collection{space.LineString{{3, 4}, {5, 6}}, space.Collection{}},
+		space.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}},
+	}
+	data := `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]},` +
+		`{"type":"GeometryCollection","geometries":[{"type":"LineString","coordinates":[[3,4],[5,6]]},` +
+		`{"type":"GeometryCollection","geometries":[]}]},{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}]}`
+
+	out, err := json.Marshal(NewGeometry(collection))
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+	if string(out) != data {
+		t.Errorf("marshal got = %s, want %s", out, data)
+	}
+
+	g, err := UnmarshalGeometry([]byte(data))
+	if err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if g.Type != "GeometryCollection" || !g.Geometry().Equal(collection) {
+		t.Errorf("unmarshal got = %v, want %v", g.Geometry(), collection)
+	}
+	if out, _ := json.Marshal(g); string(out) != data {
+		t.Errorf("round trip got = %s, want %s", out, data)
+	}
+
+	for _, geom := range []space.Geometry{space.Collection{}, nil} {
+		g := &Geometry{Type: "GeometryCollection"}
+		if geom != nil {
+			g = NewGeometry(geom)
+		}
+		out, err := json.Marshal(g)
+		if err != nil || string(out) != `{"type":"GeometryCollection","geometries":[]}` {
+			t.Errorf("marshal of an empty collection got = %s, %v", out, err)
+		}
+	}
+
+	bbox := NewBBox(collection.Bound())
+	if !reflect.DeepEqual(bbox, BBox{0, 0, 5, 6}) {
+		t.Errorf("bbox got = %v", bbox)
+	}
+	bbox = NewBBox(space.Collection{space.Collection{}, space.Point{}, space.Point{3, 4}, space.LineString{{5, 6}, {7, 8}}}.Bound())
+	if !reflect.DeepEqual(bbox, BBox{3, 4, 7, 8}) {
+		t.Errorf("bbox with empty members got = %v", bbox)
+	}
+
+	withBBox := `{"type":"GeometryCollection","bbox":[0,0,5,6],"geometries":[{"type":"Point","bbox":[1,2,1,2],"coordinates":[1,2]}]}`
+	g, err = UnmarshalGeometry([]byte(withBBox))
+	if err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if !reflect.DeepEqual(g.BBox, BBox{0, 0, 5, 6}) || !reflect.DeepEqual(g.Geometries[0].BBox, BBox{1, 2, 1, 2}) {
+		t.Errorf("unmarshal bbox got = %v, %v", g.BBox, g.Geometries[0].BBox)
+	}
+	if out, _ := json.Marshal(g); string(out) != withBBox {
+		t.Errorf("round trip got = %s, want %s", out, withBBox)
+	}
+
+	for _, data := range []string{
+		`{"type":"GeometryCollection"}`,
+		`{"type":"GeometryCollection","geometries":[null]}`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Curve"}]}`,
+	} {
+		if _, err := UnmarshalGeometry([]byte(data)); err == nil {
+			t.Errorf("unmarshal of %s got no error", data)
+		}
+	}
+}
+
+func TestGeometry_ZM(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"Point","coordinates":[1,2,3]}`,
+		`{"type":"LineString","coordinates":[[1,2,3,4],[5,6,7,8]]}`,
+	} {
+		g, err := UnmarshalGeometry([]byte(data))
+		if err != nil {
+			t.Fatalf("unmarshal error: %v", err)
+		}
+		out, err := json.Marshal(g)
+		if err != nil {
+			t.Fatalf("marshal error: %v", err)
+		}
+		if string(out) != data {
+			t.Errorf("round trip got = %s, want %s", out, data)
+		}
+	}
+
+	out, err := json.Marshal(NewGeometry(space.NewPointM(1, 2, 3)))
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+	if string(out) != `{"type":"Point","coordinates":[1,2]}` {
+		t.Errorf("marshal of a point m got = %s", out)
+	}
+}
+
 func TestHelperTypes(t *testing.T) {
 	// This test makes sure the marshal-unmarshal loop does the same thing.
 	// The code and types here are complicated to avoid duplicate code.
@@ -0,0 +1,236 @@
+package geojson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// recordSeparator starts every GeoJSON text of a GeoJSON text sequence, see RFC 8142.
+const recordSeparator = 0x1e
+
+// collectionStart starts the feature collection written by an Encoder.
+const collectionStart = `{"type":"FeatureCollection","features":[`
+
+// A Decoder reads the features of a GeoJSON feature collection or of a GeoJSON text sequence
+// one at a time, without loading the whole input in memory.
+type Decoder struct {
+	dec *json.Decoder
+	seq bool
+
+	started, typed, inFeatures bool
+
+	bbox BBox
+	err  error
+}
+
+// NewDecoder returns a decoder of the features of the feature collection read from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: json.NewDecoder(r)}
+}
+
+// NewSeqDecoder returns a decoder of the features of the GeoJSON text sequence read from r.
+// The features may be separated by the record separators of RFC 8142, or only by new lines.
+func NewSeqDecoder(r io.Reader) *Decoder {
+	return &Decoder{dec: json.NewDecoder(&separatorReader{r: r}), seq: true}
+}
+
+// Decode returns the next feature of the input, or io.EOF when all the features have been read.
+func (d *Decoder) Decode() (*Feature, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	f, err := d.next()
+	if err != nil {
+		d.err = err
+		return nil, err
+	}
+	return f, nil
+}
+
+// BBox returns the bbox of the feature collection, if it has been read before the current feature.
+func (d *Decoder) BBox() BBox {
+	return d.bbox
+}
+
+func (d *Decoder) next() (*Feature, error) {
+	if !d.seq {
+		if !d.inFeatures {
+			if err := d.seekFeatures(); err != nil {
+				return nil, err
+			}
+		}
+		if !d.dec.More() {
+			// closing bracket of the features, then the members following them.
+			if _, err := d.dec.Token(); err != nil {
+				return nil, err
+			}
+			d.inFeatures = false
+			return d.next()
+		}
+	}
+
+	f := &Feature{}
+	if err := d.dec.Decode(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// seekFeatures reads the members of the feature collection up to its features,
+// and returns io.EOF if it reaches the end of the collection.
+func (d *Decoder) seekFeatures() error {
+	if !d.started {
+		if err := d.expectDelim('{'); err != nil {
+			return err
+		}
+		d.started = true
+	}
+
+	for d.dec.More() {
+		t, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case "type":
+			var typ string
+			if err := d.dec.Decode(&typ); err != nil {
+				return err
+			}
+			if typ != featureCollection {
+				return fmt.Errorf("geojson: not a feature collection: type=%s", typ)
+			}
+			d.typed = true
+		case "bbox":
+			if err := d.dec.Decode(&d.bbox); err != nil {
+				return err
+			}
+		case "features":
+			t, err := d.dec.Token()
+			if err != nil {
+				return err
+			}
+			if t == nil {
+				continue
+			}
+			if t != json.Delim('[') {
+				return fmt.Errorf("geojson: invalid features: %v", t)
+			}
+			d.inFeatures = true
+			return nil
+		default:
+			var member json.RawMessage
+			if err := d.dec.Decode(&member); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := d.expectDelim('}'); err != nil {
+		return err
+	}
+	if !d.typed {
+		return errors.New("geojson: not a feature collection: missing type")
+	}
+	return io.EOF
+}
+
+// expectDelim reads the next token, which must be delim.
+func (d *Decoder) expectDelim(delim json.Delim) error {
+	t, err := d.dec.Token()
+	if err != nil {
+		return err
+	}
+	if t != delim {
+		return fmt.Errorf("geojson: unexpected token %v, expected %v", t, delim)
+	}
+	return nil
+}
+
+// separatorReader replaces the record separators of a GeoJSON text sequence by spaces,
+// so that its texts can be read by a json.Decoder. JSON strings cannot contain a raw separator.
+type separatorReader struct {
+	r io.Reader
+}
+
+func (s *separatorReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	for i := 0; i < n; i++ {
+		if p[i] == recordSeparator {
+			p[i] = ' '
+		}
+	}
+	return n, err
+}
+
+// An Encoder writes features one at a time, as a feature collection or as a GeoJSON text sequence.
+type Encoder struct {
+	w   io.Writer
+	seq bool
+
+	count  int
+	closed bool
+}
+
+// NewEncoder returns an encoder writing the features to w as a feature collection.
+// Close must be called to end the collection.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// NewSeqEncoder returns an encoder writing the features to w as a GeoJSON text sequence of RFC 8142,
+// each feature being preceded by a record separator and followed by a new line.
+func NewSeqEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w, seq: true}
+}
+
+// Encode writes the feature.
+func (e *Encoder) Encode(f *Feature) error {
+	if e.closed {
+		return errors.New("geojson: encoder closed")
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)+len(collectionStart)))
+	switch {
+	case e.seq:
+		buf.WriteByte(recordSeparator)
+	case e.count == 0:
+		buf.WriteString(collectionStart)
+	default:
+		buf.WriteByte(',')
+	}
+	buf.Write(data)
+	if e.seq {
+		buf.WriteByte('\n')
+	}
+	if _, err := e.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	e.count++
+	return nil
+}
+
+// Close ends the feature collection. It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	if e.seq {
+		return nil
+	}
+
+	end := `]}`
+	if e.count == 0 {
+		end = collectionStart + end
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
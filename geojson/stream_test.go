@@ -0,0 +1,153 @@
+package geojson
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestDecoder(t *testing.T) {
+	rawJSON := `
+	  { "name": "parcels",
+	    "crs": {"type": "name", "properties": {"name": "EPSG:4326"}},
+	    "bbox": [100, 0, 105, 1],
+	    "features": [
+	      { "type": "Feature",
+	        "geometry": {"type": "Point", "coordinates": [102.0, 0.5]},
+	        "properties": {"prop0": "value0"}
+	      },
+	      { "type": "Feature",
+	        "geometry": {"type": "LineString", "coordinates": [[102.0, 0.0], [103.0, 1.0]]},
+	        "properties": {"prop0": "value1"}
+	      }
+	    ],
+	    "type": "FeatureCollection"
+	  }`
+
+	d := NewDecoder(strings.NewReader(rawJSON))
+	var features []*Feature
+	for {
+		f, err := d.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		features = append(features, f)
+	}
+	if len(features) != 2 {
+		t.Fatalf("Decode() got %d features, want 2", len(features))
+	}
+	if !features[1].Geometry.Geometry().Equal(space.LineString{{102, 0}, {103, 1}}) || features[1].Properties["prop0"] != "value1" {
+		t.Errorf("Decode() got = %v", features[1])
+	}
+	if len(d.BBox()) != 4 {
+		t.Errorf("BBox() got = %v", d.BBox())
+	}
+	if _, err := d.Decode(); err != io.EOF {
+		t.Errorf("Decode() after the end error = %v, want io.EOF", err)
+	}
+
+	for _, data := range []string{
+		`{"type": "Feature", "features": []}`,
+		`{"features": []}`,
+		`[]`,
+		`{"type": "FeatureCollection", "features": [{"type": "Point"}]}`,
+	} {
+		d := NewDecoder(strings.NewReader(data))
+		var err error
+		for err == nil {
+			_, err = d.Decode()
+		}
+		if err == io.EOF {
+			t.Errorf("Decode() of %s got no error", data)
+		}
+	}
+}
+
+func TestSeqDecoder(t *testing.T) {
+	data := "\x1e{\"type\":\"Feature\",\"geometry\":{\"type\":\"Point\",\"coordinates\":[1,2]},\"properties\":{\"a\":1}}\n" +
+		"\x1e{\"type\":\"Feature\",\"geometry\":{\"type\":\"Point\",\"coordinates\":[3,4]},\"properties\":null}\n" +
+		"{\"type\":\"Feature\",\"geometry\":{\"type\":\"Point\",\"coordinates\":[5,6]},\"properties\":null}\n"
+
+	d := NewSeqDecoder(strings.NewReader(data))
+	var points []space.Geometry
+	for {
+		f, err := d.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		points = append(points, f.Geometry.Geometry())
+	}
+	if len(points) != 3 || !points[2].Equal(space.Point{5, 6}) {
+		t.Errorf("Decode() got = %v", points)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	e := NewEncoder(buf)
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if buf.String() != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("empty collection got = %s", buf.String())
+	}
+
+	buf.Reset()
+	e = NewEncoder(buf)
+	for _, p := range []space.Point{{1, 2}, {3, 4}} {
+		if err := e.Encode(NewFeature(*NewGeometry(p))); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := e.Encode(NewFeature(*NewGeometry(space.Point{5, 6}))); err == nil {
+		t.Errorf("Encode() after Close() got no error")
+	}
+
+	fc, err := UnmarshalFeatureCollection(buf.Bytes())
+	if err != nil {
+		t.Fatalf("unmarshal error = %v, json %s", err, buf.String())
+	}
+	if len(fc.Features) != 2 || !fc.Features[1].Geometry.Geometry().Equal(space.Point{3, 4}) {
+		t.Errorf("collection got = %s", buf.String())
+	}
+}
+
+func TestSeqEncoder(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	e := NewSeqEncoder(buf)
+	for _, p := range []space.Point{{1, 2}, {3, 4}} {
+		if err := e.Encode(NewFeature(*NewGeometry(p))); err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := "\x1e{\"type\":\"Feature\",\"geometry\":{\"type\":\"Point\",\"coordinates\":[1,2]},\"properties\":null}\n" +
+		"\x1e{\"type\":\"Feature\",\"geometry\":{\"type\":\"Point\",\"coordinates\":[3,4]},\"properties\":null}\n"
+	if buf.String() != want {
+		t.Errorf("sequence got = %q, want %q", buf.String(), want)
+	}
+
+	d := NewSeqDecoder(buf)
+	count := 0
+	for _, err := d.Decode(); err == nil; _, err = d.Decode() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("decoded %d features, want 2", count)
+	}
+}
@@ -2,4 +2,7 @@ module github.com/spatial-go/geoos
 
 go 1.16
 
-require golang.org/x/text v0.3.5
+require (
+	github.com/mattn/go-sqlite3 v1.14.17
+	golang.org/x/text v0.3.5
+)
@@ -0,0 +1,94 @@
+package gpkg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/spatial-go/geoos/encoding/wkb"
+	"github.com/spatial-go/geoos/space"
+)
+
+// ErrInvalidGeometry is returned when a blob is not a GeoPackage binary geometry.
+var ErrInvalidGeometry = errors.New("gpkg: invalid geometry blob")
+
+// Flags of the header of a GeoPackage binary geometry.
+const (
+	flagLittleEndian = 0x01
+	flagEnvelope     = 0x0e
+	flagEmpty        = 0x10
+	flagExtended     = 0x20
+
+	geometryHeaderSize = 8
+)
+
+// envelopeSizes are the sizes of the envelopes of the header, by their contents indicator:
+// none, XY, XYZ, XYM and XYZM.
+var envelopeSizes = []int{0, 32, 48, 48, 64}
+
+// UnmarshalGeometry decodes a GeoPackage binary geometry, and returns it with the id of its spatial reference system.
+// An empty geometry is returned as the empty geometry of its type, and an empty point has NaN coordinates.
+func UnmarshalGeometry(data []byte) (space.Geometry, int, error) {
+	if len(data) < geometryHeaderSize || data[0] != 'G' || data[1] != 'P' || data[2] != 0 {
+		return nil, 0, ErrInvalidGeometry
+	}
+	flags := data[3]
+	if flags&flagExtended != 0 {
+		return nil, 0, ErrInvalidGeometry
+	}
+	var order binary.ByteOrder = binary.BigEndian
+	if flags&flagLittleEndian != 0 {
+		order = binary.LittleEndian
+	}
+	srsID := int(int32(order.Uint32(data[4:])))
+
+	indicator := int(flags&flagEnvelope) >> 1
+	if indicator >= len(envelopeSizes) {
+		return nil, 0, ErrInvalidGeometry
+	}
+	payload := data[geometryHeaderSize:]
+	if len(payload) < envelopeSizes[indicator] {
+		return nil, 0, ErrInvalidGeometry
+	}
+	payload = payload[envelopeSizes[indicator]:]
+
+	geom, err := wkb.Unmarshal(payload)
+	if err != nil {
+		return nil, 0, err
+	}
+	return geom, srsID, nil
+}
+
+// MarshalGeometry encodes the geometry as a GeoPackage binary geometry in the spatial reference system srsID.
+// The coordinates are written in little endian ISO WKB, with the XY envelope of the geometry unless it is a point.
+// An empty geometry is flagged as empty and has no envelope.
+// A nil geometry, or the nil value of a geometry type, is marshalled as nil.
+func MarshalGeometry(geom space.Geometry, srsID int) ([]byte, error) {
+	if geom == nil {
+		return nil, nil
+	}
+	buf := bytes.NewBuffer(make([]byte, geometryHeaderSize, 64))
+	header := buf.Bytes()
+	header[0], header[1], header[2], header[3] = 'G', 'P', 0, flagLittleEndian
+	binary.LittleEndian.PutUint32(header[4:], uint32(int32(srsID)))
+
+	if geom.IsEmpty() {
+		header[3] |= flagEmpty
+	} else if _, ok := geom.(space.Point); !ok {
+		header[3] |= 1 << 1
+		bound := geom.Bound()
+		for _, v := range []float64{bound.Min.X(), bound.Max.X(), bound.Min.Y(), bound.Max.Y()} {
+			_ = binary.Write(buf, binary.LittleEndian, v)
+		}
+	}
+	e := wkb.NewEncoder(buf)
+	e.SetByteOrder(binary.LittleEndian)
+	e.SetISO(true)
+	if err := e.Encode(geom); err != nil {
+		return nil, err
+	}
+	if buf.Len() == geometryHeaderSize {
+		return nil, nil
+	}
+	return buf.Bytes(), nil
+}
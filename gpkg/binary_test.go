@@ -0,0 +1,86 @@
+package gpkg
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestUnmarshalGeometry(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		want  space.Geometry
+		srsID int
+	}{
+		{name: "point", data: "47500001E61000000101000000000000000000F03F0000000000000040", want: space.Point{1, 2}, srsID: 4326},
+		{name: "point big endian", data: "4750000000000F1100000000013FF00000000000004000000000000000", want: space.Point{1, 2}, srsID: 3857},
+		{name: "line string with envelope", data: "47500003FFFFFFFF" +
+			"000000000000F03F0000000000000840000000000000004000000000000010400102000000020000000000000000" +
+			"00F03F000000000000004000000000000008400000000000001040",
+			want: space.LineString{{1, 2}, {3, 4}}, srsID: -1},
+		{name: "empty polygon", data: "47500011E6100000010300000000000000", want: space.Polygon{}, srsID: 4326},
+		{name: "empty point", data: "47500011E61000000101000000000000000000F87F000000000000F87F",
+			want: space.Point{math.NaN(), math.NaN()}, srsID: 4326},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := hex.DecodeString(tt.data)
+			got, srsID, err := UnmarshalGeometry(data)
+			if err != nil {
+				t.Fatalf("UnmarshalGeometry() error = %v", err)
+			}
+			if !got.Equal(tt.want) || got.GeoJSONType() != tt.want.GeoJSONType() || srsID != tt.srsID {
+				t.Errorf("UnmarshalGeometry() got = %v, %v, want %v, %v", got, srsID, tt.want, tt.srsID)
+			}
+		})
+	}
+
+	for _, data := range []string{"", "4750", "5850000100000000", "47500021E6100000", "47500003E6100000000000000000F03F"} {
+		b, _ := hex.DecodeString(data)
+		if _, _, err := UnmarshalGeometry(b); err != ErrInvalidGeometry {
+			t.Errorf("UnmarshalGeometry(%s) error = %v, want %v", data, err, ErrInvalidGeometry)
+		}
+	}
+}
+
+func TestMarshalGeometry(t *testing.T) {
+	geoms := []space.Geometry{
+		space.Point{1, 2},
+		space.NewPointM(1, 2, 3),
+		space.LineString{{1, 2, 3}, {3, 4, 5}},
+		space.MultiPolygon{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, {{{5, 5}, {6, 5}, {6, 6}, {5, 5}}}},
+		space.Collection{space.Point{1, 2}, space.LineString{{1, 2}, {3, 4}}},
+		space.Point{},
+		space.MultiLineString{},
+	}
+	for _, geom := range geoms {
+		data, err := MarshalGeometry(geom, 4326)
+		if err != nil {
+			t.Fatalf("MarshalGeometry() error = %v", err)
+		}
+		got, srsID, err := UnmarshalGeometry(data)
+		if err != nil {
+			t.Fatalf("UnmarshalGeometry() error = %v", err)
+		}
+		// an empty point is read with NaN coordinates.
+		equal := got.Equal(geom) || got.IsEmpty() && geom.IsEmpty()
+		if !equal || got.GeoJSONType() != geom.GeoJSONType() || space.CoordLayout(got) != space.CoordLayout(geom) || srsID != 4326 {
+			t.Errorf("round trip got = %v, %v, want %v", got, srsID, geom)
+		}
+	}
+
+	data, _ := MarshalGeometry(space.LineString{{1, 2, 3}, {3, 4, 5}}, 4326)
+	// the envelope is XY, and the Z of the coordinates is written as ISO WKB.
+	if data[3] != 0x03 || math.Float64frombits(binary.LittleEndian.Uint64(data[16:])) != 3 || binary.LittleEndian.Uint32(data[41:]) != 1002 {
+		t.Errorf("MarshalGeometry() got = %X", data)
+	}
+	for _, geom := range []space.Geometry{nil, space.LineString(nil)} {
+		if data, err := MarshalGeometry(geom, 4326); data != nil || err != nil {
+			t.Errorf("MarshalGeometry() of %#v got = %v, %v", geom, data, err)
+		}
+	}
+}
@@ -0,0 +1,541 @@
+/*
+Package gpkg is a library for reading and writing the feature tables of OGC GeoPackages as GeoJSON feature collections.
+
+A GeoPackage is a SQLite database. It is opened with the database/sql driver registered by the caller,
+such as modernc.org/sqlite, which is pure Go, or github.com/mattn/go-sqlite3, which uses cgo.
+Neither this package nor the packages it imports use cgo.
+Geometries are stored as GeoPackage binary geometries, a header followed by WKB,
+see MarshalGeometry and UnmarshalGeometry.
+*/
+package gpkg
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+// ErrNoFeatureTable is returned when a table is not a feature table of the GeoPackage.
+var ErrNoFeatureTable = errors.New("gpkg: no feature table")
+
+const (
+	// applicationID is the application id of GeoPackages, "GPKG".
+	applicationID = 0x47504b47
+	// userVersion is the version of the GeoPackage specification written, 1.2.0.
+	userVersion = 10200
+
+	defaultGeometryColumn = "geom"
+	primaryKeyColumn      = "fid"
+	geometryTypeGeneric   = "GEOMETRY"
+)
+
+// SRS a spatial reference system of a GeoPackage
+type SRS struct {
+	ID             int
+	Name           string
+	Organization   string
+	OrganizationID int
+	// Definition is the well-known text of the spatial reference system.
+	Definition string
+}
+
+// WGS84 is the spatial reference system of longitudes and latitudes, EPSG:4326.
+var WGS84 = SRS{
+	ID:             4326,
+	Name:           "WGS 84 geodetic",
+	Organization:   "EPSG",
+	OrganizationID: 4326,
+	Definition: `GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563,AUTHORITY["EPSG","7030"]],` +
+		`AUTHORITY["EPSG","6326"]],PRIMEM["Greenwich",0,AUTHORITY["EPSG","8901"]],` +
+		`UNIT["degree",0.0174532925199433,AUTHORITY["EPSG","9122"]],AUTHORITY["EPSG","4326"]]`,
+}
+
+// requiredSRS are the spatial reference systems every GeoPackage defines.
+var requiredSRS = []SRS{
+	WGS84,
+	{ID: -1, Name: "Undefined cartesian SRS", Organization: "NONE", OrganizationID: -1, Definition: "undefined"},
+	{ID: 0, Name: "Undefined geographic SRS", Organization: "NONE", OrganizationID: 0, Definition: "undefined"},
+}
+
+// Table a feature table of a GeoPackage
+type Table struct {
+	Name           string
+	Identifier     string
+	Description    string
+	GeometryColumn string
+	// GeometryType is the geometry type name of the column, such as POINT or GEOMETRY.
+	GeometryType string
+	SRSID        int
+}
+
+// Options an options of writing a feature table
+type Options struct {
+	// SRS is the spatial reference system of the geometries, WGS84 if it is nil.
+	SRS *SRS
+	// GeometryColumn is the name of the geometry column, geom if it is empty.
+	GeometryColumn string
+	Identifier     string
+	Description    string
+}
+
+// GeoPackage a GeoPackage database
+type GeoPackage struct {
+	db *sql.DB
+}
+
+// Open opens the GeoPackage of filePath with the database/sql driver driverName,
+// which must be a SQLite driver registered by the caller.
+func Open(driverName, filePath string) (*GeoPackage, error) {
+	db, err := sql.Open(driverName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return New(db), nil
+}
+
+// New returns the GeoPackage of a SQLite database already opened.
+func New(db *sql.DB) *GeoPackage {
+	return &GeoPackage{db: db}
+}
+
+// Close closes the database.
+func (gp *GeoPackage) Close() error {
+	return gp.db.Close()
+}
+
+// Tables returns the feature tables of the GeoPackage in order.
+func (gp *GeoPackage) Tables() ([]Table, error) {
+	rows, err := gp.db.Query(`SELECT c.table_name, COALESCE(c.identifier, ''), COALESCE(c.description, ''),
+		g.column_name, g.geometry_type_name, g.srs_id
+		FROM gpkg_contents c JOIN gpkg_geometry_columns g ON c.table_name = g.table_name
+		WHERE c.data_type = 'features' ORDER BY c.table_name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []Table
+	for rows.Next() {
+		var t Table
+		if err := rows.Scan(&t.Name, &t.Identifier, &t.Description, &t.GeometryColumn, &t.GeometryType, &t.SRSID); err != nil {
+			return nil, err
+		}
+		tables = append(tables, t)
+	}
+	return tables, rows.Err()
+}
+
+// Read reads the features of a feature table. The id of a feature is its primary key,
+// and its properties are the other columns which are not null.
+func (gp *GeoPackage) Read(table string) (*geojson.FeatureCollection, error) {
+	var geometryColumn string
+	err := gp.db.QueryRow(`SELECT column_name FROM gpkg_geometry_columns WHERE table_name = ?`, table).Scan(&geometryColumn)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoFeatureTable
+	}
+	if err != nil {
+		return nil, err
+	}
+	types, primaryKey, err := gp.columns(table)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gp.db.Query(`SELECT * FROM ` + quoteIdentifier(table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	features := geojson.NewFeatureCollection()
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		feature := geojson.NewFeature(geojson.Geometry{})
+		for i, column := range columns {
+			switch {
+			case values[i] == nil:
+			case strings.EqualFold(column, geometryColumn):
+				data, ok := values[i].([]byte)
+				if !ok {
+					return nil, ErrInvalidGeometry
+				}
+				geom, _, err := UnmarshalGeometry(data)
+				if err != nil {
+					return nil, err
+				}
+				feature.Geometry = *geojson.NewGeometry(geom)
+			case strings.EqualFold(column, primaryKey):
+				feature.ID = values[i]
+			default:
+				feature.Properties[column] = propertyValue(values[i], types[strings.ToLower(column)])
+			}
+		}
+		features.Append(feature)
+	}
+	return features, rows.Err()
+}
+
+// columns returns the declared types of the columns of a table, by their lower case names, and its primary key.
+func (gp *GeoPackage) columns(table string) (map[string]string, string, error) {
+	rows, err := gp.db.Query(`PRAGMA table_info(` + quoteIdentifier(table) + `)`)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	types := map[string]string{}
+	primaryKey := ""
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, typ        string
+			defaultValue     interface{}
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &defaultValue, &pk); err != nil {
+			return nil, "", err
+		}
+		types[strings.ToLower(name)] = strings.ToUpper(typ)
+		if pk == 1 {
+			primaryKey = name
+		}
+	}
+	return types, primaryKey, rows.Err()
+}
+
+// propertyValue returns the property of the value of a column of a declared type.
+// Numbers are read as float64, like in GeoJSON, booleans as bool, texts as string and dates as RFC 3339 strings.
+func propertyValue(v interface{}, typ string) interface{} {
+	switch x := v.(type) {
+	case int64:
+		if typ == "BOOLEAN" {
+			return x != 0
+		}
+		return float64(x)
+	case []byte:
+		if typ == "BLOB" {
+			return x
+		}
+		return string(x)
+	case time.Time:
+		if typ == "DATE" {
+			return x.Format("2006-01-02")
+		}
+		return x.Format(time.RFC3339Nano)
+	}
+	return v
+}
+
+// Write writes the feature collection as a new feature table of the GeoPackage, and adds it to its contents.
+// The columns of the table are the keys of the properties in order, with a fid primary key.
+// Numbers are written as DOUBLE, booleans as BOOLEAN, and other values as TEXT, in JSON if they are not strings.
+// Properties named like the primary key or the geometry column are not written.
+func (gp *GeoPackage) Write(table string, fc *geojson.FeatureCollection, options Options) (err error) {
+	srs := WGS84
+	if options.SRS != nil {
+		srs = *options.SRS
+	}
+	geometryColumn := options.GeometryColumn
+	if geometryColumn == "" {
+		geometryColumn = defaultGeometryColumn
+	}
+
+	if err = gp.init(srs); err != nil {
+		return
+	}
+	tx, err := gp.db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	geoms := make([]space.Geometry, len(fc.Features))
+	for i, feature := range fc.Features {
+		if !isNull(feature.Geometry) {
+			geoms[i] = feature.Geometry.Geometry()
+		}
+	}
+	geometryType, z, m := geometryTypeName(geoms)
+
+	var keys []string
+	for _, key := range sortedKeys(fc.Features) {
+		if !strings.EqualFold(key, primaryKeyColumn) && !strings.EqualFold(key, geometryColumn) {
+			keys = append(keys, key)
+		}
+	}
+	definitions := []string{
+		quoteIdentifier(primaryKeyColumn) + " INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL",
+		quoteIdentifier(geometryColumn) + " " + geometryType,
+	}
+	for _, key := range keys {
+		definitions = append(definitions, quoteIdentifier(key)+" "+columnType(fc.Features, key))
+	}
+	if _, err = tx.Exec(`CREATE TABLE ` + quoteIdentifier(table) + ` (` + strings.Join(definitions, ", ") + `)`); err != nil {
+		return
+	}
+
+	var bound []interface{}
+	if b, ok := bounds(geoms); ok {
+		bound = []interface{}{b.Min.X(), b.Min.Y(), b.Max.X(), b.Max.Y()}
+	} else {
+		bound = []interface{}{nil, nil, nil, nil}
+	}
+	if _, err = tx.Exec(`INSERT INTO gpkg_contents (table_name, data_type, identifier, description, min_x, min_y, max_x, max_y, srs_id)
+		VALUES (?, 'features', ?, ?, ?, ?, ?, ?, ?)`,
+		table, orDefault(options.Identifier, table), options.Description, bound[0], bound[1], bound[2], bound[3], srs.ID); err != nil {
+		return
+	}
+	if _, err = tx.Exec(`INSERT INTO gpkg_geometry_columns (table_name, column_name, geometry_type_name, srs_id, z, m)
+		VALUES (?, ?, ?, ?, ?, ?)`, table, geometryColumn, geometryType, srs.ID, z, m); err != nil {
+		return
+	}
+
+	columns := []string{quoteIdentifier(geometryColumn)}
+	for _, key := range keys {
+		columns = append(columns, quoteIdentifier(key))
+	}
+	stmt, err := tx.Prepare(`INSERT INTO ` + quoteIdentifier(table) + ` (` + strings.Join(columns, ", ") + `) VALUES (?` +
+		strings.Repeat(", ?", len(keys)) + `)`)
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+	for i, feature := range fc.Features {
+		args := make([]interface{}, 0, len(columns))
+		data, marshalErr := MarshalGeometry(geoms[i], srs.ID)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		if data == nil {
+			args = append(args, nil)
+		} else {
+			args = append(args, data)
+		}
+		for _, key := range keys {
+			v, valueErr := columnValue(feature.Properties[key])
+			if valueErr != nil {
+				return fmt.Errorf("gpkg: column %s: %v", key, valueErr)
+			}
+			args = append(args, v)
+		}
+		if _, err = stmt.Exec(args...); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// init makes the database a GeoPackage, creating the metadata tables which do not exist,
+// and adds the spatial reference system to it.
+func (gp *GeoPackage) init(srs SRS) error {
+	statements := []string{
+		fmt.Sprintf(`PRAGMA application_id = %d`, applicationID),
+		fmt.Sprintf(`PRAGMA user_version = %d`, userVersion),
+		`CREATE TABLE IF NOT EXISTS gpkg_spatial_ref_sys (
+			srs_name TEXT NOT NULL,
+			srs_id INTEGER NOT NULL PRIMARY KEY,
+			organization TEXT NOT NULL,
+			organization_coordsys_id INTEGER NOT NULL,
+			definition TEXT NOT NULL,
+			description TEXT)`,
+		`CREATE TABLE IF NOT EXISTS gpkg_contents (
+			table_name TEXT NOT NULL PRIMARY KEY,
+			data_type TEXT NOT NULL,
+			identifier TEXT UNIQUE,
+			description TEXT DEFAULT '',
+			last_change DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+			min_x DOUBLE,
+			min_y DOUBLE,
+			max_x DOUBLE,
+			max_y DOUBLE,
+			srs_id INTEGER,
+			CONSTRAINT fk_gc_r_srs_id FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys(srs_id))`,
+		`CREATE TABLE IF NOT EXISTS gpkg_geometry_columns (
+			table_name TEXT NOT NULL,
+			column_name TEXT NOT NULL,
+			geometry_type_name TEXT NOT NULL,
+			srs_id INTEGER NOT NULL,
+			z TINYINT NOT NULL,
+			m TINYINT NOT NULL,
+			CONSTRAINT pk_geom_cols PRIMARY KEY (table_name, column_name),
+			CONSTRAINT uk_gc_table_name UNIQUE (table_name),
+			CONSTRAINT fk_gc_tn FOREIGN KEY (table_name) REFERENCES gpkg_contents(table_name),
+			CONSTRAINT fk_gc_srs FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys (srs_id))`,
+	}
+	for _, statement := range statements {
+		if _, err := gp.db.Exec(statement); err != nil {
+			return err
+		}
+	}
+	for _, s := range append(requiredSRS, srs) {
+		if _, err := gp.db.Exec(`INSERT OR IGNORE INTO gpkg_spatial_ref_sys
+			(srs_name, srs_id, organization, organization_coordsys_id, definition) VALUES (?, ?, ?, ?, ?)`,
+			s.Name, s.ID, s.Organization, s.OrganizationID, s.Definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// geometryTypeName returns the geometry type name of the geometries, GEOMETRY if they are of several types,
+// and whether their coordinates have Z and M values: 0 if none has, 1 if all have and 2 if some have.
+func geometryTypeName(geoms []space.Geometry) (string, int, int) {
+	name := ""
+	hasZ, hasM, count := 0, 0, 0
+	for _, geom := range geoms {
+		if geom == nil {
+			continue
+		}
+		count++
+		typ := strings.ToUpper(geom.GeoJSONType())
+		if geom.GeoJSONType() == space.TypeCollection {
+			typ = "GEOMETRYCOLLECTION"
+		}
+		if name == "" {
+			name = typ
+		} else if name != typ {
+			name = geometryTypeGeneric
+		}
+		layout := space.CoordLayout(geom)
+		if layout.HasZ() {
+			hasZ++
+		}
+		if layout.HasM() {
+			hasM++
+		}
+	}
+	if name == "" {
+		name = geometryTypeGeneric
+	}
+	return name, presence(hasZ, count), presence(hasM, count)
+}
+
+// presence returns 0 if no value is present, 1 if all are and 2 if some are.
+func presence(n, count int) int {
+	switch {
+	case n == 0:
+		return 0
+	case n == count:
+		return 1
+	}
+	return 2
+}
+
+// bounds returns the bound of the geometries which are not empty, and false if there is none.
+func bounds(geoms []space.Geometry) (space.Bound, bool) {
+	var bound space.Bound
+	found := false
+	for _, geom := range geoms {
+		if geom == nil || geom.IsEmpty() {
+			continue
+		}
+		if !found {
+			bound, found = geom.Bound(), true
+		} else {
+			bound = bound.Union(geom.Bound())
+		}
+	}
+	return bound, found
+}
+
+// columnType returns the type of the column of the values of a key of the properties of the features.
+func columnType(features []*geojson.Feature, key string) string {
+	numeric, logical := true, true
+	for _, feature := range features {
+		switch feature.Properties[key].(type) {
+		case nil:
+		case float64, int, int64, json.Number:
+			logical = false
+		case bool:
+			numeric = false
+		default:
+			numeric, logical = false, false
+		}
+	}
+	switch {
+	case numeric:
+		return "DOUBLE"
+	case logical:
+		return "BOOLEAN"
+	}
+	return "TEXT"
+}
+
+// columnValue returns the value of a property written to its column.
+func columnValue(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case nil, string, bool, int, int64:
+		return v, nil
+	case float64:
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil, nil
+		}
+		return v, nil
+	case json.Number:
+		return x.Float64()
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// isNull returns true if the geometry of a feature is null.
+func isNull(geometry geojson.Geometry) bool {
+	return geometry.Coordinates == nil && geometry.Geometries == nil
+}
+
+// orDefault returns s, or else the default value if it is empty.
+func orDefault(s, defaultValue string) string {
+	if s == "" {
+		return defaultValue
+	}
+	return s
+}
+
+// sortedKeys returns the keys of the properties of the features in order.
+func sortedKeys(features []*geojson.Feature) []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, feature := range features {
+		for key := range feature.Properties {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// quoteIdentifier quotes a SQL identifier.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,67 @@
+package gpkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestGeometryTypeName(t *testing.T) {
+	tests := []struct {
+		name  string
+		geoms []space.Geometry
+		want  string
+		z, m  int
+	}{
+		{name: "none", geoms: []space.Geometry{nil}, want: "GEOMETRY"},
+		{name: "points", geoms: []space.Geometry{space.Point{1, 2, 3}, nil, space.Point{1, 2}}, want: "POINT", z: 2},
+		{name: "collections m", geoms: []space.Geometry{space.Collection{space.NewPointM(1, 2, 3)}}, want: "GEOMETRYCOLLECTION", m: 1},
+		{name: "mixed", geoms: []space.Geometry{space.Point{1, 2}, space.LineString{{1, 2}, {3, 4}}}, want: "GEOMETRY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, z, m := geometryTypeName(tt.geoms); got != tt.want || z != tt.z || m != tt.m {
+				t.Errorf("geometryTypeName() got = %v, %v, %v, want %v, %v, %v", got, z, m, tt.want, tt.z, tt.m)
+			}
+		})
+	}
+}
+
+func TestColumnType(t *testing.T) {
+	features := []*geojson.Feature{
+		{Properties: geojson.Properties{"n": 1.5, "b": true, "s": "x", "m": 1.0}},
+		{Properties: geojson.Properties{"n": 2, "b": nil, "m": "2"}},
+	}
+	for key, want := range map[string]string{"n": "DOUBLE", "b": "BOOLEAN", "s": "TEXT", "m": "TEXT"} {
+		if got := columnType(features, key); got != want {
+			t.Errorf("columnType(%s) got = %v, want %v", key, got, want)
+		}
+	}
+
+	if v, err := columnValue(map[string]interface{}{"a": 1}); v != `{"a":1}` || err != nil {
+		t.Errorf("columnValue() got = %v, %v", v, err)
+	}
+}
+
+func TestPropertyValue(t *testing.T) {
+	date := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		v    interface{}
+		typ  string
+		want interface{}
+	}{
+		{v: int64(1), typ: "BOOLEAN", want: true},
+		{v: int64(3), typ: "INTEGER", want: 3.0},
+		{v: 1.5, typ: "DOUBLE", want: 1.5},
+		{v: []byte("北京"), typ: "TEXT", want: "北京"},
+		{v: date, typ: "DATE", want: "2021-03-04"},
+		{v: date, typ: "DATETIME", want: "2021-03-04T05:06:07Z"},
+	}
+	for _, tt := range tests {
+		if got := propertyValue(tt.v, tt.typ); got != tt.want {
+			t.Errorf("propertyValue(%v, %s) got = %v, want %v", tt.v, tt.typ, got, tt.want)
+		}
+	}
+}
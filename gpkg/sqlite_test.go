@@ -0,0 +1,185 @@
+//go:build sqlite
+// +build sqlite
+
+package gpkg
+
+import (
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+// driverName is the SQLite driver of github.com/mattn/go-sqlite3, which uses cgo,
+// so the tests of this file only run with go test -tags sqlite.
+const driverName = "sqlite3"
+
+func TestGeoPackage_Read(t *testing.T) {
+	// testdata/cities.gpkg is built by the sqlite3 shell from testdata/cities.sql.
+	gp, err := Open(driverName, "file:testdata/cities.gpkg?mode=ro")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer gp.Close()
+
+	tables, err := gp.Tables()
+	if err != nil {
+		t.Fatalf("Tables() error = %v", err)
+	}
+	want := Table{Name: "cities", Identifier: "cities", Description: "cities of China", GeometryColumn: "geom",
+		GeometryType: "GEOMETRY", SRSID: 4326}
+	if len(tables) != 1 || tables[0] != want {
+		t.Errorf("Tables() got = %+v, want %+v", tables, want)
+	}
+
+	got, err := gp.Read("cities")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	features := []struct {
+		geom       space.Geometry
+		properties geojson.Properties
+	}{
+		{geom: space.Point{116.4, 39.9},
+			properties: geojson.Properties{"name": "北京", "population": 21.54, "capital": true, "founded": "1949-10-01"}},
+		{geom: space.Point{121.5, 31.2, 4},
+			properties: geojson.Properties{"name": "上海", "population": 24.87, "capital": false}},
+		{geom: space.Polygon{{{113.7, 22.1}, {114.6, 22.1}, {114.6, 22.6}, {113.7, 22.1}}},
+			properties: geojson.Properties{"name": "深圳", "population": 17.56, "capital": false, "founded": "1979-03-05"}},
+		{geom: space.Point{},
+			properties: geojson.Properties{"name": "雄安", "capital": false, "founded": "2017-04-01"}},
+		{geom: space.LineString{{120.1, 30.2}, {121.3, 31.4}},
+			properties: geojson.Properties{"name": "沪杭"}},
+		{properties: geojson.Properties{"name": "未知"}},
+	}
+	if len(got.Features) != len(features) {
+		t.Fatalf("Read() got %v features, want %v", len(got.Features), len(features))
+	}
+	for i, feature := range got.Features {
+		if feature.ID != int64(i+1) {
+			t.Errorf("Read() id got = %v, want %v", feature.ID, i+1)
+		}
+		geom := feature.Geometry.Coordinates
+		switch want := features[i].geom; {
+		case want == nil:
+			if geom != nil {
+				t.Errorf("Read() geometry got = %v, want nil", geom)
+			}
+		case geom == nil || geom.GeoJSONType() != want.GeoJSONType() || space.CoordLayout(geom) != space.CoordLayout(want):
+			t.Errorf("Read() geometry got = %v, want %v", geom, want)
+		case want.IsEmpty():
+			if !geom.IsEmpty() {
+				t.Errorf("Read() geometry got = %v, want empty", geom)
+			}
+		case !geom.Equal(want):
+			t.Errorf("Read() geometry got = %v, want %v", geom, want)
+		}
+		if len(feature.Properties) != len(features[i].properties) {
+			t.Errorf("Read() properties got = %v, want %v", feature.Properties, features[i].properties)
+		}
+		for key, value := range features[i].properties {
+			if feature.Properties[key] != value {
+				t.Errorf("Read() property %s got = %#v, want %#v", key, feature.Properties[key], value)
+			}
+		}
+	}
+}
+
+func TestGeoPackage_WriteRead(t *testing.T) {
+	gp, err := Open(driverName, filepath.Join(t.TempDir(), "roundtrip.gpkg"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer gp.Close()
+
+	fc := geojson.NewFeatureCollection()
+	beijing := geojson.NewFeature(*geojson.NewGeometry(space.Point{116.4, 39.9}))
+	beijing.Properties = geojson.Properties{"name": "北京", "area": 16410.54, "capital": true}
+	shanghai := geojson.NewFeature(*geojson.NewGeometry(space.Point{121.5, 31.2, 4}))
+	shanghai.Properties = geojson.Properties{"name": "上海", "capital": false}
+	fc.Append(beijing)
+	fc.Append(shanghai)
+	if err := gp.Write("cities", fc, Options{Description: "cities of China"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := gp.Write("cities", fc, Options{}); err == nil {
+		t.Errorf("Write() of an existing table got no error")
+	}
+
+	tables, err := gp.Tables()
+	if err != nil {
+		t.Fatalf("Tables() error = %v", err)
+	}
+	want := Table{Name: "cities", Identifier: "cities", Description: "cities of China", GeometryColumn: "geom",
+		GeometryType: "POINT", SRSID: 4326}
+	if len(tables) != 1 || tables[0] != want {
+		t.Errorf("Tables() got = %+v, want %+v", tables, want)
+	}
+
+	got, err := gp.Read("cities")
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(got.Features) != 2 {
+		t.Fatalf("Read() got %v features, want 2", len(got.Features))
+	}
+	for i, feature := range got.Features {
+		if feature.ID != int64(i+1) {
+			t.Errorf("Read() id got = %v, want %v", feature.ID, i+1)
+		}
+		if !feature.Geometry.Coordinates.Equal(fc.Features[i].Geometry.Coordinates) {
+			t.Errorf("Read() geometry got = %v, want %v", feature.Geometry.Coordinates, fc.Features[i].Geometry.Coordinates)
+		}
+		if len(feature.Properties) != len(fc.Features[i].Properties) {
+			t.Errorf("Read() properties got = %v, want %v", feature.Properties, fc.Features[i].Properties)
+		}
+		for key, value := range fc.Features[i].Properties {
+			if feature.Properties[key] != value {
+				t.Errorf("Read() property %s got = %#v, want %#v", key, feature.Properties[key], value)
+			}
+		}
+	}
+	if _, err := gp.Read("gpkg_contents"); err != ErrNoFeatureTable {
+		t.Errorf("Read() of a table without geometry got error = %v, want %v", err, ErrNoFeatureTable)
+	}
+
+	var id, version int
+	if err := gp.db.QueryRow(`PRAGMA application_id`).Scan(&id); err != nil || id != applicationID {
+		t.Errorf("application_id got = %v, %v", id, err)
+	}
+	if err := gp.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil || version != userVersion {
+		t.Errorf("user_version got = %v, %v", version, err)
+	}
+	rows, err := gp.db.Query(`SELECT srs_id FROM gpkg_spatial_ref_sys ORDER BY srs_id`)
+	if err != nil {
+		t.Fatalf("gpkg_spatial_ref_sys error = %v", err)
+	}
+	var ids []int
+	for rows.Next() {
+		var srsID int
+		if err := rows.Scan(&srsID); err != nil {
+			t.Fatalf("gpkg_spatial_ref_sys error = %v", err)
+		}
+		ids = append(ids, srsID)
+	}
+	rows.Close()
+	if len(ids) != 3 || ids[0] != -1 || ids[1] != 0 || ids[2] != 4326 {
+		t.Errorf("gpkg_spatial_ref_sys srs_id got = %v", ids)
+	}
+	var (
+		minX, maxY float64
+		dataType   string
+	)
+	if err := gp.db.QueryRow(`SELECT min_x, max_y, data_type FROM gpkg_contents WHERE table_name = 'cities'`).
+		Scan(&minX, &maxY, &dataType); err != nil || minX != 116.4 || maxY != 39.9 || dataType != "features" {
+		t.Errorf("gpkg_contents got = %v, %v, %v, %v", minX, maxY, dataType, err)
+	}
+	var z, m int
+	if err := gp.db.QueryRow(`SELECT z, m FROM gpkg_geometry_columns WHERE table_name = 'cities'`).
+		Scan(&z, &m); err != nil || z != 2 || m != 0 {
+		t.Errorf("gpkg_geometry_columns got = %v, %v, %v", z, m, err)
+	}
+}
@@ -0,0 +1,73 @@
+-- cities.gpkg is built from this script with the sqlite3 shell:
+--   sqlite3 cities.gpkg < cities.sql
+-- The tables follow the layout GDAL writes, and the geometries are GeoPackage binary geometries:
+-- little endian points with and without Z, a polygon with its envelope, an empty point of NaN coordinates,
+-- a big endian line string, and a null geometry.
+PRAGMA application_id = 1196444487;
+PRAGMA user_version = 10200;
+
+CREATE TABLE gpkg_spatial_ref_sys (
+	srs_name TEXT NOT NULL,
+	srs_id INTEGER NOT NULL PRIMARY KEY,
+	organization TEXT NOT NULL,
+	organization_coordsys_id INTEGER NOT NULL,
+	definition TEXT NOT NULL,
+	description TEXT);
+INSERT INTO gpkg_spatial_ref_sys VALUES ('Undefined cartesian SRS', -1, 'NONE', -1, 'undefined', 'undefined cartesian coordinate reference system');
+INSERT INTO gpkg_spatial_ref_sys VALUES ('Undefined geographic SRS', 0, 'NONE', 0, 'undefined', 'undefined geographic coordinate reference system');
+INSERT INTO gpkg_spatial_ref_sys VALUES ('WGS 84 geodetic', 4326, 'EPSG', 4326,
+	'GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563,AUTHORITY["EPSG","7030"]],AUTHORITY["EPSG","6326"]],PRIMEM["Greenwich",0,AUTHORITY["EPSG","8901"]],UNIT["degree",0.0174532925199433,AUTHORITY["EPSG","9122"]],AXIS["Latitude",NORTH],AXIS["Longitude",EAST],AUTHORITY["EPSG","4326"]]',
+	'longitude/latitude coordinates in decimal degrees on the WGS 84 spheroid');
+
+CREATE TABLE gpkg_contents (
+	table_name TEXT NOT NULL PRIMARY KEY,
+	data_type TEXT NOT NULL,
+	identifier TEXT UNIQUE,
+	description TEXT DEFAULT '',
+	last_change DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+	min_x DOUBLE,
+	min_y DOUBLE,
+	max_x DOUBLE,
+	max_y DOUBLE,
+	srs_id INTEGER,
+	CONSTRAINT fk_gc_r_srs_id FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys(srs_id));
+INSERT INTO gpkg_contents VALUES ('cities', 'features', 'cities', 'cities of China', '2021-06-01T00:00:00.000Z',
+	113.7, 22.1, 121.5, 39.9, 4326);
+
+CREATE TABLE gpkg_geometry_columns (
+	table_name TEXT NOT NULL,
+	column_name TEXT NOT NULL,
+	geometry_type_name TEXT NOT NULL,
+	srs_id INTEGER NOT NULL,
+	z TINYINT NOT NULL,
+	m TINYINT NOT NULL,
+	CONSTRAINT pk_geom_cols PRIMARY KEY (table_name, column_name),
+	CONSTRAINT uk_gc_table_name UNIQUE (table_name),
+	CONSTRAINT fk_gc_tn FOREIGN KEY (table_name) REFERENCES gpkg_contents(table_name),
+	CONSTRAINT fk_gc_srs FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys (srs_id));
+INSERT INTO gpkg_geometry_columns VALUES ('cities', 'geom', 'GEOMETRY', 4326, 2, 0);
+
+CREATE TABLE gpkg_ogr_contents (
+	table_name TEXT NOT NULL PRIMARY KEY,
+	feature_count INTEGER DEFAULT NULL);
+INSERT INTO gpkg_ogr_contents VALUES ('cities', 6);
+
+CREATE TABLE "cities" (
+	"fid" INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	"geom" GEOMETRY,
+	"name" TEXT,
+	"population" REAL,
+	"capital" BOOLEAN,
+	"founded" DATE);
+-- POINT(116.4 39.9)
+INSERT INTO "cities" VALUES (1, X'47500001E610000001010000009A99999999195D403333333333F34340', '北京', 21.54, 1, '1949-10-01');
+-- POINT Z(121.5 31.2 4)
+INSERT INTO "cities" VALUES (2, X'47500001E610000001E90300000000000000605E403333333333333F400000000000001040', '上海', 24.87, 0, NULL);
+-- POLYGON((113.7 22.1,114.6 22.1,114.6 22.6,113.7 22.1)), with the envelope 113.7 114.6 22.1 22.6
+INSERT INTO "cities" VALUES (3, X'47500003E6100000CDCCCCCCCC6C5C406666666666A65C409A999999991936409A9999999999364001030000000100000004000000CDCCCCCCCC6C5C409A999999991936406666666666A65C409A999999991936406666666666A65C409A99999999993640CDCCCCCCCC6C5C409A99999999193640', '深圳', 17.56, 0, '1979-03-05');
+-- POINT EMPTY
+INSERT INTO "cities" VALUES (4, X'47500011E61000000101000000000000000000F87F000000000000F87F', '雄安', NULL, 0, '2017-04-01');
+-- LINESTRING(120.1 30.2,121.3 31.4) in big endian
+INSERT INTO "cities" VALUES (5, X'47500000000010E6000000000200000002405E066666666666403E333333333333405E533333333333403F666666666666', '沪杭', NULL, NULL, NULL);
+-- a null geometry
+INSERT INTO "cities" VALUES (6, NULL, '未知', NULL, NULL, NULL);
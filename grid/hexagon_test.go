@@ -1,7 +1,6 @@
 package grid
 
 import (
-	"reflect"
 	"testing"
 
 	"github.com/spatial-go/geoos/space"
@@ -81,7 +80,7 @@ func TestHexagonGrid(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if gotGridGeoms := HexagonGrid(tt.args.bound, tt.args.cellSize); !reflect.DeepEqual(gotGridGeoms, tt.wantGridGeoms) {
+			if gotGridGeoms := HexagonGrid(tt.args.bound, tt.args.cellSize); !equalGrids(gotGridGeoms, tt.wantGridGeoms) {
 				t.Errorf("HexagonGrid() = %v, want %v", gotGridGeoms, tt.wantGridGeoms)
 			}
 		})
@@ -1,7 +1,7 @@
 package grid
 
 import (
-	"reflect"
+	"math"
 	"testing"
 
 	"github.com/spatial-go/geoos/space"
@@ -76,9 +76,40 @@ func TestSquareGrid(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gotGrids := SquareGrid(tt.args.bound, tt.args.cellSize)
-			if !reflect.DeepEqual(gotGrids, tt.wantGrids) {
+			if !equalGrids(gotGrids, tt.wantGrids) {
 				t.Errorf("SquareGrid() gotGrids = %v, want %v", gotGrids, tt.wantGrids)
 			}
 		})
 	}
 }
+
+// equalGrids returns true if the polygons of two grids have the same vertices, to 1e-9 degrees.
+func equalGrids(got, want [][]Grid) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if len(got[i]) != len(want[i]) {
+			return false
+		}
+		for j := range got[i] {
+			gotPolygon, ok1 := got[i][j].Geometry.(space.Polygon)
+			wantPolygon, ok2 := want[i][j].Geometry.(space.Polygon)
+			if !ok1 || !ok2 || len(gotPolygon) != len(wantPolygon) {
+				return false
+			}
+			for k := range gotPolygon {
+				if len(gotPolygon[k]) != len(wantPolygon[k]) {
+					return false
+				}
+				for l := range gotPolygon[k] {
+					if math.Abs(gotPolygon[k][l][0]-wantPolygon[k][l][0]) > 1e-9 ||
+						math.Abs(gotPolygon[k][l][1]-wantPolygon[k][l][1]) > 1e-9 {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
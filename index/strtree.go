@@ -0,0 +1,563 @@
+// Package index provides spatial indexes over space geometries.
+package index
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+// DefaultNodeCapacity is the maximum number of children of a node used when none is given.
+const DefaultNodeCapacity = 10
+
+// Entry is an item of a spatial index: a value keyed on a bound.
+// The geometry, if any, is used to compute exact distances in nearest neighbour queries.
+type Entry struct {
+	Bound    space.Bound
+	Geometry space.Geometry
+	Value    interface{}
+}
+
+// NewEntry returns an entry for the geometry, keyed on its bound.
+func NewEntry(geom space.Geometry, value interface{}) Entry {
+	return Entry{Bound: geom.Bound(), Geometry: geom, Value: value}
+}
+
+// distance returns the distance between the entry and the geometry geom with bound b.
+func (e Entry) distance(geom space.Geometry, b space.Bound) (float64, error) {
+	if e.Geometry == nil || geom == nil {
+		return boundDistance(e.Bound, b), nil
+	}
+	return e.Geometry.Distance(geom)
+}
+
+// STRtree is an R-tree whose nodes are packed with the Sort-Tile-Recursive algorithm when bulk loaded.
+// Entries can also be inserted and removed one by one, which keeps the tree balanced but less packed.
+//
+// An STRtree is not safe for concurrent use while it is modified.
+type STRtree struct {
+	nodeCapacity int
+	root         *node
+	size         int
+}
+
+// node is a node of an STRtree. Leaves hold entries, other nodes hold children.
+type node struct {
+	bound    space.Bound
+	leaf     bool
+	children []*node
+	entries  []Entry
+}
+
+// NewSTRtree returns an empty STRtree whose nodes have at most nodeCapacity children.
+// A capacity lower than 2 is replaced with DefaultNodeCapacity.
+func NewSTRtree(nodeCapacity int) *STRtree {
+	if nodeCapacity < 2 {
+		nodeCapacity = DefaultNodeCapacity
+	}
+	return &STRtree{nodeCapacity: nodeCapacity}
+}
+
+// Size returns the number of entries in the tree.
+func (t *STRtree) Size() int {
+	return t.size
+}
+
+// Bound returns the bound of all the entries in the tree, and false if the tree is empty.
+func (t *STRtree) Bound() (space.Bound, bool) {
+	if t.root == nil {
+		return space.Bound{}, false
+	}
+	return t.root.bound, true
+}
+
+// Load bulk loads the entries, packing them together with the entries already in the tree.
+// Entries with an empty bound are not indexed.
+func (t *STRtree) Load(entries ...Entry) {
+	all := make([]Entry, 0, t.size+len(entries))
+	if t.root != nil {
+		all = t.root.collect(all)
+	}
+	for _, e := range entries {
+		if isValidBound(e.Bound) {
+			all = append(all, e)
+		}
+	}
+	t.root, t.size = nil, len(all)
+	if len(all) == 0 {
+		return
+	}
+
+	bounds := make([]space.Bound, len(all))
+	for i, e := range all {
+		bounds[i] = e.Bound
+	}
+	var level []*node
+	for _, group := range strGroups(bounds, t.nodeCapacity) {
+		n := &node{leaf: true}
+		for _, i := range group {
+			n.entries = append(n.entries, all[i])
+		}
+		n.updateBound()
+		level = append(level, n)
+	}
+	for len(level) > 1 {
+		bounds = bounds[:0]
+		for _, n := range level {
+			bounds = append(bounds, n.bound)
+		}
+		var parents []*node
+		for _, group := range strGroups(bounds, t.nodeCapacity) {
+			n := &node{}
+			for _, i := range group {
+				n.children = append(n.children, level[i])
+			}
+			n.updateBound()
+			parents = append(parents, n)
+		}
+		level = parents
+	}
+	t.root = level[0]
+}
+
+// Insert adds the entry to the tree. An entry with an empty bound is not indexed.
+func (t *STRtree) Insert(e Entry) {
+	if !isValidBound(e.Bound) {
+		return
+	}
+	t.size++
+	t.insertEntries([]Entry{e})
+}
+
+// Remove removes an entry equal to e from the tree, and returns true if one was found.
+// Entries are equal if they have the same bound and value, and equal geometries.
+// The values compared must be comparable.
+func (t *STRtree) Remove(e Entry) bool {
+	if t.root == nil || !isValidBound(e.Bound) {
+		return false
+	}
+	var orphans []Entry
+	removed := t.remove(t.root, e, &orphans)
+	if !removed {
+		return false
+	}
+	t.size--
+	if len(t.root.children) == 0 && len(t.root.entries) == 0 {
+		t.root = nil
+	}
+	for t.root != nil && !t.root.leaf && len(t.root.children) == 1 {
+		t.root = t.root.children[0]
+	}
+	t.insertEntries(orphans)
+	return true
+}
+
+// Query returns the entries whose bound intersects the bound b.
+func (t *STRtree) Query(b space.Bound) []Entry {
+	var result []Entry
+	t.Visit(b, func(e Entry) bool {
+		result = append(result, e)
+		return true
+	})
+	return result
+}
+
+// Visit calls visitor for every entry whose bound intersects the bound b,
+// until visitor returns false.
+func (t *STRtree) Visit(b space.Bound, visitor func(e Entry) bool) {
+	if t.root == nil || !isValidBound(b) {
+		return
+	}
+	t.root.visit(b, visitor)
+}
+
+// Nearest returns the entry nearest to the geometry geom, or nil if the tree is empty.
+func (t *STRtree) Nearest(geom space.Geometry) (*Entry, error) {
+	entries, err := t.KNearest(geom, 1)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+// KNearest returns the k entries nearest to the geometry geom, nearest first.
+// Distances are computed with Geometry.Distance, or between bounds for entries without a geometry.
+func (t *STRtree) KNearest(geom space.Geometry, k int) ([]Entry, error) {
+	if t.root == nil || k <= 0 || geom == nil || geom.IsEmpty() {
+		return nil, nil
+	}
+	b := geom.Bound()
+	queue := &nearestQueue{{node: t.root, distance: boundDistance(t.root.bound, b)}}
+	var result []Entry
+	for queue.Len() > 0 && len(result) < k {
+		item := heap.Pop(queue).(nearestItem)
+		switch {
+		case item.node == nil:
+			result = append(result, item.entry)
+		case item.node.leaf:
+			for _, e := range item.node.entries {
+				d, err := e.distance(geom, b)
+				if err != nil {
+					return nil, err
+				}
+				heap.Push(queue, nearestItem{entry: e, distance: d})
+			}
+		default:
+			for _, c := range item.node.children {
+				heap.Push(queue, nearestItem{node: c, distance: boundDistance(c.bound, b)})
+			}
+		}
+	}
+	return result, nil
+}
+
+// insertEntries inserts the entries one by one, splitting the nodes which overflow.
+func (t *STRtree) insertEntries(entries []Entry) {
+	for _, e := range entries {
+		if t.root == nil {
+			t.root = &node{leaf: true, bound: e.Bound}
+		}
+		if sibling := t.insert(t.root, e); sibling != nil {
+			root := &node{children: []*node{t.root, sibling}}
+			root.updateBound()
+			t.root = root
+		}
+	}
+}
+
+// insert adds the entry under the node n, and returns the new sibling of n if it was split.
+func (t *STRtree) insert(n *node, e Entry) *node {
+	if n.leaf {
+		n.entries = append(n.entries, e)
+		n.bound = unionBound(n.bound, e.Bound)
+		if len(n.entries) <= t.nodeCapacity {
+			return nil
+		}
+		bounds := make([]space.Bound, len(n.entries))
+		for i, e := range n.entries {
+			bounds[i] = e.Bound
+		}
+		first, second := quadraticSplit(bounds, t.minFill())
+		entries := n.entries
+		n.entries, n.children = nil, nil
+		sibling := &node{leaf: true}
+		for _, i := range first {
+			n.entries = append(n.entries, entries[i])
+		}
+		for _, i := range second {
+			sibling.entries = append(sibling.entries, entries[i])
+		}
+		n.updateBound()
+		sibling.updateBound()
+		return sibling
+	}
+
+	child := chooseChild(n.children, e.Bound)
+	n.bound = unionBound(n.bound, e.Bound)
+	split := t.insert(child, e)
+	if split == nil {
+		return nil
+	}
+	n.children = append(n.children, split)
+	if len(n.children) <= t.nodeCapacity {
+		return nil
+	}
+	bounds := make([]space.Bound, len(n.children))
+	for i, c := range n.children {
+		bounds[i] = c.bound
+	}
+	first, second := quadraticSplit(bounds, t.minFill())
+	children := n.children
+	n.children = nil
+	sibling := &node{}
+	for _, i := range first {
+		n.children = append(n.children, children[i])
+	}
+	for _, i := range second {
+		sibling.children = append(sibling.children, children[i])
+	}
+	n.updateBound()
+	sibling.updateBound()
+	return sibling
+}
+
+// remove removes the entry e from the subtree of n. The entries of the nodes
+// left underfull are removed with them and appended to orphans for reinsertion.
+func (t *STRtree) remove(n *node, e Entry, orphans *[]Entry) bool {
+	if !containsBound(n.bound, e.Bound) {
+		return false
+	}
+	if n.leaf {
+		for i, candidate := range n.entries {
+			if sameEntry(candidate, e) {
+				n.entries = append(n.entries[:i], n.entries[i+1:]...)
+				n.updateBound()
+				return true
+			}
+		}
+		return false
+	}
+	for i, c := range n.children {
+		if !t.remove(c, e, orphans) {
+			continue
+		}
+		if c.count() < t.minFill() {
+			*orphans = c.collect(*orphans)
+			n.children = append(n.children[:i], n.children[i+1:]...)
+		}
+		n.updateBound()
+		return true
+	}
+	return false
+}
+
+// minFill returns the minimum number of children of a node other than the root.
+func (t *STRtree) minFill() int {
+	return (t.nodeCapacity + 1) / 2
+}
+
+// count returns the number of children or entries of the node.
+func (n *node) count() int {
+	if n.leaf {
+		return len(n.entries)
+	}
+	return len(n.children)
+}
+
+// collect appends the entries of the subtree of n to entries.
+func (n *node) collect(entries []Entry) []Entry {
+	if n.leaf {
+		return append(entries, n.entries...)
+	}
+	for _, c := range n.children {
+		entries = c.collect(entries)
+	}
+	return entries
+}
+
+// visit calls visitor for the entries of the subtree of n intersecting b, and returns false once visitor does.
+func (n *node) visit(b space.Bound, visitor func(e Entry) bool) bool {
+	if !intersectsBound(n.bound, b) {
+		return true
+	}
+	if n.leaf {
+		for _, e := range n.entries {
+			if intersectsBound(e.Bound, b) && !visitor(e) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, c := range n.children {
+		if !c.visit(b, visitor) {
+			return false
+		}
+	}
+	return true
+}
+
+// updateBound recomputes the bound of the node from its children or entries.
+func (n *node) updateBound() {
+	if n.leaf {
+		for i, e := range n.entries {
+			if i == 0 {
+				n.bound = e.Bound
+			} else {
+				n.bound = unionBound(n.bound, e.Bound)
+			}
+		}
+		return
+	}
+	for i, c := range n.children {
+		if i == 0 {
+			n.bound = c.bound
+		} else {
+			n.bound = unionBound(n.bound, c.bound)
+		}
+	}
+}
+
+// strGroups partitions the bounds into groups of at most capacity with the Sort-Tile-Recursive algorithm:
+// the bounds are sorted by the x of their center into vertical slices, and each slice is sorted by y.
+func strGroups(bounds []space.Bound, capacity int) [][]int {
+	order := make([]int, len(bounds))
+	for i := range order {
+		order[i] = i
+	}
+	centerX := func(i int) float64 { return (bounds[i].Min[0] + bounds[i].Max[0]) / 2 }
+	centerY := func(i int) float64 { return (bounds[i].Min[1] + bounds[i].Max[1]) / 2 }
+	sort.SliceStable(order, func(a, b int) bool { return centerX(order[a]) < centerX(order[b]) })
+
+	nodes := int(math.Ceil(float64(len(bounds)) / float64(capacity)))
+	sliceSize := int(math.Ceil(math.Sqrt(float64(nodes)))) * capacity
+	var groups [][]int
+	for start := 0; start < len(order); start += sliceSize {
+		end := start + sliceSize
+		if end > len(order) {
+			end = len(order)
+		}
+		slice := order[start:end]
+		sort.SliceStable(slice, func(a, b int) bool { return centerY(slice[a]) < centerY(slice[b]) })
+		for i := 0; i < len(slice); i += capacity {
+			j := i + capacity
+			if j > len(slice) {
+				j = len(slice)
+			}
+			groups = append(groups, slice[i:j:j])
+		}
+	}
+	return groups
+}
+
+// quadraticSplit splits the bounds into two groups of at least minFill bounds with Guttman's quadratic split.
+func quadraticSplit(bounds []space.Bound, minFill int) (first, second []int) {
+	// pick as seeds the pair of bounds wasting the most area if grouped together
+	seedA, seedB, worst := 0, 1, math.Inf(-1)
+	for i := range bounds {
+		for j := i + 1; j < len(bounds); j++ {
+			waste := boundArea(unionBound(bounds[i], bounds[j])) - boundArea(bounds[i]) - boundArea(bounds[j])
+			if waste > worst {
+				seedA, seedB, worst = i, j, waste
+			}
+		}
+	}
+	first, second = []int{seedA}, []int{seedB}
+	boundA, boundB := bounds[seedA], bounds[seedB]
+	assigned := make([]bool, len(bounds))
+	assigned[seedA], assigned[seedB] = true, true
+	remaining := len(bounds) - 2
+	for remaining > 0 {
+		// assign all the remaining bounds to a group which would otherwise be underfull
+		if len(first)+remaining <= minFill || len(second)+remaining <= minFill {
+			toFirst := len(first)+remaining <= minFill
+			for i, done := range assigned {
+				if done {
+					continue
+				}
+				if toFirst {
+					first = append(first, i)
+				} else {
+					second = append(second, i)
+				}
+			}
+			return first, second
+		}
+		// pick the bound with the greatest preference for one group
+		next, best := -1, math.Inf(-1)
+		var growA, growB float64
+		for i, done := range assigned {
+			if done {
+				continue
+			}
+			da := boundArea(unionBound(boundA, bounds[i])) - boundArea(boundA)
+			db := boundArea(unionBound(boundB, bounds[i])) - boundArea(boundB)
+			if diff := math.Abs(da - db); diff > best {
+				next, best, growA, growB = i, diff, da, db
+			}
+		}
+		assigned[next] = true
+		remaining--
+		if growA < growB || (growA == growB && len(first) <= len(second)) {
+			first = append(first, next)
+			boundA = unionBound(boundA, bounds[next])
+		} else {
+			second = append(second, next)
+			boundB = unionBound(boundB, bounds[next])
+		}
+	}
+	return first, second
+}
+
+// chooseChild returns the child whose bound needs the least enlargement to include b,
+// the smallest one in case of a tie.
+func chooseChild(children []*node, b space.Bound) *node {
+	var best *node
+	bestGrowth, bestArea := math.Inf(1), math.Inf(1)
+	for _, c := range children {
+		area := boundArea(c.bound)
+		growth := boundArea(unionBound(c.bound, b)) - area
+		if growth < bestGrowth || (growth == bestGrowth && area < bestArea) {
+			best, bestGrowth, bestArea = c, growth, area
+		}
+	}
+	return best
+}
+
+// sameEntry returns true if the entries have the same bound and value, and equal geometries.
+func sameEntry(a, b Entry) bool {
+	if !a.Bound.EqualBound(b.Bound) || a.Value != b.Value {
+		return false
+	}
+	if a.Geometry == nil || b.Geometry == nil {
+		return a.Geometry == nil && b.Geometry == nil
+	}
+	return a.Geometry.Equal(b.Geometry)
+}
+
+// isValidBound returns true if the bound has both corners and is not empty.
+func isValidBound(b space.Bound) bool {
+	return len(b.Min) >= 2 && len(b.Max) >= 2 && !b.IsEmpty()
+}
+
+// unionBound returns the smallest bound containing a and b.
+func unionBound(a, b space.Bound) space.Bound {
+	return space.Bound{
+		Min: space.Point{math.Min(a.Min[0], b.Min[0]), math.Min(a.Min[1], b.Min[1])},
+		Max: space.Point{math.Max(a.Max[0], b.Max[0]), math.Max(a.Max[1], b.Max[1])},
+	}
+}
+
+// intersectsBound returns true if the bounds a and b have at least one point in common.
+func intersectsBound(a, b space.Bound) bool {
+	return a.Min[0] <= b.Max[0] && b.Min[0] <= a.Max[0] && a.Min[1] <= b.Max[1] && b.Min[1] <= a.Max[1]
+}
+
+// containsBound returns true if the bound a contains the bound b, boundary included.
+func containsBound(a, b space.Bound) bool {
+	return a.Min[0] <= b.Min[0] && b.Max[0] <= a.Max[0] && a.Min[1] <= b.Min[1] && b.Max[1] <= a.Max[1]
+}
+
+// boundArea returns the area of the bound.
+func boundArea(b space.Bound) float64 {
+	return (b.Max[0] - b.Min[0]) * (b.Max[1] - b.Min[1])
+}
+
+// boundDistance returns the distance between the bounds a and b, 0 if they intersect.
+func boundDistance(a, b space.Bound) float64 {
+	dx := math.Max(0, math.Max(a.Min[0]-b.Max[0], b.Min[0]-a.Max[0]))
+	dy := math.Max(0, math.Max(a.Min[1]-b.Max[1], b.Min[1]-a.Max[1]))
+	return math.Hypot(dx, dy)
+}
+
+// nearestItem is a node or an entry queued by a nearest neighbour query.
+type nearestItem struct {
+	node     *node
+	entry    Entry
+	distance float64
+}
+
+// nearestQueue is a priority queue of nearestItem, nearest first.
+// Entries come before nodes at the same distance, so that they are returned as soon as possible.
+type nearestQueue []nearestItem
+
+func (q nearestQueue) Len() int { return len(q) }
+
+func (q nearestQueue) Less(i, j int) bool {
+	if q[i].distance != q[j].distance {
+		return q[i].distance < q[j].distance
+	}
+	return q[i].node == nil && q[j].node != nil
+}
+
+func (q nearestQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *nearestQueue) Push(x interface{}) { *q = append(*q, x.(nearestItem)) }
+
+func (q *nearestQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
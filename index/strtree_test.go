@@ -0,0 +1,204 @@
+package index
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+func randomEntries(n int, r *rand.Rand) []Entry {
+	entries := make([]Entry, n)
+	for i := range entries {
+		x, y := r.Float64()*100, r.Float64()*100
+		var geom space.Geometry = space.Point{x, y}
+		if i%3 == 0 {
+			geom = space.LineString{{x, y}, {x + r.Float64()*5, y + r.Float64()*5}}
+		}
+		entries[i] = NewEntry(geom, i)
+	}
+	return entries
+}
+
+func bruteQuery(entries []Entry, b space.Bound) []int {
+	var ids []int
+	for _, e := range entries {
+		if intersectsBound(e.Bound, b) {
+			ids = append(ids, e.Value.(int))
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func values(entries []Entry) []int {
+	ids := make([]int, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.Value.(int))
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSTRtree_Query(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	entries := randomEntries(500, r)
+	loaded := NewSTRtree(0)
+	loaded.Load(entries...)
+	inserted := NewSTRtree(4)
+	for _, e := range entries {
+		inserted.Insert(e)
+	}
+	for _, tree := range []*STRtree{loaded, inserted} {
+		if tree.Size() != len(entries) {
+			t.Fatalf("Size() got = %v, want %v", tree.Size(), len(entries))
+		}
+		for i := 0; i < 50; i++ {
+			x, y := r.Float64()*100, r.Float64()*100
+			b := space.Bound{Min: space.Point{x, y}, Max: space.Point{x + 10, y + 10}}
+			if got, want := values(tree.Query(b)), bruteQuery(entries, b); !equalInts(got, want) {
+				t.Errorf("Query(%v) got = %v, want %v", b, got, want)
+			}
+		}
+	}
+}
+
+func TestSTRtree_Remove(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	entries := randomEntries(300, r)
+	tree := NewSTRtree(4)
+	tree.Load(entries...)
+	for i, e := range entries {
+		if i%2 == 0 {
+			continue
+		}
+		if !tree.Remove(e) {
+			t.Fatalf("Remove(%v) got = false, want true", e.Value)
+		}
+	}
+	if tree.Remove(entries[1]) {
+		t.Errorf("Remove() of a removed entry got = true, want false")
+	}
+	var kept []Entry
+	for i, e := range entries {
+		if i%2 == 0 {
+			kept = append(kept, e)
+		}
+	}
+	if tree.Size() != len(kept) {
+		t.Fatalf("Size() got = %v, want %v", tree.Size(), len(kept))
+	}
+	all := space.Bound{Min: space.Point{-1, -1}, Max: space.Point{200, 200}}
+	if got, want := values(tree.Query(all)), values(kept); !equalInts(got, want) {
+		t.Errorf("Query() got = %v, want %v", got, want)
+	}
+	for _, e := range kept {
+		tree.Remove(e)
+	}
+	if _, ok := tree.Bound(); ok || tree.Size() != 0 {
+		t.Errorf("tree is not empty after removing all entries")
+	}
+}
+
+func TestSTRtree_Visit(t *testing.T) {
+	tree := NewSTRtree(0)
+	tree.Load(randomEntries(100, rand.New(rand.NewSource(3)))...)
+	count := 0
+	tree.Visit(space.Bound{Min: space.Point{0, 0}, Max: space.Point{200, 200}}, func(e Entry) bool {
+		count++
+		return count < 5
+	})
+	if count != 5 {
+		t.Errorf("Visit() called visitor %v times, want 5", count)
+	}
+}
+
+func TestSTRtree_KNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	entries := randomEntries(400, r)
+	tree := NewSTRtree(0)
+	tree.Load(entries...)
+	for i := 0; i < 20; i++ {
+		query := space.Point{r.Float64() * 100, r.Float64() * 100}
+		got, err := tree.KNearest(query, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		distances := make([]float64, len(entries))
+		for j, e := range entries {
+			distances[j], _ = e.Geometry.Distance(query)
+		}
+		sort.Float64s(distances)
+		if len(got) != 5 {
+			t.Fatalf("KNearest() got %v entries, want 5", len(got))
+		}
+		for j, e := range got {
+			d, _ := e.Geometry.Distance(query)
+			if d != distances[j] {
+				t.Errorf("KNearest()[%v] distance got = %v, want %v", j, d, distances[j])
+			}
+		}
+	}
+
+	nearest, err := tree.Nearest(space.Point{-50, -50})
+	if err != nil || nearest == nil {
+		t.Fatalf("Nearest() got = %v, %v", nearest, err)
+	}
+	if empty, _ := NewSTRtree(0).Nearest(space.Point{0, 0}); empty != nil {
+		t.Errorf("Nearest() on an empty tree got = %v, want nil", empty)
+	}
+}
+
+func TestSTRtree_KNearestPolygons(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	entries := make([]Entry, 200)
+	for i := range entries {
+		x, y := r.Float64()*100, r.Float64()*100
+		square := space.Polygon{{{x, y}, {x + 2, y}, {x + 2, y + 2}, {x, y + 2}, {x, y}}}
+		entries[i] = NewEntry(square, i)
+	}
+	tree := NewSTRtree(0)
+	tree.Load(entries...)
+	for i := 0; i < 20; i++ {
+		x, y := r.Float64()*100, r.Float64()*100
+		query := space.LineString{{x, y}, {x + 5, y + 3}}
+		got, err := tree.KNearest(query, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		distances := make([]float64, len(entries))
+		for j, e := range entries {
+			distances[j], _ = e.Geometry.Distance(query)
+		}
+		sort.Float64s(distances)
+		if len(got) != 3 {
+			t.Fatalf("KNearest() got %v entries, want 3", len(got))
+		}
+		for j, e := range got {
+			d, _ := e.Geometry.Distance(query)
+			if d != distances[j] {
+				t.Errorf("KNearest()[%v] distance got = %v, want %v", j, d, distances[j])
+			}
+		}
+	}
+
+	covered := space.Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}
+	tree = NewSTRtree(0)
+	tree.Load(NewEntry(covered, "covered"), NewEntry(space.Point{11, 5}, "point"))
+	if nearest, err := tree.Nearest(space.Point{5, 5}); err != nil || nearest == nil || nearest.Value != "covered" {
+		t.Errorf("Nearest() got = %v, %v, want the polygon containing the query", nearest, err)
+	}
+}
@@ -0,0 +1,146 @@
+package mvt
+
+// rect is a rectangle of the coordinates of a tile, against which geometries are clipped.
+type rect struct {
+	minX, minY, maxX, maxY float64
+}
+
+func (r rect) contains(p []float64) bool {
+	return p[0] >= r.minX && p[0] <= r.maxX && p[1] >= r.minY && p[1] <= r.maxY
+}
+
+// clipPoints returns the points in the rectangle.
+func clipPoints(points [][]float64, r rect) [][]float64 {
+	var clipped [][]float64
+	for _, p := range points {
+		if r.contains(p) {
+			clipped = append(clipped, p)
+		}
+	}
+	return clipped
+}
+
+// clipLine returns the parts of the line in the rectangle, clipping each segment with the Liang-Barsky algorithm.
+func clipLine(line [][]float64, r rect) [][][]float64 {
+	var (
+		lines   [][][]float64
+		current [][]float64
+	)
+	for i := 0; i+1 < len(line); i++ {
+		a, b, ok := clipSegment(line[i], line[i+1], r)
+		if !ok {
+			continue
+		}
+		if len(current) == 0 || !equalPoint(current[len(current)-1], a) {
+			if len(current) > 1 {
+				lines = append(lines, current)
+			}
+			current = [][]float64{a}
+		}
+		current = append(current, b)
+	}
+	if len(current) > 1 {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// clipSegment returns the part of the segment ab in the rectangle, and false if there is none.
+func clipSegment(a, b []float64, r rect) ([]float64, []float64, bool) {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	t0, t1 := 0.0, 1.0
+	for _, edge := range [4][2]float64{
+		{-dx, a[0] - r.minX},
+		{dx, r.maxX - a[0]},
+		{-dy, a[1] - r.minY},
+		{dy, r.maxY - a[1]},
+	} {
+		p, q := edge[0], edge[1]
+		if p == 0 {
+			if q < 0 {
+				return nil, nil, false
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			if t > t1 {
+				return nil, nil, false
+			}
+			if t > t0 {
+				t0 = t
+			}
+		} else {
+			if t < t0 {
+				return nil, nil, false
+			}
+			if t < t1 {
+				t1 = t
+			}
+		}
+	}
+	start, end := a, b
+	if t0 > 0 {
+		start = []float64{a[0] + t0*dx, a[1] + t0*dy}
+	}
+	if t1 < 1 {
+		end = []float64{a[0] + t1*dx, a[1] + t1*dy}
+	}
+	return start, end, true
+}
+
+// clipRing returns the closed ring clipped by the rectangle with the Sutherland-Hodgman algorithm,
+// or nil if it is outside. Parts of the ring outside the rectangle are replaced by its edges.
+func clipRing(ring [][]float64, r rect) [][]float64 {
+	edges := []struct {
+		inside    func(p []float64) bool
+		intersect func(a, b []float64) []float64
+	}{
+		{func(p []float64) bool { return p[0] >= r.minX }, func(a, b []float64) []float64 { return intersectX(a, b, r.minX) }},
+		{func(p []float64) bool { return p[0] <= r.maxX }, func(a, b []float64) []float64 { return intersectX(a, b, r.maxX) }},
+		{func(p []float64) bool { return p[1] >= r.minY }, func(a, b []float64) []float64 { return intersectY(a, b, r.minY) }},
+		{func(p []float64) bool { return p[1] <= r.maxY }, func(a, b []float64) []float64 { return intersectY(a, b, r.maxY) }},
+	}
+
+	points := ring
+	if len(points) > 1 && equalPoint(points[0], points[len(points)-1]) {
+		points = points[:len(points)-1]
+	}
+	for _, edge := range edges {
+		if len(points) == 0 {
+			return nil
+		}
+		var clipped [][]float64
+		prev := points[len(points)-1]
+		for _, p := range points {
+			if edge.inside(p) {
+				if !edge.inside(prev) {
+					clipped = append(clipped, edge.intersect(prev, p))
+				}
+				clipped = append(clipped, p)
+			} else if edge.inside(prev) {
+				clipped = append(clipped, edge.intersect(prev, p))
+			}
+			prev = p
+		}
+		points = clipped
+	}
+	if len(points) < 3 {
+		return nil
+	}
+	return append(points, points[0])
+}
+
+// intersectX returns the intersection of the segment ab and the vertical line of x.
+func intersectX(a, b []float64, x float64) []float64 {
+	return []float64{x, a[1] + (x-a[0])*(b[1]-a[1])/(b[0]-a[0])}
+}
+
+// intersectY returns the intersection of the segment ab and the horizontal line of y.
+func intersectY(a, b []float64, y float64) []float64 {
+	return []float64{a[0] + (y-a[1])*(b[0]-a[0])/(b[1]-a[1]), y}
+}
+
+func equalPoint(a, b []float64) bool {
+	return a[0] == b[0] && a[1] == b[1]
+}
@@ -0,0 +1,290 @@
+package mvt
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+// Geometry types of the features of a tile.
+const (
+	typeUnknown    = 0
+	typePoint      = 1
+	typeLineString = 2
+	typePolygon    = 3
+)
+
+// Commands of the geometries of the features.
+const (
+	commandMoveTo    = 1
+	commandLineTo    = 2
+	commandClosePath = 7
+)
+
+// shapes are the points, lines and polygons of a geometry. Each kind of them is encoded as a feature.
+type shapes struct {
+	points   [][]float64
+	lines    [][][]float64
+	polygons [][][][]float64
+}
+
+// add adds the shapes of a geometry, those of the members of a collection.
+func (s *shapes) add(geom space.Geometry) {
+	switch g := geom.(type) {
+	case space.Point:
+		if !g.IsEmpty() {
+			s.points = append(s.points, g)
+		}
+	case space.MultiPoint:
+		for _, p := range g {
+			s.add(p)
+		}
+	case space.LineString:
+		s.lines = append(s.lines, g)
+	case space.MultiLineString:
+		for _, ls := range g {
+			s.lines = append(s.lines, ls)
+		}
+	case space.Polygon:
+		s.polygons = append(s.polygons, g)
+	case space.MultiPolygon:
+		for _, p := range g {
+			s.polygons = append(s.polygons, p)
+		}
+	case space.Collection:
+		for _, member := range g {
+			s.add(member)
+		}
+	}
+}
+
+// tileShapes returns the shapes of the geometry projected in the tile, clipped by the rectangle and
+// rounded to the grid of the tile. Lines and rings which are degenerated by the rounding are dropped.
+func tileShapes(geom space.Geometry, tile Tile, extent float64, r rect) shapes {
+	var s, tiled shapes
+	s.add(geom)
+
+	for _, p := range s.points {
+		if p = tile.project(p, extent); r.contains(p) {
+			tiled.points = append(tiled.points, round(p))
+		}
+	}
+	for _, line := range s.lines {
+		for _, clipped := range clipLine(projectLine(line, tile, extent), r) {
+			if clipped = roundLine(clipped); len(clipped) > 1 {
+				tiled.lines = append(tiled.lines, clipped)
+			}
+		}
+	}
+	for _, polygon := range s.polygons {
+		var rings [][][]float64
+		for i, ring := range polygon {
+			clipped := roundLine(clipRing(projectLine(ring, tile, extent), r))
+			area := ringArea(clipped)
+			if len(clipped) < 4 || area == 0 {
+				if i == 0 {
+					break
+				}
+				continue
+			}
+			// exterior rings have a positive area in the coordinates of the tile, and interior rings a negative one.
+			if (i == 0) != (area > 0) {
+				reverse(clipped)
+			}
+			rings = append(rings, clipped)
+		}
+		if len(rings) > 0 {
+			tiled.polygons = append(tiled.polygons, rings)
+		}
+	}
+	return tiled
+}
+
+func projectLine(line [][]float64, tile Tile, extent float64) [][]float64 {
+	projected := make([][]float64, len(line))
+	for i, p := range line {
+		projected[i] = tile.project(p, extent)
+	}
+	return projected
+}
+
+func round(p []float64) []float64 {
+	return []float64{math.Round(p[0]), math.Round(p[1])}
+}
+
+// roundLine rounds the points of a line, removing the points repeated.
+func roundLine(line [][]float64) [][]float64 {
+	var rounded [][]float64
+	for _, p := range line {
+		p = round(p)
+		if len(rounded) == 0 || !equalPoint(rounded[len(rounded)-1], p) {
+			rounded = append(rounded, p)
+		}
+	}
+	return rounded
+}
+
+// ringArea returns the area of a closed ring given by the surveyor's formula,
+// positive for a clockwise ring in the coordinates of a tile where y grows downwards.
+func ringArea(ring [][]float64) float64 {
+	area := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+func reverse(line [][]float64) {
+	for i, j := 0, len(line)-1; i < j; i, j = i+1, j-1 {
+		line[i], line[j] = line[j], line[i]
+	}
+}
+
+// commandWriter writes the commands of a geometry, from the cursor at the last point written.
+type commandWriter struct {
+	commands []uint32
+	x, y     int64
+}
+
+// write writes a command with a parameter for each point.
+func (w *commandWriter) write(id int, points [][]float64) {
+	w.commands = append(w.commands, command(id, len(points)))
+	for _, p := range points {
+		x, y := int64(p[0]), int64(p[1])
+		w.commands = append(w.commands, zigzag(x-w.x), zigzag(y-w.y))
+		w.x, w.y = x, y
+	}
+}
+
+// encodePoints returns the commands of the points.
+func encodePoints(points [][]float64) []uint32 {
+	w := commandWriter{}
+	w.write(commandMoveTo, points)
+	return w.commands
+}
+
+// encodeLines returns the commands of the lines, or of the closed rings of polygons if closed is true.
+func encodeLines(lines [][][]float64, closed bool) []uint32 {
+	w := commandWriter{}
+	for _, line := range lines {
+		if closed {
+			line = line[:len(line)-1]
+		}
+		w.write(commandMoveTo, line[:1])
+		w.write(commandLineTo, line[1:])
+		if closed {
+			w.commands = append(w.commands, command(commandClosePath, 1))
+		}
+	}
+	return w.commands
+}
+
+func command(id, count int) uint32 {
+	return uint32(id&0x7 | count<<3)
+}
+
+func zigzag(v int64) uint32 {
+	return uint32((v << 1) ^ (v >> 63))
+}
+
+func unzigzag(v uint32) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+// decodePaths returns the paths drawn by the commands of a geometry. Each point of a MoveTo starts a path,
+// and a ClosePath closes the path with its first point.
+func decodePaths(commands []uint32) ([][][]float64, error) {
+	var (
+		paths [][][]float64
+		x, y  int64
+	)
+	for i := 0; i < len(commands); {
+		id, count := int(commands[i]&0x7), int(commands[i]>>3)
+		i++
+		switch id {
+		case commandMoveTo, commandLineTo:
+			if i+2*count > len(commands) || (id == commandLineTo && len(paths) == 0) {
+				return nil, ErrInvalidTile
+			}
+			for j := 0; j < count; j++ {
+				x += unzigzag(commands[i])
+				y += unzigzag(commands[i+1])
+				i += 2
+				p := []float64{float64(x), float64(y)}
+				if id == commandMoveTo {
+					paths = append(paths, [][]float64{p})
+				} else {
+					paths[len(paths)-1] = append(paths[len(paths)-1], p)
+				}
+			}
+		case commandClosePath:
+			if len(paths) == 0 {
+				return nil, ErrInvalidTile
+			}
+			path := paths[len(paths)-1]
+			paths[len(paths)-1] = append(path, path[0])
+		default:
+			return nil, ErrInvalidTile
+		}
+	}
+	return paths, nil
+}
+
+// decodeGeometry returns the geometry of the commands of a feature of the type, in longitudes and latitudes.
+// Polygons are split at each ring of positive area, the rings of negative area being their holes.
+func decodeGeometry(typ int, commands []uint32, tile Tile, extent float64) (space.Geometry, error) {
+	paths, err := decodePaths(commands)
+	if err != nil {
+		return nil, err
+	}
+	unproject := func(line [][]float64) [][]float64 {
+		points := make([][]float64, len(line))
+		for i, p := range line {
+			points[i] = tile.unproject(p, extent)
+		}
+		return points
+	}
+
+	switch typ {
+	case typePoint:
+		var mp space.MultiPoint
+		for _, path := range paths {
+			for _, p := range unproject(path) {
+				mp = append(mp, p)
+			}
+		}
+		if len(mp) == 1 {
+			return mp[0], nil
+		}
+		return mp, nil
+	case typeLineString:
+		var mls space.MultiLineString
+		for _, path := range paths {
+			if len(path) > 1 {
+				mls = append(mls, unproject(path))
+			}
+		}
+		if len(mls) == 1 {
+			return mls[0], nil
+		}
+		return mls, nil
+	case typePolygon:
+		var mp space.MultiPolygon
+		for _, path := range paths {
+			area := ringArea(path)
+			if len(path) < 4 || area == 0 {
+				continue
+			}
+			if area > 0 || len(mp) == 0 {
+				mp = append(mp, space.Polygon{unproject(path)})
+			} else {
+				mp[len(mp)-1] = append(mp[len(mp)-1], unproject(path))
+			}
+		}
+		if len(mp) == 1 {
+			return mp[0], nil
+		}
+		return mp, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+package mvt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestEncodeGeometry(t *testing.T) {
+	// examples of the specification.
+	tests := []struct {
+		name     string
+		commands []uint32
+		want     []uint32
+	}{
+		{name: "point", commands: encodePoints([][]float64{{25, 17}}), want: []uint32{9, 50, 34}},
+		{name: "multi point", commands: encodePoints([][]float64{{5, 7}, {3, 2}}), want: []uint32{17, 10, 14, 3, 9}},
+		{name: "line string", commands: encodeLines([][][]float64{{{2, 2}, {2, 10}, {10, 10}}}, false),
+			want: []uint32{9, 4, 4, 18, 0, 16, 16, 0}},
+		{name: "multi line string", commands: encodeLines([][][]float64{{{2, 2}, {2, 10}, {10, 10}}, {{1, 1}, {3, 5}}}, false),
+			want: []uint32{9, 4, 4, 18, 0, 16, 16, 0, 9, 17, 17, 10, 4, 8}},
+		{name: "polygon", commands: encodeLines([][][]float64{{{3, 6}, {8, 12}, {20, 34}, {3, 6}}}, true),
+			want: []uint32{9, 6, 12, 18, 10, 12, 24, 44, 15}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.commands, tt.want) {
+				t.Errorf("commands got = %v, want %v", tt.commands, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePaths(t *testing.T) {
+	paths, err := decodePaths([]uint32{9, 4, 4, 18, 0, 16, 16, 0, 9, 17, 17, 10, 4, 8, 15})
+	want := [][][]float64{{{2, 2}, {2, 10}, {10, 10}}, {{1, 1}, {3, 5}, {1, 1}}}
+	if err != nil || !reflect.DeepEqual(paths, want) {
+		t.Errorf("decodePaths() got = %v, %v, want %v", paths, err, want)
+	}
+
+	for _, commands := range [][]uint32{{18, 0, 16}, {9, 4}, {15}, {12, 1, 1}} {
+		if _, err := decodePaths(commands); err != ErrInvalidTile {
+			t.Errorf("decodePaths(%v) error = %v, want %v", commands, err, ErrInvalidTile)
+		}
+	}
+}
+
+func TestClip(t *testing.T) {
+	r := rect{minX: 0, minY: 0, maxX: 10, maxY: 10}
+	lines := clipLine([][]float64{{-5, 5}, {5, 5}, {5, 15}, {8, 15}, {8, 5}}, r)
+	want := [][][]float64{{{0, 5}, {5, 5}, {5, 10}}, {{8, 10}, {8, 5}}}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("clipLine() got = %v, want %v", lines, want)
+	}
+
+	ring := clipRing([][]float64{{-5, -5}, {5, -5}, {5, 5}, {-5, 5}, {-5, -5}}, r)
+	if area := ringArea(ring); len(ring) < 4 || !equalPoint(ring[0], ring[len(ring)-1]) || area != 25 {
+		t.Errorf("clipRing() got = %v, area %v", ring, area)
+	}
+	if ring := clipRing([][]float64{{20, 20}, {30, 20}, {30, 30}, {20, 20}}, r); ring != nil {
+		t.Errorf("clipRing() outside got = %v", ring)
+	}
+}
+
+func TestTileShapes(t *testing.T) {
+	tile := Tile{}
+	r := rect{minX: 0, minY: 0, maxX: 256, maxY: 256}
+	// exterior ring counterclockwise and hole clockwise, as in GeoJSON.
+	polygon := space.Polygon{
+		{{-90, -45}, {90, -45}, {90, 45}, {-90, 45}, {-90, -45}},
+		{{-10, -10}, {-10, 10}, {10, 10}, {10, -10}, {-10, -10}},
+		{{0, 0}, {0.01, 0}, {0.01, 0.01}, {0, 0}},
+	}
+	s := tileShapes(space.Collection{polygon, space.Point{0, 0}, space.Point{200, 0}}, tile, 256, r)
+	if len(s.polygons) != 1 || len(s.polygons[0]) != 2 || len(s.points) != 1 || !equalPoint(s.points[0], []float64{128, 128}) {
+		t.Fatalf("tileShapes() got = %v", s)
+	}
+	if ringArea(s.polygons[0][0]) <= 0 || ringArea(s.polygons[0][1]) >= 0 {
+		t.Errorf("tileShapes() rings got = %v", s.polygons[0])
+	}
+}
@@ -0,0 +1,431 @@
+/*
+Package mvt is a library for encoding and decoding Mapbox Vector Tiles of GeoJSON features.
+
+Features are encoded in the layers of a tile z/x/y of the Web Mercator tiling scheme: their geometries in
+longitudes and latitudes are projected in the tile, clipped to it with a buffer, and rounded to its grid.
+Decoding projects them back in longitudes and latitudes.
+See the specification at https://github.com/mapbox/vector-tile-spec/tree/master/2.1
+*/
+package mvt
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/spatial-go/geoos/geojson"
+)
+
+const (
+	// DefaultExtent is the extent of the layers of which the Extent is zero.
+	DefaultExtent = 4096
+	// DefaultBuffer is the buffer of the options of which the Buffer is zero.
+	DefaultBuffer = 64
+
+	version = 2
+)
+
+// Fields of the messages of a tile.
+const (
+	tileLayers = 3
+
+	layerName     = 1
+	layerFeatures = 2
+	layerKeys     = 3
+	layerValues   = 4
+	layerExtent   = 5
+	layerVersion  = 15
+
+	featureID       = 1
+	featureTags     = 2
+	featureType     = 3
+	featureGeometry = 4
+
+	valueString = 1
+	valueFloat  = 2
+	valueDouble = 3
+	valueInt    = 4
+	valueUint   = 5
+	valueSint   = 6
+	valueBool   = 7
+)
+
+// Layer a layer of features of a vector tile
+type Layer struct {
+	Name string
+	// Extent is the width of the tile in the coordinates of the geometries, DefaultExtent if it is zero.
+	Extent   uint32
+	Features []*geojson.Feature
+}
+
+// Options an options of encoding a vector tile
+type Options struct {
+	// Buffer is the width of the margin around the tile, in the coordinates of the geometries, within which
+	// they are kept when they are clipped. DefaultBuffer if it is zero, and no margin if it is negative.
+	Buffer int
+}
+
+// Marshal encodes the layers of features in the vector tile.
+// A feature of several kinds of geometries, like a collection of points and lines, is encoded as a feature
+// for each of them. Features outside the tile are left out, and so are properties with a null value.
+// The id of a feature is encoded if it is an unsigned integer.
+func Marshal(tile Tile, layers []*Layer, options Options) ([]byte, error) {
+	buffer := float64(options.Buffer)
+	if options.Buffer == 0 {
+		buffer = DefaultBuffer
+	} else if options.Buffer < 0 {
+		buffer = 0
+	}
+
+	w := protoWriter{}
+	for _, layer := range layers {
+		data, err := encodeLayer(tile, layer, buffer)
+		if err != nil {
+			return nil, err
+		}
+		w.bytes(tileLayers, data)
+	}
+	return w.buf, nil
+}
+
+// encodeLayer returns the message of a layer, the keys and the values of the properties of which are deduplicated.
+func encodeLayer(tile Tile, layer *Layer, buffer float64) ([]byte, error) {
+	extent := layer.Extent
+	if extent == 0 {
+		extent = DefaultExtent
+	}
+	r := rect{minX: -buffer, minY: -buffer, maxX: float64(extent) + buffer, maxY: float64(extent) + buffer}
+
+	var (
+		keys    []string
+		values  []interface{}
+		keyIDs  = map[string]uint32{}
+		valueID = map[interface{}]uint32{}
+	)
+	w := protoWriter{}
+	w.string(layerName, layer.Name)
+	for _, feature := range layer.Features {
+		if feature.Geometry.Coordinates == nil && feature.Geometry.Geometries == nil {
+			continue
+		}
+		s := tileShapes(feature.Geometry.Geometry(), tile, float64(extent), r)
+		if len(s.points) == 0 && len(s.lines) == 0 && len(s.polygons) == 0 {
+			continue
+		}
+
+		var tags []uint32
+		for _, key := range sortedKeys(feature.Properties) {
+			v, err := tagValue(feature.Properties[key])
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				continue
+			}
+			if _, ok := keyIDs[key]; !ok {
+				keyIDs[key] = uint32(len(keys))
+				keys = append(keys, key)
+			}
+			if _, ok := valueID[v]; !ok {
+				valueID[v] = uint32(len(values))
+				values = append(values, v)
+			}
+			tags = append(tags, keyIDs[key], valueID[v])
+		}
+
+		id, hasID := toID(feature.ID)
+		write := func(typ int, commands []uint32) {
+			f := protoWriter{}
+			if hasID {
+				f.uint(featureID, id)
+			}
+			if len(tags) > 0 {
+				f.packed(featureTags, tags)
+			}
+			f.uint(featureType, uint64(typ))
+			f.packed(featureGeometry, commands)
+			w.bytes(layerFeatures, f.buf)
+		}
+		if len(s.points) > 0 {
+			write(typePoint, encodePoints(s.points))
+		}
+		if len(s.lines) > 0 {
+			write(typeLineString, encodeLines(s.lines, false))
+		}
+		if len(s.polygons) > 0 {
+			var rings [][][]float64
+			for _, polygon := range s.polygons {
+				rings = append(rings, polygon...)
+			}
+			write(typePolygon, encodeLines(rings, true))
+		}
+	}
+
+	for _, key := range keys {
+		w.string(layerKeys, key)
+	}
+	for _, v := range values {
+		w.bytes(layerValues, encodeValue(v))
+	}
+	w.uint(layerExtent, uint64(extent))
+	w.uint(layerVersion, version)
+	return w.buf, nil
+}
+
+// tagValue returns the value of a property as a string, a float64, an int64, a uint64 or a bool,
+// integers being encoded more compactly than doubles. Values of other types are encoded in JSON,
+// and null values and NaN are nil.
+func tagValue(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case nil:
+		return nil, nil
+	case string, bool, int64, uint64:
+		return x, nil
+	case float64:
+		if math.IsNaN(x) {
+			return nil, nil
+		}
+		if x == math.Trunc(x) && math.Abs(x) < 1<<53 {
+			return int64(x), nil
+		}
+		return x, nil
+	case float32:
+		return tagValue(float64(x))
+	case int:
+		return int64(x), nil
+	case int32:
+		return int64(x), nil
+	case uint:
+		return uint64(x), nil
+	case uint32:
+		return uint64(x), nil
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return tagValue(f)
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// encodeValue returns the message of a value given by tagValue.
+func encodeValue(v interface{}) []byte {
+	w := protoWriter{}
+	switch x := v.(type) {
+	case string:
+		w.string(valueString, x)
+	case float64:
+		w.double(valueDouble, x)
+	case int64:
+		if x < 0 {
+			w.uint(valueSint, uint64((x<<1)^(x>>63)))
+		} else {
+			w.uint(valueUint, uint64(x))
+		}
+	case uint64:
+		w.uint(valueUint, x)
+	case bool:
+		if x {
+			w.uint(valueBool, 1)
+		} else {
+			w.uint(valueBool, 0)
+		}
+	}
+	return w.buf
+}
+
+// toID returns the id of a feature, and false if it is not an unsigned integer.
+func toID(id interface{}) (uint64, bool) {
+	switch x := id.(type) {
+	case float64:
+		return uint64(x), x >= 0 && x == math.Trunc(x) && x < 1<<64
+	case int:
+		return uint64(x), x >= 0
+	case int64:
+		return uint64(x), x >= 0
+	case uint64:
+		return x, true
+	case uint32:
+		return uint64(x), true
+	case json.Number:
+		return toID(string(x))
+	case string:
+		v, err := strconv.ParseUint(x, 10, 64)
+		return v, err == nil
+	}
+	return 0, false
+}
+
+// Unmarshal decodes the layers of features of the vector tile, their geometries in longitudes and latitudes.
+// The numbers of the properties are decoded as float64, like in GeoJSON, and the ids of the features as uint64.
+func Unmarshal(tile Tile, data []byte) ([]*Layer, error) {
+	var layers []*Layer
+	r := protoReader{data: data}
+	for {
+		field, wireType, ok := r.next()
+		if !ok {
+			break
+		}
+		if field != tileLayers || wireType != wireBytes {
+			r.skip(wireType)
+			continue
+		}
+		layer, err := decodeLayer(tile, r.bytes())
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return layers, nil
+}
+
+// rawFeature is a feature of a layer, decoded once its keys, values and extent are read.
+type rawFeature struct {
+	id       interface{}
+	tags     []uint32
+	typ      int
+	geometry []uint32
+}
+
+// decodeLayer returns the layer of a message.
+func decodeLayer(tile Tile, data []byte) (*Layer, error) {
+	layer := &Layer{Extent: DefaultExtent}
+	var (
+		keys     []string
+		values   []interface{}
+		features []rawFeature
+	)
+	r := protoReader{data: data}
+	for {
+		field, wireType, ok := r.next()
+		if !ok {
+			break
+		}
+		switch {
+		case field == layerName && wireType == wireBytes:
+			layer.Name = string(r.bytes())
+		case field == layerFeatures && wireType == wireBytes:
+			features = append(features, decodeFeature(&r, r.bytes()))
+		case field == layerKeys && wireType == wireBytes:
+			keys = append(keys, string(r.bytes()))
+		case field == layerValues && wireType == wireBytes:
+			values = append(values, decodeValue(&r, r.bytes()))
+		case field == layerExtent && wireType == wireVarint:
+			layer.Extent = uint32(r.varint())
+		default:
+			r.skip(wireType)
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if layer.Extent == 0 {
+		return nil, ErrInvalidTile
+	}
+
+	for _, raw := range features {
+		feature := geojson.NewFeature(geojson.Geometry{})
+		feature.ID = raw.id
+		geom, err := decodeGeometry(raw.typ, raw.geometry, tile, float64(layer.Extent))
+		if err != nil {
+			return nil, err
+		}
+		if geom != nil && !geom.IsEmpty() {
+			feature.Geometry = *geojson.NewGeometry(geom)
+		}
+		if len(raw.tags)%2 != 0 {
+			return nil, ErrInvalidTile
+		}
+		for i := 0; i < len(raw.tags); i += 2 {
+			k, v := int(raw.tags[i]), int(raw.tags[i+1])
+			if k >= len(keys) || v >= len(values) {
+				return nil, ErrInvalidTile
+			}
+			feature.Properties[keys[k]] = values[v]
+		}
+		layer.Features = append(layer.Features, feature)
+	}
+	return layer, nil
+}
+
+// decodeFeature returns the feature of a message, reporting its errors to the reader of the layer.
+func decodeFeature(layer *protoReader, data []byte) rawFeature {
+	f := rawFeature{typ: typeUnknown}
+	r := protoReader{data: data}
+	for {
+		field, wireType, ok := r.next()
+		if !ok {
+			break
+		}
+		switch {
+		case field == featureID && wireType == wireVarint:
+			f.id = r.varint()
+		case field == featureTags:
+			f.tags = append(f.tags, r.packed(wireType)...)
+		case field == featureType && wireType == wireVarint:
+			f.typ = int(r.varint())
+		case field == featureGeometry:
+			f.geometry = append(f.geometry, r.packed(wireType)...)
+		default:
+			r.skip(wireType)
+		}
+	}
+	if r.err != nil && layer.err == nil {
+		layer.err = r.err
+	}
+	return f
+}
+
+// decodeValue returns the value of a message, reporting its errors to the reader of the layer.
+func decodeValue(layer *protoReader, data []byte) interface{} {
+	var v interface{}
+	r := protoReader{data: data}
+	for {
+		field, wireType, ok := r.next()
+		if !ok {
+			break
+		}
+		switch {
+		case field == valueString && wireType == wireBytes:
+			v = string(r.bytes())
+		case field == valueFloat && wireType == wireFixed32:
+			v = r.float()
+		case field == valueDouble && wireType == wireFixed64:
+			v = r.double()
+		case field == valueInt && wireType == wireVarint:
+			v = float64(int64(r.varint()))
+		case field == valueUint && wireType == wireVarint:
+			v = float64(r.varint())
+		case field == valueSint && wireType == wireVarint:
+			x := r.varint()
+			v = float64(int64(x>>1) ^ -int64(x&1))
+		case field == valueBool && wireType == wireVarint:
+			v = r.varint() != 0
+		default:
+			r.skip(wireType)
+		}
+	}
+	if r.err != nil && layer.err == nil {
+		layer.err = r.err
+	}
+	return v
+}
+
+// sortedKeys returns the keys of the properties in order, so that tiles are encoded the same way every time.
+func sortedKeys(properties geojson.Properties) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,169 @@
+package mvt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+func newFeature(id interface{}, geom space.Geometry, properties geojson.Properties) *geojson.Feature {
+	f := geojson.NewFeature(*geojson.NewGeometry(geom))
+	f.ID = id
+	f.Properties = properties
+	return f
+}
+
+// near returns true if the geometries have the same points, within the tolerance.
+func near(a, b space.Geometry, tolerance float64) bool {
+	switch g := a.(type) {
+	case space.Point:
+		return g.EqualsExact(b, tolerance)
+	case space.LineString:
+		line, ok := b.(space.LineString)
+		if !ok || len(line) != len(g) {
+			return false
+		}
+		for i := range g {
+			if !near(space.Point(g[i]), space.Point(line[i]), tolerance) {
+				return false
+			}
+		}
+		return true
+	case space.Polygon:
+		polygon, ok := b.(space.Polygon)
+		if !ok || len(polygon) != len(g) {
+			return false
+		}
+		for i := range g {
+			if !near(space.LineString(g[i]), space.LineString(polygon[i]), tolerance) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	tile := Tile{Z: 10, X: 843, Y: 388}
+	// the point at a fraction of the width and the height of the tile.
+	at := func(fx, fy float64) space.Point {
+		return tile.unproject([]float64{fx, fy}, 1)
+	}
+	features := []*geojson.Feature{
+		newFeature(1.0, at(0.25, 0.5), geojson.Properties{"name": "a", "kind": "poi", "rank": 1.0, "open": true}),
+		newFeature(2.0, space.MultiPoint{at(0.1, 0.1), at(2, 2)}, geojson.Properties{"name": "b", "kind": "poi", "rank": -2.5}),
+		newFeature("3", space.LineString{at(-0.5, 0.5), at(0.5, 0.5), at(0.5, 0.25)}, geojson.Properties{"kind": "road", "empty": nil}),
+		// rings oriented as in vector tiles, the exterior ring clockwise.
+		newFeature(nil, space.Polygon{
+			{at(0.2, 0.8), at(0.2, 0.2), at(0.8, 0.2), at(0.8, 0.8), at(0.2, 0.8)},
+			{at(0.4, 0.4), at(0.4, 0.6), at(0.6, 0.6), at(0.6, 0.4), at(0.4, 0.4)},
+		}, geojson.Properties{"kind": "park", "tags": []interface{}{"x"}}),
+		newFeature(5.0, at(3, 3), geojson.Properties{"name": "outside"}),
+	}
+	data, err := Marshal(tile, []*Layer{{Name: "pois", Features: features}, {Name: "empty", Extent: 256}}, Options{})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	layers, err := Unmarshal(tile, data)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(layers) != 2 || layers[0].Name != "pois" || layers[0].Extent != DefaultExtent ||
+		layers[1].Name != "empty" || layers[1].Extent != 256 || len(layers[1].Features) != 0 {
+		t.Fatalf("Unmarshal() got = %v", layers)
+	}
+	got := layers[0].Features
+	if len(got) != 4 {
+		t.Fatalf("Unmarshal() got %d features, want 4", len(got))
+	}
+
+	// a pixel of the tile is the tolerance of the coordinates.
+	bound := tile.Bound()
+	tolerance := (bound.Max.X() - bound.Min.X()) / DefaultExtent
+	wants := []space.Geometry{
+		at(0.25, 0.5),
+		at(0.1, 0.1),
+		space.LineString{tile.unproject([]float64{-DefaultBuffer, DefaultExtent / 2}, DefaultExtent), at(0.5, 0.5), at(0.5, 0.25)},
+		features[3].Geometry.Coordinates,
+	}
+	ids := []interface{}{uint64(1), uint64(2), uint64(3), nil}
+	for i, f := range got {
+		if !near(f.Geometry.Coordinates, wants[i], tolerance) {
+			t.Errorf("feature %d geometry got = %v, want %v", i, f.Geometry.Coordinates, wants[i])
+		}
+		if f.ID != ids[i] {
+			t.Errorf("feature %d id got = %v, want %v", i, f.ID, ids[i])
+		}
+	}
+	if p := got[0].Properties; len(p) != 4 || p["name"] != "a" || p["rank"] != 1.0 || p["open"] != true {
+		t.Errorf("feature 0 properties got = %v", p)
+	}
+	if p := got[1].Properties; p["rank"] != -2.5 || p["kind"] != "poi" {
+		t.Errorf("feature 1 properties got = %v", p)
+	}
+	if p := got[2].Properties; len(p) != 1 || p["kind"] != "road" {
+		t.Errorf("feature 2 properties got = %v", p)
+	}
+	if p := got[3].Properties; p["tags"] != `["x"]` {
+		t.Errorf("feature 3 properties got = %v", p)
+	}
+}
+
+func TestEncodeLayer_Tags(t *testing.T) {
+	tile := Tile{}
+	features := []*geojson.Feature{
+		newFeature(nil, space.Point{0, 0}, geojson.Properties{"kind": "a", "n": 1.0}),
+		newFeature(nil, space.Point{1, 1}, geojson.Properties{"kind": "a", "n": 1}),
+		newFeature(nil, space.Point{2, 2}, geojson.Properties{"kind": "b", "m": 1.0}),
+	}
+	data, err := encodeLayer(tile, &Layer{Name: "l", Features: features}, 0)
+	if err != nil {
+		t.Fatalf("encodeLayer() error = %v", err)
+	}
+	keys, values := 0, 0
+	r := protoReader{data: data}
+	for {
+		field, wireType, ok := r.next()
+		if !ok {
+			break
+		}
+		switch field {
+		case layerKeys:
+			keys++
+		case layerValues:
+			values++
+		}
+		r.skip(wireType)
+	}
+	// keys kind, n and m, values a, 1 and b.
+	if keys != 3 || values != 3 {
+		t.Errorf("encodeLayer() got %d keys and %d values, want 3 and 3", keys, values)
+	}
+}
+
+func TestTile_project(t *testing.T) {
+	tile := Tile{Z: 1, X: 1, Y: 0}
+	tests := []struct {
+		lonLat, want []float64
+	}{
+		{lonLat: []float64{0, 0}, want: []float64{0, 4096}},
+		{lonLat: []float64{90, maxLatitude}, want: []float64{2048, 0}},
+		{lonLat: []float64{180, 90}, want: []float64{4096, 0}},
+	}
+	for _, tt := range tests {
+		got := tile.project(tt.lonLat, DefaultExtent)
+		if math.Abs(got[0]-tt.want[0]) > 1e-6 || math.Abs(got[1]-tt.want[1]) > 1e-6 {
+			t.Errorf("project(%v) got = %v, want %v", tt.lonLat, got, tt.want)
+		}
+	}
+	if p := tile.unproject([]float64{2048, 4096}, DefaultExtent); math.Abs(p.X()-90) > 1e-9 || math.Abs(p.Y()) > 1e-9 {
+		t.Errorf("unproject() got = %v", p)
+	}
+	if _, err := Unmarshal(tile, []byte{0x1a, 0x05, 0x0a}); err != ErrInvalidTile {
+		t.Errorf("Unmarshal() of invalid data error = %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package mvt
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// ErrInvalidTile is returned when data is not a valid vector tile.
+var ErrInvalidTile = errors.New("mvt: invalid vector tile")
+
+// Wire types of protocol buffers.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// protoWriter writes the fields of a protocol buffers message.
+type protoWriter struct {
+	buf []byte
+}
+
+func (w *protoWriter) varint(v uint64) {
+	for v >= 0x80 {
+		w.buf = append(w.buf, byte(v)|0x80)
+		v >>= 7
+	}
+	w.buf = append(w.buf, byte(v))
+}
+
+func (w *protoWriter) key(field, wireType int) {
+	w.varint(uint64(field<<3 | wireType))
+}
+
+func (w *protoWriter) uint(field int, v uint64) {
+	w.key(field, wireVarint)
+	w.varint(v)
+}
+
+func (w *protoWriter) bytes(field int, b []byte) {
+	w.key(field, wireBytes)
+	w.varint(uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *protoWriter) string(field int, s string) {
+	w.bytes(field, []byte(s))
+}
+
+func (w *protoWriter) double(field int, v float64) {
+	w.key(field, wireFixed64)
+	w.buf = append(w.buf, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(w.buf[len(w.buf)-8:], math.Float64bits(v))
+}
+
+// packed writes the values as a packed repeated field.
+func (w *protoWriter) packed(field int, values []uint32) {
+	packed := protoWriter{}
+	for _, v := range values {
+		packed.varint(uint64(v))
+	}
+	w.bytes(field, packed.buf)
+}
+
+// protoReader reads the fields of a protocol buffers message.
+type protoReader struct {
+	data []byte
+	err  error
+}
+
+// next returns the field and the wire type of the next field, and false at the end of the message or on error.
+func (r *protoReader) next() (int, int, bool) {
+	if r.err != nil || len(r.data) == 0 {
+		return 0, 0, false
+	}
+	key := r.varint()
+	return int(key >> 3), int(key & 0x7), r.err == nil
+}
+
+func (r *protoReader) varint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = ErrInvalidTile
+		r.data = nil
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *protoReader) bytes() []byte {
+	n := r.varint()
+	if r.err != nil {
+		return nil
+	}
+	if n > uint64(len(r.data)) {
+		r.err = ErrInvalidTile
+		r.data = nil
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *protoReader) fixed(size int) []byte {
+	if len(r.data) < size {
+		r.err = ErrInvalidTile
+		r.data = nil
+		return make([]byte, size)
+	}
+	b := r.data[:size]
+	r.data = r.data[size:]
+	return b
+}
+
+func (r *protoReader) double() float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(r.fixed(8)))
+}
+
+func (r *protoReader) float() float64 {
+	return float64(math.Float32frombits(binary.LittleEndian.Uint32(r.fixed(4))))
+}
+
+// packed reads a packed repeated field, or a single value of it if it is not packed.
+func (r *protoReader) packed(wireType int) []uint32 {
+	if wireType == wireVarint {
+		return []uint32{uint32(r.varint())}
+	}
+	packed := protoReader{data: r.bytes()}
+	var values []uint32
+	for r.err == nil && len(packed.data) > 0 {
+		values = append(values, uint32(packed.varint()))
+	}
+	if packed.err != nil {
+		r.err = packed.err
+	}
+	return values
+}
+
+// skip skips the value of a field of the wire type.
+func (r *protoReader) skip(wireType int) {
+	switch wireType {
+	case wireVarint:
+		r.varint()
+	case wireFixed64:
+		r.fixed(8)
+	case wireBytes:
+		r.bytes()
+	case wireFixed32:
+		r.fixed(4)
+	default:
+		r.err = ErrInvalidTile
+		r.data = nil
+	}
+}
@@ -0,0 +1,43 @@
+package mvt
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+// maxLatitude is the latitude of the edges of the Web Mercator tiles, beyond which points are projected on them.
+const maxLatitude = 85.05112877980659
+
+// Tile a tile z/x/y of the Web Mercator tiling scheme, in which the tile 0/0/0 covers the world
+// and y grows southwards.
+type Tile struct {
+	Z, X, Y uint32
+}
+
+// Bound returns the bound of the tile in longitudes and latitudes.
+func (t Tile) Bound() space.Bound {
+	return space.Bound{
+		Min: t.unproject([]float64{0, 1}, 1),
+		Max: t.unproject([]float64{1, 0}, 1),
+	}
+}
+
+// project returns the coordinates in the tile of a longitude and a latitude, the tile being extent wide.
+func (t Tile) project(p []float64, extent float64) []float64 {
+	n := math.Exp2(float64(t.Z))
+	lat := math.Max(-maxLatitude, math.Min(maxLatitude, p[1]))
+	sin := math.Sin(lat * math.Pi / 180)
+	x := (p[0]+180)/360*n - float64(t.X)
+	y := (0.5-math.Log((1+sin)/(1-sin))/(4*math.Pi))*n - float64(t.Y)
+	return []float64{x * extent, y * extent}
+}
+
+// unproject returns the longitude and the latitude of coordinates in the tile, the tile being extent wide.
+func (t Tile) unproject(p []float64, extent float64) space.Point {
+	n := math.Exp2(float64(t.Z))
+	x := (p[0]/extent + float64(t.X)) / n
+	y := (p[1]/extent + float64(t.Y)) / n
+	lat := math.Atan(math.Sinh(math.Pi*(1-2*y))) * 180 / math.Pi
+	return space.Point{x*360 - 180, lat}
+}
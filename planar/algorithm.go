@@ -4,21 +4,30 @@ package planar
 import (
 	"errors"
 
+	"github.com/spatial-go/geoos/algorithm/buffer"
+	"github.com/spatial-go/geoos/algorithm/measure"
 	"github.com/spatial-go/geoos/space"
 )
 
 // ErrNotPolygon UnaryUnion parameter is not polygon
 var ErrNotPolygon = errors.New("Geometry is not polygon")
 
+// ErrUnsupportedGeometry the type of the geometry parameter is not supported
+var ErrUnsupportedGeometry = errors.New("Geometry type is not supported")
+
 // Algorithm is the interface implemented by an object that can implementation
 // spatial algorithm.
 type Algorithm interface {
 	Area(geom space.Geometry) (float64, error)
 
+	SpheroidArea(geom space.Geometry) (float64, error)
+
 	Boundary(geom space.Geometry) (space.Geometry, error)
 
 	Buffer(geom space.Geometry, width float64, quadsegs int32) space.Geometry
 
+	BufferWithParams(geom space.Geometry, width float64, params buffer.Params) space.Geometry
+
 	Centroid(geom space.Geometry) (space.Geometry, error)
 
 	Contains(geom1, geom2 space.Geometry) (bool, error)
@@ -37,7 +46,9 @@ type Algorithm interface {
 
 	Distance(geom1, geom2 space.Geometry) (float64, error)
 
-	SphericalDistance(geom1, geom2 space.Geometry) (float64, error)
+	// SphericalDistance returns the distance in meters between two geometries of longitudes and latitudes,
+	// on a sphere, or with the distance between two points given, such as measure.GeodesicDistance.
+	SphericalDistance(geom1, geom2 space.Geometry, f ...measure.Distance) (float64, error)
 
 	Envelope(geom space.Geometry) (space.Geometry, error)
 
@@ -61,18 +72,33 @@ type Algorithm interface {
 
 	IsSimple(geom space.Geometry) (bool, error)
 
+	IsValid(geom space.Geometry) (bool, error)
+
+	IsValidReason(geom space.Geometry) (space.ValidReason, error)
+
 	Length(geom space.Geometry) (float64, error)
 
+	SpheroidLength(geom space.Geometry) (float64, error)
+
 	LineMerge(geom space.Geometry) (space.Geometry, error)
 
+	// MakeValid returns a valid geometry covering the points of the geometry.
+	// The algorithms differ on the parts which collapse: GEOS keeps them as lines or points,
+	// so that no vertex is lost, while Megrez drops them, so that POLYGON((0 0,10 0,0 0)) becomes POLYGON EMPTY.
+	MakeValid(geom space.Geometry) (space.Geometry, error)
+
 	NGeometry(geom space.Geometry) (int, error)
 
 	Overlaps(geom1, geom2 space.Geometry) (bool, error)
 
 	PointOnSurface(geom space.Geometry) (space.Geometry, error)
 
+	Prepare(geom space.Geometry) (PreparedGeometry, error)
+
 	Relate(s, d space.Geometry) (string, error)
 
+	RelatePattern(geom1, geom2 space.Geometry, pattern string) (bool, error)
+
 	SharedPaths(geom1, geom2 space.Geometry) (string, error)
 
 	Simplify(geom space.Geometry, tolerance float64) (space.Geometry, error)
@@ -1,9 +1,11 @@
 package planar
 
 import (
+	"math"
 	"testing"
 
 	"github.com/spatial-go/geoos/encoding/wkt"
+	"github.com/spatial-go/geoos/geo"
 	"github.com/spatial-go/geoos/space"
 )
 
@@ -41,3 +43,48 @@ func Benchmark_GeosUnaryUnion(b *testing.B) {
 		_, _ = G.UnaryUnion(multiPolygon)
 	}
 }
+
+// largePolygon is a polygon with many vertices, for which the conversion to GEOS dominates.
+var largePolygon = func() space.Geometry {
+	const n = 10000
+	ring := make(space.Ring, n+1)
+	for i := 0; i < n; i++ {
+		angle := 2 * math.Pi * float64(i) / n
+		ring[i] = space.Point{100 * math.Cos(angle), 100 * math.Sin(angle)}
+	}
+	ring[n] = ring[0]
+	return space.Polygon{ring}
+}()
+
+// Benchmark_GeosBridgeWKT test converting to GEOS and back through WKT
+func Benchmark_GeosBridgeWKT(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		g := geo.GeomFromWKTStr(wkt.MarshalString(largePolygon))
+		result, _ := geo.ToWKTStr(g)
+		_, _ = wkt.UnmarshalString(result)
+		geo.DestroyGeometry(g)
+	}
+}
+
+// Benchmark_GeosBridge test converting to GEOS and back through coordinate sequences
+func Benchmark_GeosBridge(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		g, _ := geo.GeomFromSpace(largePolygon)
+		_, _ = geo.ToSpace(g)
+		geo.DestroyGeometry(g)
+	}
+}
+
+// Benchmark_GeosAreaLargePolygon test geos area of a large polygon
+func Benchmark_GeosAreaLargePolygon(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = GetStrategy(newGEOAlgorithm).Area(largePolygon)
+	}
+}
+
+// Benchmark_GeosBufferLargePolygon test geos buffer of a large polygon
+func Benchmark_GeosBufferLargePolygon(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = GetStrategy(newGEOAlgorithm).Buffer(largePolygon, 1, 8)
+	}
+}
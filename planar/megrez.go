@@ -1,8 +1,11 @@
 package planar
 
 import (
+	"github.com/spatial-go/geoos/algorithm/buffer"
 	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
 	"github.com/spatial-go/geoos/algorithm/overlay"
+	"github.com/spatial-go/geoos/algorithm/relate"
 	"github.com/spatial-go/geoos/space"
 )
 
@@ -21,6 +24,33 @@ func (g *MegrezAlgorithm) Area(geom space.Geometry) (float64, error) {
 	}
 }
 
+// SpheroidArea returns the area in square meters of a polygonal geometry of longitudes and latitudes,
+// on the WGS84 ellipsoid, 0 for a point or a line, and the sum of the areas of the members of a collection.
+func (g *MegrezAlgorithm) SpheroidArea(geom space.Geometry) (float64, error) {
+	switch geom := geom.(type) {
+	case space.Polygon:
+		return geom.SpheroidArea(), nil
+	case space.MultiPolygon:
+		return geom.SpheroidArea(), nil
+	case space.Bound:
+		return geom.ToPolygon().SpheroidArea(), nil
+	case space.Point, space.MultiPoint, space.LineString, space.MultiLineString, space.Ring:
+		return 0.0, nil
+	case space.Collection:
+		area := 0.0
+		for _, v := range geom {
+			areaV, err := g.SpheroidArea(v)
+			if err != nil {
+				return 0, err
+			}
+			area += areaV
+		}
+		return area, nil
+	default:
+		return 0, ErrUnsupportedGeometry
+	}
+}
+
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
 func (g *MegrezAlgorithm) Boundary(geom space.Geometry) (space.Geometry, error) {
 	return geom.Boundary()
@@ -29,8 +59,63 @@ func (g *MegrezAlgorithm) Boundary(geom space.Geometry) (space.Geometry, error)
 // Buffer sReturns a geometry that represents all points whose distance
 // from this space.Geometry is less than or equal to distance.
 func (g *MegrezAlgorithm) Buffer(geom space.Geometry, width float64, quadsegs int32) (geometry space.Geometry) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Buffer(geom, width, quadsegs)
+	return g.BufferWithParams(geom, width, buffer.DefaultParams(int(quadsegs)))
+}
+
+// BufferWithParams returns a geometry that represents all points whose distance
+// from this space.Geometry is less than or equal to distance, using the end cap style,
+// join style, mitre limit and single sidedness of params.
+func (g *MegrezAlgorithm) BufferWithParams(geom space.Geometry, width float64, params buffer.Params) (geometry space.Geometry) {
+	builder := buffer.NewBuilderWithParams(width, params)
+	addBufferComponents(builder, geom)
+	result := builder.Build()
+	switch len(result) {
+	case 0:
+		return space.Polygon{}
+	case 1:
+		return space.Polygon(result[0])
+	default:
+		multiPolygon := make(space.MultiPolygon, 0, len(result))
+		for _, v := range result {
+			multiPolygon = append(multiPolygon, space.Polygon(v))
+		}
+		return multiPolygon
+	}
+}
+
+// addBufferComponents adds the components of geom to the buffer builder.
+func addBufferComponents(builder *buffer.Builder, geom space.Geometry) {
+	if geom == nil || geom.IsEmpty() {
+		return
+	}
+	switch g := geom.(type) {
+	case space.Point:
+		builder.AddPoint(matrix.Matrix(g))
+	case space.MultiPoint:
+		for _, v := range g {
+			builder.AddPoint(matrix.Matrix(v))
+		}
+	case space.LineString:
+		builder.AddLine(matrix.LineMatrix(g))
+	case space.MultiLineString:
+		for _, v := range g {
+			builder.AddLine(matrix.LineMatrix(v))
+		}
+	case space.Ring:
+		builder.AddPolygon(matrix.PolygonMatrix{matrix.LineMatrix(g)})
+	case space.Polygon:
+		builder.AddPolygon(matrix.PolygonMatrix(g))
+	case space.MultiPolygon:
+		for _, v := range g {
+			builder.AddPolygon(matrix.PolygonMatrix(v))
+		}
+	case space.Bound:
+		builder.AddPolygon(matrix.PolygonMatrix(g.ToPolygon()))
+	case space.Collection:
+		for _, v := range g {
+			addBufferComponents(builder, v)
+		}
+	}
 }
 
 // Centroid  computes the geometric center of a geometry, or equivalently, the center of mass of the geometry as a POINT.
@@ -53,8 +138,8 @@ func (g *MegrezAlgorithm) Centroid(geom space.Geometry) (space.Geometry, error)
 // For this function to make sense, the source geometries must both be of the same coordinate projection,
 // having the same SRID.
 func (g *MegrezAlgorithm) Contains(geom1, geom2 space.Geometry) (bool, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Contains(geom1, geom2)
+	im, _, _ := relateGeometries(geom1, geom2)
+	return im.IsContains(), nil
 }
 
 // ConvexHull computes the convex hull of a geometry. The convex hull is the smallest convex geometry
@@ -69,14 +154,14 @@ func (g *MegrezAlgorithm) ConvexHull(geom space.Geometry) (space.Geometry, error
 
 // CoveredBy returns TRUE if no point in space.Geometry A is outside space.Geometry B
 func (g *MegrezAlgorithm) CoveredBy(geom1, geom2 space.Geometry) (bool, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).CoveredBy(geom1, geom2)
+	im, _, _ := relateGeometries(geom1, geom2)
+	return im.IsCoveredBy(), nil
 }
 
 // Covers returns TRUE if no point in space.Geometry B is outside space.Geometry A
 func (g *MegrezAlgorithm) Covers(geom1, geom2 space.Geometry) (bool, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Covers(geom1, geom2)
+	im, _, _ := relateGeometries(geom1, geom2)
+	return im.IsCovers(), nil
 }
 
 // Crosses takes two geometry objects and returns TRUE if their intersection "spatially cross",
@@ -86,24 +171,23 @@ func (g *MegrezAlgorithm) Covers(geom1, geom2 space.Geometry) (bool, error) {
 // Additionally, the intersection of the two geometries must not equal either of the source geometries.
 // Otherwise, it returns FALSE.
 func (g *MegrezAlgorithm) Crosses(geom1, geom2 space.Geometry) (bool, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Crosses(geom1, geom2)
+	im, dim1, dim2 := relateGeometries(geom1, geom2)
+	return im.IsCrosses(dim1, dim2), nil
 }
 
 // Difference returns a geometry that represents that part of geometry A that does not intersect with geometry B.
 // One can think of this as GeometryA - Intersection(A,B).
 // If A is completely contained in B then an empty geometry collection is returned.
 func (g *MegrezAlgorithm) Difference(geom1, geom2 space.Geometry) (space.Geometry, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Difference(geom1, geom2)
+	return overlayGeometries(geom1, geom2, overlay.OpDifference), nil
 }
 
 // Disjoint Overlaps, Touches, Within all imply geometries are not spatially disjoint.
 // If any of the aforementioned returns true, then the geometries are not spatially disjoint.
 // Disjoint implies false for spatial intersection.
 func (g *MegrezAlgorithm) Disjoint(geom1, geom2 space.Geometry) (bool, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Disjoint(geom1, geom2)
+	im, _, _ := relateGeometries(geom1, geom2)
+	return im.IsDisjoint(), nil
 }
 
 // Distance returns the minimum 2D Cartesian (planar) distance between two geometries, in projected units (spatial ref units).
@@ -114,8 +198,8 @@ func (g *MegrezAlgorithm) Distance(geom1, geom2 space.Geometry) (float64, error)
 // SphericalDistance calculates spherical distance
 //
 // To get real distance in m
-func (g *MegrezAlgorithm) SphericalDistance(geom1, geom2 space.Geometry) (float64, error) {
-	return geom1.SpheroidDistance(geom2)
+func (g *MegrezAlgorithm) SphericalDistance(geom1, geom2 space.Geometry, f ...measure.Distance) (float64, error) {
+	return geom1.SpheroidDistance(geom2, f...)
 }
 
 // Envelope returns the  minimum bounding box for the supplied geometry, as a geometry.
@@ -158,14 +242,13 @@ func (g *MegrezAlgorithm) HausdorffDistanceDensify(s, d space.Geometry, densifyF
 
 // Intersection returns a geometry that represents the point set intersection of the Geometries.
 func (g *MegrezAlgorithm) Intersection(geom1, geom2 space.Geometry) (space.Geometry, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Intersection(geom1, geom2)
+	return overlayGeometries(geom1, geom2, overlay.OpIntersection), nil
 }
 
 // Intersects If a geometry  shares any portion of space then they intersect
 func (g *MegrezAlgorithm) Intersects(geom1, geom2 space.Geometry) (bool, error) {
-	//todo
-	return GetStrategy(newGEOAlgorithm).Intersects(geom1, geom2)
+	im, _, _ := relateGeometries(geom1, geom2)
+	return im.IsIntersects(), nil
 }
 
 // IsClosed Returns TRUE if the LINESTRING's start and end points are coincident.
@@ -192,17 +275,76 @@ func (g *MegrezAlgorithm) IsSimple(geom space.Geometry) (bool, error) {
 	return geom.IsSimple(), nil
 }
 
+// IsValid returns true if the geometry is valid in the OGC sense.
+func (g *MegrezAlgorithm) IsValid(geom space.Geometry) (bool, error) {
+	elem := space.ElementValid{Geometry: geom}
+	return elem.IsValid(), nil
+}
+
+// IsValidReason returns the reason why the geometry is not valid: the kind of the first error found, such as
+// a self-intersection or a hole outside its shell, and its location.
+func (g *MegrezAlgorithm) IsValidReason(geom space.Geometry) (space.ValidReason, error) {
+	elem := space.ElementValid{Geometry: geom}
+	return elem.IsValidReason(), nil
+}
+
 // Length returns the 2D Cartesian length of the geometry if it is a LineString, MultiLineString
 func (g *MegrezAlgorithm) Length(geom space.Geometry) (float64, error) {
 	return geom.Length(), nil
 }
 
+// SpheroidLength returns the length in meters of a geometry of longitudes and latitudes along the geodesics of the
+// WGS84 ellipsoid if it is a LineString or MultiLineString, its perimeter if it is a Polygon or MultiPolygon,
+// 0 for a point, and the sum of the lengths of the members of a collection.
+func (g *MegrezAlgorithm) SpheroidLength(geom space.Geometry) (float64, error) {
+	switch geom := geom.(type) {
+	case space.LineString:
+		return measure.SpheroidOfLine(matrix.LineMatrix(geom)), nil
+	case space.Ring:
+		return measure.SpheroidOfLine(matrix.LineMatrix(geom)), nil
+	case space.MultiLineString:
+		length := 0.0
+		for _, v := range geom {
+			length += measure.SpheroidOfLine(matrix.LineMatrix(v))
+		}
+		return length, nil
+	case space.Polygon:
+		return geom.SpheroidLength(), nil
+	case space.MultiPolygon:
+		return geom.SpheroidLength(), nil
+	case space.Bound:
+		return geom.ToPolygon().SpheroidLength(), nil
+	case space.Point, space.MultiPoint:
+		return 0.0, nil
+	case space.Collection:
+		length := 0.0
+		for _, v := range geom {
+			lengthV, err := g.SpheroidLength(v)
+			if err != nil {
+				return 0, err
+			}
+			length += lengthV
+		}
+		return length, nil
+	default:
+		return 0, ErrUnsupportedGeometry
+	}
+}
+
 // LineMerge returns a (set of) LineString(s) formed by sewing together the constituent line work of a MULTILINESTRING.
 func (g *MegrezAlgorithm) LineMerge(geom space.Geometry) (space.Geometry, error) {
 	//TODO
 	return GetStrategy(newGEOAlgorithm).LineMerge(geom)
 }
 
+// MakeValid returns a valid geometry covering the points of the geometry, which is returned as is if it is valid.
+// Polygons are rebuilt from their rings by the even-odd rule, and the polygons of a multi polygon are unioned.
+// Rings which collapse to lines are dropped, unlike with GEOS.
+func (g *MegrezAlgorithm) MakeValid(geom space.Geometry) (space.Geometry, error) {
+	elem := space.ElementValid{Geometry: geom}
+	return elem.MakeValid(), nil
+}
+
 // NGeometry returns the number of component geometries.
 func (g *MegrezAlgorithm) NGeometry(geom space.Geometry) (int, error) {
 	//TODO
@@ -212,8 +354,8 @@ func (g *MegrezAlgorithm) NGeometry(geom space.Geometry) (int, error) {
 // Overlaps returns TRUE if the Geometries "spatially overlap".
 // By that we mean they intersect, but one does not completely contain another.
 func (g *MegrezAlgorithm) Overlaps(geom1, geom2 space.Geometry) (bool, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Overlaps(geom1, geom2)
+	im, dim1, dim2 := relateGeometries(geom1, geom2)
+	return im.IsOverlaps(dim1, dim2), nil
 }
 
 // PointOnSurface Returns a POINT guaranteed to intersect a surface.
@@ -222,12 +364,199 @@ func (g *MegrezAlgorithm) PointOnSurface(geom space.Geometry) (space.Geometry, e
 	return GetStrategy(newGEOAlgorithm).PointOnSurface(geom)
 }
 
+// Prepare indexes geom for the repeated evaluation of predicates against other geometries.
+func (g *MegrezAlgorithm) Prepare(geom space.Geometry) (PreparedGeometry, error) {
+	return &megrezPrepared{prepared: relate.NewPrepared(relateComponents(geom))}, nil
+}
+
 // Relate computes the intersection matrix (Dimensionally Extended
 // Nine-Intersection Model (DE-9IM) matrix) for the spatial relationship between
 // the two geometries.
 func (g *MegrezAlgorithm) Relate(s, d space.Geometry) (string, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Relate(s, d)
+	im, _, _ := relateGeometries(s, d)
+	return im.String(), nil
+}
+
+// RelatePattern returns true if the DE-9IM matrix of the two geometries matches pattern,
+// a 9 character string of 'T', 'F', '*', '0', '1' or '2', such as "T*F**F***".
+func (g *MegrezAlgorithm) RelatePattern(geom1, geom2 space.Geometry, pattern string) (bool, error) {
+	im, _, _ := relateGeometries(geom1, geom2)
+	return im.Matches(pattern)
+}
+
+// polygonalMatrices returns the polygons of geom1 and geom2, and false if one of them is not polygonal.
+func polygonalMatrices(geom1, geom2 space.Geometry) (matrix.MultiPolygonMatrix, matrix.MultiPolygonMatrix, bool) {
+	m1, ok1 := polygonalMatrix(geom1)
+	m2, ok2 := polygonalMatrix(geom2)
+	return m1, m2, ok1 && ok2
+}
+
+// polygonalMatrix returns the polygons of geom, and false if it is not polygonal.
+func polygonalMatrix(geom space.Geometry) (matrix.MultiPolygonMatrix, bool) {
+	switch g := geom.(type) {
+	case space.Polygon:
+		if g.IsEmpty() {
+			return matrix.MultiPolygonMatrix{}, true
+		}
+		return matrix.MultiPolygonMatrix{matrix.PolygonMatrix(g)}, true
+	case space.MultiPolygon:
+		m := make(matrix.MultiPolygonMatrix, 0, len(g))
+		for _, v := range g {
+			if !v.IsEmpty() {
+				m = append(m, matrix.PolygonMatrix(v))
+			}
+		}
+		return m, true
+	case space.Bound:
+		return matrix.MultiPolygonMatrix{matrix.PolygonMatrix(g.ToPolygon())}, true
+	}
+	return nil, false
+}
+
+// polygonalGeometry returns the result of a polygon overlay as a Polygon, or a MultiPolygon if it has several polygons.
+func polygonalGeometry(m matrix.MultiPolygonMatrix) space.Geometry {
+	switch len(m) {
+	case 0:
+		return space.Polygon{}
+	case 1:
+		return space.Polygon(m[0])
+	}
+	multiPolygon := make(space.MultiPolygon, len(m))
+	for i, v := range m {
+		multiPolygon[i] = space.Polygon(v)
+	}
+	return multiPolygon
+}
+
+// dissolvePolygons returns the union of polygons which may overlap, such as the members of a collection
+// or of an invalid multipolygon, as the polygons with disjoint interiors an overlay operand is made of.
+func dissolvePolygons(polygons []matrix.PolygonMatrix) []matrix.PolygonMatrix {
+	if len(polygons) <= 1 {
+		return polygons
+	}
+	m := make(matrix.MultiPolygonMatrix, len(polygons))
+	for i, v := range polygons {
+		m[i] = v
+	}
+	union := overlay.UnaryUnionPolygons(m)
+	dissolved := make([]matrix.PolygonMatrix, len(union))
+	for i, v := range union {
+		dissolved[i] = v
+	}
+	return dissolved
+}
+
+// overlayGeometries computes the overlay of two geometries. The result has the type GEOS would return:
+// a single component, a multi geometry of components of the same dimension, or a collection of
+// polygons, lines and points. An empty result has the dimension of the result of op.
+func overlayGeometries(geom1, geom2 space.Geometry, op int) space.Geometry {
+	a, b := relateComponents(geom1), relateComponents(geom2)
+	a.Polygons, b.Polygons = dissolvePolygons(a.Polygons), dissolvePolygons(b.Polygons)
+	r := overlay.Compute(overlay.Operand(*a), overlay.Operand(*b), op)
+	if r.IsEmpty() {
+		dim := a.Dimension()
+		switch op {
+		case overlay.OpIntersection:
+			if b.Dimension() < dim {
+				dim = b.Dimension()
+			}
+		case overlay.OpUnion, overlay.OpSymDifference:
+			if b.Dimension() > dim {
+				dim = b.Dimension()
+			}
+		}
+		switch dim {
+		case relate.DimArea:
+			return space.Polygon{}
+		case relate.DimLine:
+			return space.LineString{}
+		case relate.DimPoint:
+			return space.Point{}
+		}
+		return space.Collection{}
+	}
+
+	switch {
+	case len(r.Lines) == 0 && len(r.Points) == 0:
+		return polygonalGeometry(r.Polygons)
+	case len(r.Polygons) == 0 && len(r.Points) == 0:
+		if len(r.Lines) == 1 {
+			return space.LineString(r.Lines[0])
+		}
+		multiLine := make(space.MultiLineString, len(r.Lines))
+		for i, v := range r.Lines {
+			multiLine[i] = space.LineString(v)
+		}
+		return multiLine
+	case len(r.Polygons) == 0 && len(r.Lines) == 0:
+		if len(r.Points) == 1 {
+			return space.Point(r.Points[0])
+		}
+		multiPoint := make(space.MultiPoint, len(r.Points))
+		for i, v := range r.Points {
+			multiPoint[i] = space.Point(v)
+		}
+		return multiPoint
+	}
+	collection := make(space.Collection, 0, len(r.Polygons)+len(r.Lines)+len(r.Points))
+	for _, v := range r.Polygons {
+		collection = append(collection, space.Polygon(v))
+	}
+	for _, v := range r.Lines {
+		collection = append(collection, space.LineString(v))
+	}
+	for _, v := range r.Points {
+		collection = append(collection, space.Point(v))
+	}
+	return collection
+}
+
+// relateGeometries computes the DE-9IM matrix and the dimensions of the two geometries.
+func relateGeometries(geom1, geom2 space.Geometry) (relate.IntersectionMatrix, int, int) {
+	a, b := relateComponents(geom1), relateComponents(geom2)
+	return relate.Relate(a, b), a.Dimension(), b.Dimension()
+}
+
+// relateComponents returns the points, lines and polygons of geom.
+func relateComponents(geom space.Geometry) *relate.Components {
+	c := &relate.Components{}
+	addRelateComponents(c, geom)
+	return c
+}
+
+// addRelateComponents adds the components of geom to c.
+func addRelateComponents(c *relate.Components, geom space.Geometry) {
+	if geom == nil || geom.IsEmpty() {
+		return
+	}
+	switch g := geom.(type) {
+	case space.Point:
+		c.Points = append(c.Points, matrix.Matrix(g))
+	case space.MultiPoint:
+		for _, v := range g {
+			c.Points = append(c.Points, matrix.Matrix(v))
+		}
+	case space.LineString:
+		c.Lines = append(c.Lines, matrix.LineMatrix(g))
+	case space.MultiLineString:
+		for _, v := range g {
+			c.Lines = append(c.Lines, matrix.LineMatrix(v))
+		}
+	case space.Ring:
+		c.Polygons = append(c.Polygons, matrix.PolygonMatrix{matrix.LineMatrix(g)})
+	case space.Polygon:
+		c.Polygons = append(c.Polygons, matrix.PolygonMatrix(g))
+	case space.MultiPolygon:
+		for _, v := range g {
+			c.Polygons = append(c.Polygons, matrix.PolygonMatrix(v))
+		}
+	case space.Bound:
+		c.Polygons = append(c.Polygons, matrix.PolygonMatrix(g.ToPolygon()))
+	case space.Collection:
+		for _, v := range g {
+			addRelateComponents(c, v)
+		}
+	}
 }
 
 // SharedPaths returns a collection containing paths shared by the two input geometries.
@@ -266,16 +595,15 @@ func (g *MegrezAlgorithm) Snap(input, reference space.Geometry, tolerance float6
 // It is called a symmetric difference because SymDifference(A,B) = SymDifference(B,A).
 // One can think of this as Union(geomA,geomB) - Intersection(A,B).
 func (g *MegrezAlgorithm) SymDifference(geom1, geom2 space.Geometry) (space.Geometry, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).SymDifference(geom1, geom2)
+	return overlayGeometries(geom1, geom2, overlay.OpSymDifference), nil
 }
 
 // Touches returns TRUE if the only points in common between geom1 and geom2 lie in the union of the boundaries of geom1 and geom2.
 // The ouches relation applies to all Area/Area, Line/Line, Line/Area, Point/Area and Point/Line pairs of relationships,
 // but not to the Point/Point pair.
 func (g *MegrezAlgorithm) Touches(geom1, geom2 space.Geometry) (bool, error) {
-	//TODO
-	return GetStrategy(newGEOAlgorithm).Touches(geom1, geom2)
+	im, dim1, dim2 := relateGeometries(geom1, geom2)
+	return im.IsTouches(dim1, dim2), nil
 }
 
 // UnaryUnion does dissolve boundaries between components of a multipolygon (invalid) and does perform union
@@ -286,17 +614,22 @@ func (g *MegrezAlgorithm) UnaryUnion(geom space.Geometry) (space.Geometry, error
 		for _, v := range geom.(space.MultiPolygon) {
 			matrix4 = append(matrix4, v)
 		}
-		result := overlay.UnaryUnion(matrix4)
-		return space.Polygon(result), nil
+		result := overlay.UnaryUnionPolygons(matrix4)
+		return polygonalGeometry(result), nil
 	}
 	return nil, ErrNotPolygon
 }
 
 // Union returns a new geometry representing all points in this geometry and the other.
 func (g *MegrezAlgorithm) Union(geom1, geom2 space.Geometry) (space.Geometry, error) {
-	if geom1.GeoJSONType() == space.TypePolygon && geom2.GeoJSONType() == space.TypePolygon {
-		result := overlay.Union(matrix.PolygonMatrix(geom1.(space.Polygon)), matrix.PolygonMatrix(geom2.(space.Polygon)))
-		return space.Polygon(result), nil
+	if m1, m2, ok := polygonalMatrices(geom1, geom2); ok {
+		if len(m1) > 1 {
+			m1 = overlay.UnaryUnionPolygons(m1)
+		}
+		if len(m2) > 1 {
+			m2 = overlay.UnaryUnionPolygons(m2)
+		}
+		return polygonalGeometry(overlay.UnionPolygons(m1, m2)), nil
 	} else if geom1.GeoJSONType() == space.TypePoint && geom2.GeoJSONType() == space.TypePoint {
 		return space.MultiPoint{geom1.(space.Point), geom2.(space.Point)}, nil
 	} else if geom1.GeoJSONType() == space.TypeLineString && geom2.GeoJSONType() == space.TypeLineString {
@@ -315,27 +648,6 @@ func (g *MegrezAlgorithm) UniquePoints(geom space.Geometry) (space.Geometry, err
 // For this function to make sense, the source geometries must both be of the same coordinate projection,
 // having the same SRID.
 func (g *MegrezAlgorithm) Within(geom1, geom2 space.Geometry) (bool, error) {
-	// optimization - lower dimension cannot contain areas
-	if geom2.Dimensions() == 2 && geom1.Dimensions() < 2 {
-		return false, nil
-	}
-	// optimization - P cannot contain a non-zero-length L
-	// Note that a point can contain a zero-length lineal geometry,
-	// since the line has no boundary due to Mod-2 Boundary Rule
-	if geom2.Dimensions() == 1 && geom1.Dimensions() < 1 && geom2.Length() > 0.0 {
-		return false, nil
-	}
-	// optimization - envelope test
-	if !geom2.Bound().ContainsBound(geom1.Bound()) {
-		return false, nil
-	}
-	// optimization for rectangle arguments
-	if geom2.GeoJSONType() == space.TypePolygon && geom2.(space.Polygon).IsRectangle() {
-		return geom2.Bound().ContainsBound(geom1.Bound()), nil
-	}
-	// general case
-	//return relate(g).isContains()
-
-	//todo
-	return GetStrategy(newGEOAlgorithm).Within(geom1, geom2)
+	im, _, _ := relateGeometries(geom1, geom2)
+	return im.IsWithin(), nil
 }
@@ -1,9 +1,13 @@
 package planar
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
+	"github.com/spatial-go/geoos/algorithm/buffer"
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
 	"github.com/spatial-go/geoos/encoding/wkt"
 	"github.com/spatial-go/geoos/space"
 )
@@ -65,6 +69,44 @@ func TestAlgorithm_Length(t *testing.T) {
 	}
 }
 
+func TestAlgorithm_SpheroidArea(t *testing.T) {
+	const parcel = `POLYGON((116.4 39.9, 116.4001 39.9, 116.4001 39.9001, 116.4 39.9001, 116.4 39.9))`
+	const holed = `MULTIPOLYGON(((0 0, 1 0, 1 1, 0 1, 0 0), (0.25 0.25, 0.25 0.75, 0.75 0.75, 0.75 0.25, 0.25 0.25)))`
+	tests := []struct {
+		name       string
+		g          string
+		wantArea   float64
+		wantLength float64
+	}{
+		{name: "parcel", g: parcel, wantArea: 94.953212, wantLength: 39.3102},
+		{name: "multi polygon with a hole", g: holed, wantArea: 9.2314e9, wantLength: 665659.5},
+		{name: "line", g: `LINESTRING(-73.8 40.6, -0.5 51.6)`, wantArea: 0, wantLength: 5551759.400},
+		{name: "point", g: `POINT(116.4 39.9)`, wantArea: 0, wantLength: 0},
+		{name: "collection", g: `GEOMETRYCOLLECTION(` + parcel + `, LINESTRING(-73.8 40.6, -0.5 51.6), POINT(1 1))`,
+			wantArea: 94.953212, wantLength: 5551759.400 + 39.3102},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geometry, _ := wkt.UnmarshalString(tt.g)
+			G := NormalStrategy()
+			area, err := G.SpheroidArea(geometry)
+			if err != nil || math.Abs(area-tt.wantArea) > 1e-4*tt.wantArea {
+				t.Errorf("SpheroidArea() got = %v, %v, want %v", area, err, tt.wantArea)
+			}
+			length, err := G.SpheroidLength(geometry)
+			if err != nil || math.Abs(length-tt.wantLength) > 1e-4*tt.wantLength {
+				t.Errorf("SpheroidLength() got = %v, %v, want %v", length, err, tt.wantLength)
+			}
+		})
+	}
+	if _, err := NormalStrategy().SpheroidArea(nil); err != ErrUnsupportedGeometry {
+		t.Errorf("SpheroidArea() error = %v, want %v", err, ErrUnsupportedGeometry)
+	}
+	if _, err := NormalStrategy().SpheroidLength(nil); err != ErrUnsupportedGeometry {
+		t.Errorf("SpheroidLength() error = %v, want %v", err, ErrUnsupportedGeometry)
+	}
+}
+
 func TestAlgorithm_Boundary(t *testing.T) {
 	const sourceLine = `LINESTRING(1 1,0 0, -1 1)`
 	const expectLine = `MULTIPOINT(1 1,-1 1)`
@@ -231,11 +273,15 @@ func TestAlgorithm_SphericalDistance(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			G := NormalStrategy()
 			got, _ := G.SphericalDistance(tt.args.p1, tt.args.p2)
-			if got != tt.want {
+			if math.Abs(got-tt.want) > 1e-5 {
 				t.Errorf("SphericalDistance() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
+	if got, err := NormalStrategy().SphericalDistance(point01, point03, measure.GeodesicDistance); err != nil ||
+		got != measure.GeodesicDistance(matrix.Matrix(point01), matrix.Matrix(point03)) {
+		t.Errorf("SphericalDistance() along geodesics got = %v, %v", got, err)
+	}
 }
 
 func TestAlgorithm_IsClosed(t *testing.T) {
@@ -450,3 +496,379 @@ func TestAlgorithm_IsRing(t *testing.T) {
 		})
 	}
 }
+
+func TestAlgorithm_Buffer(t *testing.T) {
+	geometry, _ := wkt.UnmarshalString("POINT(100 90)")
+	expectGeometry, _ := wkt.UnmarshalString("POLYGON((150 90,146.193976625564 70.8658283817455,135.355339059327 54.6446609406727,119.134171618255 43.8060233744357,100 40,80.8658283817456 43.8060233744356,64.6446609406727 54.6446609406725,53.8060233744357 70.8658283817454,50 89.9999999999998,53.8060233744356 109.134171618254,64.6446609406725 125.355339059327,80.8658283817453 136.193976625564,99.9999999999998 140,119.134171618254 136.193976625564,135.355339059327 125.355339059328,146.193976625564 109.134171618255,150 90))")
+	multiPoint, _ := wkt.UnmarshalString("MULTIPOINT(0 0,100 0)")
+	type args struct {
+		g        space.Geometry
+		width    float64
+		quadsegs int32
+	}
+	tests := []struct {
+		name      string
+		args      args
+		want      space.Geometry
+		wantCount int
+	}{
+		{name: "buffer point", args: args{g: geometry, width: 50, quadsegs: 4}, want: expectGeometry, wantCount: 1},
+		{name: "buffer multipoint", args: args{g: multiPoint, width: 10, quadsegs: 8}, wantCount: 2},
+		{name: "buffer negative", args: args{g: geometry, width: -1, quadsegs: 8}, wantCount: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			G := NormalStrategy()
+			gotGeometry := G.Buffer(tt.args.g, tt.args.width, tt.args.quadsegs)
+			var polygons space.MultiPolygon
+			switch g := gotGeometry.(type) {
+			case space.Polygon:
+				if !g.IsEmpty() {
+					polygons = space.MultiPolygon{g}
+				}
+			case space.MultiPolygon:
+				polygons = g
+			}
+			if len(polygons) != tt.wantCount {
+				t.Fatalf("Buffer() = %v, want %d polygons", wkt.MarshalString(gotGeometry), tt.wantCount)
+			}
+			if tt.want == nil {
+				return
+			}
+			want := tt.want.(space.Polygon)
+			if len(polygons[0][0]) != len(want[0]) {
+				t.Fatalf("Buffer() = %v, want %v", wkt.MarshalString(gotGeometry), wkt.MarshalString(tt.want))
+			}
+			for i, v := range polygons[0][0] {
+				if !space.Point(v).EqualsExact(space.Point(want[0][i]), 0.000001) {
+					t.Errorf("Buffer() = %v, want %v", wkt.MarshalString(gotGeometry), wkt.MarshalString(tt.want))
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestAlgorithm_BufferWithParams(t *testing.T) {
+	line, _ := wkt.UnmarshalString("LINESTRING(0 0,10 0,10 10)")
+	corridor := buffer.Params{QuadSegs: 8, EndCapStyle: buffer.CapFlat, JoinStyle: buffer.JoinMitre, MitreLimit: buffer.DefaultMitreLimit}
+	leftSide := buffer.Params{QuadSegs: 8, EndCapStyle: buffer.CapFlat, JoinStyle: buffer.JoinRound, MitreLimit: buffer.DefaultMitreLimit, SingleSided: true}
+	type args struct {
+		g      space.Geometry
+		width  float64
+		params buffer.Params
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{name: "buffer flat cap mitre join", args: args{g: line, width: 1, params: corridor}, want: 40},
+		{name: "buffer left side", args: args{g: line, width: 1, params: leftSide}, want: 19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			G := NormalStrategy()
+			gotGeometry := G.BufferWithParams(tt.args.g, tt.args.width, tt.args.params)
+			got, _ := G.Area(gotGeometry)
+			if math.Abs(got-tt.want) > 0.000001 {
+				t.Errorf("BufferWithParams() = %v, area %v, want %v", wkt.MarshalString(gotGeometry), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgorithm_Relate(t *testing.T) {
+	polygon1, _ := wkt.UnmarshalString(`POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))`)
+	polygon2, _ := wkt.UnmarshalString(`POLYGON((5 5, 15 5, 15 15, 5 15, 5 5))`)
+	line, _ := wkt.UnmarshalString(`LINESTRING(0 0, 10 10)`)
+	point, _ := wkt.UnmarshalString(`POINT(5 0)`)
+	type args struct {
+		g1 space.Geometry
+		g2 space.Geometry
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{name: "polygon polygon", args: args{g1: polygon1, g2: polygon2}, want: "212101212"},
+		{name: "polygon line", args: args{g1: polygon1, g2: line}, want: "1F2F01FF2"},
+		{name: "polygon point", args: args{g1: polygon1, g2: point}, want: "FF20F1FF2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			G := NormalStrategy()
+			got, err := G.Relate(tt.args.g1, tt.args.g2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Relate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Relate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgorithm_RelatePattern(t *testing.T) {
+	polygon, _ := wkt.UnmarshalString(`POLYGON((0 0, 6 0, 6 6, 0 6, 0 0))`)
+	point, _ := wkt.UnmarshalString(`POINT(3 3)`)
+	type args struct {
+		g1      space.Geometry
+		g2      space.Geometry
+		pattern string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{name: "contains", args: args{g1: polygon, g2: point, pattern: "T*****FF*"}, want: true},
+		{name: "within", args: args{g1: polygon, g2: point, pattern: "T*F**F***"}, want: false},
+		{name: "invalid pattern", args: args{g1: polygon, g2: point, pattern: "T*F"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			G := NormalStrategy()
+			got, err := G.RelatePattern(tt.args.g1, tt.args.g2, tt.args.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RelatePattern() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RelatePattern() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgorithm_Predicates(t *testing.T) {
+	polygon, _ := wkt.UnmarshalString(`POLYGON((0 0, 6 0, 6 6, 0 6, 0 0))`)
+	overlapping, _ := wkt.UnmarshalString(`POLYGON((3 3, 9 3, 9 9, 3 9, 3 3))`)
+	adjacent, _ := wkt.UnmarshalString(`POLYGON((6 0, 9 0, 9 6, 6 6, 6 0))`)
+	inside, _ := wkt.UnmarshalString(`POINT(3 3)`)
+	outside, _ := wkt.UnmarshalString(`POINT(-1 35)`)
+	onBoundary, _ := wkt.UnmarshalString(`POINT(6 3)`)
+	line1, _ := wkt.UnmarshalString(`LINESTRING(0 0, 10 10)`)
+	line2, _ := wkt.UnmarshalString(`LINESTRING(10 0, 0 10)`)
+	lineEnd, _ := wkt.UnmarshalString(`POINT(0 0)`)
+	G := NormalStrategy()
+	tests := []struct {
+		name      string
+		predicate func(geom1, geom2 space.Geometry) (bool, error)
+		g1, g2    space.Geometry
+		want      bool
+	}{
+		{name: "contains", predicate: G.Contains, g1: polygon, g2: inside, want: true},
+		{name: "not contains boundary", predicate: G.Contains, g1: polygon, g2: onBoundary, want: false},
+		{name: "covers boundary", predicate: G.Covers, g1: polygon, g2: onBoundary, want: true},
+		{name: "covered by", predicate: G.CoveredBy, g1: onBoundary, g2: polygon, want: true},
+		{name: "within", predicate: G.Within, g1: inside, g2: polygon, want: true},
+		{name: "not within", predicate: G.Within, g1: outside, g2: polygon, want: false},
+		{name: "crosses", predicate: G.Crosses, g1: line1, g2: line2, want: true},
+		{name: "line crosses polygon", predicate: G.Crosses, g1: line1, g2: polygon, want: true},
+		{name: "overlaps", predicate: G.Overlaps, g1: polygon, g2: overlapping, want: true},
+		{name: "not overlaps", predicate: G.Overlaps, g1: polygon, g2: adjacent, want: false},
+		{name: "touches", predicate: G.Touches, g1: polygon, g2: adjacent, want: true},
+		{name: "touches line end", predicate: G.Touches, g1: line1, g2: lineEnd, want: true},
+		{name: "not touches", predicate: G.Touches, g1: polygon, g2: overlapping, want: false},
+		{name: "disjoint", predicate: G.Disjoint, g1: polygon, g2: outside, want: true},
+		{name: "intersects", predicate: G.Intersects, g1: polygon, g2: onBoundary, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.predicate(tt.g1, tt.g2)
+			if err != nil {
+				t.Errorf("%s() error = %v", tt.name, err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("%s() got = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgorithm_Prepare(t *testing.T) {
+	polygon, _ := wkt.UnmarshalString(`POLYGON((0 0, 6 0, 6 6, 0 6, 0 0))`)
+	inside, _ := wkt.UnmarshalString(`POINT(3 3)`)
+	onBoundary, _ := wkt.UnmarshalString(`POINT(6 3)`)
+	crossing, _ := wkt.UnmarshalString(`LINESTRING(3 3, 9 3)`)
+	outside, _ := wkt.UnmarshalString(`POLYGON((7 7, 9 7, 9 9, 7 9, 7 7))`)
+	tests := []struct {
+		name                                           string
+		geom                                           space.Geometry
+		contains, containsProperly, covers, intersects bool
+	}{
+		{name: "inside", geom: inside, contains: true, containsProperly: true, covers: true, intersects: true},
+		{name: "on boundary", geom: onBoundary, covers: true, intersects: true},
+		{name: "crossing", geom: crossing, intersects: true},
+		{name: "outside", geom: outside},
+		{name: "equal", geom: polygon, contains: true, covers: true, intersects: true},
+	}
+	G := NormalStrategy()
+	prepared, err := G.Prepare(polygon)
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicates := []struct {
+				name string
+				f    func(geom space.Geometry) (bool, error)
+				want bool
+			}{
+				{"Contains", prepared.Contains, tt.contains},
+				{"ContainsProperly", prepared.ContainsProperly, tt.containsProperly},
+				{"Covers", prepared.Covers, tt.covers},
+				{"Intersects", prepared.Intersects, tt.intersects},
+			}
+			for _, p := range predicates {
+				got, err := p.f(tt.geom)
+				if err != nil {
+					t.Errorf("Prepare().%s() error = %v", p.name, err)
+					continue
+				}
+				if got != p.want {
+					t.Errorf("Prepare().%s() got = %v, want %v", p.name, got, p.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAlgorithm_PolygonOverlay(t *testing.T) {
+	polygon, _ := wkt.UnmarshalString(`POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 8, 8 8, 8 2, 2 2))`)
+	square, _ := wkt.UnmarshalString(`POLYGON((5 5, 15 5, 15 15, 5 15, 5 5))`)
+	inHole, _ := wkt.UnmarshalString(`POLYGON((4 4, 6 4, 6 6, 4 6, 4 4))`)
+	adjacent, _ := wkt.UnmarshalString(`MULTIPOLYGON(((10 0, 20 0, 20 10, 10 10, 10 0)), ((10 10, 20 10, 20 20, 10 20, 10 10)))`)
+
+	union, _ := wkt.UnmarshalString(`POLYGON((0 0, 0 10, 5 10, 5 15, 15 15, 15 5, 10 5, 10 0, 0 0), (2 2, 8 2, 8 5, 5 5, 5 8, 2 8, 2 2))`)
+	intersection, _ := wkt.UnmarshalString(`POLYGON((8 5, 10 5, 10 10, 5 10, 5 8, 8 8, 8 5))`)
+	difference, _ := wkt.UnmarshalString(`POLYGON((0 0, 0 10, 5 10, 5 8, 2 8, 2 2, 8 2, 8 5, 10 5, 10 0, 0 0))`)
+	unionInHole, _ := wkt.UnmarshalString(`MULTIPOLYGON(((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 8, 8 8, 8 2, 2 2)), ((4 4, 6 4, 6 6, 4 6, 4 4)))`)
+	unionAdjacent, _ := wkt.UnmarshalString(`POLYGON((0 0, 20 0, 20 20, 10 20, 10 10, 0 10, 0 0), (2 2, 2 8, 8 8, 8 2, 2 2))`)
+
+	tests := []struct {
+		name string
+		op   func(geom1, geom2 space.Geometry) (space.Geometry, error)
+		g1   space.Geometry
+		g2   space.Geometry
+		want space.Geometry
+	}{
+		{name: "union with hole", op: NormalStrategy().Union, g1: polygon, g2: square, want: union},
+		{name: "intersection with hole", op: NormalStrategy().Intersection, g1: polygon, g2: square, want: intersection},
+		{name: "difference with hole", op: NormalStrategy().Difference, g1: polygon, g2: square, want: difference},
+		{name: "union in hole", op: NormalStrategy().Union, g1: polygon, g2: inHole, want: unionInHole},
+		{name: "union shared edges", op: NormalStrategy().Union, g1: polygon, g2: adjacent, want: unionAdjacent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op(tt.g1, tt.g2)
+			if err != nil {
+				t.Fatalf("overlay error = %v", err)
+			}
+			if got.GeoJSONType() != tt.want.GeoJSONType() {
+				t.Fatalf("overlay got = %v, want %v", wkt.MarshalString(got), wkt.MarshalString(tt.want))
+			}
+			if equal, _ := NormalStrategy().RelatePattern(got, tt.want, "T*F**FFF*"); !equal {
+				t.Errorf("overlay got = %v, want %v", wkt.MarshalString(got), wkt.MarshalString(tt.want))
+			}
+		})
+	}
+}
+
+func TestAlgorithm_Overlay(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(geom1, geom2 space.Geometry) (space.Geometry, error)
+		g1   string
+		g2   string
+		want string
+	}{
+		{name: "line difference", op: NormalStrategy().Difference,
+			g1: `LINESTRING(50 100, 50 200)`, g2: `LINESTRING(50 50, 50 150)`, want: `LINESTRING(50 150,50 200)`},
+		{name: "line sym difference", op: NormalStrategy().SymDifference,
+			g1: `LINESTRING(50 100, 50 200)`, g2: `LINESTRING(50 50, 50 150)`, want: `MULTILINESTRING((50 150,50 200),(50 50,50 100))`},
+		{name: "point on line", op: NormalStrategy().Intersection,
+			g1: `POINT(0 0)`, g2: `LINESTRING(0 0, 0 2)`, want: `POINT(0 0)`},
+		{name: "line in polygon", op: NormalStrategy().Intersection,
+			g1: `LINESTRING(-5 5, 15 5)`, g2: `POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))`, want: `LINESTRING(0 5,10 5)`},
+		{name: "shared edge", op: NormalStrategy().Intersection,
+			g1: `POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))`, g2: `POLYGON((10 0, 20 0, 20 10, 10 10, 10 0))`, want: `LINESTRING(10 0,10 10)`},
+		{name: "points out of polygon", op: NormalStrategy().Difference,
+			g1: `MULTIPOINT(5 5, 20 20, 30 30)`, g2: `POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))`, want: `MULTIPOINT((20 20),(30 30))`},
+		{name: "polygon and point", op: NormalStrategy().SymDifference,
+			g1: `POLYGON((0 0, 0 10, 10 10, 10 0, 0 0))`, g2: `POINT(20 20)`,
+			want: `GEOMETRYCOLLECTION(POLYGON((0 0,0 10,10 10,10 0,0 0)),POINT(20 20))`},
+		{name: "disjoint lines", op: NormalStrategy().Intersection,
+			g1: `LINESTRING(0 0, 1 1)`, g2: `LINESTRING(5 5, 6 6)`, want: `LINESTRING EMPTY`},
+		{name: "overlapping collection members", op: NormalStrategy().Intersection,
+			g1: `GEOMETRYCOLLECTION(POLYGON((0 0, 10 0, 10 10, 0 10, 0 0)), POLYGON((5 0, 15 0, 15 10, 5 10, 5 0)))`,
+			g2: `POLYGON((8 2, 12 2, 12 4, 8 4, 8 2))`, want: `POLYGON((8 2,8 4,12 4,12 2,8 2))`},
+		{name: "overlapping multipolygon members", op: NormalStrategy().Difference,
+			g1: `MULTIPOLYGON(((0 0, 10 0, 10 10, 0 10, 0 0)), ((5 0, 15 0, 15 10, 5 10, 5 0)))`,
+			g2: `POLYGON((-1 -1, 14 -1, 14 11, -1 11, -1 -1))`, want: `POLYGON((14 0,14 10,15 10,15 0,14 0))`},
+		{name: "union of overlapping multipolygon members", op: NormalStrategy().Union,
+			g1: `MULTIPOLYGON(((0 0, 10 0, 10 10, 0 10, 0 0)), ((5 0, 15 0, 15 10, 5 10, 5 0)))`,
+			g2: `POLYGON((15 0, 20 0, 20 10, 15 10, 15 0))`, want: `POLYGON((0 0,0 10,5 10,10 10,15 10,20 10,20 0,15 0,10 0,5 0,0 0))`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g1, _ := wkt.UnmarshalString(tt.g1)
+			g2, _ := wkt.UnmarshalString(tt.g2)
+			got, err := tt.op(g1, g2)
+			if err != nil {
+				t.Fatalf("overlay error = %v", err)
+			}
+			if wkt.MarshalString(got) != tt.want {
+				t.Errorf("overlay got = %v, want %v", wkt.MarshalString(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestAlgorithm_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		wkt  string
+		want string
+	}{
+		{name: "polygon", wkt: `POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,2 4,4 4,4 2,2 2))`, want: "Valid Geometry"},
+		{name: "bow tie", wkt: `POLYGON((0 0,10 10,10 0,0 10,0 0))`, want: "Self-intersection[5 5]"},
+		{name: "hole outside shell", wkt: `POLYGON((0 0,10 0,10 10,0 10,0 0),(20 20,20 30,30 30,30 20,20 20))`,
+			want: "Hole lies outside shell[20 20]"},
+		{name: "nested shells", wkt: `MULTIPOLYGON(((0 0,10 0,10 10,0 10,0 0)),((2 2,2 4,4 4,4 2,2 2)))`,
+			want: "Nested shells[2 2]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geom, _ := wkt.UnmarshalString(tt.wkt)
+			G := NormalStrategy()
+			reason, err := G.IsValidReason(geom)
+			if err != nil {
+				t.Fatalf("IsValidReason() error = %v", err)
+			}
+			if reason.String() != tt.want {
+				t.Errorf("IsValidReason() got = %v, want %v", reason, tt.want)
+			}
+			valid, _ := G.IsValid(geom)
+			if valid != reason.IsValid() {
+				t.Errorf("IsValid() got = %v, want %v", valid, reason.IsValid())
+			}
+			if valid {
+				return
+			}
+			made, err := G.MakeValid(geom)
+			if err != nil {
+				t.Fatalf("MakeValid() error = %v", err)
+			}
+			if valid, _ := G.IsValid(made); !valid {
+				t.Errorf("MakeValid() got = %v, which is not valid", made)
+			}
+		})
+	}
+}
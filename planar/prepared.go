@@ -0,0 +1,74 @@
+package planar
+
+import (
+	"github.com/spatial-go/geoos/algorithm/relate"
+	"github.com/spatial-go/geoos/geo"
+	"github.com/spatial-go/geoos/space"
+)
+
+// PreparedGeometry is a geometry prepared for repeated evaluation of predicates
+// against other geometries. The prepared geometry is indexed once, so evaluating
+// many candidates against it is much faster than calling the plain predicates.
+type PreparedGeometry interface {
+	// Contains returns true if the prepared geometry contains geom.
+	Contains(geom space.Geometry) (bool, error)
+
+	// ContainsProperly returns true if geom lies in the interior of the prepared geometry.
+	ContainsProperly(geom space.Geometry) (bool, error)
+
+	// Covers returns true if no point of geom lies in the exterior of the prepared geometry.
+	Covers(geom space.Geometry) (bool, error)
+
+	// Intersects returns true if the prepared geometry and geom have at least one point in common.
+	Intersects(geom space.Geometry) (bool, error)
+}
+
+// megrezPrepared is a PreparedGeometry implemented in pure Go.
+type megrezPrepared struct {
+	prepared *relate.Prepared
+}
+
+// Contains returns true if the prepared geometry contains geom.
+func (p *megrezPrepared) Contains(geom space.Geometry) (bool, error) {
+	return p.prepared.Contains(relateComponents(geom)), nil
+}
+
+// ContainsProperly returns true if geom lies in the interior of the prepared geometry.
+func (p *megrezPrepared) ContainsProperly(geom space.Geometry) (bool, error) {
+	return p.prepared.ContainsProperly(relateComponents(geom)), nil
+}
+
+// Covers returns true if no point of geom lies in the exterior of the prepared geometry.
+func (p *megrezPrepared) Covers(geom space.Geometry) (bool, error) {
+	return p.prepared.Covers(relateComponents(geom)), nil
+}
+
+// Intersects returns true if the prepared geometry and geom have at least one point in common.
+func (p *megrezPrepared) Intersects(geom space.Geometry) (bool, error) {
+	return p.prepared.Intersects(relateComponents(geom)), nil
+}
+
+// geosPrepared is a PreparedGeometry backed by a GEOS prepared geometry.
+type geosPrepared struct {
+	prepared *geo.PreparedGeometry
+}
+
+// Contains returns true if the prepared geometry contains geom.
+func (p *geosPrepared) Contains(geom space.Geometry) (bool, error) {
+	return p.prepared.Contains(geom)
+}
+
+// ContainsProperly returns true if geom lies in the interior of the prepared geometry.
+func (p *geosPrepared) ContainsProperly(geom space.Geometry) (bool, error) {
+	return p.prepared.ContainsProperly(geom)
+}
+
+// Covers returns true if no point of geom lies in the exterior of the prepared geometry.
+func (p *geosPrepared) Covers(geom space.Geometry) (bool, error) {
+	return p.prepared.Covers(geom)
+}
+
+// Intersects returns true if the prepared geometry and geom have at least one point in common.
+func (p *geosPrepared) Intersects(geom space.Geometry) (bool, error) {
+	return p.prepared.Intersects(geom)
+}
@@ -2,9 +2,6 @@ package planar
 
 import (
 	"sync"
-
-	"github.com/spatial-go/geoos/encoding/wkt"
-	"github.com/spatial-go/geoos/space"
 )
 
 var algorithmGeos, algorithmMegrez Algorithm
@@ -37,10 +34,3 @@ func newGEOAlgorithm() Algorithm {
 	})
 	return algorithmGeos
 }
-
-// convertGeomToWKT help to convert geoos.Geometry to WKT string
-func convertGeomToWKT(geom1, geom2 space.Geometry) (string, string) {
-	ms1 := wkt.MarshalString(geom1)
-	ms2 := wkt.MarshalString(geom2)
-	return ms1, ms2
-}
@@ -1,7 +1,8 @@
 package planar
 
 import (
-	"github.com/spatial-go/geoos/encoding/wkt"
+	"github.com/spatial-go/geoos/algorithm/buffer"
+	"github.com/spatial-go/geoos/algorithm/measure"
 	"github.com/spatial-go/geoos/geo"
 	"github.com/spatial-go/geoos/space"
 )
@@ -11,31 +12,32 @@ type GEOAlgorithm struct{}
 
 // Area returns the area of a polygonal geometry.
 func (g *GEOAlgorithm) Area(geom space.Geometry) (float64, error) {
-	return geo.Area(wkt.MarshalString(geom))
+	return geo.Area(geom)
+}
+
+// SpheroidArea returns the area in square meters of a polygonal geometry of longitudes and latitudes,
+// on the WGS84 ellipsoid. GEOS measuring planar areas only, it is computed by the Megrez algorithm.
+func (g *GEOAlgorithm) SpheroidArea(geom space.Geometry) (float64, error) {
+	return GetStrategy(newMegrezAlgorithm).SpheroidArea(geom)
 }
 
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
 func (g *GEOAlgorithm) Boundary(geom space.Geometry) (space.Geometry, error) {
-	aa := wkt.MarshalString(geom)
-	boundary, err := geo.Boundary(aa)
-	if err != nil {
-		return nil, err
-	}
-	geometry, err := wkt.UnmarshalString(boundary)
-	if err != nil {
-		return nil, err
-	}
-	return geometry, nil
+	return geo.Boundary(geom)
 }
 
 // Buffer sReturns a geometry that represents all points whose distance
 // from this space.Geometry is less than or equal to distance.
 func (g *GEOAlgorithm) Buffer(geom space.Geometry, width float64, quadsegs int32) (geometry space.Geometry) {
-	result, err := geo.Buffer(wkt.MarshalString(geom), width, quadsegs)
-	if err != nil {
-		return
-	}
-	geometry, _ = wkt.UnmarshalString(result)
+	geometry, _ = geo.Buffer(geom, width, quadsegs)
+	return
+}
+
+// BufferWithParams returns a geometry that represents all points whose distance
+// from this space.Geometry is less than or equal to distance, using the end cap style,
+// join style, mitre limit and single sidedness of params.
+func (g *GEOAlgorithm) BufferWithParams(geom space.Geometry, width float64, params buffer.Params) (geometry space.Geometry) {
+	geometry, _ = geo.BufferWithParams(geom, width, params)
 	return
 }
 
@@ -48,15 +50,7 @@ func (g *GEOAlgorithm) Buffer(geom space.Geometry, width float64, quadsegs int32
 // If CIRCULARSTRING or COMPOUNDCURVE are supplied, they are converted to linestring wtih CurveToLine first,
 // then same than for LINESTRING
 func (g *GEOAlgorithm) Centroid(geom space.Geometry) (space.Geometry, error) {
-	result, err := geo.Centroid(wkt.MarshalString(geom))
-	if err != nil {
-		return nil, err
-	}
-	geometry, err := wkt.UnmarshalString(result)
-	if err != nil {
-		return nil, err
-	}
-	return geometry, nil
+	return geo.Centroid(geom)
 }
 
 // Contains space.Geometry A contains space.Geometry B if and only if no points of B lie in the exterior of A,
@@ -66,8 +60,7 @@ func (g *GEOAlgorithm) Centroid(geom space.Geometry) (space.Geometry, error) {
 // For this function to make sense, the source geometries must both be of the same coordinate projection,
 // having the same SRID.
 func (g *GEOAlgorithm) Contains(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Contains(ms1, ms2)
+	return geo.Contains(geom1, geom2)
 }
 
 // ConvexHull computes the convex hull of a geometry. The convex hull is the smallest convex geometry
@@ -76,23 +69,17 @@ func (g *GEOAlgorithm) Contains(geom1, geom2 space.Geometry) (bool, error) {
 // The convex hull of two or more collinear points is a two-point LineString.
 // The convex hull of one or more identical points is a Point.
 func (g *GEOAlgorithm) ConvexHull(geom space.Geometry) (space.Geometry, error) {
-	result, err := geo.ConvexHull(wkt.MarshalString(geom))
-	if err != nil {
-		return nil, err
-	}
-	return wkt.UnmarshalString(result)
+	return geo.ConvexHull(geom)
 }
 
 // CoveredBy returns TRUE if no point in space.Geometry A is outside space.Geometry B
 func (g *GEOAlgorithm) CoveredBy(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.CoversBy(ms1, ms2)
+	return geo.CoversBy(geom1, geom2)
 }
 
 // Covers returns TRUE if no point in space.Geometry B is outside space.Geometry A
 func (g *GEOAlgorithm) Covers(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Covers(ms1, ms2)
+	return geo.Covers(geom1, geom2)
 }
 
 // Crosses takes two geometry objects and returns TRUE if their intersection "spatially cross",
@@ -102,68 +89,50 @@ func (g *GEOAlgorithm) Covers(geom1, geom2 space.Geometry) (bool, error) {
 // Additionally, the intersection of the two geometries must not equal either of the source geometries.
 // Otherwise, it returns FALSE.
 func (g *GEOAlgorithm) Crosses(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Crosses(ms1, ms2)
+	return geo.Crosses(geom1, geom2)
 }
 
 // Difference returns a geometry that represents that part of geometry A that does not intersect with geometry B.
 // One can think of this as GeometryA - Intersection(A,B).
 // If A is completely contained in B then an empty geometry collection is returned.
 func (g *GEOAlgorithm) Difference(geom1, geom2 space.Geometry) (space.Geometry, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	result, err := geo.Difference(ms1, ms2)
-	if err != nil {
-		return nil, err
-	}
-	geometry, err := wkt.UnmarshalString(result)
-	if err != nil {
-		return nil, err
-	}
-	return geometry, nil
+	return geo.Difference(geom1, geom2)
 }
 
 // Disjoint Overlaps, Touches, Within all imply geometries are not spatially disjoint.
 // If any of the aforementioned returns true, then the geometries are not spatially disjoint.
 // Disjoint implies false for spatial intersection.
 func (g *GEOAlgorithm) Disjoint(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Disjoint(ms1, ms2)
+	return geo.Disjoint(geom1, geom2)
 }
 
 // Distance returns the minimum 2D Cartesian (planar) distance between two geometries, in projected units (spatial ref units).
 func (g *GEOAlgorithm) Distance(geom1, geom2 space.Geometry) (float64, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Distance(ms1, ms2)
+	return geo.Distance(geom1, geom2)
 }
 
 // SphericalDistance calculates spherical distance
 // To get real distance in m
-func (g *GEOAlgorithm) SphericalDistance(geom1, geom2 space.Geometry) (float64, error) {
-	return geom1.SpheroidDistance(geom2)
+func (g *GEOAlgorithm) SphericalDistance(geom1, geom2 space.Geometry, f ...measure.Distance) (float64, error) {
+	return geom1.SpheroidDistance(geom2, f...)
 }
 
 // Envelope returns the  minimum bounding box for the supplied geometry, as a geometry.
 // The polygon is defined by the corner points of the bounding box
 // ((MINX, MINY), (MINX, MAXY), (MAXX, MAXY), (MAXX, MINY), (MINX, MINY)).
 func (g *GEOAlgorithm) Envelope(geom space.Geometry) (space.Geometry, error) {
-	result, err := geo.Envelope(wkt.MarshalString(geom))
-	if err != nil {
-		return nil, err
-	}
-	return wkt.UnmarshalString(result)
+	return geo.Envelope(geom)
 }
 
 // Equals returns TRUE if the given Geometries are "spatially equal".
 func (g *GEOAlgorithm) Equals(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Equals(ms1, ms2)
+	return geo.Equals(geom1, geom2)
 }
 
 // EqualsExact returns true if both geometries are Equal, as evaluated by their
 // points being within the given tolerance.
 func (g *GEOAlgorithm) EqualsExact(geom1, geom2 space.Geometry, tolerance float64) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.EqualsExact(ms1, ms2, tolerance)
+	return geo.EqualsExact(geom1, geom2, tolerance)
 }
 
 // HausdorffDistance returns the Hausdorff distance between two geometries, a measure of how similar
@@ -171,100 +140,116 @@ func (g *GEOAlgorithm) EqualsExact(geom1, geom2 space.Geometry, tolerance float6
 // thought of as the "Discrete Hausdorff Distance". This is the Hausdorff distance restricted
 // to discrete points for one of the geometries
 func (g *GEOAlgorithm) HausdorffDistance(geom1, geom2 space.Geometry) (float64, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.HausdorffDistance(ms1, ms2)
+	return geo.HausdorffDistance(geom1, geom2)
 }
 
 // HausdorffDistanceDensify computes the Hausdorff distance with an additional densification fraction amount
 func (g *GEOAlgorithm) HausdorffDistanceDensify(s, d space.Geometry, densifyFrac float64) (float64, error) {
-	ms1, ms2 := convertGeomToWKT(s, d)
-	return geo.HausdorffDistanceDensify(ms1, ms2, densifyFrac)
+	return geo.HausdorffDistanceDensify(s, d, densifyFrac)
 }
 
 // Intersection returns a geometry that represents the point set intersection of the Geometries.
 func (g *GEOAlgorithm) Intersection(geom1, geom2 space.Geometry) (space.Geometry, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	result, err := geo.Intersection(ms1, ms2)
-	if err != nil {
-		return nil, err
-	}
-	geometry, err := wkt.UnmarshalString(result)
-	if err != nil {
-		return nil, err
-	}
-	return geometry, nil
+	return geo.Intersection(geom1, geom2)
 }
 
 // Intersects If a geometry  shares any portion of space then they intersect
 func (g *GEOAlgorithm) Intersects(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Intersects(ms1, ms2)
+	return geo.Intersects(geom1, geom2)
 }
 
 // IsClosed Returns TRUE if the LINESTRING's start and end points are coincident.
 // For Polyhedral Surfaces, reports if the surface is areal (open) or volumetric (closed).
 func (g *GEOAlgorithm) IsClosed(geom space.Geometry) (bool, error) {
-	return geo.IsClosed(wkt.MarshalString(geom))
+	return geo.IsClosed(geom)
 }
 
 // IsEmpty returns true if this space.Geometry is an empty geometry.
 // If true, then this space.Geometry represents an empty geometry collection, polygon, point etc.
 func (g *GEOAlgorithm) IsEmpty(geom space.Geometry) (bool, error) {
-	return geo.IsEmpty(wkt.MarshalString(geom))
+	return geo.IsEmpty(geom)
 }
 
 // IsRing returns true if the lineal geometry has the ring property.
 func (g *GEOAlgorithm) IsRing(geom space.Geometry) (bool, error) {
-	return geo.IsRing(wkt.MarshalString(geom))
+	return geo.IsRing(geom)
 
 }
 
 // IsSimple returns true if this space.Geometry has no anomalous geometric points, such as self intersection or self tangency.
 func (g *GEOAlgorithm) IsSimple(geom space.Geometry) (bool, error) {
-	return geo.IsSimple(wkt.MarshalString(geom))
+	return geo.IsSimple(geom)
+}
+
+// IsValid returns true if the geometry is valid in the OGC sense.
+func (g *GEOAlgorithm) IsValid(geom space.Geometry) (bool, error) {
+	return geo.IsValid(geom)
+}
+
+// IsValidReason returns the reason why the geometry is not valid, and its location.
+func (g *GEOAlgorithm) IsValidReason(geom space.Geometry) (space.ValidReason, error) {
+	return geo.IsValidReason(geom)
 }
 
 // Length returns the 2D Cartesian length of the geometry if it is a LineString, MultiLineString
 func (g *GEOAlgorithm) Length(geom space.Geometry) (float64, error) {
-	return geo.Length(wkt.MarshalString(geom))
+	return geo.Length(geom)
+}
+
+// SpheroidLength returns the length in meters of a geometry of longitudes and latitudes along the geodesics of the
+// WGS84 ellipsoid, its perimeter if it is polygonal. GEOS measuring planar lengths only, it is computed by the Megrez algorithm.
+func (g *GEOAlgorithm) SpheroidLength(geom space.Geometry) (float64, error) {
+	return GetStrategy(newMegrezAlgorithm).SpheroidLength(geom)
 }
 
 // LineMerge returns a (set of) LineString(s) formed by sewing together the constituent line work of a MULTILINESTRING.
 func (g *GEOAlgorithm) LineMerge(geom space.Geometry) (space.Geometry, error) {
-	result, err := geo.LineMerge(wkt.MarshalString(geom))
-	if err != nil {
-		return nil, err
-	}
-	return wkt.UnmarshalString(result)
+	return geo.LineMerge(geom)
+}
+
+// MakeValid returns a valid geometry covering the points of the geometry, of which the parts which collapse
+// are kept as lines or points.
+func (g *GEOAlgorithm) MakeValid(geom space.Geometry) (space.Geometry, error) {
+	return geo.MakeValid(geom)
 }
 
 // NGeometry returns the number of component geometries.
 func (g *GEOAlgorithm) NGeometry(geom space.Geometry) (int, error) {
-	return geo.NGeometry(wkt.MarshalString(geom))
+	return geo.NGeometry(geom)
 }
 
 // Overlaps returns TRUE if the Geometries "spatially overlap".
 // By that we mean they intersect, but one does not completely contain another.
 func (g *GEOAlgorithm) Overlaps(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Overlaps(ms1, ms2)
+	return geo.Overlaps(geom1, geom2)
 }
 
 // PointOnSurface Returns a POINT guaranteed to intersect a surface.
 func (g *GEOAlgorithm) PointOnSurface(geom space.Geometry) (space.Geometry, error) {
-	result, err := geo.PointOnSurface(wkt.MarshalString(geom))
+	return geo.PointOnSurface(geom)
+}
+
+// Prepare indexes geom for the repeated evaluation of predicates against other geometries.
+// The returned geometry holds GEOS resources which are released when it is garbage collected.
+func (g *GEOAlgorithm) Prepare(geom space.Geometry) (PreparedGeometry, error) {
+	prepared, err := geo.NewPreparedGeometry(geom)
 	if err != nil {
 		return nil, err
 	}
-	return wkt.UnmarshalString(result)
+	return &geosPrepared{prepared: prepared}, nil
 }
 
 // Relate computes the intersection matrix (Dimensionally Extended
 // Nine-Intersection Model (DE-9IM) matrix) for the spatial relationship between
 // the two geometries.
 func (g *GEOAlgorithm) Relate(s, d space.Geometry) (string, error) {
-	ms1, ms2 := convertGeomToWKT(s, d)
-	return geo.Relate(ms1, ms2)
+	return geo.Relate(s, d)
+}
+
+// RelatePattern returns true if the DE-9IM matrix of the two geometries matches pattern,
+// a 9 character string of 'T', 'F', '*', '0', '1' or '2', such as "T*F**F***".
+func (g *GEOAlgorithm) RelatePattern(geom1, geom2 space.Geometry, pattern string) (bool, error) {
+	return geo.RelatePattern(geom1, geom2, pattern)
 }
 
 // SharedPaths returns a collection containing paths shared by the two input geometries.
@@ -272,32 +257,19 @@ func (g *GEOAlgorithm) Relate(s, d space.Geometry) (string, error) {
 // those going in the opposite direction are in the second element.
 // The paths themselves are given in the direction of the first geometry.
 func (g *GEOAlgorithm) SharedPaths(geom1, geom2 space.Geometry) (string, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	result, err := geo.SharedPaths(ms1, ms2)
-	if err != nil {
-		return "", err
-	}
-	return result, nil
+	return geo.SharedPaths(geom1, geom2)
 }
 
 // Simplify returns a "simplified" version of the given geometry using the Douglas-Peucker algorithm,
 // May not preserve topology
 func (g *GEOAlgorithm) Simplify(geom space.Geometry, tolerance float64) (space.Geometry, error) {
-	result, err := geo.Simplify(wkt.MarshalString(geom), tolerance)
-	if err != nil {
-		return nil, err
-	}
-	return wkt.UnmarshalString(result)
+	return geo.Simplify(geom, tolerance)
 }
 
 // SimplifyP returns a geometry simplified by amount given by tolerance.
 // Unlike Simplify, SimplifyP guarantees it will preserve topology.
 func (g *GEOAlgorithm) SimplifyP(geom space.Geometry, tolerance float64) (space.Geometry, error) {
-	result, err := geo.SimplifyP(wkt.MarshalString(geom), tolerance)
-	if err != nil {
-		return nil, err
-	}
-	return wkt.UnmarshalString(result)
+	return geo.SimplifyP(geom, tolerance)
 }
 
 // Snap the vertices and segments of a geometry to another space.Geometry's vertices.
@@ -305,85 +277,42 @@ func (g *GEOAlgorithm) SimplifyP(geom space.Geometry, tolerance float64) (space.
 // The result geometry is the input geometry with the vertices snapped.
 // If no snapping occurs then the input geometry is returned unchanged.
 func (g *GEOAlgorithm) Snap(input, reference space.Geometry, tolerance float64) (space.Geometry, error) {
-	inGeom := wkt.MarshalString(input)
-	refGeom := wkt.MarshalString(reference)
-	result, err := geo.Snap(inGeom, refGeom, tolerance)
-	if err != nil {
-		return nil, err
-	}
-	geometry, err := wkt.UnmarshalString(result)
-	if err != nil {
-		return nil, err
-	}
-	return geometry, nil
+	return geo.Snap(input, reference, tolerance)
 }
 
 // SymDifference returns a geometry that represents the portions of A and B that do not intersect.
 // It is called a symmetric difference because SymDifference(A,B) = SymDifference(B,A).
 // One can think of this as Union(geomA,geomB) - Intersection(A,B).
 func (g *GEOAlgorithm) SymDifference(geom1, geom2 space.Geometry) (space.Geometry, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	result, err := geo.SymDifference(ms1, ms2)
-	if err != nil {
-		return nil, err
-	}
-	geometry, err := wkt.UnmarshalString(result)
-	if err != nil {
-		return nil, err
-	}
-	return geometry, nil
+	return geo.SymDifference(geom1, geom2)
 }
 
 // Touches returns TRUE if the only points in common between geom1 and geom2 lie in the union of the boundaries of geom1 and geom2.
 // The ouches relation applies to all Area/Area, Line/Line, Line/Area, Point/Area and Point/Line pairs of relationships,
 // but not to the Point/Point pair.
 func (g *GEOAlgorithm) Touches(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Touches(ms1, ms2)
+	return geo.Touches(geom1, geom2)
 }
 
 // UnaryUnion does dissolve boundaries between components of a multipolygon (invalid) and does perform union
 // between the components of a geometrycollection
 func (g *GEOAlgorithm) UnaryUnion(geom space.Geometry) (space.Geometry, error) {
-	result, err := geo.UnaryUnion(wkt.MarshalString(geom))
-	if err != nil {
-		return nil, err
-	}
-	return wkt.UnmarshalString(result)
+	return geo.UnaryUnion(geom)
 }
 
 // Union returns a new geometry representing all points in this geometry and the other.
 func (g *GEOAlgorithm) Union(geom1, geom2 space.Geometry) (space.Geometry, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	result, err := geo.Union(ms1, ms2)
-	if err != nil {
-		return nil, err
-	}
-	geometry, err := wkt.UnmarshalString(result)
-	if err != nil {
-		return nil, err
-	}
-	return geometry, nil
+	return geo.Union(geom1, geom2)
 }
 
 // UniquePoints return all distinct vertices of input geometry as a MultiPoint.
 func (g *GEOAlgorithm) UniquePoints(geom space.Geometry) (space.Geometry, error) {
-	result, err := geo.UniquePoints(wkt.MarshalString(geom))
-	if err != nil {
-		return nil, err
-	}
-	geometry, err := wkt.UnmarshalString(result)
-	if err != nil {
-		return nil, err
-	}
-	return geometry, nil
-
+	return geo.UniquePoints(geom)
 }
 
 // Within returns TRUE if geometry A is completely inside geometry B.
 // For this function to make sense, the source geometries must both be of the same coordinate projection,
 // having the same SRID.
 func (g *GEOAlgorithm) Within(geom1, geom2 space.Geometry) (bool, error) {
-	ms1, ms2 := convertGeomToWKT(geom1, geom2)
-	return geo.Within(ms1, ms2)
+	return geo.Within(geom1, geom2)
 }
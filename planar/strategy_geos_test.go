@@ -1,9 +1,11 @@
 package planar
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
+	"github.com/spatial-go/geoos/algorithm/buffer"
 	"github.com/spatial-go/geoos/encoding/wkt"
 	"github.com/spatial-go/geoos/space"
 )
@@ -772,6 +774,35 @@ func TestGEOSAlgorithm_Buffer(t *testing.T) {
 	}
 }
 
+func TestGEOSAlgorithm_BufferWithParams(t *testing.T) {
+	line, _ := wkt.UnmarshalString("LINESTRING(0 0,10 0,10 10)")
+	corridor := buffer.Params{QuadSegs: 8, EndCapStyle: buffer.CapFlat, JoinStyle: buffer.JoinMitre, MitreLimit: buffer.DefaultMitreLimit}
+	leftSide := buffer.Params{QuadSegs: 8, EndCapStyle: buffer.CapFlat, JoinStyle: buffer.JoinRound, MitreLimit: buffer.DefaultMitreLimit, SingleSided: true}
+	type args struct {
+		g      space.Geometry
+		width  float64
+		params buffer.Params
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{name: "buffer flat cap mitre join", args: args{g: line, width: 1, params: corridor}, want: 40},
+		{name: "buffer left side", args: args{g: line, width: 1, params: leftSide}, want: 19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			G := GEOAlgorithm{}
+			gotGeometry := G.BufferWithParams(tt.args.g, tt.args.width, tt.args.params)
+			got, _ := G.Area(gotGeometry)
+			if math.Abs(got-tt.want) > 0.000001 {
+				t.Errorf("GEOAlgorithm.BufferWithParams() = %v, area %v, want %v", wkt.MarshalString(gotGeometry), got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGEOSAlgorithm_EqualsExact(t *testing.T) {
 	geometry1, _ := wkt.UnmarshalString("POINT(116.309878625564 40.0427783817455)")
 	geometry2, _ := wkt.UnmarshalString("POINT(116.309878725564 40.0427783827455)")
@@ -1063,7 +1094,7 @@ func TestGEOSAlgorithm_SphericalDistance(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			G := GEOAlgorithm{}
 			got, _ := G.SphericalDistance(tt.args.p1, tt.args.p2)
-			if got != tt.want {
+			if math.Abs(got-tt.want) > 1e-5 {
 				t.Errorf("SphericalDistance() got = %v, want %v", got, tt.want)
 			}
 		})
@@ -1175,3 +1206,83 @@ func Test_code(t *testing.T) {
 	print(geometry)
 
 }
+
+func TestGEOSAlgorithm_RelatePattern(t *testing.T) {
+	polygon, _ := wkt.UnmarshalString(`POLYGON((0 0, 6 0, 6 6, 0 6, 0 0))`)
+	point, _ := wkt.UnmarshalString(`POINT(3 3)`)
+	type args struct {
+		g1      space.Geometry
+		g2      space.Geometry
+		pattern string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{name: "contains", args: args{g1: polygon, g2: point, pattern: "T*****FF*"}, want: true},
+		{name: "within", args: args{g1: polygon, g2: point, pattern: "T*F**F***"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			G := GEOAlgorithm{}
+			got, err := G.RelatePattern(tt.args.g1, tt.args.g2, tt.args.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GEOAlgorithm.RelatePattern() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GEOAlgorithm.RelatePattern() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGEOSAlgorithm_Prepare(t *testing.T) {
+	polygon, _ := wkt.UnmarshalString(`POLYGON((0 0, 6 0, 6 6, 0 6, 0 0))`)
+	inside, _ := wkt.UnmarshalString(`POINT(3 3)`)
+	onBoundary, _ := wkt.UnmarshalString(`POINT(6 3)`)
+	crossing, _ := wkt.UnmarshalString(`LINESTRING(3 3, 9 3)`)
+	outside, _ := wkt.UnmarshalString(`POLYGON((7 7, 9 7, 9 9, 7 9, 7 7))`)
+	tests := []struct {
+		name                                           string
+		geom                                           space.Geometry
+		contains, containsProperly, covers, intersects bool
+	}{
+		{name: "inside", geom: inside, contains: true, containsProperly: true, covers: true, intersects: true},
+		{name: "on boundary", geom: onBoundary, covers: true, intersects: true},
+		{name: "crossing", geom: crossing, intersects: true},
+		{name: "outside", geom: outside},
+		{name: "equal", geom: polygon, contains: true, covers: true, intersects: true},
+	}
+	G := GEOAlgorithm{}
+	prepared, err := G.Prepare(polygon)
+	if err != nil {
+		t.Fatalf("GEOAlgorithm.Prepare() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicates := []struct {
+				name string
+				f    func(geom space.Geometry) (bool, error)
+				want bool
+			}{
+				{"Contains", prepared.Contains, tt.contains},
+				{"ContainsProperly", prepared.ContainsProperly, tt.containsProperly},
+				{"Covers", prepared.Covers, tt.covers},
+				{"Intersects", prepared.Intersects, tt.intersects},
+			}
+			for _, p := range predicates {
+				got, err := p.f(tt.geom)
+				if err != nil {
+					t.Errorf("GEOAlgorithm.Prepare().%s() error = %v", p.name, err)
+					continue
+				}
+				if got != p.want {
+					t.Errorf("GEOAlgorithm.Prepare().%s() got = %v, want %v", p.name, got, p.want)
+				}
+			}
+		})
+	}
+}
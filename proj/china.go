@@ -0,0 +1,130 @@
+package proj
+
+import "math"
+
+// Coordinate reference systems of the Chinese map providers, of which the longitudes and the latitudes
+// are offset from WGS84: GCJ-02 is used by AMap and Tencent, and BD-09, which is offset from GCJ-02, by Baidu.
+// The offsets are applied in China only, points outside of China being left as they are.
+//
+// The conversions to WGS84 are inverted by iterations, until the conversion back of their result is within
+// 1e-9 degrees, about 0.1 mm, of the input: they are as accurate as the positions converted.
+var (
+	GCJ02 = &CRS{Code: "GCJ-02", Name: "GCJ-02", Projection: gcj02{}}
+	BD09  = &CRS{Code: "BD-09", Name: "BD-09", Projection: bd09{}}
+)
+
+func init() {
+	Register(GCJ02)
+	Register(BD09)
+}
+
+const (
+	// gcj02A and gcj02EE are the semi-major axis and the square of the eccentricity of the ellipsoid of GCJ-02, Krasovsky 1940.
+	gcj02A  = 6378245.0
+	gcj02EE = 0.00669342162296594323
+
+	// bd09XPi scales the coordinates of the offset of BD-09.
+	bd09XPi = math.Pi * 3000 / 180
+
+	// inverseTolerance is the tolerance in degrees of the inverse conversions.
+	inverseTolerance = 1e-9
+	// inverseIterations is the maximum number of iterations of the inverse conversions.
+	inverseIterations = 30
+)
+
+// gcj02 converts WGS84 longitudes and latitudes to GCJ-02.
+type gcj02 struct{}
+
+// Forward converts a WGS84 longitude and latitude to GCJ-02.
+func (gcj02) Forward(lon, lat float64) (x, y float64) {
+	return WGS84ToGCJ02(lon, lat)
+}
+
+// Inverse converts a GCJ-02 longitude and latitude to WGS84.
+func (gcj02) Inverse(x, y float64) (lon, lat float64) {
+	return GCJ02ToWGS84(x, y)
+}
+
+// bd09 converts WGS84 longitudes and latitudes to BD-09.
+type bd09 struct{}
+
+// Forward converts a WGS84 longitude and latitude to BD-09.
+func (bd09) Forward(lon, lat float64) (x, y float64) {
+	return WGS84ToBD09(lon, lat)
+}
+
+// Inverse converts a BD-09 longitude and latitude to WGS84.
+func (bd09) Inverse(x, y float64) (lon, lat float64) {
+	return BD09ToWGS84(x, y)
+}
+
+// outOfChina returns true if a point is outside of the bound of China, where GCJ-02 is not offset.
+func outOfChina(lon, lat float64) bool {
+	return lon < 72.004 || lon > 137.8347 || lat < 0.8293 || lat > 55.8271
+}
+
+// WGS84ToGCJ02 converts a WGS84 longitude and latitude to GCJ-02.
+func WGS84ToGCJ02(lon, lat float64) (float64, float64) {
+	if outOfChina(lon, lat) {
+		return lon, lat
+	}
+	x, y := lon-105, lat-35
+	dLat := -100 + 2*x + 3*y + 0.2*y*y + 0.1*x*y + 0.2*math.Sqrt(math.Abs(x)) +
+		(20*math.Sin(6*math.Pi*x)+20*math.Sin(2*math.Pi*x))*2/3 +
+		(20*math.Sin(math.Pi*y)+40*math.Sin(math.Pi*y/3))*2/3 +
+		(160*math.Sin(math.Pi*y/12)+320*math.Sin(math.Pi*y/30))*2/3
+	dLon := 300 + x + 2*y + 0.1*x*x + 0.1*x*y + 0.1*math.Sqrt(math.Abs(x)) +
+		(20*math.Sin(6*math.Pi*x)+20*math.Sin(2*math.Pi*x))*2/3 +
+		(20*math.Sin(math.Pi*x)+40*math.Sin(math.Pi*x/3))*2/3 +
+		(150*math.Sin(math.Pi*x/12)+300*math.Sin(math.Pi*x/30))*2/3
+
+	radLat := lat * math.Pi / 180
+	magic := 1 - gcj02EE*math.Sin(radLat)*math.Sin(radLat)
+	sqrtMagic := math.Sqrt(magic)
+	dLat = dLat * 180 / (gcj02A * (1 - gcj02EE) / (magic * sqrtMagic) * math.Pi)
+	dLon = dLon * 180 / (gcj02A / sqrtMagic * math.Cos(radLat) * math.Pi)
+	return lon + dLon, lat + dLat
+}
+
+// GCJ02ToWGS84 converts a GCJ-02 longitude and latitude to WGS84, by iterations.
+func GCJ02ToWGS84(lon, lat float64) (float64, float64) {
+	return inverse(WGS84ToGCJ02, lon, lat)
+}
+
+// GCJ02ToBD09 converts a GCJ-02 longitude and latitude to BD-09.
+func GCJ02ToBD09(lon, lat float64) (float64, float64) {
+	z := math.Sqrt(lon*lon+lat*lat) + 0.00002*math.Sin(lat*bd09XPi)
+	theta := math.Atan2(lat, lon) + 0.000003*math.Cos(lon*bd09XPi)
+	return z*math.Cos(theta) + 0.0065, z*math.Sin(theta) + 0.006
+}
+
+// BD09ToGCJ02 converts a BD-09 longitude and latitude to GCJ-02, by iterations.
+func BD09ToGCJ02(lon, lat float64) (float64, float64) {
+	return inverse(GCJ02ToBD09, lon, lat)
+}
+
+// WGS84ToBD09 converts a WGS84 longitude and latitude to BD-09.
+func WGS84ToBD09(lon, lat float64) (float64, float64) {
+	return GCJ02ToBD09(WGS84ToGCJ02(lon, lat))
+}
+
+// BD09ToWGS84 converts a BD-09 longitude and latitude to WGS84, by iterations on WGS84ToBD09,
+// so that the tolerance holds for the whole conversion rather than for each of its two steps.
+func BD09ToWGS84(lon, lat float64) (float64, float64) {
+	return inverse(WGS84ToBD09, lon, lat)
+}
+
+// inverse returns the point which forward converts to a point, found by correcting a guess
+// with the difference between its conversion and the point, as the offsets vary slowly.
+func inverse(forward func(lon, lat float64) (float64, float64), lon, lat float64) (float64, float64) {
+	x, y := lon, lat
+	for i := 0; i < inverseIterations; i++ {
+		fx, fy := forward(x, y)
+		dx, dy := fx-lon, fy-lat
+		if math.Abs(dx) < inverseTolerance && math.Abs(dy) < inverseTolerance {
+			break
+		}
+		x, y = x-dx, y-dy
+	}
+	return x, y
+}
@@ -0,0 +1,89 @@
+package proj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+func TestChinaOffsets(t *testing.T) {
+	tests := []struct {
+		name     string
+		lon, lat float64
+	}{
+		{name: "beijing", lon: 116.391, lat: 39.906},
+		{name: "shanghai", lon: 121.4737, lat: 31.2304},
+		{name: "urumqi", lon: 87.6168, lat: 43.8256},
+		{name: "sanya", lon: 109.5119, lat: 18.2528},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := WGS84ToGCJ02(tt.lon, tt.lat)
+			if d := measure.SpheroidDistance(matrix.Matrix{tt.lon, tt.lat}, matrix.Matrix{x, y}); d < 50 || d > 1000 {
+				t.Errorf("WGS84ToGCJ02() got = %v %v, offset by %v m", x, y, d)
+			}
+			if lon, lat := GCJ02ToWGS84(x, y); math.Abs(lon-tt.lon) > 1e-8 || math.Abs(lat-tt.lat) > 1e-8 {
+				t.Errorf("GCJ02ToWGS84() got = %v %v, want %v %v", lon, lat, tt.lon, tt.lat)
+			}
+
+			bx, by := GCJ02ToBD09(x, y)
+			if math.Abs(bx-x-0.0065) > 5e-4 || math.Abs(by-y-0.006) > 5e-4 {
+				t.Errorf("GCJ02ToBD09() got = %v %v", bx, by)
+			}
+			if gx, gy := BD09ToGCJ02(bx, by); math.Abs(gx-x) > 1e-8 || math.Abs(gy-y) > 1e-8 {
+				t.Errorf("BD09ToGCJ02() got = %v %v, want %v %v", gx, gy, x, y)
+			}
+			if wx, wy := WGS84ToBD09(tt.lon, tt.lat); wx != bx || wy != by {
+				t.Errorf("WGS84ToBD09() got = %v %v, want %v %v", wx, wy, bx, by)
+			}
+			lon, lat := BD09ToWGS84(bx, by)
+			if math.Abs(lon-tt.lon) > 1e-8 || math.Abs(lat-tt.lat) > 1e-8 {
+				t.Errorf("BD09ToWGS84() got = %v %v, want %v %v", lon, lat, tt.lon, tt.lat)
+			}
+			// the conversion back is within the tolerance of the inverse, see inverseTolerance.
+			if fx, fy := WGS84ToBD09(lon, lat); math.Abs(fx-bx) > inverseTolerance || math.Abs(fy-by) > inverseTolerance {
+				t.Errorf("WGS84ToBD09(BD09ToWGS84()) got = %v %v, want %v %v", fx, fy, bx, by)
+			}
+		})
+	}
+
+	if x, y := WGS84ToGCJ02(2.35, 48.85); x != 2.35 || y != 48.85 {
+		t.Errorf("WGS84ToGCJ02() got = %v %v out of China", x, y)
+	}
+}
+
+func TestTransform_China(t *testing.T) {
+	line := space.LineString{{116.391, 39.906}, {121.4737, 31.2304}}
+	fc := geojson.NewFeatureCollection()
+	fc.Append(geojson.NewFeature(*geojson.NewGeometry(line)))
+
+	gcj, err := TransformFeatures(fc, WGS84, GCJ02)
+	if err != nil {
+		t.Fatalf("TransformFeatures() error = %v", err)
+	}
+	bd, err := TransformFeatures(gcj, GCJ02, BD09)
+	if err != nil {
+		t.Fatalf("TransformFeatures() error = %v", err)
+	}
+	got := bd.Features[0].Geometry.Geometry().(space.LineString)
+	for i, p := range line {
+		x, y := WGS84ToBD09(p[0], p[1])
+		if !near(got[i], []float64{x, y}, 1e-8) {
+			t.Errorf("TransformFeatures() got = %v, want %v %v", got[i], x, y)
+		}
+	}
+
+	back, _ := Transform(got, BD09, WGS84)
+	for i, p := range back.(space.LineString) {
+		if !near(p, line[i], 1e-8) {
+			t.Errorf("Transform() got = %v, want %v", p, line[i])
+		}
+	}
+	if crs, err := Lookup("GCJ-02"); err != nil || crs != GCJ02 {
+		t.Errorf("Lookup() got = %v, %v", crs, err)
+	}
+}
@@ -0,0 +1,27 @@
+package proj
+
+import "math"
+
+// webMercatorRadius is the radius of the sphere of Web Mercator, the semi-major axis of WGS84.
+const webMercatorRadius = 6378137.0
+
+// webMercatorMaxLatitude is the latitude at which Web Mercator is square, beyond which latitudes are clamped.
+const webMercatorMaxLatitude = 85.05112877980659
+
+// webMercator projects longitudes and latitudes of WGS84 as if they were on a sphere, in meters.
+type webMercator struct{}
+
+// Forward projects a longitude and a latitude, the latitude being clamped to the square of Web Mercator.
+func (webMercator) Forward(lon, lat float64) (x, y float64) {
+	lat = math.Max(-webMercatorMaxLatitude, math.Min(webMercatorMaxLatitude, lat))
+	x = webMercatorRadius * lon * math.Pi / 180
+	y = webMercatorRadius * math.Log(math.Tan(math.Pi/4+lat*math.Pi/360))
+	return x, y
+}
+
+// Inverse returns the longitude and the latitude of projected coordinates.
+func (webMercator) Inverse(x, y float64) (lon, lat float64) {
+	lon = x / webMercatorRadius * 180 / math.Pi
+	lat = math.Atan(math.Sinh(y/webMercatorRadius)) * 180 / math.Pi
+	return lon, lat
+}
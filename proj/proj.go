@@ -0,0 +1,339 @@
+/*
+Package proj is a library for transforming geometries and GeoJSON features between coordinate reference systems.
+
+A coordinate reference system is geographic, its coordinates being longitudes and latitudes in degrees, or
+projected by a Projection of the longitudes and the latitudes, like Web Mercator, the UTM zones and the
+Gauss-Kruger zones of CGCS2000, or offset like GCJ-02 and BD-09, the longitudes and latitudes of the Chinese
+map providers. Other projections are used by implementing the Projection interface.
+WGS84 and CGCS2000 are taken as the same datum: they differ by a few centimeters, which is below the
+accuracy of most data, so no datum shift is applied between them.
+*/
+package proj
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+var (
+	// ErrUnknownCRS is returned when no coordinate reference system has a code, or is given.
+	ErrUnknownCRS = errors.New("proj: unknown coordinate reference system")
+	// ErrInvalidCoordinate is returned when a position is transformed to a NaN or infinite coordinate,
+	// like a NaN longitude. WebMercator clamps the latitudes beyond 85.0511 degrees, the poles included, instead.
+	ErrInvalidCoordinate = errors.New("proj: invalid coordinate")
+)
+
+// Projection projects longitudes and latitudes in degrees to the coordinates of a projected
+// coordinate reference system, and back.
+type Projection interface {
+	Forward(lon, lat float64) (x, y float64)
+	Inverse(x, y float64) (lon, lat float64)
+}
+
+// CRS a coordinate reference system
+type CRS struct {
+	// Code is the code of the coordinate reference system, like "EPSG:4326".
+	Code string
+	Name string
+	// Projection is the projection of the coordinates, nil if they are longitudes and latitudes.
+	Projection Projection
+}
+
+// Geographic and projected coordinate reference systems.
+var (
+	WGS84       = &CRS{Code: "EPSG:4326", Name: "WGS 84"}
+	CGCS2000    = &CRS{Code: "EPSG:4490", Name: "China Geodetic Coordinate System 2000"}
+	WebMercator = &CRS{Code: "EPSG:3857", Name: "WGS 84 / Pseudo-Mercator", Projection: webMercator{}}
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*CRS{}
+)
+
+func init() {
+	for _, crs := range []*CRS{WGS84, CGCS2000, WebMercator} {
+		Register(crs)
+	}
+	Register(&CRS{Code: "EPSG:900913", Name: WebMercator.Name, Projection: WebMercator.Projection})
+	for zone := 1; zone <= 60; zone++ {
+		north, _ := UTM(zone, true)
+		south, _ := UTM(zone, false)
+		Register(north)
+		Register(south)
+	}
+	for zone := 13; zone <= 23; zone++ {
+		crs, _ := GaussKruger(zone, 6)
+		Register(crs)
+		Register(gaussKrugerCM(4502+zone-13, zone, 6))
+	}
+	for zone := 25; zone <= 45; zone++ {
+		crs, _ := GaussKruger(zone, 3)
+		Register(crs)
+		Register(gaussKrugerCM(4534+zone-25, zone, 3))
+	}
+}
+
+// Register registers a coordinate reference system, which Lookup returns for its code.
+func Register(crs *CRS) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[crs.Code] = crs
+}
+
+// Lookup returns the coordinate reference system of a code, like "EPSG:3857".
+// The coordinate reference systems of the package are registered, and so are the UTM zones of WGS84
+// and the Gauss-Kruger zones of CGCS2000, EPSG:32601 to EPSG:32760 and EPSG:4491 to EPSG:4554.
+func Lookup(code string) (*CRS, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	crs, ok := registry[code]
+	if !ok {
+		return nil, ErrUnknownCRS
+	}
+	return crs, nil
+}
+
+// UTM returns the coordinate reference system of a UTM zone of WGS84, from 1 to 60, in the northern or
+// the southern hemisphere.
+func UTM(zone int, north bool) (*CRS, error) {
+	if zone < 1 || zone > 60 {
+		return nil, ErrUnknownCRS
+	}
+	crs := &CRS{
+		Code:       fmt.Sprintf("EPSG:%d", 32600+zone),
+		Name:       fmt.Sprintf("WGS 84 / UTM zone %dN", zone),
+		Projection: NewTransverseMercator(WGS84Ellipsoid, float64(6*zone-183), 0.9996, 500000, 0),
+	}
+	if !north {
+		crs.Code = fmt.Sprintf("EPSG:%d", 32700+zone)
+		crs.Name = fmt.Sprintf("WGS 84 / UTM zone %dS", zone)
+		crs.Projection = NewTransverseMercator(WGS84Ellipsoid, float64(6*zone-183), 0.9996, 500000, 10000000)
+	}
+	return crs, nil
+}
+
+// UTMZone returns the UTM zone of a longitude.
+func UTMZone(lon float64) int {
+	zone := int(math.Floor((lon+180)/6)) + 1
+	if zone > 60 {
+		zone -= 60
+	}
+	if zone < 1 {
+		zone += 60
+	}
+	return zone
+}
+
+// GaussKruger returns the coordinate reference system of a Gauss-Kruger zone of CGCS2000, 3 or 6 degrees wide,
+// of which the false easting is prefixed with the zone: 13 to 23 for the 6 degree zones, and 25 to 45 for the
+// 3 degree zones.
+func GaussKruger(zone, width int) (*CRS, error) {
+	var code int
+	var name string
+	switch {
+	case width == 6 && zone >= 13 && zone <= 23:
+		code, name = 4491+zone-13, fmt.Sprintf("CGCS2000 / Gauss-Kruger zone %d", zone)
+	case width == 3 && zone >= 25 && zone <= 45:
+		code, name = 4513+zone-25, fmt.Sprintf("CGCS2000 / 3-degree Gauss-Kruger zone %d", zone)
+	default:
+		return nil, ErrUnknownCRS
+	}
+	return &CRS{
+		Code:       fmt.Sprintf("EPSG:%d", code),
+		Name:       name,
+		Projection: NewTransverseMercator(CGCS2000Ellipsoid, gaussKrugerMeridian(zone, width), 1, float64(zone)*1e6+500000, 0),
+	}, nil
+}
+
+// gaussKrugerCM returns the coordinate reference system of a Gauss-Kruger zone of CGCS2000,
+// of which the false easting is not prefixed with the zone.
+func gaussKrugerCM(code, zone, width int) *CRS {
+	meridian := gaussKrugerMeridian(zone, width)
+	name := fmt.Sprintf("CGCS2000 / Gauss-Kruger CM %vE", meridian)
+	if width == 3 {
+		name = fmt.Sprintf("CGCS2000 / 3-degree Gauss-Kruger CM %vE", meridian)
+	}
+	return &CRS{
+		Code:       fmt.Sprintf("EPSG:%d", code),
+		Name:       name,
+		Projection: NewTransverseMercator(CGCS2000Ellipsoid, meridian, 1, 500000, 0),
+	}
+}
+
+// gaussKrugerMeridian returns the central meridian of a Gauss-Kruger zone.
+func gaussKrugerMeridian(zone, width int) float64 {
+	if width == 6 {
+		return float64(6*zone - 3)
+	}
+	return float64(3 * zone)
+}
+
+// Transform returns the geometry with its positions transformed from a coordinate reference system to another.
+// The coordinates other than x and y, like z, are kept. A bound is transformed to the bound of its corners.
+func Transform(geom space.Geometry, from, to *CRS) (space.Geometry, error) {
+	if from == nil || to == nil {
+		return nil, ErrUnknownCRS
+	}
+	return transformGeometry(geom, func(p []float64) ([]float64, error) {
+		lon, lat := p[0], p[1]
+		if from.Projection != nil {
+			lon, lat = from.Projection.Inverse(lon, lat)
+		}
+		x, y := lon, lat
+		if to.Projection != nil {
+			x, y = to.Projection.Forward(lon, lat)
+		}
+		if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
+			return nil, ErrInvalidCoordinate
+		}
+		transformed := append([]float64{x, y}, p[2:]...)
+		return transformed, nil
+	})
+}
+
+// TransformFeatures returns the features with their geometries transformed from a coordinate reference system
+// to another. The features share their properties with those of the collection, and their bounding boxes are
+// computed again if they have one.
+func TransformFeatures(fc *geojson.FeatureCollection, from, to *CRS) (*geojson.FeatureCollection, error) {
+	return transformFeatures(fc, func(geom space.Geometry) (space.Geometry, error) {
+		return Transform(geom, from, to)
+	})
+}
+
+// transformFeatures returns the features with their geometries transformed by a function.
+func transformFeatures(fc *geojson.FeatureCollection, transform func(space.Geometry) (space.Geometry, error)) (*geojson.FeatureCollection, error) {
+	transformed := geojson.NewFeatureCollection()
+	var bound space.Bound
+	hasBound := false
+	for _, feature := range fc.Features {
+		f := *feature
+		if feature.Geometry.Coordinates != nil || feature.Geometry.Geometries != nil {
+			geom, err := transform(feature.Geometry.Geometry())
+			if err != nil {
+				return nil, err
+			}
+			f.Geometry = *geojson.NewGeometry(geom)
+			if feature.Geometry.BBox != nil {
+				f.Geometry.BBox = geojson.NewBBox(geom.Bound())
+			}
+			if feature.BBox != nil {
+				f.BBox = geojson.NewBBox(geom.Bound())
+			}
+			if !geom.IsEmpty() {
+				if !hasBound {
+					bound, hasBound = geom.Bound(), true
+				} else {
+					bound = bound.Union(geom.Bound())
+				}
+			}
+		}
+		transformed.Append(&f)
+	}
+	if fc.BBox != nil && hasBound {
+		transformed.BBox = geojson.NewBBox(bound)
+	}
+	return transformed, nil
+}
+
+// transformGeometry returns the geometry with each of its positions transformed by a function.
+func transformGeometry(geom space.Geometry, transform func(p []float64) ([]float64, error)) (space.Geometry, error) {
+	line := func(ls [][]float64) ([][]float64, error) {
+		transformed := make([][]float64, len(ls))
+		for i, p := range ls {
+			q, err := transform(p)
+			if err != nil {
+				return nil, err
+			}
+			transformed[i] = q
+		}
+		return transformed, nil
+	}
+	polygon := func(polygon space.Polygon) (space.Polygon, error) {
+		transformed := make(space.Polygon, len(polygon))
+		for i, ring := range polygon {
+			r, err := line(ring)
+			if err != nil {
+				return nil, err
+			}
+			transformed[i] = r
+		}
+		return transformed, nil
+	}
+
+	switch g := geom.(type) {
+	case space.Point:
+		if g.IsEmpty() {
+			return space.Point{}, nil
+		}
+		p, err := transform(g)
+		return space.Point(p), err
+	case space.MultiPoint:
+		transformed := make(space.MultiPoint, len(g))
+		for i, p := range g {
+			t, err := transform(p)
+			if err != nil {
+				return nil, err
+			}
+			transformed[i] = t
+		}
+		return transformed, nil
+	case space.LineString:
+		ls, err := line(g)
+		return space.LineString(ls), err
+	case space.Ring:
+		ring, err := line(g)
+		return space.Ring(ring), err
+	case space.MultiLineString:
+		transformed := make(space.MultiLineString, len(g))
+		for i, ls := range g {
+			l, err := line(ls)
+			if err != nil {
+				return nil, err
+			}
+			transformed[i] = l
+		}
+		return transformed, nil
+	case space.Polygon:
+		return polygon(g)
+	case space.MultiPolygon:
+		transformed := make(space.MultiPolygon, len(g))
+		for i, p := range g {
+			t, err := polygon(p)
+			if err != nil {
+				return nil, err
+			}
+			transformed[i] = t
+		}
+		return transformed, nil
+	case space.Collection:
+		transformed := make(space.Collection, len(g))
+		for i, member := range g {
+			t, err := transformGeometry(member, transform)
+			if err != nil {
+				return nil, err
+			}
+			transformed[i] = t
+		}
+		return transformed, nil
+	case space.Bound:
+		if g.IsEmpty() {
+			return g, nil
+		}
+		corners, err := line(g.ToRing()[:4])
+		if err != nil {
+			return nil, err
+		}
+		bound := space.Bound{Min: corners[0], Max: corners[0]}
+		for _, p := range corners[1:] {
+			bound = bound.Extend(p)
+		}
+		return bound, nil
+	}
+	return nil, ErrInvalidCoordinate
+}
@@ -0,0 +1,152 @@
+package proj
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+func near(p, q []float64, tolerance float64) bool {
+	return len(p) == len(q) && math.Abs(p[0]-q[0]) <= tolerance && math.Abs(p[1]-q[1]) <= tolerance
+}
+
+// positions returns the positions of a geometry, in order.
+func positions(geom space.Geometry) [][]float64 {
+	var ps [][]float64
+	_, _ = transformGeometry(geom, func(p []float64) ([]float64, error) {
+		ps = append(ps, p)
+		return p, nil
+	})
+	return ps
+}
+
+func TestTransform(t *testing.T) {
+	utm31, _ := UTM(31, true)
+	utm23s, _ := UTM(23, false)
+	gk39, _ := GaussKruger(39, 3)
+	gk20, _ := GaussKruger(20, 6)
+	cm117, _ := Lookup("EPSG:4548")
+
+	tests := []struct {
+		name      string
+		p         space.Point
+		from, to  *CRS
+		want      space.Point
+		tolerance float64
+	}{
+		{name: "web mercator", p: space.Point{180, 0}, from: WGS84, to: WebMercator, want: space.Point{20037508.342789244, 0}, tolerance: 1e-6},
+		{name: "web mercator max latitude", p: space.Point{0, 85.05112877980659}, from: WGS84, to: WebMercator,
+			want: space.Point{0, 20037508.342789244}, tolerance: 1e-6},
+		{name: "utm", p: space.Point{2 + 17.0/60 + 40.2/3600, 48 + 51.0/60 + 29.5/3600}, from: WGS84, to: utm31,
+			want: space.Point{448251.789, 5411932.060}, tolerance: 1e-3},
+		{name: "utm south", p: space.Point{-45, 0}, from: WGS84, to: utm23s, want: space.Point{500000, 10000000}, tolerance: 1e-6},
+		{name: "gauss kruger 3 degree", p: space.Point{117, 30}, from: CGCS2000, to: gk39, want: space.Point{39500000, 3320113.398}, tolerance: 1e-3},
+		{name: "gauss kruger 6 degree", p: space.Point{117, 30}, from: CGCS2000, to: gk20, want: space.Point{20500000, 3320113.398}, tolerance: 1e-3},
+		{name: "gauss kruger central meridian", p: space.Point{117, 30}, from: CGCS2000, to: cm117, want: space.Point{500000, 3320113.398}, tolerance: 1e-3},
+		{name: "projected to projected", p: space.Point{500000, 0}, from: utm31, to: WebMercator, want: space.Point{333958.4723798207, 0}, tolerance: 1e-6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Transform(tt.p, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Transform() error = %v", err)
+			}
+			if !near(got.(space.Point), tt.want, tt.tolerance) {
+				t.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+			back, _ := Transform(got, tt.to, tt.from)
+			if !near(back.(space.Point), tt.p, 1e-9) {
+				t.Errorf("Transform() back got = %v, want %v", back, tt.p)
+			}
+		})
+	}
+}
+
+func TestTransform_Geometries(t *testing.T) {
+	polygon := space.Polygon{{{116, 39}, {117, 39}, {117, 40}, {116, 40}, {116, 39}}}
+	geoms := []space.Geometry{
+		space.Point{116, 39, 50},
+		space.MultiPoint{{116, 39}, {117, 40}},
+		space.LineString{{116, 39}, {117, 40}},
+		space.MultiLineString{{{116, 39}, {117, 40}}},
+		polygon,
+		space.MultiPolygon{polygon},
+		space.Collection{space.Point{116, 39}, polygon},
+	}
+	for _, geom := range geoms {
+		t.Run(geom.GeoJSONType(), func(t *testing.T) {
+			got, err := Transform(geom, WGS84, WebMercator)
+			if err != nil {
+				t.Fatalf("Transform() error = %v", err)
+			}
+			if got.GeoJSONType() != geom.GeoJSONType() {
+				t.Errorf("Transform() got = %v, want a %v", got, geom.GeoJSONType())
+			}
+			back, _ := Transform(got, WebMercator, WGS84)
+			want, ps := positions(geom), positions(back)
+			for i := range want {
+				if !near(ps[i], want[i], 1e-9) {
+					t.Errorf("Transform() back got = %v, want %v", back, geom)
+				}
+			}
+		})
+	}
+
+	got, _ := Transform(space.Point{116, 39, 50}, WGS84, WebMercator)
+	if len(got.(space.Point)) != 3 || got.(space.Point)[2] != 50 {
+		t.Errorf("Transform() got = %v, want z kept", got)
+	}
+	if _, err := Transform(space.Point{0, math.NaN()}, WGS84, WebMercator); err != ErrInvalidCoordinate {
+		t.Errorf("Transform() error = %v, want %v", err, ErrInvalidCoordinate)
+	}
+	if _, err := Transform(polygon, nil, WebMercator); err != ErrUnknownCRS {
+		t.Errorf("Transform() error = %v, want %v", err, ErrUnknownCRS)
+	}
+}
+
+func TestTransformFeatures(t *testing.T) {
+	fc := geojson.NewFeatureCollection()
+	feature := geojson.NewFeature(*geojson.NewGeometry(space.LineString{{0, 0}, {180, 0}}))
+	feature.ID = 1
+	feature.Properties["name"] = "equator"
+	feature.BBox = geojson.BBox{0, 0, 180, 0}
+	fc.Append(feature)
+	fc.Append(geojson.NewFeature(geojson.Geometry{}))
+
+	got, err := TransformFeatures(fc, WGS84, WebMercator)
+	if err != nil {
+		t.Fatalf("TransformFeatures() error = %v", err)
+	}
+	if len(got.Features) != 2 || got.Features[0].ID != 1 || got.Features[0].Properties["name"] != "equator" {
+		t.Fatalf("TransformFeatures() got = %v", got.Features)
+	}
+	ls := got.Features[0].Geometry.Geometry().(space.LineString)
+	if !near(ls[1], []float64{20037508.342789244, 0}, 1e-6) || !near(got.Features[0].BBox[2:], ls[1], 1e-6) {
+		t.Errorf("TransformFeatures() got = %v, bbox %v", ls, got.Features[0].BBox)
+	}
+	if got.Features[1].Geometry.Coordinates != nil {
+		t.Errorf("TransformFeatures() got = %v, want a null geometry", got.Features[1].Geometry)
+	}
+	if fc.Features[0].Geometry.Geometry().(space.LineString)[1][0] != 180 {
+		t.Errorf("TransformFeatures() changed the features of the collection")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, code := range []string{"EPSG:4326", "EPSG:3857", "EPSG:32650", "EPSG:32750", "EPSG:4491", "EPSG:4554"} {
+		if crs, err := Lookup(code); err != nil || crs.Code != code {
+			t.Errorf("Lookup(%v) got = %v, %v", code, crs, err)
+		}
+	}
+	if _, err := Lookup("EPSG:0"); err != ErrUnknownCRS {
+		t.Errorf("Lookup() error = %v, want %v", err, ErrUnknownCRS)
+	}
+	if zone := UTMZone(116.4); zone != 50 {
+		t.Errorf("UTMZone() got = %v, want 50", zone)
+	}
+	if _, err := GaussKruger(12, 6); err != ErrUnknownCRS {
+		t.Errorf("GaussKruger() error = %v, want %v", err, ErrUnknownCRS)
+	}
+}
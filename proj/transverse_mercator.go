@@ -0,0 +1,120 @@
+package proj
+
+import "math"
+
+// Ellipsoid an ellipsoid of revolution, given by its semi-major axis in meters and its flattening.
+type Ellipsoid struct {
+	A, F float64
+}
+
+// Ellipsoids of the geographic coordinate reference systems.
+var (
+	WGS84Ellipsoid    = Ellipsoid{A: 6378137, F: 1 / 298.257223563}
+	CGCS2000Ellipsoid = Ellipsoid{A: 6378137, F: 1 / 298.257222101}
+)
+
+// TransverseMercator the transverse Mercator projection of an ellipsoid, which UTM and Gauss-Kruger zones use.
+// It is computed with the series of Kruger to the sixth order in the third flattening, which are accurate
+// to a few nanometers within 3900 km of the central meridian.
+type TransverseMercator struct {
+	centralMeridian, scaleFactor float64
+	falseEasting, falseNorthing  float64
+	e, radius                    float64
+	alpha, beta                  [7]float64
+}
+
+// NewTransverseMercator returns the transverse Mercator projection of the ellipsoid about a central meridian,
+// in degrees, with a scale factor on the central meridian and false easting and northing in meters.
+func NewTransverseMercator(ellipsoid Ellipsoid, centralMeridian, scaleFactor, falseEasting, falseNorthing float64) *TransverseMercator {
+	f := ellipsoid.F
+	n := f / (2 - f)
+	n2, n3 := n*n, n*n*n
+	n4, n5, n6 := n3*n, n3*n2, n3*n3
+	return &TransverseMercator{
+		centralMeridian: centralMeridian,
+		scaleFactor:     scaleFactor,
+		falseEasting:    falseEasting,
+		falseNorthing:   falseNorthing,
+		e:               math.Sqrt(f * (2 - f)),
+		radius:          ellipsoid.A / (1 + n) * (1 + n2/4 + n4/64 + n6/256),
+		alpha: [7]float64{0,
+			n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180 - 127*n5/288 + 7891*n6/37800,
+			13*n2/48 - 3*n3/5 + 557*n4/1440 + 281*n5/630 - 1983433*n6/1935360,
+			61*n3/240 - 103*n4/140 + 15061*n5/26880 + 167603*n6/181440,
+			49561*n4/161280 - 179*n5/168 + 6601661*n6/7257600,
+			34729*n5/80640 - 3418889*n6/1995840,
+			212378941 * n6 / 319334400,
+		},
+		beta: [7]float64{0,
+			n/2 - 2*n2/3 + 37*n3/96 - n4/360 - 81*n5/512 + 96199*n6/604800,
+			n2/48 + n3/15 - 437*n4/1440 + 46*n5/105 - 1118711*n6/3870720,
+			17*n3/480 - 37*n4/840 - 209*n5/4480 + 5569*n6/90720,
+			4397*n4/161280 - 11*n5/504 - 830251*n6/7257600,
+			4583*n5/161280 - 108847*n6/3991680,
+			20648693 * n6 / 638668800,
+		},
+	}
+}
+
+// conformal returns the tangent of the conformal latitude of the latitude of tangent tau.
+func (t *TransverseMercator) conformal(tau float64) float64 {
+	sigma := math.Sinh(t.e * math.Atanh(t.e*tau/math.Sqrt(1+tau*tau)))
+	return tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+}
+
+// Forward projects a longitude and a latitude to an easting and a northing.
+func (t *TransverseMercator) Forward(lon, lat float64) (x, y float64) {
+	lambda := remainder(lon-t.centralMeridian) * math.Pi / 180
+	phi := lat * math.Pi / 180
+
+	tau := t.conformal(math.Tan(phi))
+	cosLambda := math.Cos(lambda)
+	xi0 := math.Atan2(tau, cosLambda)
+	eta0 := math.Asinh(math.Sin(lambda) / math.Sqrt(tau*tau+cosLambda*cosLambda))
+
+	xi, eta := xi0, eta0
+	for j := 1; j <= 6; j++ {
+		k := float64(2 * j)
+		xi += t.alpha[j] * math.Sin(k*xi0) * math.Cosh(k*eta0)
+		eta += t.alpha[j] * math.Cos(k*xi0) * math.Sinh(k*eta0)
+	}
+	return t.scaleFactor*t.radius*eta + t.falseEasting, t.scaleFactor*t.radius*xi + t.falseNorthing
+}
+
+// Inverse returns the longitude and the latitude of an easting and a northing. The latitude is
+// found from its conformal latitude by the iterations of Newton.
+func (t *TransverseMercator) Inverse(x, y float64) (lon, lat float64) {
+	eta := (x - t.falseEasting) / (t.scaleFactor * t.radius)
+	xi := (y - t.falseNorthing) / (t.scaleFactor * t.radius)
+
+	xi0, eta0 := xi, eta
+	for j := 1; j <= 6; j++ {
+		k := float64(2 * j)
+		xi0 -= t.beta[j] * math.Sin(k*xi) * math.Cosh(k*eta)
+		eta0 -= t.beta[j] * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+
+	sinhEta, sinXi, cosXi := math.Sinh(eta0), math.Sin(xi0), math.Cos(xi0)
+	tau0 := sinXi / math.Sqrt(sinhEta*sinhEta+cosXi*cosXi)
+	e2 := t.e * t.e
+	tau := tau0
+	for i := 0; i < 10; i++ {
+		taui := t.conformal(tau)
+		delta := (tau0 - taui) / math.Sqrt(1+taui*taui) * (1 + (1-e2)*tau*tau) / ((1 - e2) * math.Sqrt(1+tau*tau))
+		tau += delta
+		if math.Abs(delta) < 1e-12 {
+			break
+		}
+	}
+	lambda := math.Atan2(sinhEta, cosXi)
+	return remainder(lambda*180/math.Pi + t.centralMeridian), math.Atan(tau) * 180 / math.Pi
+}
+
+// remainder returns the longitude in the range [-180, 180).
+func remainder(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
@@ -0,0 +1,417 @@
+package shapefile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/utils"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+const (
+	dbfVersion    = 0x03
+	dbfHeaderSize = 32
+	dbfFieldSize  = 32
+
+	dbfFieldEnd = 0x0d
+	dbfFileEnd  = 0x1a
+	dbfDeleted  = '*'
+
+	// ldidGBK is the language driver id of the code page 936.
+	ldidGBK = 0x4d
+
+	maxFieldLength   = 254
+	maxFieldDecimals = 15
+	maxNameLength    = 10
+)
+
+// field is a field descriptor of a dBASE table.
+type field struct {
+	name             string
+	typ              byte
+	length, decimals int
+}
+
+// readTable reads the records of a .dbf file. Deleted records are read as nil properties.
+// Character values are decoded from the encoding, given by the language driver of the file if it is empty,
+// or else detected value by value.
+func readTable(r io.Reader, encoding string) ([]geojson.Properties, error) {
+	header := make([]byte, dbfHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, ErrNotShapefile
+	}
+	numRecords := int(binary.LittleEndian.Uint32(header[4:]))
+	headerSize := int(binary.LittleEndian.Uint16(header[8:]))
+	recordSize := int(binary.LittleEndian.Uint16(header[10:]))
+	if encoding == "" && header[29] == ldidGBK {
+		encoding = utils.GBK
+	}
+	if headerSize < dbfHeaderSize+1 || recordSize < 1 {
+		return nil, ErrNotShapefile
+	}
+
+	descriptors := make([]byte, headerSize-dbfHeaderSize)
+	if _, err := io.ReadFull(r, descriptors); err != nil {
+		return nil, ErrNotShapefile
+	}
+	var fields []field
+	size := 1
+	for i := 0; i+dbfFieldSize <= len(descriptors) && descriptors[i] != dbfFieldEnd; i += dbfFieldSize {
+		d := descriptors[i : i+dbfFieldSize]
+		name := d[:11]
+		if end := bytes.IndexByte(name, 0); end >= 0 {
+			name = name[:end]
+		}
+		f := field{name: decodeString(name, encoding), typ: d[11], length: int(d[16]), decimals: int(d[17])}
+		fields = append(fields, f)
+		size += f.length
+	}
+	if size > recordSize {
+		return nil, ErrNotShapefile
+	}
+
+	properties := make([]geojson.Properties, 0, numRecords)
+	record := make([]byte, recordSize)
+	for i := 0; i < numRecords; i++ {
+		if _, err := io.ReadFull(r, record); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, ErrNotShapefile
+		}
+		if record[0] == dbfDeleted {
+			properties = append(properties, nil)
+			continue
+		}
+		p := geojson.Properties{}
+		offset := 1
+		for _, f := range fields {
+			if v := decodeValue(f, record[offset:offset+f.length], encoding); v != nil {
+				p[f.name] = v
+			}
+			offset += f.length
+		}
+		properties = append(properties, p)
+	}
+	return properties, nil
+}
+
+// decodeValue returns the value of a field, or nil if it is empty.
+// Numbers are read as float64, like in GeoJSON, and dates as their YYYYMMDD string.
+func decodeValue(f field, raw []byte, encoding string) interface{} {
+	switch f.typ {
+	case 'N', 'F':
+		s := strings.TrimSpace(string(raw))
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil
+		}
+		return v
+	case 'L':
+		switch strings.TrimSpace(string(raw)) {
+		case "T", "t", "Y", "y":
+			return true
+		case "F", "f", "N", "n":
+			return false
+		}
+		return nil
+	default:
+		raw = bytes.TrimRight(raw, " \x00")
+		if len(raw) == 0 {
+			return nil
+		}
+		return decodeString(raw, encoding)
+	}
+}
+
+// decodeString decodes the bytes of a string from the encoding, detecting it if it is not utils.UTF8 or utils.GBK.
+func decodeString(raw []byte, encoding string) string {
+	if encoding != utils.UTF8 && encoding != utils.GBK {
+		encoding = utils.GetStringEncoding(string(raw))
+	}
+	switch encoding {
+	case utils.UTF8:
+		return string(raw)
+	default:
+		if decoded, err := simplifiedchinese.GBK.NewDecoder().Bytes(raw); err == nil {
+			return string(decoded)
+		}
+		return string(raw)
+	}
+}
+
+// writeTable writes the properties to a .dbf file, in the encoding utils.GBK or else in UTF8.
+// Its fields are the keys of the properties in order. Numbers are written as numeric fields, booleans
+// as logical fields, and other values as character fields. A FID field is written if there is no key,
+// since a table must have a field.
+func writeTable(w io.Writer, properties []geojson.Properties, encoding string) error {
+	var keys []string
+	seen := map[string]bool{}
+	for _, p := range properties {
+		for key := range p {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	rows := make([][]interface{}, len(properties))
+	for i, p := range properties {
+		rows[i] = make([]interface{}, len(keys))
+		for j, key := range keys {
+			rows[i][j] = p[key]
+		}
+	}
+	if len(keys) == 0 {
+		keys = []string{"FID"}
+		for i := range rows {
+			rows[i] = []interface{}{float64(i)}
+		}
+	}
+
+	fields := make([]field, len(keys))
+	values := make([][][]byte, len(rows))
+	for i := range values {
+		values[i] = make([][]byte, len(keys))
+	}
+	names := map[string]bool{}
+	for j, key := range keys {
+		column := make([]interface{}, len(rows))
+		for i := range rows {
+			column[i] = rows[i][j]
+		}
+		f, encoded, err := encodeColumn(column, encoding)
+		if err != nil {
+			return fmt.Errorf("shapefile: field %s: %v", key, err)
+		}
+		f.name = fieldName(key, names, encoding)
+		fields[j] = f
+		for i := range rows {
+			values[i][j] = encoded[i]
+		}
+	}
+
+	recordSize := 1
+	for _, f := range fields {
+		recordSize += f.length
+	}
+	headerSize := dbfHeaderSize + dbfFieldSize*len(fields) + 1
+
+	buf := bytes.NewBuffer(make([]byte, 0, headerSize+recordSize*len(rows)+1))
+	header := make([]byte, dbfHeaderSize)
+	now := time.Now()
+	header[0] = dbfVersion
+	header[1], header[2], header[3] = byte(now.Year()-1900), byte(now.Month()), byte(now.Day())
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(rows)))
+	binary.LittleEndian.PutUint16(header[8:], uint16(headerSize))
+	binary.LittleEndian.PutUint16(header[10:], uint16(recordSize))
+	if encoding == utils.GBK {
+		header[29] = ldidGBK
+	}
+	buf.Write(header)
+	for _, f := range fields {
+		d := make([]byte, dbfFieldSize)
+		copy(d[:11], f.name)
+		d[11] = f.typ
+		d[16], d[17] = byte(f.length), byte(f.decimals)
+		buf.Write(d)
+	}
+	buf.WriteByte(dbfFieldEnd)
+
+	for _, row := range values {
+		buf.WriteByte(' ')
+		for j, v := range row {
+			buf.Write(v)
+			buf.Write(bytes.Repeat([]byte{' '}, fields[j].length-len(v)))
+		}
+	}
+	buf.WriteByte(dbfFileEnd)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// encodeColumn returns the field of the values of a column and their bytes. Nil values are written empty.
+func encodeColumn(column []interface{}, encoding string) (field, [][]byte, error) {
+	numeric, logical := true, true
+	for _, v := range column {
+		if v == nil {
+			continue
+		}
+		_, isNumber := toFloat(v)
+		_, isBool := v.(bool)
+		numeric, logical = numeric && isNumber, logical && isBool
+	}
+
+	encoded := make([][]byte, len(column))
+	f := field{length: 1}
+	switch {
+	case numeric:
+		f.typ = 'N'
+		for _, v := range column {
+			if x, ok := toFloat(v); ok {
+				s := strconv.FormatFloat(x, 'f', -1, 64)
+				if dot := strings.IndexByte(s, '.'); dot >= 0 && len(s)-dot-1 > f.decimals {
+					f.decimals = len(s) - dot - 1
+				}
+			}
+		}
+		if f.decimals > maxFieldDecimals {
+			f.decimals = maxFieldDecimals
+		}
+		for i, v := range column {
+			if x, ok := toFloat(v); ok {
+				encoded[i] = []byte(strconv.FormatFloat(x, 'f', f.decimals, 64))
+			}
+		}
+	case logical:
+		f.typ = 'L'
+		for i, v := range column {
+			switch v {
+			case true:
+				encoded[i] = []byte{'T'}
+			case false:
+				encoded[i] = []byte{'F'}
+			}
+		}
+	default:
+		f.typ = 'C'
+		for i, v := range column {
+			if v == nil {
+				continue
+			}
+			s, ok := v.(string)
+			if !ok {
+				if x, isNumber := toFloat(v); isNumber {
+					s = strconv.FormatFloat(x, 'f', -1, 64)
+				} else {
+					data, err := json.Marshal(v)
+					if err != nil {
+						return f, nil, err
+					}
+					s = string(data)
+				}
+			}
+			b, err := encodeString(s, encoding)
+			if err != nil {
+				return f, nil, err
+			}
+			encoded[i] = b
+		}
+	}
+
+	for i, b := range encoded {
+		if len(b) > maxFieldLength {
+			if f.typ == 'N' {
+				return f, nil, fmt.Errorf("number %s is too long", b)
+			}
+			encoded[i] = truncate(b, maxFieldLength, encoding)
+		}
+		if len(encoded[i]) > f.length {
+			f.length = len(encoded[i])
+		}
+	}
+	if f.typ == 'N' {
+		for i, b := range encoded {
+			// numbers are right aligned.
+			if b != nil {
+				encoded[i] = append(bytes.Repeat([]byte{' '}, f.length-len(b)), b...)
+			}
+		}
+	}
+	return f, encoded, nil
+}
+
+// toFloat returns the value of a number.
+func toFloat(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case float32:
+		return float64(x), true
+	case int:
+		return float64(x), true
+	case int8:
+		return float64(x), true
+	case int16:
+		return float64(x), true
+	case int32:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case uint:
+		return float64(x), true
+	case uint8:
+		return float64(x), true
+	case uint16:
+		return float64(x), true
+	case uint32:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	case json.Number:
+		f, err := x.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// encodeString returns the bytes of the string in the encoding.
+func encodeString(s, encoding string) ([]byte, error) {
+	if encoding == utils.GBK {
+		return utils.UTF82GBK(s)
+	}
+	return []byte(s), nil
+}
+
+// truncate truncates the bytes of a string in the encoding to n bytes at most, without splitting a character.
+func truncate(b []byte, n int, encoding string) []byte {
+	if len(b) <= n {
+		return b
+	}
+	if encoding == utils.GBK {
+		i := 0
+		for i < len(b) {
+			size := 1
+			if b[i] > 0x7f {
+				size = 2
+			}
+			if i+size > n {
+				break
+			}
+			i += size
+		}
+		return b[:i]
+	}
+	for n > 0 && !utf8.RuneStart(b[n]) {
+		n--
+	}
+	return b[:n]
+}
+
+// fieldName returns the name of the field of a key, truncated to the length of dBASE names
+// and made unique among the names already used.
+func fieldName(key string, used map[string]bool, encoding string) string {
+	b, err := encodeString(key, encoding)
+	if err != nil {
+		b = []byte(key)
+	}
+	name := string(truncate(b, maxNameLength, encoding))
+	for i := 1; used[name]; i++ {
+		suffix := strconv.Itoa(i)
+		name = string(truncate(b, maxNameLength-len(suffix), encoding)) + suffix
+	}
+	used[name] = true
+	return name
+}
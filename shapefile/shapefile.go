@@ -0,0 +1,176 @@
+/*
+Package shapefile is a library for reading and writing ESRI Shapefiles as GeoJSON feature collections.
+
+The geometries are read from the .shp file and the attributes from the .dbf file.
+Attributes in GBK are decoded according to the .cpg file, the language driver of the .dbf file,
+or else detected value by value.
+*/
+package shapefile
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+	"github.com/spatial-go/geoos/utils"
+)
+
+// ErrNotShapefile is returned when a file is not a valid shapefile.
+var ErrNotShapefile = errors.New("shapefile: invalid shapefile")
+
+// Shapefile a shapefile read with its geometries and attributes
+type Shapefile struct {
+	// Prj is the well-known text of the coordinate reference system, read from the .prj file.
+	Prj string
+
+	geometries []space.Geometry
+	properties []geojson.Properties
+}
+
+// Options an options of Shapefile
+type Options struct {
+	// Encoding is the encoding of the attributes, utils.UTF8 or utils.GBK.
+	// When it is empty, the attributes are read in the encoding of the .cpg file if there is one,
+	// and written in UTF8.
+	Encoding string
+	// Prj is the well-known text of the coordinate reference system written to the .prj file.
+	Prj string
+}
+
+// Read reads the shapefile of filePath, with or without its .shp extension.
+func Read(filePath string, options Options) (sf *Shapefile, err error) {
+	base := basePath(filePath)
+	shp, err := os.Open(siblingPath(base, ".shp"))
+	if err != nil {
+		return
+	}
+	defer shp.Close()
+
+	sf = &Shapefile{}
+	if sf.geometries, err = readShapes(shp); err != nil {
+		return nil, err
+	}
+
+	if options.Encoding == "" {
+		if cpg, cpgErr := ioutil.ReadFile(siblingPath(base, ".cpg")); cpgErr == nil {
+			options.Encoding = codePageEncoding(string(cpg))
+		}
+	}
+	if dbf, dbfErr := os.Open(siblingPath(base, ".dbf")); dbfErr == nil {
+		defer dbf.Close()
+		if sf.properties, err = readTable(dbf, options.Encoding); err != nil {
+			return nil, err
+		}
+	}
+
+	if prj, prjErr := ioutil.ReadFile(siblingPath(base, ".prj")); prjErr == nil {
+		sf.Prj = strings.TrimSpace(string(prj))
+	}
+	return
+}
+
+// ToGeoJSON export geojson
+// Features of the records deleted in the .dbf file are left out.
+func (sf *Shapefile) ToGeoJSON() (features *geojson.FeatureCollection) {
+	features = geojson.NewFeatureCollection()
+	for i, geom := range sf.geometries {
+		properties := geojson.Properties{}
+		if sf.properties != nil {
+			if i >= len(sf.properties) || sf.properties[i] == nil {
+				continue
+			}
+			properties = sf.properties[i].Clone()
+		}
+
+		feature := geojson.NewFeature(geojson.Geometry{})
+		if geom != nil {
+			feature.Geometry = *geojson.NewGeometry(geom)
+		}
+		feature.Properties = properties
+		features.Features = append(features.Features, feature)
+	}
+	return
+}
+
+// Write writes the feature collection as the shapefile of filePath, with or without its .shp extension.
+// The geometries of the features must be all puntal, all lineal or all polygonal, or null.
+func Write(filePath string, fc *geojson.FeatureCollection, options Options) error {
+	geoms := make([]space.Geometry, len(fc.Features))
+	properties := make([]geojson.Properties, len(fc.Features))
+	for i, f := range fc.Features {
+		if f.Geometry.Coordinates != nil || len(f.Geometry.Geometries) > 0 {
+			geoms[i] = f.Geometry.Geometry()
+		}
+		properties[i] = f.Properties
+	}
+
+	base := basePath(filePath)
+	shp, err := os.Create(base + ".shp")
+	if err != nil {
+		return err
+	}
+	defer shp.Close()
+	shx, err := os.Create(base + ".shx")
+	if err != nil {
+		return err
+	}
+	defer shx.Close()
+	if err := writeShapes(shp, shx, geoms); err != nil {
+		return err
+	}
+
+	dbf, err := os.Create(base + ".dbf")
+	if err != nil {
+		return err
+	}
+	defer dbf.Close()
+	if err := writeTable(dbf, properties, options.Encoding); err != nil {
+		return err
+	}
+
+	cpg := "UTF-8"
+	if options.Encoding == utils.GBK {
+		cpg = utils.GBK
+	}
+	if err := ioutil.WriteFile(base+".cpg", []byte(cpg), 0644); err != nil {
+		return err
+	}
+	if options.Prj != "" {
+		return ioutil.WriteFile(base+".prj", []byte(options.Prj), 0644)
+	}
+	return nil
+}
+
+// basePath returns the path of the shapefile without its .shp extension.
+func basePath(filePath string) string {
+	if strings.EqualFold(filepath.Ext(filePath), ".shp") {
+		return filePath[:len(filePath)-len(".shp")]
+	}
+	return filePath
+}
+
+// siblingPath returns the path of the file of the shapefile with the extension,
+// in upper case if only that one exists.
+func siblingPath(base, ext string) string {
+	if _, err := os.Stat(base + ext); err != nil {
+		if _, err := os.Stat(base + strings.ToUpper(ext)); err == nil {
+			return base + strings.ToUpper(ext)
+		}
+	}
+	return base + ext
+}
+
+// codePageEncoding returns the encoding of the code page of a .cpg file, or "" if it is not supported.
+func codePageEncoding(cpg string) string {
+	switch strings.ToUpper(strings.TrimSpace(cpg)) {
+	case "UTF-8", "UTF8", "65001":
+		return utils.UTF8
+	case "GBK", "936", "CP936", "ANSI 936", "GB2312", "GB18030":
+		return utils.GBK
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+package shapefile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+	"github.com/spatial-go/geoos/utils"
+)
+
+func newFeature(geom space.Geometry, properties geojson.Properties) *geojson.Feature {
+	f := geojson.NewFeature(geojson.Geometry{})
+	if geom != nil {
+		f.Geometry = *geojson.NewGeometry(geom)
+	}
+	f.Properties = properties
+	return f
+}
+
+func TestWriteRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		geoms []space.Geometry
+		want  []space.Geometry
+	}{
+		{name: "points", geoms: []space.Geometry{space.Point{1, 2}, nil, space.Point{3, 4}}},
+		{name: "points z", geoms: []space.Geometry{space.Point{1, 2, 3}, space.Point{3, 4, 5}}},
+		{name: "points and multi points", geoms: []space.Geometry{space.Point{1, 2}, space.MultiPoint{{3, 4}, {5, 6}}},
+			want: []space.Geometry{space.MultiPoint{{1, 2}}, space.MultiPoint{{3, 4}, {5, 6}}}},
+		{name: "lines m", geoms: []space.Geometry{
+			space.LineString{space.NewPointM(0, 0, 1), space.NewPointM(1, 1, 2)},
+			space.MultiLineString{{{0, 0}, {1, 0}}, {{2, 2}, {3, 3}}},
+		}},
+		{name: "polygons", geoms: []space.Geometry{
+			space.Polygon{{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}}, {{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}}},
+			space.MultiPolygon{
+				{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}},
+				{{{5, 5}, {5, 9}, {9, 9}, {9, 5}, {5, 5}}, {{6, 6}, {7, 6}, {7, 7}, {6, 7}, {6, 6}}},
+			},
+		}},
+		{name: "polygon oriented on write", geoms: []space.Geometry{
+			space.Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		}, want: []space.Geometry{
+			space.Polygon{{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := geojson.NewFeatureCollection()
+			for i, g := range tt.geoms {
+				fc.Append(newFeature(g, geojson.Properties{"id": float64(i)}))
+			}
+			path := filepath.Join(t.TempDir(), "test.shp")
+			if err := Write(path, fc, Options{}); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			sf, err := Read(path, Options{})
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			got := sf.ToGeoJSON()
+			want := tt.want
+			if want == nil {
+				want = tt.geoms
+			}
+			if len(got.Features) != len(want) {
+				t.Fatalf("Read() got %d features, want %d", len(got.Features), len(want))
+			}
+			for i, f := range got.Features {
+				if want[i] == nil {
+					if f.Geometry.Coordinates != nil {
+						t.Errorf("feature %d got = %v, want null", i, f.Geometry.Coordinates)
+					}
+				} else if !f.Geometry.Coordinates.Equal(want[i]) {
+					t.Errorf("feature %d got = %v, want %v", i, f.Geometry.Coordinates, want[i])
+				}
+				if f.Properties["id"] != float64(i) {
+					t.Errorf("feature %d properties got = %v", i, f.Properties)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteRead_Attributes(t *testing.T) {
+	fc := geojson.NewFeatureCollection()
+	fc.Append(newFeature(space.Point{1, 2}, geojson.Properties{
+		"name": "北京", "population": 21.54, "capital": true, "a_very_long_name": "x", "a_very_long_value": "y",
+	}))
+	fc.Append(newFeature(space.Point{3, 4}, geojson.Properties{"name": "上海", "population": 24}))
+
+	for _, encoding := range []string{"", utils.GBK} {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "cities")
+		if err := Write(path, fc, Options{Encoding: encoding, Prj: `GEOGCS["WGS 84"]`}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		// without .cpg, the encoding is detected.
+		if encoding == utils.GBK {
+			_ = os.Remove(path + ".cpg")
+		}
+		sf, err := Read(path+".shp", Options{})
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		got := sf.ToGeoJSON().Features
+		if sf.Prj != `GEOGCS["WGS 84"]` {
+			t.Errorf("Prj got = %v", sf.Prj)
+		}
+		want := geojson.Properties{"name": "北京", "population": 21.54, "capital": true, "a_very_lon": "x", "a_very_lo1": "y"}
+		if len(got) != 2 || len(got[0].Properties) != len(want) {
+			t.Fatalf("%s properties got = %v", encoding, got)
+		}
+		for key, v := range want {
+			if got[0].Properties[key] != v {
+				t.Errorf("%s property %s got = %v, want %v", encoding, key, got[0].Properties[key], v)
+			}
+		}
+		if got[1].Properties["name"] != "上海" || got[1].Properties["population"] != 24.0 || got[1].Properties["capital"] != nil {
+			t.Errorf("%s properties got = %v", encoding, got[1].Properties)
+		}
+	}
+}
+
+func TestRead_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Read(filepath.Join(dir, "missing.shp"), Options{}); err == nil {
+		t.Errorf("Read() of a missing file got no error")
+	}
+	path := filepath.Join(dir, "invalid.shp")
+	if err := ioutil.WriteFile(path, []byte("not a shapefile"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path, Options{}); err != ErrNotShapefile {
+		t.Errorf("Read() of an invalid file error = %v", err)
+	}
+
+	fc := geojson.NewFeatureCollection()
+	fc.Append(newFeature(space.Point{1, 2}, nil))
+	fc.Append(newFeature(space.LineString{{1, 2}, {3, 4}}, nil))
+	if err := Write(filepath.Join(dir, "mixed"), fc, Options{}); err == nil {
+		t.Errorf("Write() of points and lines got no error")
+	}
+}
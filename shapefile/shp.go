@@ -0,0 +1,583 @@
+package shapefile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+	"github.com/spatial-go/geoos/algorithm/topology"
+	"github.com/spatial-go/geoos/space"
+)
+
+// Shape types of the records of a .shp file. The types with a Z add 10 to the base type, and with a M 20.
+const (
+	shapeNull       = 0
+	shapePoint      = 1
+	shapePolyLine   = 3
+	shapePolygon    = 5
+	shapeMultiPoint = 8
+
+	shapeZ = 10
+	shapeM = 20
+)
+
+const (
+	fileCode    = 9994
+	fileVersion = 1000
+	headerSize  = 100
+
+	// noData is written for missing measures. Any measure less than noDataLimit has no data.
+	noData      = -1e39
+	noDataLimit = -1e38
+)
+
+// splitShapeType returns the base type of the shape type, and whether it has Z and M values.
+// The M values of the types with a Z are optional.
+func splitShapeType(typ int) (base int, hasZ, hasM bool) {
+	switch {
+	case typ > shapeM:
+		return typ - shapeM, false, true
+	case typ > shapeZ:
+		return typ - shapeZ, true, true
+	}
+	return typ, false, false
+}
+
+// readShapes reads the geometries of the records of a .shp file. Null shapes are read as nil.
+func readShapes(r io.Reader) ([]space.Geometry, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, ErrNotShapefile
+	}
+	if binary.BigEndian.Uint32(header) != fileCode {
+		return nil, ErrNotShapefile
+	}
+	length := int64(binary.BigEndian.Uint32(header[24:])) * 2
+
+	var geoms []space.Geometry
+	recordHeader := make([]byte, 8)
+	for read := int64(headerSize); read < length; {
+		if _, err := io.ReadFull(r, recordHeader); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		size := int64(binary.BigEndian.Uint32(recordHeader[4:])) * 2
+		if size > length-read-8 {
+			return nil, ErrNotShapefile
+		}
+		content := make([]byte, size)
+		if _, err := io.ReadFull(r, content); err != nil {
+			return nil, err
+		}
+		geom, err := decodeShape(content)
+		if err != nil {
+			return nil, fmt.Errorf("shapefile: record %d: %v", len(geoms)+1, err)
+		}
+		geoms = append(geoms, geom)
+		read += 8 + size
+	}
+	return geoms, nil
+}
+
+// shapeReader reads the little endian values of the content of a record.
+type shapeReader struct {
+	data []byte
+	err  error
+}
+
+func (s *shapeReader) next(n int) []byte {
+	if s.err != nil || n > len(s.data) {
+		s.err = ErrNotShapefile
+		return make([]byte, n)
+	}
+	b := s.data[:n]
+	s.data = s.data[n:]
+	return b
+}
+
+func (s *shapeReader) int() int {
+	return int(int32(binary.LittleEndian.Uint32(s.next(4))))
+}
+
+func (s *shapeReader) float() float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(s.next(8)))
+}
+
+// count reads a number of values of size bytes, checking there are enough bytes left for them.
+func (s *shapeReader) count(size int) int {
+	n := s.int()
+	if n < 0 || n*size > len(s.data) {
+		s.err = ErrNotShapefile
+		return 0
+	}
+	return n
+}
+
+// values reads a range and n values, or returns nil if there are not enough bytes left for them.
+func (s *shapeReader) values(n int) []float64 {
+	if s.err != nil || len(s.data) < 16+8*n {
+		return nil
+	}
+	s.next(16)
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = s.float()
+	}
+	return values
+}
+
+// decodeShape decodes the content of a record.
+func decodeShape(content []byte) (space.Geometry, error) {
+	s := &shapeReader{data: content}
+	base, hasZ, hasM := splitShapeType(s.int())
+	var geom space.Geometry
+	switch base {
+	case shapeNull:
+		return nil, s.err
+	case shapePoint:
+		xy := [][]float64{{s.float(), s.float()}}
+		var z, m []float64
+		if hasZ {
+			z = []float64{s.float()}
+		}
+		if hasM && len(s.data) >= 8 {
+			m = []float64{s.float()}
+		}
+		geom = space.Point(coordinates(xy, z, m)[0])
+	case shapeMultiPoint:
+		s.next(32)
+		xy := s.points(s.count(16))
+		z, m := s.measures(len(xy), hasZ, hasM)
+		mp := space.MultiPoint{}
+		for _, p := range coordinates(xy, z, m) {
+			mp = append(mp, p)
+		}
+		geom = mp
+	case shapePolyLine, shapePolygon:
+		s.next(32)
+		numParts := s.count(4)
+		numPoints := s.int()
+		parts := make([]int, numParts)
+		for i := range parts {
+			parts[i] = s.int()
+		}
+		xy := s.points(numPoints)
+		z, m := s.measures(len(xy), hasZ, hasM)
+		if s.err != nil {
+			return nil, s.err
+		}
+		lines, err := splitParts(coordinates(xy, z, m), parts)
+		if err != nil {
+			return nil, err
+		}
+		geom = assemble(base, lines)
+	default:
+		return nil, fmt.Errorf("unsupported shape type %d", base)
+	}
+	return geom, s.err
+}
+
+// points reads n points of X and Y.
+func (s *shapeReader) points(n int) [][]float64 {
+	if s.err != nil || n < 0 || n*16 > len(s.data) {
+		s.err = ErrNotShapefile
+		return nil
+	}
+	xy := make([][]float64, n)
+	for i := range xy {
+		xy[i] = []float64{s.float(), s.float()}
+	}
+	return xy
+}
+
+// measures reads the Z and M values of n points. Either are nil if they are not present.
+func (s *shapeReader) measures(n int, hasZ, hasM bool) (z, m []float64) {
+	if hasZ {
+		if z = s.values(n); z == nil {
+			s.err = ErrNotShapefile
+		}
+	}
+	if hasM {
+		m = s.values(n)
+	}
+	return
+}
+
+// coordinates returns the coordinates of the points, with their Z and M values if any.
+// M values are dropped if none of them has data, see space.Layout for the coordinates of points with a M.
+func coordinates(xy [][]float64, z, m []float64) [][]float64 {
+	hasM := false
+	for _, v := range m {
+		hasM = hasM || v >= noDataLimit
+	}
+	for i, p := range xy {
+		switch {
+		case z != nil && hasM:
+			xy[i] = append(p, z[i], measureOf(m[i]))
+		case z != nil:
+			xy[i] = append(p, z[i])
+		case hasM:
+			xy[i] = append(p, math.NaN(), measureOf(m[i]))
+		}
+	}
+	return xy
+}
+
+// measureOf returns the measure, or NaN if it has no data.
+func measureOf(m float64) float64 {
+	if m < noDataLimit {
+		return math.NaN()
+	}
+	return m
+}
+
+// splitParts splits the points into the parts starting at the indexes.
+func splitParts(points [][]float64, parts []int) ([][][]float64, error) {
+	lines := make([][][]float64, len(parts))
+	for i, start := range parts {
+		end := len(points)
+		if i+1 < len(parts) {
+			end = parts[i+1]
+		}
+		if start < 0 || start > end || end > len(points) {
+			return nil, ErrNotShapefile
+		}
+		lines[i] = points[start:end]
+	}
+	return lines, nil
+}
+
+// assemble returns the geometry of the parts of a polyline or polygon record.
+func assemble(base int, parts [][][]float64) space.Geometry {
+	if base == shapePolyLine {
+		if len(parts) == 1 {
+			return space.LineString(parts[0])
+		}
+		mls := make(space.MultiLineString, len(parts))
+		for i, part := range parts {
+			mls[i] = part
+		}
+		return mls
+	}
+
+	polygons := polygonize(parts)
+	if len(polygons) == 1 {
+		return polygons[0]
+	}
+	return polygons
+}
+
+// polygonize groups the rings of a polygon record into polygons. Clockwise rings are shells,
+// and counter-clockwise rings are the holes of the smallest shell containing them.
+// Holes contained by no shell are read as shells.
+func polygonize(rings [][][]float64) space.MultiPolygon {
+	var shells, holes []matrix.LineMatrix
+	for _, ring := range rings {
+		if measure.AreaDirection(ring) >= 0 {
+			shells = append(shells, ring)
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+
+	polygons := make(space.MultiPolygon, len(shells))
+	locators := make([]*topology.AreaLocator, len(shells))
+	for i, shell := range shells {
+		polygons[i] = space.Polygon{shell}
+		locators[i] = topology.NewAreaLocator([]matrix.PolygonMatrix{{shell}})
+	}
+	for _, hole := range holes {
+		owner := -1
+		for i, shell := range shells {
+			if (owner < 0 || measure.Area(shell) < measure.Area(shells[owner])) && containsRing(locators[i], hole) {
+				owner = i
+			}
+		}
+		if owner < 0 {
+			polygons = append(polygons, space.Polygon{hole})
+			continue
+		}
+		polygons[owner] = append(polygons[owner], hole)
+	}
+	return polygons
+}
+
+// containsRing returns true if a vertex of the ring lies in the interior of the located shell.
+// Holes may touch their shell, so that some of their vertices lie on it.
+func containsRing(locator *topology.AreaLocator, ring matrix.LineMatrix) bool {
+	for _, p := range ring {
+		if locator.Contains(topology.Vertex{p[0], p[1]}) {
+			return true
+		}
+	}
+	return false
+}
+
+// shapeTypeOf returns the shape type of the geometries, which must be all puntal, all lineal or all polygonal.
+// Points are written as multi points if some of the geometries are multi points.
+func shapeTypeOf(geoms []space.Geometry) (typ int, hasM bool, err error) {
+	hasZ := false
+	for _, geom := range geoms {
+		if geom == nil {
+			continue
+		}
+		base := shapeNull
+		switch geom.(type) {
+		case space.Point:
+			base = shapePoint
+			if typ == shapeMultiPoint {
+				base = shapeMultiPoint
+			}
+		case space.MultiPoint:
+			base = shapeMultiPoint
+			if typ == shapePoint {
+				typ = shapeMultiPoint
+			}
+		case space.LineString, space.MultiLineString:
+			base = shapePolyLine
+		case space.Polygon, space.MultiPolygon, space.Ring, space.Bound:
+			base = shapePolygon
+		default:
+			return 0, false, fmt.Errorf("shapefile: unsupported geometry type %s", geom.GeoJSONType())
+		}
+		if typ != shapeNull && typ != base {
+			return 0, false, fmt.Errorf("shapefile: geometries of shape types %d and %d cannot be written together", typ, base)
+		}
+		typ = base
+		layout := space.CoordLayout(geom)
+		hasZ, hasM = hasZ || layout.HasZ(), hasM || layout.HasM()
+	}
+
+	switch {
+	case typ == shapeNull:
+	case hasZ:
+		typ += shapeZ
+	case hasM:
+		typ += shapeM
+	}
+	return typ, hasM, nil
+}
+
+// extent is the bounding box of the X, Y, Z and M values of points.
+type extent struct {
+	min, max [4]float64
+	hasM     bool
+}
+
+func newExtent() *extent {
+	e := &extent{}
+	for i := range e.min {
+		e.min[i], e.max[i] = math.Inf(1), math.Inf(-1)
+	}
+	return e
+}
+
+// add extends the extent to the values of the point, ignoring missing Z and M.
+func (e *extent) add(p []float64) {
+	values := [4]float64{p[0], p[1], space.Point(p).Z(), space.Point(p).M()}
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		e.min[i], e.max[i] = math.Min(e.min[i], v), math.Max(e.max[i], v)
+	}
+}
+
+// bound returns the range of the values of index i, or 0, 0 if there is none.
+func (e *extent) bound(i int) (float64, float64) {
+	if e.min[i] > e.max[i] {
+		return 0, 0
+	}
+	return e.min[i], e.max[i]
+}
+
+// union extends the extent to other.
+func (e *extent) union(other *extent) {
+	for i := range e.min {
+		e.min[i], e.max[i] = math.Min(e.min[i], other.min[i]), math.Max(e.max[i], other.max[i])
+	}
+}
+
+// writeShapes writes the geometries to a .shp file and its .shx index. Nil geometries are written as null shapes.
+func writeShapes(shp, shx io.Writer, geoms []space.Geometry) error {
+	typ, hasM, err := shapeTypeOf(geoms)
+	if err != nil {
+		return err
+	}
+
+	records := bytes.NewBuffer(nil)
+	index := bytes.NewBuffer(nil)
+	total := newExtent()
+	for i, geom := range geoms {
+		content, e := encodeShape(geom, typ, hasM)
+		total.union(e)
+
+		offset := headerSize + records.Len()
+		_ = binary.Write(records, binary.BigEndian, [2]int32{int32(i + 1), int32(len(content) / 2)})
+		records.Write(content)
+		_ = binary.Write(index, binary.BigEndian, [2]int32{int32(offset / 2), int32(len(content) / 2)})
+	}
+
+	if _, err := shp.Write(fileHeader(typ, headerSize+records.Len(), total)); err != nil {
+		return err
+	}
+	if _, err := shp.Write(records.Bytes()); err != nil {
+		return err
+	}
+	if _, err := shx.Write(fileHeader(typ, headerSize+index.Len(), total)); err != nil {
+		return err
+	}
+	_, err = shx.Write(index.Bytes())
+	return err
+}
+
+// fileHeader returns the header of a .shp or .shx file of size bytes.
+func fileHeader(typ, size int, e *extent) []byte {
+	header := make([]byte, headerSize)
+	binary.BigEndian.PutUint32(header, fileCode)
+	binary.BigEndian.PutUint32(header[24:], uint32(size/2))
+	binary.LittleEndian.PutUint32(header[28:], fileVersion)
+	binary.LittleEndian.PutUint32(header[32:], uint32(typ))
+	minX, maxX := e.bound(0)
+	minY, maxY := e.bound(1)
+	minZ, maxZ := e.bound(2)
+	minM, maxM := e.bound(3)
+	for i, v := range []float64{minX, minY, maxX, maxY, minZ, maxZ, minM, maxM} {
+		binary.LittleEndian.PutUint64(header[36+8*i:], math.Float64bits(v))
+	}
+	return header
+}
+
+// encodeShape returns the content of the record of the geometry, and the extent of its points.
+func encodeShape(geom space.Geometry, typ int, hasM bool) ([]byte, *extent) {
+	e := newExtent()
+	buf := bytes.NewBuffer(nil)
+	if geom == nil || geom.IsEmpty() {
+		_ = binary.Write(buf, binary.LittleEndian, int32(shapeNull))
+		return buf.Bytes(), e
+	}
+
+	base, hasZ, _ := splitShapeType(typ)
+	var parts [][][]float64
+	switch g := geom.(type) {
+	case space.Point:
+		parts = [][][]float64{{g}}
+	case space.MultiPoint:
+		parts = [][][]float64{{}}
+		for _, p := range g {
+			parts[0] = append(parts[0], p)
+		}
+	case space.LineString:
+		parts = [][][]float64{g}
+	case space.MultiLineString:
+		for _, ls := range g {
+			parts = append(parts, ls)
+		}
+	case space.Ring:
+		parts = orientRings(space.Polygon{g})
+	case space.Bound:
+		parts = orientRings(g.ToPolygon())
+	case space.Polygon:
+		parts = orientRings(g)
+	case space.MultiPolygon:
+		for _, p := range g {
+			parts = append(parts, orientRings(p)...)
+		}
+	}
+	var points [][]float64
+	for _, part := range parts {
+		for _, p := range part {
+			e.add(p)
+			points = append(points, p)
+		}
+	}
+
+	_ = binary.Write(buf, binary.LittleEndian, int32(typ))
+	if base == shapePoint {
+		p := space.Point(points[0])
+		_ = binary.Write(buf, binary.LittleEndian, [2]float64{p[0], p[1]})
+		if hasZ {
+			_ = binary.Write(buf, binary.LittleEndian, zOf(p))
+		}
+		if hasM {
+			_ = binary.Write(buf, binary.LittleEndian, mOf(p))
+		}
+		return buf.Bytes(), e
+	}
+
+	minX, maxX := e.bound(0)
+	minY, maxY := e.bound(1)
+	_ = binary.Write(buf, binary.LittleEndian, [4]float64{minX, minY, maxX, maxY})
+	if base == shapeMultiPoint {
+		_ = binary.Write(buf, binary.LittleEndian, int32(len(points)))
+	} else {
+		_ = binary.Write(buf, binary.LittleEndian, [2]int32{int32(len(parts)), int32(len(points))})
+		start := 0
+		for _, part := range parts {
+			_ = binary.Write(buf, binary.LittleEndian, int32(start))
+			start += len(part)
+		}
+	}
+	for _, p := range points {
+		_ = binary.Write(buf, binary.LittleEndian, [2]float64{p[0], p[1]})
+	}
+	if hasZ {
+		minZ, maxZ := e.bound(2)
+		_ = binary.Write(buf, binary.LittleEndian, [2]float64{minZ, maxZ})
+		for _, p := range points {
+			_ = binary.Write(buf, binary.LittleEndian, zOf(p))
+		}
+	}
+	if hasM {
+		minM, maxM := e.bound(3)
+		_ = binary.Write(buf, binary.LittleEndian, [2]float64{minM, maxM})
+		for _, p := range points {
+			_ = binary.Write(buf, binary.LittleEndian, mOf(p))
+		}
+	}
+	return buf.Bytes(), e
+}
+
+// zOf returns the Z of the point, or 0 if it has none.
+func zOf(p space.Point) float64 {
+	if z := p.Z(); !math.IsNaN(z) {
+		return z
+	}
+	return 0
+}
+
+// mOf returns the M of the point, or noData if it has none.
+func mOf(p space.Point) float64 {
+	if m := p.M(); !math.IsNaN(m) {
+		return m
+	}
+	return noData
+}
+
+// orientRings returns the closed rings of the polygon, with a clockwise shell and counter-clockwise holes.
+func orientRings(polygon space.Polygon) [][][]float64 {
+	rings := make([][][]float64, 0, len(polygon))
+	for i, ring := range polygon {
+		if len(ring) == 0 {
+			continue
+		}
+		r := make([][]float64, len(ring), len(ring)+1)
+		copy(r, ring)
+		if !matrix.Equal(r[0], r[len(r)-1]) {
+			r = append(r, r[0])
+		}
+		if (measure.AreaDirection(r) > 0) != (i == 0) {
+			for j, k := 0, len(r)-1; j < k; j, k = j+1, k-1 {
+				r[j], r[k] = r[k], r[j]
+			}
+		}
+		rings = append(rings, r)
+	}
+	return rings
+}
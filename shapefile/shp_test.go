@@ -0,0 +1,115 @@
+package shapefile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/space"
+)
+
+// record returns the content of a record of the values, written as int32 or float64.
+func record(values ...interface{}) []byte {
+	buf := bytes.NewBuffer(nil)
+	for _, v := range values {
+		switch x := v.(type) {
+		case int:
+			_ = binary.Write(buf, binary.LittleEndian, int32(x))
+		case float64:
+			_ = binary.Write(buf, binary.LittleEndian, x)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeShape(t *testing.T) {
+	box := []interface{}{0.0, 0.0, 10.0, 10.0}
+	tests := []struct {
+		name    string
+		content []byte
+		want    space.Geometry
+	}{
+		{name: "null", content: record(0), want: nil},
+		{name: "point", content: record(1, 1.0, 2.0), want: space.Point{1, 2}},
+		{name: "point z without m", content: record(11, 1.0, 2.0, 3.0), want: space.Point{1, 2, 3}},
+		{name: "point z", content: record(11, 1.0, 2.0, 3.0, 4.0), want: space.Point{1, 2, 3, 4}},
+		{name: "point m no data", content: record(21, 1.0, 2.0, -1e39), want: space.Point{1, 2}},
+		{name: "multi point", content: record(append(append([]interface{}{8}, box...), 2, 1.0, 2.0, 3.0, 4.0)...),
+			want: space.MultiPoint{{1, 2}, {3, 4}}},
+		{name: "polyline", content: record(append(append([]interface{}{3}, box...), 2, 4, 0, 2,
+			0.0, 0.0, 1.0, 1.0, 5.0, 5.0, 6.0, 6.0)...),
+			want: space.MultiLineString{{{0, 0}, {1, 1}}, {{5, 5}, {6, 6}}}},
+		{name: "polyline m", content: record(append(append([]interface{}{23}, box...), 1, 2, 0,
+			0.0, 0.0, 1.0, 1.0, 1.0, 2.0, 1.0, 2.0)...),
+			want: space.LineString{space.NewPointM(0, 0, 1), space.NewPointM(1, 1, 2)}},
+		{name: "polygon with hole and island", content: record(append(append([]interface{}{5}, box...), 3, 15, 0, 5, 10,
+			0.0, 0.0, 0.0, 10.0, 10.0, 10.0, 10.0, 0.0, 0.0, 0.0,
+			2.0, 2.0, 4.0, 2.0, 4.0, 4.0, 2.0, 4.0, 2.0, 2.0,
+			20.0, 20.0, 20.0, 30.0, 30.0, 30.0, 30.0, 20.0, 20.0, 20.0)...),
+			want: space.MultiPolygon{
+				{{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}}, {{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}}},
+				{{{20, 20}, {20, 30}, {30, 30}, {30, 20}, {20, 20}}},
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeShape(tt.content)
+			if err != nil {
+				t.Fatalf("decodeShape() error = %v", err)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("decodeShape() got = %v, want nil", got)
+				}
+				return
+			}
+			if !got.Equal(tt.want) || space.CoordLayout(got) != space.CoordLayout(tt.want) {
+				t.Errorf("decodeShape() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, content := range [][]byte{
+		record(1, 1.0),
+		record(append(append([]interface{}{8}, box...), 1000, 1.0, 2.0)...),
+		record(append(append([]interface{}{3}, box...), 1, 2, 5, 0.0, 0.0, 1.0, 1.0)...),
+		record(31),
+	} {
+		if _, err := decodeShape(content); err == nil {
+			t.Errorf("decodeShape(%v) got no error", content)
+		}
+	}
+}
+
+func TestWriteShapes(t *testing.T) {
+	shp, shx := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	geoms := []space.Geometry{space.Point{1, 2, 3}, space.Point{-1, 5, 7}}
+	if err := writeShapes(shp, shx, geoms); err != nil {
+		t.Fatalf("writeShapes() error = %v", err)
+	}
+
+	data := shp.Bytes()
+	if binary.BigEndian.Uint32(data) != fileCode || int(binary.BigEndian.Uint32(data[24:]))*2 != len(data) ||
+		binary.LittleEndian.Uint32(data[28:]) != fileVersion || binary.LittleEndian.Uint32(data[32:]) != shapePoint+shapeZ {
+		t.Errorf("header got = %v", data[:headerSize])
+	}
+	var bbox [6]float64
+	_ = binary.Read(bytes.NewReader(data[36:]), binary.LittleEndian, &bbox)
+	if bbox != [6]float64{-1, 2, 1, 5, 3, 7} {
+		t.Errorf("bbox got = %v", bbox)
+	}
+
+	index := shx.Bytes()
+	if len(index) != headerSize+16 || binary.BigEndian.Uint32(index[108:]) != (headerSize+8+28)/2 {
+		t.Errorf("index got = %v", index[headerSize:])
+	}
+
+	got, err := readShapes(bytes.NewReader(data))
+	if err != nil || len(got) != 2 || !got[1].Equal(space.Point{-1, 5, 7}) {
+		t.Errorf("readShapes() got = %v, %v", got, err)
+	}
+	if !math.IsNaN(got[0].(space.Point).M()) {
+		t.Errorf("readShapes() got a M for %v", got[0])
+	}
+}
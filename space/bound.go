@@ -3,6 +3,8 @@ package space
 import (
 	"errors"
 	"math"
+
+	"github.com/spatial-go/geoos/algorithm/measure"
 )
 
 var emptyBound = Bound{Min: Point{1, 1}, Max: Point{-1, -1}}
@@ -182,14 +184,14 @@ func (b Bound) Distance(g Geometry) (float64, error) {
 }
 
 // SpheroidDistance returns  spheroid distance Between the two Geometry.
-func (b Bound) SpheroidDistance(g Geometry) (float64, error) {
+func (b Bound) SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error) {
 	if b.IsEmpty() && g.IsEmpty() {
 		return 0, nil
 	}
 	if b.IsEmpty() != g.IsEmpty() {
 		return 0, errors.New("Geometry is nil")
 	}
-	return b.ToRing().SpheroidDistance(g)
+	return b.ToRing().SpheroidDistance(g, f...)
 }
 
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
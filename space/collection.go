@@ -2,6 +2,9 @@ package space
 
 import (
 	"errors"
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/measure"
 )
 
 // A Collection is a collection of geometries that is also a Geometry.
@@ -38,7 +41,7 @@ func (c Collection) Bound() Bound {
 	start := -1
 
 	for i, g := range c {
-		if g != nil {
+		if g != nil && !g.Bound().IsEmpty() {
 			start = i
 			b = g.Bound()
 			break
@@ -127,16 +130,16 @@ func (c Collection) IsEmpty() bool {
 }
 
 // SpheroidDistance returns  spheroid distance Between the two Geometry.
-func (c Collection) SpheroidDistance(g Geometry) (float64, error) {
+func (c Collection) SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error) {
 	if c.IsEmpty() && g.IsEmpty() {
 		return 0, nil
 	}
 	if c.IsEmpty() != g.IsEmpty() {
 		return 0, errors.New("Geometry is nil")
 	}
-	var dist float64
+	dist := math.MaxFloat64
 	for _, v := range c {
-		if distP, _ := v.SpheroidDistance(g); dist > distP {
+		if distP, err := v.SpheroidDistance(g, f...); err == nil && distP < dist {
 			dist = distP
 		}
 	}
@@ -151,9 +154,9 @@ func (c Collection) Distance(g Geometry) (float64, error) {
 	if c.IsEmpty() != g.IsEmpty() {
 		return 0, errors.New("Geometry is nil")
 	}
-	var dist float64
+	dist := math.MaxFloat64
 	for _, v := range c {
-		if distP, _ := v.Distance(g); dist > distP {
+		if distP, err := v.Distance(g); err == nil && distP < dist {
 			dist = distP
 		}
 	}
@@ -2,11 +2,13 @@ package space
 
 import (
 	"errors"
+	"math"
 
 	"github.com/spatial-go/geoos/algorithm"
 	"github.com/spatial-go/geoos/algorithm/matrix"
 	"github.com/spatial-go/geoos/algorithm/measure"
 	"github.com/spatial-go/geoos/algorithm/overlay"
+	"github.com/spatial-go/geoos/algorithm/topology"
 )
 
 // const geomtype
@@ -61,6 +63,7 @@ func (el *Element) distanceWithFunc(g Geometry, f measure.Distance) (float64, er
 	if el.IsEmpty() != g.IsEmpty() {
 		return 0, errors.New("Geometry is nil")
 	}
+	el, g = &Element{distanceOperand(el.Geometry)}, distanceOperand(g)
 	switch g.GeoJSONType() {
 	case TypePoint:
 		if el.GeoJSONType() == TypePoint {
@@ -82,10 +85,14 @@ func (el *Element) distanceWithFunc(g Geometry, f measure.Distance) (float64, er
 		} else if el.GeoJSONType() == TypeLineString {
 			return el.distanceLineWithFunc(g, f)
 		} else if el.GeoJSONType() == TypePolygon {
-			var dist float64
+			// the polygons intersect if g starts inside el, or else one of the rings of el is nearest to g
+			if shell := g.(Polygon)[0]; len(shell) > 0 && coversPoint(el.Geometry.(Polygon), shell[0]) {
+				return 0, nil
+			}
+			dist := math.MaxFloat64
 			for _, v := range el.Geometry.(Polygon) {
 				elem := &Element{LineString(v)}
-				if distP, _ := elem.distanceWithFunc(g, f); dist > distP {
+				if distP, _ := elem.distanceWithFunc(g, f); distP < dist {
 					dist = distP
 				}
 			}
@@ -94,45 +101,51 @@ func (el *Element) distanceWithFunc(g Geometry, f measure.Distance) (float64, er
 		elem := &Element{g}
 		return elem.distanceWithFunc(el.Geometry, f)
 	case TypeMultiPoint:
-		var dist float64
+		dist := math.MaxFloat64
 		for _, v := range g.(MultiPoint) {
-			if distP, _ := el.distanceWithFunc(v, f); dist > distP {
+			if distP, _ := el.distanceWithFunc(v, f); distP < dist {
 				dist = distP
 			}
 		}
 		return dist, nil
 	case TypeMultiLineString:
-		var dist float64
+		dist := math.MaxFloat64
 		for _, v := range g.(MultiLineString) {
-			if distP, _ := el.distanceWithFunc(v, f); dist > distP {
+			if distP, _ := el.distanceWithFunc(v, f); distP < dist {
 				dist = distP
 			}
 		}
 		return dist, nil
 	case TypeMultiPolygon:
-		var dist float64
+		dist := math.MaxFloat64
 		for _, v := range g.(MultiPolygon) {
-			if distP, _ := el.distanceWithFunc(v, f); dist > distP {
+			if distP, _ := el.distanceWithFunc(v, f); distP < dist {
 				dist = distP
 			}
 		}
 		return dist, nil
 	case TypeCollection:
-		var dist float64
+		dist := math.MaxFloat64
 		for _, v := range g.(Collection) {
-			if distP, err := el.distanceWithFunc(v, f); err == nil && dist > distP {
+			if distP, err := el.distanceWithFunc(v, f); err == nil && distP < dist {
 				dist = distP
 			}
 		}
 		return dist, nil
-	case TypeBound:
-		elem := &Element{el.Bound().ToRing()}
-		return elem.distanceWithFunc(g, f)
 	default:
 		return 0, nil
 	}
 }
 
+// spheroidDistanceFunc returns the distance between two points of SpheroidDistance,
+// measure.SpheroidDistance unless a function is given.
+func spheroidDistanceFunc(f []measure.Distance) measure.Distance {
+	if len(f) > 0 && f[0] != nil {
+		return f[0]
+	}
+	return measure.SpheroidDistance
+}
+
 // distancePointWithFunc returns distance Between the two Geometry.
 func (el *Element) distancePointWithFunc(g Geometry, f measure.Distance) (float64, error) {
 	switch g.GeoJSONType() {
@@ -141,6 +154,9 @@ func (el *Element) distancePointWithFunc(g Geometry, f measure.Distance) (float6
 	case TypeLineString:
 		return measure.DistanceLineToPoint(matrix.LineMatrix(g.(LineString)), matrix.Matrix(el.Geometry.(Point)), f), nil
 	case TypePolygon:
+		if coversPoint(g.(Polygon), el.Geometry.(Point)) {
+			return 0, nil
+		}
 		return measure.DistancePolygonToPoint(matrix.PolygonMatrix(g.(Polygon)), matrix.Matrix(el.Geometry.(Point)), f), nil
 	default:
 		return 0, errors.New("Wrong usage function distancePointWithFunc")
@@ -151,22 +167,33 @@ func (el *Element) distancePointWithFunc(g Geometry, f measure.Distance) (float6
 func (el *Element) distanceLineWithFunc(g Geometry, f measure.Distance) (float64, error) {
 	switch g.GeoJSONType() {
 	case TypeLineString:
-		var dist float64
+		dist := math.MaxFloat64
 		if mark := IsIntersectionLineString(el.Geometry.(LineString), g.(LineString)); mark {
 			return 0, nil
 		}
+		// the nearest points of two disjoint lines include a vertex of one of them
 		for _, v := range el.Geometry.(LineString) {
 			elem := &Element{Point(v)}
-			if distP, _ := elem.distanceWithFunc(g, f); dist > distP {
+			if distP, _ := elem.distanceWithFunc(g, f); distP < dist {
+				dist = distP
+			}
+		}
+		for _, v := range g.(LineString) {
+			elem := &Element{Point(v)}
+			if distP, _ := elem.distanceWithFunc(el.Geometry, f); distP < dist {
 				dist = distP
 			}
 		}
 		return dist, nil
 	case TypePolygon:
-		var dist float64
+		if coversPoint(g.(Polygon), el.Geometry.(LineString)[0]) {
+			return 0, nil
+		}
+		// a line outside the polygon, or crossing its boundary, is nearest to one of the rings
+		dist := math.MaxFloat64
 		for _, v := range g.(Polygon) {
 			elem := &Element{LineString(v)}
-			if distP, _ := elem.distanceWithFunc(el, f); dist > distP {
+			if distP, _ := elem.distanceWithFunc(el.Geometry, f); distP < dist {
 				dist = distP
 			}
 		}
@@ -176,6 +203,38 @@ func (el *Element) distanceLineWithFunc(g Geometry, f measure.Distance) (float64
 	}
 }
 
+// distanceOperand returns the geometry measured by distanceWithFunc for g:
+// rings as line strings, bounds as polygons, and elements as the geometry they wrap.
+func distanceOperand(g Geometry) Geometry {
+	switch geom := g.(type) {
+	case Ring:
+		return LineString(geom)
+	case Bound:
+		return geom.ToPolygon()
+	case *Element:
+		return distanceOperand(geom.Geometry)
+	default:
+		return g
+	}
+}
+
+// coversPoint returns true if p lies in the interior or on the boundary of the polygon.
+func coversPoint(poly Polygon, p Point) bool {
+	if len(poly) == 0 || len(p) < 2 {
+		return false
+	}
+	v := topology.Vertex{p[0], p[1]}
+	if locateInRing(v, distinctVertices(poly[0])) == locationExterior {
+		return false
+	}
+	for _, hole := range poly[1:] {
+		if locateInRing(v, distinctVertices(hole)) == locationInterior {
+			return false
+		}
+	}
+	return true
+}
+
 // IsIntersectionLineString returns intersection of edge a and b.
 func IsIntersectionLineString(aLine, bLine LineString) bool {
 	aEdge, bEdge := &algorithm.Edge{Vertexs: []algorithm.Vertex{}}, &algorithm.Edge{Vertexs: []algorithm.Vertex{}}
@@ -184,7 +243,7 @@ func IsIntersectionLineString(aLine, bLine LineString) bool {
 		aEdge.Vertexs = append(aEdge.Vertexs, algorithm.Vertex{Matrix: v})
 	}
 	for _, v := range bLine {
-		bEdge.Vertexs = append(aEdge.Vertexs, algorithm.Vertex{Matrix: v})
+		bEdge.Vertexs = append(bEdge.Vertexs, algorithm.Vertex{Matrix: v})
 	}
 	return overlay.IsIntersectionEdge(*aEdge, *bEdge)
 }
@@ -197,7 +256,7 @@ func IntersectionLineString(aLine, bLine LineString) (bool, []Point) {
 		aEdge.Vertexs = append(aEdge.Vertexs, algorithm.Vertex{Matrix: v})
 	}
 	for _, v := range bLine {
-		bEdge.Vertexs = append(aEdge.Vertexs, algorithm.Vertex{Matrix: v})
+		bEdge.Vertexs = append(bEdge.Vertexs, algorithm.Vertex{Matrix: v})
 	}
 	mark, ps := overlay.IntersectionEdge(*aEdge, *bEdge)
 	intersectPoints := []Point{}
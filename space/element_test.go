@@ -0,0 +1,76 @@
+package space
+
+import (
+	"math"
+	"testing"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+)
+
+func TestElement_Distance(t *testing.T) {
+	square := Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}
+	holed := Polygon{square[0], {{4, 4}, {4, 6}, {6, 6}, {6, 4}, {4, 4}}}
+
+	tests := []struct {
+		name string
+		a, b Geometry
+		want float64
+	}{
+		{name: "point inside polygon", a: Point{5, 5}, b: square, want: 0},
+		{name: "point on boundary", a: Point{10, 5}, b: square, want: 0},
+		{name: "point outside polygon", a: Point{15, 5}, b: square, want: 5},
+		{name: "point in hole", a: Point{5, 5}, b: holed, want: 1},
+		{name: "line inside polygon", a: LineString{{2, 2}, {8, 8}}, b: square, want: 0},
+		{name: "line crossing polygon", a: LineString{{5, 5}, {15, 5}}, b: square, want: 0},
+		{name: "line outside polygon", a: LineString{{12, 0}, {12, 10}}, b: square, want: 2},
+		{name: "line in hole", a: LineString{{4.5, 5}, {5.5, 5}}, b: holed, want: 0.5},
+		{name: "polygon inside polygon", a: Polygon{{{2, 2}, {3, 2}, {3, 3}, {2, 2}}}, b: square, want: 0},
+		{name: "disjoint polygons", a: Polygon{{{12, 0}, {14, 0}, {14, 2}, {12, 0}}}, b: square, want: 2},
+		{name: "ring and polygon", a: Ring{{12, 0}, {14, 0}, {14, 2}, {12, 0}}, b: square, want: 2},
+		{name: "bound and point", a: Bound{Min: Point{0, 0}, Max: Point{10, 10}}, b: Point{13, 14}, want: 5},
+		{name: "collection and polygon", a: Collection{Point{20, 20}, LineString{{12, 5}, {13, 5}}}, b: square, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, pair := range [][2]Geometry{{tt.a, tt.b}, {tt.b, tt.a}} {
+				got, err := pair[0].Distance(pair[1])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.want {
+					t.Errorf("%v.Distance(%v) = %v, want %v", pair[0].GeoJSONType(), pair[1].GeoJSONType(), got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestElement_SpheroidDistance(t *testing.T) {
+	from, to := Point{116.4, 39.9}, Point{121.47, 31.23}
+	if got, err := from.SpheroidDistance(from); err != nil || got != 0 {
+		t.Errorf("SpheroidDistance() of identical points = %v, %v, want 0", got, err)
+	}
+	if got, _ := from.SpheroidDistance(Point{116.4, 39.9 + 1e-6}); math.Abs(got-measure.R*math.Pi/180*1e-6) > 1e-6 {
+		t.Errorf("SpheroidDistance() of close points = %v", got)
+	}
+
+	got, err := from.SpheroidDistance(to, measure.GeodesicDistance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := measure.GeodesicDistance(matrix.Matrix(from), matrix.Matrix(to)); got != want {
+		t.Errorf("SpheroidDistance() along geodesics = %v, want %v", got, want)
+	}
+	if spheroid, _ := from.SpheroidDistance(to); got == spheroid {
+		t.Errorf("SpheroidDistance() along geodesics = %v, want a distance on the ellipsoid", got)
+	}
+	square := Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}
+	if got, err := (Point{5, 5}).SpheroidDistance(square, measure.GeodesicDistance); err != nil || got != 0 {
+		t.Errorf("SpheroidDistance() along geodesics = %v, %v, want 0", got, err)
+	}
+	if got, err := (Collection{Point{20, 0}}).SpheroidDistance(Point{20, 1}, measure.GeodesicDistance); err != nil ||
+		got != measure.GeodesicDistance(matrix.Matrix{20, 0}, matrix.Matrix{20, 1}) {
+		t.Errorf("SpheroidDistance() of a collection along geodesics = %v, %v", got, err)
+	}
+}
@@ -1,5 +1,7 @@
 package space
 
+import "github.com/spatial-go/geoos/algorithm/measure"
+
 // Geometry is the interface implemented by other spatial objects
 type Geometry interface {
 	GeoJSONType() string
@@ -28,7 +30,9 @@ type Geometry interface {
 	Distance(g Geometry) (float64, error)
 
 	// SpheroidDistance returns  spheroid distance Between the two Geometry.
-	SpheroidDistance(g Geometry) (float64, error)
+	// The distance between two points is measure.SpheroidDistance, on a sphere, unless a function is given,
+	// such as measure.GeodesicDistance to measure it along the geodesics of the WGS84 ellipsoid.
+	SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error)
 
 	// Boundary returns the closure of the combinatorial boundary of this space.Geometry.
 	Boundary() (Geometry, error)
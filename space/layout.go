@@ -0,0 +1,252 @@
+package space
+
+import (
+	"math"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/measure"
+)
+
+// Layout describes the values of the coordinates of a geometry.
+// Z and M values follow X and Y in the coordinates. A coordinate with a M but no Z
+// has a NaN Z, so that the M is always the fourth value.
+type Layout int
+
+// Layouts of coordinates.
+const (
+	XY Layout = iota
+	XYZ
+	XYM
+	XYZM
+)
+
+// HasZ returns true if the coordinates have a Z.
+func (l Layout) HasZ() bool {
+	return l == XYZ || l == XYZM
+}
+
+// HasM returns true if the coordinates have a M.
+func (l Layout) HasM() bool {
+	return l == XYM || l == XYZM
+}
+
+// Stride returns the number of values stored for a coordinate.
+func (l Layout) Stride() int {
+	switch l {
+	case XYZ:
+		return 3
+	case XYM, XYZM:
+		return 4
+	}
+	return 2
+}
+
+// NewPointZ returns a point with a Z.
+func NewPointZ(x, y, z float64) Point {
+	return Point{x, y, z}
+}
+
+// NewPointM returns a point with a M but no Z.
+func NewPointM(x, y, m float64) Point {
+	return Point{x, y, math.NaN(), m}
+}
+
+// NewPointZM returns a point with a Z and a M.
+func NewPointZM(x, y, z, m float64) Point {
+	return Point{x, y, z, m}
+}
+
+// NewEmptyPoint returns an empty point with the layout. Like POINT EMPTY in WKB,
+// an empty point with a Z or a M has NaN values, and the Z of an empty XYZM point is 0
+// so that it is told from an empty XYM point.
+func NewEmptyPoint(layout Layout) Point {
+	switch layout {
+	case XYZ:
+		return Point{math.NaN(), math.NaN(), math.NaN()}
+	case XYM:
+		return NewPointM(math.NaN(), math.NaN(), math.NaN())
+	case XYZM:
+		return Point{math.NaN(), math.NaN(), 0, math.NaN()}
+	}
+	return Point{}
+}
+
+// CoordLayout returns the layout of the coordinates of the geometry, given by its first coordinate.
+func CoordLayout(geom Geometry) Layout {
+	p, ok := firstCoordinate(geom)
+	if !ok {
+		return XY
+	}
+	return p.Layout()
+}
+
+// CoordinateDimension returns the number of values of the coordinates of the geometry:
+// 2 for XY, 3 for XYZ and XYM, and 4 for XYZM.
+func CoordinateDimension(geom Geometry) int {
+	switch CoordLayout(geom) {
+	case XYZ, XYM:
+		return 3
+	case XYZM:
+		return 4
+	}
+	return 2
+}
+
+// firstCoordinate returns the first coordinate of the geometry, and false if it is empty.
+func firstCoordinate(geom Geometry) (Point, bool) {
+	switch g := geom.(type) {
+	case Point:
+		return g, len(g) > 0
+	case MultiPoint:
+		if len(g) > 0 {
+			return g[0], true
+		}
+	case LineString:
+		if len(g) > 0 {
+			return g[0], true
+		}
+	case Ring:
+		if len(g) > 0 {
+			return g[0], true
+		}
+	case MultiLineString:
+		for _, ls := range g {
+			if len(ls) > 0 {
+				return ls[0], true
+			}
+		}
+	case Polygon:
+		for _, r := range g {
+			if len(r) > 0 {
+				return r[0], true
+			}
+		}
+	case MultiPolygon:
+		for _, p := range g {
+			if c, ok := firstCoordinate(p); ok {
+				return c, true
+			}
+		}
+	case Collection:
+		for _, v := range g {
+			if c, ok := firstCoordinate(v); ok {
+				return c, true
+			}
+		}
+	case Bound:
+		return g.Min, len(g.Min) > 0
+	}
+	return nil, false
+}
+
+// ForceLayout returns a copy of the geometry with its coordinates in the layout.
+// Missing Z and M values are set to 0, like PostGIS ST_Force3D and ST_Force4D do.
+func ForceLayout(geom Geometry, layout Layout) Geometry {
+	switch g := geom.(type) {
+	case Point:
+		if g.IsEmpty() {
+			return NewEmptyPoint(layout)
+		}
+		return forcePoint(g, layout)
+	case MultiPoint:
+		mp := make(MultiPoint, len(g))
+		for i, p := range g {
+			mp[i] = forcePoint(p, layout)
+		}
+		return mp
+	case LineString:
+		return LineString(forceLine(matrix.LineMatrix(g), layout))
+	case Ring:
+		return Ring(forceLine(matrix.LineMatrix(g), layout))
+	case MultiLineString:
+		mls := make(MultiLineString, len(g))
+		for i, ls := range g {
+			mls[i] = LineString(forceLine(matrix.LineMatrix(ls), layout))
+		}
+		return mls
+	case Polygon:
+		p := make(Polygon, len(g))
+		for i, r := range g {
+			p[i] = forceLine(r, layout)
+		}
+		return p
+	case MultiPolygon:
+		mp := make(MultiPolygon, len(g))
+		for i, p := range g {
+			mp[i] = ForceLayout(p, layout).(Polygon)
+		}
+		return mp
+	case Collection:
+		c := make(Collection, len(g))
+		for i, v := range g {
+			c[i] = ForceLayout(v, layout)
+		}
+		return c
+	}
+	return geom
+}
+
+// forceLine returns a copy of the line with its coordinates in the layout.
+func forceLine(line matrix.LineMatrix, layout Layout) matrix.LineMatrix {
+	if line == nil {
+		return nil
+	}
+	forced := make(matrix.LineMatrix, len(line))
+	for i, p := range line {
+		forced[i] = forcePoint(p, layout)
+	}
+	return forced
+}
+
+// forcePoint returns a copy of the point with its coordinates in the layout.
+func forcePoint(p Point, layout Layout) Point {
+	forced := make(Point, 2, layout.Stride())
+	copy(forced, p[:2])
+	switch layout {
+	case XYZ:
+		forced = append(forced, p.zOr(0))
+	case XYM:
+		forced = append(forced, math.NaN(), p.mOr(0))
+	case XYZM:
+		forced = append(forced, p.zOr(0), p.mOr(0))
+	}
+	return forced
+}
+
+// Length3D returns the length of the geometry in 3D, using the Z of the coordinates when they have one.
+// Like Length, it is the perimeter of polygonal geometries.
+func Length3D(geom Geometry) float64 {
+	switch g := geom.(type) {
+	case LineString:
+		return measure.OfLine3D(matrix.LineMatrix(g))
+	case Ring:
+		return measure.OfLine3D(matrix.LineMatrix(g))
+	case MultiLineString:
+		length := 0.0
+		for _, ls := range g {
+			length += Length3D(ls)
+		}
+		return length
+	case Polygon:
+		length := 0.0
+		for _, r := range g {
+			length += measure.OfLine3D(r)
+		}
+		return length
+	case MultiPolygon:
+		length := 0.0
+		for _, p := range g {
+			length += Length3D(p)
+		}
+		return length
+	case Collection:
+		length := 0.0
+		for _, v := range g {
+			length += Length3D(v)
+		}
+		return length
+	case nil:
+		return 0
+	}
+	return geom.Length()
+}
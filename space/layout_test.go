@@ -0,0 +1,86 @@
+package space
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCoordLayout(t *testing.T) {
+	tests := []struct {
+		name      string
+		geom      Geometry
+		layout    Layout
+		dimension int
+	}{
+		{name: "xy", geom: Point{1, 2}, layout: XY, dimension: 2},
+		{name: "xyz", geom: LineString{{1, 2, 3}, {4, 5, 6}}, layout: XYZ, dimension: 3},
+		{name: "xym", geom: MultiPoint{NewPointM(1, 2, 3)}, layout: XYM, dimension: 3},
+		{name: "xyzm", geom: Polygon{{{0, 0, 1, 2}, {1, 0, 1, 2}, {1, 1, 1, 2}, {0, 0, 1, 2}}}, layout: XYZM, dimension: 4},
+		{name: "empty", geom: MultiLineString{}, layout: XY, dimension: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CoordLayout(tt.geom); got != tt.layout {
+				t.Errorf("CoordLayout() = %v, want %v", got, tt.layout)
+			}
+			if got := CoordinateDimension(tt.geom); got != tt.dimension {
+				t.Errorf("CoordinateDimension() = %v, want %v", got, tt.dimension)
+			}
+		})
+	}
+}
+
+func TestPoint_ZM(t *testing.T) {
+	p := NewPointM(1, 2, 3)
+	if !math.IsNaN(p.Z()) || p.M() != 3 {
+		t.Errorf("Z(), M() = %v, %v, want NaN, 3", p.Z(), p.M())
+	}
+	if !p.Equal(NewPointM(1, 2, 3)) {
+		t.Errorf("Equal() = false, want true")
+	}
+	if p.Equal(Point{1, 2, math.NaN(), 4}) || p.Equal(Point{1, 2, 0, 3}) {
+		t.Errorf("Equal() of points with other M or Z = true, want false")
+	}
+	if empty := NewEmptyPoint(XYM); !empty.IsEmpty() || empty.Layout() != XYM || !empty.Equal(NewEmptyPoint(XYM)) {
+		t.Errorf("NewEmptyPoint() = %v, want an empty XYM point", empty)
+	}
+	if NewEmptyPoint(XYZM).Layout() != XYZM || NewEmptyPoint(XYZ).Equal(NewEmptyPoint(XYM)) {
+		t.Errorf("NewEmptyPoint() layouts are not told apart")
+	}
+	if p := NewPointZ(1, 2, 3); p.Z() != 3 || !math.IsNaN(p.M()) {
+		t.Errorf("Z(), M() = %v, %v, want 3, NaN", p.Z(), p.M())
+	}
+}
+
+func TestForceLayout(t *testing.T) {
+	line := LineString{{0, 0, 1}, {3, 4, 13}}
+	if got := ForceLayout(line, XY); !got.Equal(LineString{{0, 0}, {3, 4}}) {
+		t.Errorf("ForceLayout() = %v", got)
+	}
+	if got := ForceLayout(line, XYZM); !got.Equal(LineString{{0, 0, 1, 0}, {3, 4, 13, 0}}) {
+		t.Errorf("ForceLayout() = %v", got)
+	}
+	if got := ForceLayout(line, XYM).(LineString); CoordLayout(got) != XYM || Point(got[1]).M() != 0 {
+		t.Errorf("ForceLayout() = %v", got)
+	}
+}
+
+func TestLength3D(t *testing.T) {
+	tests := []struct {
+		name string
+		geom Geometry
+		want float64
+	}{
+		{name: "line", geom: LineString{{0, 0, 0}, {3, 4, 12}}, want: 13},
+		{name: "line without z", geom: LineString{{0, 0}, {3, 4}}, want: 5},
+		{name: "multi line", geom: MultiLineString{{{0, 0, 0}, {3, 4, 12}}, {{0, 0, 0}, {0, 0, 1}}}, want: 14},
+		{name: "point", geom: Point{1, 2, 3}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Length3D(tt.geom); got != tt.want {
+				t.Errorf("Length3D() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -120,9 +120,9 @@ func (ls LineString) Distance(g Geometry) (float64, error) {
 }
 
 // SpheroidDistance returns  spheroid distance Between the two Geometry.
-func (ls LineString) SpheroidDistance(g Geometry) (float64, error) {
+func (ls LineString) SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error) {
 	elem := &Element{ls}
-	return elem.distanceWithFunc(g, measure.SpheroidDistance)
+	return elem.distanceWithFunc(g, spheroidDistanceFunc(f))
 }
 
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
@@ -105,9 +105,9 @@ func (mls MultiLineString) Distance(g Geometry) (float64, error) {
 }
 
 // SpheroidDistance returns  spheroid distance Between the two Geometry.
-func (mls MultiLineString) SpheroidDistance(g Geometry) (float64, error) {
+func (mls MultiLineString) SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error) {
 	elem := &Element{mls}
-	return elem.distanceWithFunc(g, measure.SpheroidDistance)
+	return elem.distanceWithFunc(g, spheroidDistanceFunc(f))
 }
 
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
@@ -97,9 +97,9 @@ func (mp MultiPoint) Distance(g Geometry) (float64, error) {
 }
 
 // SpheroidDistance returns  spheroid distance Between the two Geometry.
-func (mp MultiPoint) SpheroidDistance(g Geometry) (float64, error) {
+func (mp MultiPoint) SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error) {
 	elem := &Element{mp}
-	return elem.distanceWithFunc(g, measure.SpheroidDistance)
+	return elem.distanceWithFunc(g, spheroidDistanceFunc(f))
 }
 
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
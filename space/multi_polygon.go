@@ -91,6 +91,25 @@ func (mp MultiPolygon) IsEmpty() bool {
 	return mp == nil || len(mp) == 0
 }
 
+// SphericalArea returns the area in square meters of a multi polygon of longitudes and latitudes
+// on the mean sphere of WGS84.
+func (mp MultiPolygon) SphericalArea() float64 {
+	area := 0.0
+	for _, polygon := range mp {
+		area += polygon.SphericalArea()
+	}
+	return area
+}
+
+// SpheroidArea returns the area in square meters of a multi polygon of longitudes and latitudes on the WGS84 ellipsoid.
+func (mp MultiPolygon) SpheroidArea() float64 {
+	area := 0.0
+	for _, polygon := range mp {
+		area += polygon.SpheroidArea()
+	}
+	return area
+}
+
 // Distance returns distance Between the two Geometry.
 func (mp MultiPolygon) Distance(g Geometry) (float64, error) {
 	elem := &Element{mp}
@@ -98,9 +117,9 @@ func (mp MultiPolygon) Distance(g Geometry) (float64, error) {
 }
 
 // SpheroidDistance returns  spheroid distance Between the two Geometry.
-func (mp MultiPolygon) SpheroidDistance(g Geometry) (float64, error) {
+func (mp MultiPolygon) SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error) {
 	elem := &Element{mp}
-	return elem.distanceWithFunc(g, measure.SpheroidDistance)
+	return elem.distanceWithFunc(g, spheroidDistanceFunc(f))
 }
 
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
@@ -126,6 +145,26 @@ func (mp MultiPolygon) Length() float64 {
 	return length
 }
 
+// SphericalLength returns the perimeter in meters of a multi polygon of longitudes and latitudes
+// along the great circles of the mean sphere of WGS84.
+func (mp MultiPolygon) SphericalLength() float64 {
+	length := 0.0
+	for _, v := range mp {
+		length += v.SphericalLength()
+	}
+	return length
+}
+
+// SpheroidLength returns the perimeter in meters of a multi polygon of longitudes and latitudes
+// along the geodesics of the WGS84 ellipsoid.
+func (mp MultiPolygon) SpheroidLength() float64 {
+	length := 0.0
+	for _, v := range mp {
+		length += v.SpheroidLength()
+	}
+	return length
+}
+
 // IsSimple returns true if this space.Geometry has no anomalous geometric points,
 // such as self intersection or self tangency.
 func (mp MultiPolygon) IsSimple() bool {
@@ -1,6 +1,9 @@
 package space
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestMultiPolygon_Nums(t *testing.T) {
 	mp := MultiPolygon{
@@ -24,3 +27,25 @@ func TestMultiPolygon_Nums(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiPolygon_SpheroidArea(t *testing.T) {
+	polygon := Polygon{
+		{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+		{{0.25, 0.25}, {0.25, 0.75}, {0.75, 0.75}, {0.75, 0.25}, {0.25, 0.25}},
+	}
+	mp := MultiPolygon{polygon, {polygon[0]}}
+	shell, hole := Polygon{polygon[0]}, Polygon{polygon[1]}
+
+	if got, want := mp.SpheroidArea(), 2*shell.SpheroidArea()-hole.SpheroidArea(); math.Abs(got-want) > 1e-3 {
+		t.Errorf("MultiPolygon.SpheroidArea() = %v, want %v", got, want)
+	}
+	if got := mp.SphericalArea(); math.Abs(got-mp.SpheroidArea()) > 0.01*got {
+		t.Errorf("MultiPolygon.SphericalArea() = %v, want near %v", got, mp.SpheroidArea())
+	}
+	if got, want := mp.SpheroidLength(), polygon.SpheroidLength()+shell.SpheroidLength(); got != want || want < 1.5*443770 {
+		t.Errorf("MultiPolygon.SpheroidLength() = %v, want %v", got, want)
+	}
+	if got := mp.SphericalLength(); math.Abs(got-mp.SpheroidLength()) > 0.01*got {
+		t.Errorf("MultiPolygon.SphericalLength() = %v, want near %v", got, mp.SpheroidLength())
+	}
+}
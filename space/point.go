@@ -2,6 +2,7 @@ package space
 
 import (
 	"errors"
+	"math"
 	"math/rand"
 	"reflect"
 
@@ -24,6 +25,9 @@ func (p Point) Dimensions() int {
 
 // Bound returns a single point bound of the point.
 func (p Point) Bound() Bound {
+	if p.IsEmpty() {
+		return emptyBound
+	}
 	return Bound{p, p}
 }
 
@@ -52,9 +56,60 @@ func (p Point) X() float64 {
 	return p[0]
 }
 
+// Z returns the elevation of the point, NaN if it has none.
+func (p Point) Z() float64 {
+	return p.zOr(math.NaN())
+}
+
+// M returns the measure of the point, NaN if it has none.
+func (p Point) M() float64 {
+	return p.mOr(math.NaN())
+}
+
+// Layout returns the layout of the coordinates of the point.
+func (p Point) Layout() Layout {
+	switch {
+	case len(p) == 3:
+		return XYZ
+	case len(p) >= 4 && math.IsNaN(p[2]):
+		return XYM
+	case len(p) >= 4:
+		return XYZM
+	}
+	return XY
+}
+
+// zOr returns the Z of the point, or def if it has none.
+func (p Point) zOr(def float64) float64 {
+	if len(p) < 3 || math.IsNaN(p[2]) {
+		return def
+	}
+	return p[2]
+}
+
+// mOr returns the M of the point, or def if it has none.
+func (p Point) mOr(def float64) float64 {
+	if len(p) < 4 {
+		return def
+	}
+	return p[3]
+}
+
 // EqualPoint checks if the point represents the same point or vector.
+// The NaN Z of points with a M but no Z are equal, as are the NaN X and Y of empty points, see Layout.
 func (p Point) EqualPoint(point Point) bool {
-	return matrix.Equal(matrix.Matrix(p), matrix.Matrix(point))
+	if len(p) != len(point) || p.Layout() != point.Layout() {
+		return false
+	}
+	if p.IsEmpty() || point.IsEmpty() {
+		return p.IsEmpty() == point.IsEmpty()
+	}
+	for i := range p {
+		if p[i] != point[i] && !(i == 2 && math.IsNaN(p[i]) && math.IsNaN(point[i])) {
+			return false
+		}
+	}
+	return true
 }
 
 // Equal checks if the point represents the same Geometry or vector.
@@ -98,8 +153,9 @@ func (p Point) Area() (float64, error) {
 }
 
 // IsEmpty returns true if the Geometry is empty.
+// An empty point with a Z or a M has NaN X and Y, see NewEmptyPoint.
 func (p Point) IsEmpty() bool {
-	return p == nil || len(p) == 0
+	return p == nil || len(p) == 0 || (len(p) >= 2 && math.IsNaN(p[0]) && math.IsNaN(p[1]))
 }
 
 // Distance returns distance Between the two Geometry.
@@ -109,9 +165,9 @@ func (p Point) Distance(g Geometry) (float64, error) {
 }
 
 // SpheroidDistance returns  spheroid distance Between the two Geometry.
-func (p Point) SpheroidDistance(g Geometry) (float64, error) {
+func (p Point) SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error) {
 	elem := &Element{p}
-	return elem.distanceWithFunc(g, measure.SpheroidDistance)
+	return elem.distanceWithFunc(g, spheroidDistanceFunc(f))
 }
 
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
@@ -122,6 +122,16 @@ func (p Polygon) Area() (float64, error) {
 	return measure.AreaOfPolygon(p.ToMatrix()), nil
 }
 
+// SphericalArea returns the area in square meters of a polygon of longitudes and latitudes on the mean sphere of WGS84.
+func (p Polygon) SphericalArea() float64 {
+	return measure.SphericalAreaOfPolygon(p.ToMatrix())
+}
+
+// SpheroidArea returns the area in square meters of a polygon of longitudes and latitudes on the WGS84 ellipsoid.
+func (p Polygon) SpheroidArea() float64 {
+	return measure.SpheroidAreaOfPolygon(p.ToMatrix())
+}
+
 // ToMatrix returns the PolygonMatrix of a polygonal geometry.
 func (p Polygon) ToMatrix() matrix.PolygonMatrix {
 	return matrix.PolygonMatrix(p)
@@ -147,9 +157,9 @@ func (p Polygon) Distance(g Geometry) (float64, error) {
 }
 
 // SpheroidDistance returns  spheroid distance Between the two Geometry.
-func (p Polygon) SpheroidDistance(g Geometry) (float64, error) {
+func (p Polygon) SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error) {
 	elem := &Element{p}
-	return elem.distanceWithFunc(g, measure.SpheroidDistance)
+	return elem.distanceWithFunc(g, spheroidDistanceFunc(f))
 }
 
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
@@ -176,6 +186,26 @@ func (p Polygon) Length() float64 {
 	return length
 }
 
+// SphericalLength returns the perimeter in meters of a polygon of longitudes and latitudes, the lengths of its holes
+// included, along the great circles of the mean sphere of WGS84.
+func (p Polygon) SphericalLength() float64 {
+	length := 0.0
+	for _, v := range p {
+		length += measure.SphericalOfLine(v)
+	}
+	return length
+}
+
+// SpheroidLength returns the perimeter in meters of a polygon of longitudes and latitudes, the lengths of its holes
+// included, along the geodesics of the WGS84 ellipsoid.
+func (p Polygon) SpheroidLength() float64 {
+	length := 0.0
+	for _, v := range p {
+		length += measure.SpheroidOfLine(v)
+	}
+	return length
+}
+
 // IsSimple returns true if this space.Geometry has no anomalous geometric points,
 // such as self intersection or self tangency.
 func (p Polygon) IsSimple() bool {
@@ -75,8 +75,8 @@ func (r Ring) Distance(g Geometry) (float64, error) {
 }
 
 // SpheroidDistance returns  spheroid distance Between the two Geometry.
-func (r Ring) SpheroidDistance(g Geometry) (float64, error) {
-	return LineString(r).SpheroidDistance(g)
+func (r Ring) SpheroidDistance(g Geometry, f ...measure.Distance) (float64, error) {
+	return LineString(r).SpheroidDistance(g, f...)
 }
 
 // Boundary returns the closure of the combinatorial boundary of this space.Geometry.
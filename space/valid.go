@@ -0,0 +1,576 @@
+package space
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/spatial-go/geoos/algorithm/matrix"
+	"github.com/spatial-go/geoos/algorithm/overlay"
+	"github.com/spatial-go/geoos/algorithm/topology"
+)
+
+// ValidKind is the kind of the error making a geometry invalid.
+type ValidKind int
+
+// Kinds of the errors making a geometry invalid, in the OGC sense.
+const (
+	Valid ValidKind = iota
+	InvalidCoordinate
+	InvalidTooFewPoints
+	InvalidRingNotClosed
+	InvalidSelfIntersection
+	InvalidRingSelfIntersection
+	InvalidHoleOutsideShell
+	InvalidNestedHoles
+	InvalidDisconnectedInterior
+	InvalidNestedShells
+	// InvalidTopology is any other error, such as the duplicate rings reported by GEOS.
+	InvalidTopology
+)
+
+// validMessages are the messages of the kinds of errors, as GEOS reports them.
+var validMessages = []string{
+	Valid:                       "Valid Geometry",
+	InvalidCoordinate:           "Invalid Coordinate",
+	InvalidTooFewPoints:         "Too few points in geometry component",
+	InvalidRingNotClosed:        "Ring is not closed",
+	InvalidSelfIntersection:     "Self-intersection",
+	InvalidRingSelfIntersection: "Ring Self-intersection",
+	InvalidHoleOutsideShell:     "Hole lies outside shell",
+	InvalidNestedHoles:          "Holes are nested",
+	InvalidDisconnectedInterior: "Interior is disconnected",
+	InvalidNestedShells:         "Nested shells",
+	InvalidTopology:             "Topology Validation Error",
+}
+
+// String returns the message of the kind of error.
+func (k ValidKind) String() string {
+	if k < 0 || int(k) >= len(validMessages) {
+		return validMessages[InvalidTopology]
+	}
+	return validMessages[k]
+}
+
+// ValidKindOf returns the kind of error of a message of GEOS, InvalidTopology if it is unknown.
+func ValidKindOf(message string) ValidKind {
+	for k, m := range validMessages {
+		if m == message {
+			return ValidKind(k)
+		}
+	}
+	return InvalidTopology
+}
+
+// ValidReason is the reason why a geometry is valid or not: the kind of the first error found,
+// and the point where it is located, which is empty if the error has no location.
+type ValidReason struct {
+	Kind     ValidKind
+	Location Point
+}
+
+// IsValid returns true if the reason is that the geometry is valid.
+func (r ValidReason) IsValid() bool {
+	return r.Kind == Valid
+}
+
+// String returns the message of the reason, followed by its location like in PostGIS: "Self-intersection[1 1]".
+func (r ValidReason) String() string {
+	if r.Kind == Valid || len(r.Location) < 2 {
+		return r.Kind.String()
+	}
+	return fmt.Sprintf("%s[%v %v]", r.Kind, r.Location[0], r.Location[1])
+}
+
+// IsValid returns true if the geometry is valid in the OGC sense: its coordinates are finite,
+// its lines have two distinct points at least, and the rings of its polygons are closed,
+// do not intersect themselves nor cross each other, and bound connected interiors, with the holes
+// inside the shells and not nested, and the polygons of a multi polygon not nested nor overlapping.
+func (el *ElementValid) IsValid() bool {
+	return el.IsValidReason().IsValid()
+}
+
+// IsValidReason returns the reason why the geometry is not valid, the first error found and its location,
+// or a Valid reason.
+func (el *ElementValid) IsValidReason() ValidReason {
+	return validate(el.Geometry)
+}
+
+// MakeValid returns a valid geometry covering the same points as the geometry, which is returned as is if it is valid.
+// Invalid coordinates and repeated points are removed, lines collapsed to one point become points, and
+// polygons are rebuilt from their rings by the even-odd rule, the polygons of a multi polygon being unioned.
+// Polygons which collapse to no area are dropped.
+func (el *ElementValid) MakeValid() Geometry {
+	if el.Geometry == nil || el.IsValid() {
+		return el.Geometry
+	}
+	return makeValid(el.Geometry)
+}
+
+func validate(geom Geometry) ValidReason {
+	switch g := geom.(type) {
+	case Point:
+		if !g.IsEmpty() && !validCoordinate(g) {
+			return ValidReason{Kind: InvalidCoordinate, Location: g}
+		}
+	case MultiPoint:
+		for _, p := range g {
+			if r := validate(p); !r.IsValid() {
+				return r
+			}
+		}
+	case LineString:
+		return validateLine(g)
+	case MultiLineString:
+		for _, ls := range g {
+			if r := validateLine(ls); !r.IsValid() {
+				return r
+			}
+		}
+	case Polygon:
+		return validatePolygons([]Polygon{g})
+	case MultiPolygon:
+		return validatePolygons(g)
+	case Collection:
+		for _, member := range g {
+			if r := validate(member); !r.IsValid() {
+				return r
+			}
+		}
+	}
+	return ValidReason{Kind: Valid}
+}
+
+// validateLine checks the coordinates of a line and that it has two distinct points.
+func validateLine(ls LineString) ValidReason {
+	if len(ls) == 0 {
+		return ValidReason{Kind: Valid}
+	}
+	for _, p := range ls {
+		if !validCoordinate(p) {
+			return ValidReason{Kind: InvalidCoordinate, Location: p}
+		}
+	}
+	if len(distinctVertices(ls)) < 2 {
+		return ValidReason{Kind: InvalidTooFewPoints, Location: ls[0]}
+	}
+	return ValidReason{Kind: Valid}
+}
+
+func validCoordinate(p []float64) bool {
+	for _, v := range p {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return len(p) >= 2
+}
+
+// distinctVertices returns the vertices of a line without its repeated points.
+func distinctVertices(ls [][]float64) []topology.Vertex {
+	vertices := make([]topology.Vertex, 0, len(ls))
+	for _, p := range ls {
+		v := topology.Vertex{p[0], p[1]}
+		if len(vertices) == 0 || vertices[len(vertices)-1] != v {
+			vertices = append(vertices, v)
+		}
+	}
+	return vertices
+}
+
+// validSegment is a segment of the ring of a polygon being validated.
+type validSegment struct {
+	start, end          topology.Vertex
+	polygon, ring, edge int
+}
+
+// validTouch is a point where two rings of a polygon touch.
+type validTouch struct {
+	polygon, ring int
+	at            topology.Vertex
+}
+
+// validatePolygons checks the rings of the polygons, then their intersections, the location of
+// the holes and of the shells, and the connectivity of the interiors, like the validation of GEOS.
+func validatePolygons(polygons []Polygon) ValidReason {
+	rings := make([][][]topology.Vertex, len(polygons))
+	for i, polygon := range polygons {
+		for _, ring := range polygon {
+			for _, p := range ring {
+				if !validCoordinate(p) {
+					return ValidReason{Kind: InvalidCoordinate, Location: p}
+				}
+			}
+		}
+		for _, ring := range polygon {
+			if len(ring) > 0 && !Point(ring[0]).Equal(Point(ring[len(ring)-1])) {
+				return ValidReason{Kind: InvalidRingNotClosed, Location: ring[0]}
+			}
+		}
+		for _, ring := range polygon {
+			vertices := distinctVertices(ring)
+			if len(vertices) < 4 {
+				var location Point
+				if len(ring) > 0 {
+					location = ring[0]
+				}
+				return ValidReason{Kind: InvalidTooFewPoints, Location: location}
+			}
+			rings[i] = append(rings[i], vertices)
+		}
+	}
+
+	touches, r := validateIntersections(rings)
+	if !r.IsValid() {
+		return r
+	}
+	for _, polygon := range rings {
+		if len(polygon) == 0 {
+			continue
+		}
+		if r := validateHoles(polygon); !r.IsValid() {
+			return r
+		}
+	}
+	if r := validateShells(rings); !r.IsValid() {
+		return r
+	}
+	return validateConnectivity(rings, touches)
+}
+
+// validateIntersections checks that the rings do not cross nor overlap each other, and that they touch
+// themselves only at the vertices between their consecutive segments. It returns the points where the
+// rings of a polygon touch each other.
+func validateIntersections(rings [][][]topology.Vertex) ([]validTouch, ValidReason) {
+	var segs []validSegment
+	for i, polygon := range rings {
+		for j, ring := range polygon {
+			for k := 0; k < len(ring)-1; k++ {
+				segs = append(segs, validSegment{start: ring[k], end: ring[k+1], polygon: i, ring: j, edge: k})
+			}
+		}
+	}
+	minX := func(s validSegment) float64 { return math.Min(s.start[0], s.end[0]) }
+	sort.SliceStable(segs, func(i, j int) bool { return minX(segs[i]) < minX(segs[j]) })
+
+	var touches []validTouch
+	for i, a := range segs {
+		maxX := math.Max(a.start[0], a.end[0])
+		for _, b := range segs[i+1:] {
+			if minX(b) > maxX {
+				break
+			}
+			kind, p := intersectSegments(a, b)
+			sameRing := a.polygon == b.polygon && a.ring == b.ring
+			switch {
+			case kind == segmentsDisjoint:
+			case kind != segmentsTouch:
+				return nil, ValidReason{Kind: InvalidSelfIntersection, Location: Point{p[0], p[1]}}
+			case sameRing:
+				n := len(rings[a.polygon][a.ring]) - 1
+				if d := a.edge - b.edge; d == 1 || d == -1 || d == n-1 || d == 1-n {
+					continue
+				}
+				return nil, ValidReason{Kind: InvalidRingSelfIntersection, Location: Point{p[0], p[1]}}
+			case a.polygon == b.polygon:
+				touches = append(touches,
+					validTouch{polygon: a.polygon, ring: a.ring, at: p},
+					validTouch{polygon: b.polygon, ring: b.ring, at: p})
+			}
+		}
+	}
+	return touches, ValidReason{Kind: Valid}
+}
+
+// Kinds of the intersection of two segments.
+const (
+	segmentsDisjoint = iota
+	segmentsTouch
+	segmentsCross
+	segmentsOverlap
+)
+
+// intersectSegments returns the kind of the intersection of two segments, and a point of it.
+// Segments touch if they meet at a single point which is an endpoint of one of them.
+func intersectSegments(a, b validSegment) (int, topology.Vertex) {
+	if math.Max(a.start[1], a.end[1]) < math.Min(b.start[1], b.end[1]) ||
+		math.Min(a.start[1], a.end[1]) > math.Max(b.start[1], b.end[1]) {
+		return segmentsDisjoint, topology.Vertex{}
+	}
+	o1 := topology.Orient(a.start, a.end, b.start)
+	o2 := topology.Orient(a.start, a.end, b.end)
+	o3 := topology.Orient(b.start, b.end, a.start)
+	o4 := topology.Orient(b.start, b.end, a.end)
+
+	if o1 == 0 && o2 == 0 && o3 == 0 && o4 == 0 {
+		for _, p := range []topology.Vertex{b.start, b.end} {
+			if topology.Between(a.start, a.end, p) {
+				return segmentsOverlap, p
+			}
+		}
+		for _, p := range []topology.Vertex{a.start, a.end} {
+			if topology.Between(b.start, b.end, p) {
+				return segmentsOverlap, p
+			}
+		}
+		if (a.start == b.start && a.end == b.end) || (a.start == b.end && a.end == b.start) {
+			return segmentsOverlap, a.start
+		}
+		for _, p := range []topology.Vertex{a.start, a.end} {
+			if p == b.start || p == b.end {
+				return segmentsTouch, p
+			}
+		}
+		return segmentsDisjoint, topology.Vertex{}
+	}
+	if o1*o2 > 0 || o3*o4 > 0 {
+		return segmentsDisjoint, topology.Vertex{}
+	}
+	switch {
+	case o1 == 0:
+		return segmentsTouch, b.start
+	case o2 == 0:
+		return segmentsTouch, b.end
+	case o3 == 0:
+		return segmentsTouch, a.start
+	case o4 == 0:
+		return segmentsTouch, a.end
+	}
+	rx, ry := a.end[0]-a.start[0], a.end[1]-a.start[1]
+	sx, sy := b.end[0]-b.start[0], b.end[1]-b.start[1]
+	t := ((b.start[0]-a.start[0])*sy - (b.start[1]-a.start[1])*sx) / (rx*sy - ry*sx)
+	return segmentsCross, topology.Vertex{a.start[0] + t*rx, a.start[1] + t*ry}
+}
+
+// Locations of a point relative to a ring.
+const (
+	locationExterior = iota
+	locationBoundary
+	locationInterior
+)
+
+// locateInRing returns the location of p relative to the closed ring.
+func locateInRing(p topology.Vertex, ring []topology.Vertex) int {
+	inside := false
+	for i := 0; i < len(ring)-1; i++ {
+		a, b := ring[i], ring[i+1]
+		o := topology.Orient(a, b, p)
+		if o == 0 && (p == a || p == b || topology.Between(a, b, p)) {
+			return locationBoundary
+		}
+		if (a[1] <= p[1]) != (b[1] <= p[1]) && (o > 0) == (b[1] > a[1]) {
+			inside = !inside
+		}
+	}
+	if inside {
+		return locationInterior
+	}
+	return locationExterior
+}
+
+// pointOffRings returns a point of the ring which lies on none of the other rings: one of its vertices,
+// or else the midpoint of one of its segments, and false if there is none.
+func pointOffRings(ring []topology.Vertex, others ...[]topology.Vertex) (topology.Vertex, bool) {
+	off := func(p topology.Vertex) bool {
+		for _, other := range others {
+			if locateInRing(p, other) == locationBoundary {
+				return false
+			}
+		}
+		return true
+	}
+	for _, p := range ring {
+		if off(p) {
+			return p, true
+		}
+	}
+	for i := 0; i < len(ring)-1; i++ {
+		if p := (topology.Vertex{(ring[i][0] + ring[i+1][0]) / 2, (ring[i][1] + ring[i+1][1]) / 2}); off(p) {
+			return p, true
+		}
+	}
+	return topology.Vertex{}, false
+}
+
+// validateHoles checks that the holes of a polygon lie inside its shell and not inside each other.
+// The rings do not cross, so a point of a hole which is not on another ring locates the whole hole.
+func validateHoles(polygon [][]topology.Vertex) ValidReason {
+	shell, holes := polygon[0], polygon[1:]
+	for _, hole := range holes {
+		if p, ok := pointOffRings(hole, shell); ok && locateInRing(p, shell) == locationExterior {
+			return ValidReason{Kind: InvalidHoleOutsideShell, Location: Point{p[0], p[1]}}
+		}
+	}
+	for i, hole := range holes {
+		for j, other := range holes {
+			if i == j {
+				continue
+			}
+			if p, ok := pointOffRings(hole, other); ok && locateInRing(p, other) == locationInterior {
+				return ValidReason{Kind: InvalidNestedHoles, Location: Point{p[0], p[1]}}
+			}
+		}
+	}
+	return ValidReason{Kind: Valid}
+}
+
+// validateShells checks that the shell of no polygon lies inside another polygon, out of its holes.
+func validateShells(rings [][][]topology.Vertex) ValidReason {
+	for i, polygon := range rings {
+		for j, other := range rings {
+			if i == j || len(polygon) == 0 || len(other) == 0 {
+				continue
+			}
+			p, ok := pointOffRings(polygon[0], other...)
+			if !ok || locateInRing(p, other[0]) != locationInterior {
+				continue
+			}
+			inHole := false
+			for _, hole := range other[1:] {
+				inHole = inHole || locateInRing(p, hole) == locationInterior
+			}
+			if !inHole {
+				return ValidReason{Kind: InvalidNestedShells, Location: Point{p[0], p[1]}}
+			}
+		}
+	}
+	return ValidReason{Kind: Valid}
+}
+
+// validateConnectivity checks that the rings of each polygon touching each other do not enclose
+// a part of its interior: the graph linking the rings to the points where they touch has no cycle.
+func validateConnectivity(rings [][][]topology.Vertex, touches []validTouch) ValidReason {
+	parent := map[interface{}]interface{}{}
+	var find func(x interface{}) interface{}
+	find = func(x interface{}) interface{} {
+		p, ok := parent[x]
+		if !ok || p == x {
+			return x
+		}
+		root := find(p)
+		parent[x] = root
+		return root
+	}
+	type node struct {
+		polygon int
+		at      topology.Vertex
+	}
+	seen := map[validTouch]bool{}
+	for _, t := range touches {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		ring, point := find([2]int{t.polygon, t.ring}), find(node{polygon: t.polygon, at: t.at})
+		if ring == point {
+			return ValidReason{Kind: InvalidDisconnectedInterior, Location: Point{t.at[0], t.at[1]}}
+		}
+		parent[ring] = point
+	}
+	return ValidReason{Kind: Valid}
+}
+
+func makeValid(geom Geometry) Geometry {
+	switch g := geom.(type) {
+	case Point:
+		if !validCoordinate(g) {
+			return Point{}
+		}
+	case MultiPoint:
+		mp := MultiPoint{}
+		for _, p := range g {
+			if validCoordinate(p) {
+				mp = append(mp, p)
+			}
+		}
+		return mp
+	case LineString:
+		return makeValidLine(g)
+	case MultiLineString:
+		mls, collapsed := MultiLineString{}, Collection{}
+		for _, ls := range g {
+			switch v := makeValidLine(ls).(type) {
+			case LineString:
+				if !v.IsEmpty() {
+					mls = append(mls, v)
+				}
+			case Point:
+				collapsed = append(collapsed, v)
+			}
+		}
+		if len(collapsed) == 0 {
+			return mls
+		}
+		for _, ls := range mls {
+			collapsed = append(collapsed, ls)
+		}
+		return collapsed
+	case Polygon:
+		return polygonalOf(makeValidPolygon(g))
+	case MultiPolygon:
+		var polygons matrix.MultiPolygonMatrix
+		for _, polygon := range g {
+			polygons = append(polygons, makeValidPolygon(polygon)...)
+		}
+		if len(polygons) > 1 {
+			polygons = overlay.UnaryUnionPolygons(polygons)
+		}
+		return polygonalOf(polygons)
+	case Collection:
+		collection := make(Collection, len(g))
+		for i, member := range g {
+			collection[i] = makeValid(member)
+		}
+		return collection
+	}
+	return geom
+}
+
+// makeValidLine returns the line without its invalid coordinates and repeated points,
+// or its point if it has only one.
+func makeValidLine(ls LineString) Geometry {
+	line := LineString{}
+	for _, p := range ls {
+		if validCoordinate(p) && (len(line) == 0 || !Point(line[len(line)-1]).Equal(Point(p))) {
+			line = append(line, p)
+		}
+	}
+	if len(line) == 1 {
+		return Point(line[0])
+	}
+	return line
+}
+
+// makeValidPolygon returns the polygons covering the points inside an odd number of the rings of the polygon,
+// closed and without their invalid coordinates.
+func makeValidPolygon(polygon Polygon) matrix.MultiPolygonMatrix {
+	var rings []matrix.LineMatrix
+	for _, ring := range polygon {
+		closed := matrix.LineMatrix{}
+		for _, p := range ring {
+			if validCoordinate(p) {
+				closed = append(closed, p)
+			}
+		}
+		if len(closed) > 0 && !Point(closed[0]).Equal(Point(closed[len(closed)-1])) {
+			closed = append(closed, closed[0])
+		}
+		rings = append(rings, closed)
+	}
+	return overlay.EvenOdd(rings)
+}
+
+// polygonalOf returns the polygons as a Polygon, which is empty if there are none, or a MultiPolygon.
+func polygonalOf(m matrix.MultiPolygonMatrix) Geometry {
+	switch len(m) {
+	case 0:
+		return Polygon{}
+	case 1:
+		return Polygon(m[0])
+	}
+	mp := make(MultiPolygon, len(m))
+	for i, polygon := range m {
+		mp[i] = Polygon(polygon)
+	}
+	return mp
+}
@@ -0,0 +1,112 @@
+package space
+
+import (
+	"math"
+	"testing"
+)
+
+func TestElementValid_IsValidReason(t *testing.T) {
+	square := LineString{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}
+	tests := []struct {
+		name     string
+		geom     Geometry
+		kind     ValidKind
+		location Point
+	}{
+		{name: "polygon", geom: Polygon{square, {{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}}}, kind: Valid},
+		{name: "line", geom: LineString{{0, 0}, {1, 1}}, kind: Valid},
+		{name: "invalid coordinate", geom: LineString{{0, 0}, {math.NaN(), 1}}, kind: InvalidCoordinate},
+		{name: "line too few points", geom: LineString{{1, 1}, {1, 1}}, kind: InvalidTooFewPoints, location: Point{1, 1}},
+		{name: "ring not closed", geom: Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}, kind: InvalidRingNotClosed, location: Point{0, 0}},
+		{name: "ring too few points", geom: Polygon{{{0, 0}, {10, 0}, {10, 0}, {0, 0}}}, kind: InvalidTooFewPoints, location: Point{0, 0}},
+		{name: "bow tie", geom: Polygon{{{0, 0}, {10, 10}, {10, 0}, {0, 10}, {0, 0}}}, kind: InvalidSelfIntersection, location: Point{5, 5}},
+		{name: "ring self touching",
+			geom: Polygon{{{0, 0}, {10, 0}, {10, 10}, {5, 0}, {0, 10}, {0, 0}}}, kind: InvalidRingSelfIntersection, location: Point{5, 0}},
+		{name: "hole crossing shell",
+			geom: Polygon{square, {{5, 5}, {5, 15}, {15, 15}, {15, 5}, {5, 5}}}, kind: InvalidSelfIntersection},
+		{name: "hole outside shell",
+			geom: Polygon{square, {{20, 20}, {20, 30}, {30, 30}, {30, 20}, {20, 20}}}, kind: InvalidHoleOutsideShell, location: Point{20, 20}},
+		{name: "nested holes",
+			geom: Polygon{square, {{1, 1}, {1, 9}, {9, 9}, {9, 1}, {1, 1}}, {{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}}},
+			kind: InvalidNestedHoles, location: Point{2, 2}},
+		{name: "hole touching shell", geom: Polygon{square, {{0, 0}, {5, 2}, {5, 8}, {0, 0}}}, kind: Valid},
+		{name: "disconnected interior",
+			geom: Polygon{square, {{0, 5}, {5, 0}, {10, 5}, {5, 10}, {0, 5}}}, kind: InvalidDisconnectedInterior},
+		{name: "nested shells",
+			geom: MultiPolygon{{square}, {{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}}}}, kind: InvalidNestedShells, location: Point{2, 2}},
+		{name: "shell in hole",
+			geom: MultiPolygon{{square, {{1, 1}, {1, 9}, {9, 9}, {9, 1}, {1, 1}}}, {{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}}}}, kind: Valid},
+		{name: "overlapping shells",
+			geom: MultiPolygon{{square}, {{{5, 5}, {5, 15}, {15, 15}, {15, 5}, {5, 5}}}}, kind: InvalidSelfIntersection},
+		{name: "shells touching at a point",
+			geom: MultiPolygon{{square}, {{{10, 10}, {10, 20}, {20, 20}, {20, 10}, {10, 10}}}}, kind: Valid},
+		{name: "collection", geom: Collection{Point{1, 1}, LineString{{2, 2}, {2, 2}}}, kind: InvalidTooFewPoints},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elem := ElementValid{tt.geom}
+			got := elem.IsValidReason()
+			if got.Kind != tt.kind {
+				t.Errorf("IsValidReason() = %v, want %v", got, tt.kind)
+			}
+			if tt.location != nil && !got.Location.Equal(tt.location) {
+				t.Errorf("IsValidReason() location = %v, want %v", got.Location, tt.location)
+			}
+			if elem.IsValid() != (tt.kind == Valid) {
+				t.Errorf("IsValid() = %v, want %v", elem.IsValid(), tt.kind == Valid)
+			}
+		})
+	}
+}
+
+func TestValidReason_String(t *testing.T) {
+	tests := []struct {
+		reason ValidReason
+		want   string
+	}{
+		{reason: ValidReason{Kind: Valid}, want: "Valid Geometry"},
+		{reason: ValidReason{Kind: InvalidSelfIntersection, Location: Point{1, 1.5}}, want: "Self-intersection[1 1.5]"},
+		{reason: ValidReason{Kind: InvalidTooFewPoints}, want: "Too few points in geometry component"},
+	}
+	for _, tt := range tests {
+		if got := tt.reason.String(); got != tt.want {
+			t.Errorf("ValidReason.String() = %v, want %v", got, tt.want)
+		}
+		if got := ValidKindOf(tt.reason.Kind.String()); got != tt.reason.Kind {
+			t.Errorf("ValidKindOf() = %v, want %v", got, tt.reason.Kind)
+		}
+	}
+}
+
+func TestElementValid_MakeValid(t *testing.T) {
+	tests := []struct {
+		name string
+		geom Geometry
+		typ  string
+		area float64
+	}{
+		{name: "valid", geom: Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}, typ: TypePolygon, area: 100},
+		{name: "bow tie", geom: Polygon{{{0, 0}, {10, 10}, {10, 0}, {0, 10}, {0, 0}}}, typ: TypeMultiPolygon, area: 50},
+		{name: "ring not closed", geom: Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}, typ: TypePolygon, area: 100},
+		{name: "hole outside shell",
+			geom: Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}, {{20, 20}, {20, 30}, {30, 30}, {30, 20}, {20, 20}}},
+			typ:  TypeMultiPolygon, area: 200},
+		{name: "overlapping shells",
+			geom: MultiPolygon{{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}, {{{5, 5}, {5, 15}, {15, 15}, {15, 5}, {5, 5}}}},
+			typ:  TypePolygon, area: 175},
+		{name: "collapsed line", geom: LineString{{1, 1}, {1, 1}}, typ: TypePoint},
+		{name: "collapsed ring", geom: Polygon{{{0, 0}, {10, 0}, {0, 0}}}, typ: TypePolygon},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elem := ElementValid{tt.geom}
+			got := elem.MakeValid()
+			if area, _ := got.Area(); got.GeoJSONType() != tt.typ || math.Abs(area-tt.area) > 1e-9 {
+				t.Errorf("MakeValid() = %v, want a %v of area %v", got, tt.typ, tt.area)
+			}
+			if r := (&ElementValid{got}).IsValidReason(); !r.IsValid() {
+				t.Errorf("MakeValid() = %v is not valid: %v", got, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package topojson
+
+import (
+	"errors"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+// ErrNoObject is returned when a topology has no object of a name.
+var ErrNoObject = errors.New("topojson: no object")
+
+// ToGeoJSON converts an object of the topology to features: a feature for each member of a geometry collection,
+// or else a feature of the geometry of the object.
+func (t *Topology) ToGeoJSON(name string) (*geojson.FeatureCollection, error) {
+	object, ok := t.Objects[name]
+	if !ok || object == nil {
+		return nil, ErrNoObject
+	}
+	d := decoder{transform: t.Transform, arcs: t.decodeArcs()}
+
+	members := []*Geometry{object}
+	if object.Type == TypeGeometryCollection {
+		members = object.Geometries
+	}
+	features := geojson.NewFeatureCollection()
+	for _, member := range members {
+		if member == nil {
+			return nil, ErrInvalidTopology
+		}
+		geom, err := d.geometry(member)
+		if err != nil {
+			return nil, err
+		}
+		feature := geojson.NewFeature(geojson.Geometry{})
+		if geom != nil {
+			feature.Geometry = *geojson.NewGeometry(geom)
+		}
+		feature.ID = member.ID
+		if member.Properties != nil {
+			feature.Properties = member.Properties
+		}
+		features.Append(feature)
+	}
+	return features, nil
+}
+
+// decodeArcs returns the positions of the arcs, decoded if they are quantized.
+func (t *Topology) decodeArcs() [][][]float64 {
+	arcs := make([][][]float64, len(t.Arcs))
+	for i, arc := range t.Arcs {
+		positions := make([][]float64, len(arc))
+		x, y := 0.0, 0.0
+		for j, p := range arc {
+			if len(p) < 2 {
+				continue
+			}
+			if t.Transform == nil {
+				positions[j] = []float64{p[0], p[1]}
+				continue
+			}
+			x, y = x+p[0], y+p[1]
+			positions[j] = t.Transform.apply([]float64{x, y})
+		}
+		arcs[i] = positions
+	}
+	return arcs
+}
+
+// apply returns the position of a quantized position.
+func (t *Transform) apply(p []float64) []float64 {
+	return []float64{p[0]*t.Scale[0] + t.Translate[0], p[1]*t.Scale[1] + t.Translate[1]}
+}
+
+// decoder converts the geometries of a topology to geometries.
+type decoder struct {
+	transform *Transform
+	arcs      [][][]float64
+}
+
+// geometry returns the geometry of a geometry of the topology, nil if it is null.
+func (d decoder) geometry(g *Geometry) (space.Geometry, error) {
+	switch g.Type {
+	case "":
+		return nil, nil
+	case TypePoint:
+		return d.point(g.Point)
+	case TypeMultiPoint:
+		mp := make(space.MultiPoint, len(g.MultiPoint))
+		for i, p := range g.MultiPoint {
+			point, err := d.point(p)
+			if err != nil {
+				return nil, err
+			}
+			mp[i] = point
+		}
+		return mp, nil
+	case TypeLineString:
+		return d.line(g.LineString)
+	case TypeMultiLineString:
+		mls := make(space.MultiLineString, len(g.MultiLineString))
+		for i, arcs := range g.MultiLineString {
+			ls, err := d.line(arcs)
+			if err != nil {
+				return nil, err
+			}
+			mls[i] = ls
+		}
+		return mls, nil
+	case TypePolygon:
+		return d.polygon(g.Polygon)
+	case TypeMultiPolygon:
+		mp := make(space.MultiPolygon, len(g.MultiPolygon))
+		for i, rings := range g.MultiPolygon {
+			polygon, err := d.polygon(rings)
+			if err != nil {
+				return nil, err
+			}
+			mp[i] = polygon
+		}
+		return mp, nil
+	case TypeGeometryCollection:
+		collection := make(space.Collection, 0, len(g.Geometries))
+		for _, member := range g.Geometries {
+			if member == nil {
+				return nil, ErrInvalidTopology
+			}
+			geom, err := d.geometry(member)
+			if err != nil {
+				return nil, err
+			}
+			if geom != nil {
+				collection = append(collection, geom)
+			}
+		}
+		return collection, nil
+	}
+	return nil, ErrInvalidTopology
+}
+
+func (d decoder) point(p []float64) (space.Point, error) {
+	if len(p) < 2 {
+		return nil, ErrInvalidTopology
+	}
+	if d.transform != nil {
+		return d.transform.apply(p), nil
+	}
+	return space.Point{p[0], p[1]}, nil
+}
+
+// line returns the line of the arcs, each arc starting at the last position of the previous one.
+func (d decoder) line(arcs []int) (space.LineString, error) {
+	ls := space.LineString{}
+	for _, i := range arcs {
+		reversed := i < 0
+		if reversed {
+			i = ^i
+		}
+		if i >= len(d.arcs) {
+			return nil, ErrInvalidTopology
+		}
+		arc := d.arcs[i]
+		for j := range arc {
+			p := arc[j]
+			if reversed {
+				p = arc[len(arc)-1-j]
+			}
+			if p == nil {
+				return nil, ErrInvalidTopology
+			}
+			if j > 0 || len(ls) == 0 {
+				ls = append(ls, p)
+			}
+		}
+	}
+	return ls, nil
+}
+
+func (d decoder) polygon(rings [][]int) (space.Polygon, error) {
+	polygon := make(space.Polygon, len(rings))
+	for i, arcs := range rings {
+		ring, err := d.line(arcs)
+		if err != nil {
+			return nil, err
+		}
+		polygon[i] = ring
+	}
+	return polygon, nil
+}
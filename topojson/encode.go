@@ -0,0 +1,356 @@
+package topojson
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+// Options an options of converting features to a topology
+type Options struct {
+	// Quantization is the number of positions on each axis of the grid to which the positions are quantized,
+	// like 1e4 or 1e6. The arcs of a quantized topology are delta-encoded.
+	// Positions are not quantized if it is less than 2.
+	Quantization int
+}
+
+// point is a position of a topology, in two dimensions.
+type point [2]float64
+
+// NewTopology converts the feature collections to a topology, each of them being the geometry collection
+// of the object of the name it is given. The lines and the rings of the geometries are cut into arcs
+// at the junctions where they meet, and the arcs shared by several geometries are stored once.
+func NewTopology(objects map[string]*geojson.FeatureCollection, options Options) *Topology {
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	geoms := map[string][]space.Geometry{}
+	var bound space.Bound
+	hasBound := false
+	for _, name := range names {
+		for _, feature := range objects[name].Features {
+			var geom space.Geometry
+			if feature.Geometry.Coordinates != nil || feature.Geometry.Geometries != nil {
+				geom = feature.Geometry.Geometry()
+			}
+			geoms[name] = append(geoms[name], geom)
+			if geom == nil || geom.IsEmpty() {
+				continue
+			}
+			if !hasBound {
+				bound, hasBound = geom.Bound(), true
+			} else {
+				bound = bound.Union(geom.Bound())
+			}
+		}
+	}
+
+	topology := &Topology{Type: TypeTopology, Objects: map[string]*Geometry{}}
+	b := &builder{quantize: func(p []float64) point { return point{p[0], p[1]} }}
+	if hasBound {
+		topology.BBox = []float64{bound.Min.X(), bound.Min.Y(), bound.Max.X(), bound.Max.Y()}
+		if options.Quantization > 1 {
+			topology.Transform = newTransform(bound, options.Quantization)
+			b.quantize = topology.Transform.quantize
+		}
+	}
+
+	for _, name := range names {
+		for _, geom := range geoms[name] {
+			b.collect(geom)
+		}
+	}
+	b.join()
+	b.cut()
+	for _, name := range names {
+		collection := &Geometry{Type: TypeGeometryCollection, Geometries: []*Geometry{}}
+		for i, feature := range objects[name].Features {
+			g := b.build(geoms[name][i])
+			g.ID, g.Properties = feature.ID, feature.Properties
+			collection.Geometries = append(collection.Geometries, g)
+		}
+		topology.Objects[name] = collection
+	}
+	topology.Arcs = b.encodeArcs(topology.Transform != nil)
+	return topology
+}
+
+// newTransform returns the transform of the positions in the bound to a grid of n positions on each axis.
+func newTransform(bound space.Bound, n int) *Transform {
+	scale := func(min, max float64) float64 {
+		if max > min {
+			return (max - min) / float64(n-1)
+		}
+		return 1
+	}
+	return &Transform{
+		Scale:     [2]float64{scale(bound.Min.X(), bound.Max.X()), scale(bound.Min.Y(), bound.Max.Y())},
+		Translate: [2]float64{bound.Min.X(), bound.Min.Y()},
+	}
+}
+
+// quantize returns the position of the grid of the transform nearest to p.
+func (t *Transform) quantize(p []float64) point {
+	return point{
+		math.Round((p[0] - t.Translate[0]) / t.Scale[0]),
+		math.Round((p[1] - t.Translate[1]) / t.Scale[1]),
+	}
+}
+
+// line is a line or a ring of the geometries of a topology, and the arcs it is cut into.
+type line struct {
+	points []point
+	ring   bool
+	arcs   []int
+}
+
+// builder builds the arcs of a topology from the lines and the rings of its geometries.
+type builder struct {
+	quantize  func(p []float64) point
+	lines     []*line
+	junctions map[point]bool
+	arcs      [][]point
+	cursor    int
+}
+
+// collect adds the lines and the rings of a geometry.
+func (b *builder) collect(geom space.Geometry) {
+	switch g := geom.(type) {
+	case space.LineString:
+		b.add(g, false)
+	case space.MultiLineString:
+		for _, ls := range g {
+			b.add(ls, false)
+		}
+	case space.Polygon:
+		for _, ring := range g {
+			b.add(ring, true)
+		}
+	case space.MultiPolygon:
+		for _, polygon := range g {
+			b.collect(polygon)
+		}
+	case space.Collection:
+		for _, member := range g {
+			b.collect(member)
+		}
+	}
+}
+
+// add adds a line or a ring, quantized and without repeated points.
+// A degenerated line is kept with two points, so that it is a valid arc.
+func (b *builder) add(positions [][]float64, ring bool) {
+	l := &line{ring: ring, arcs: []int{}}
+	for _, p := range positions {
+		q := b.quantize(p)
+		if len(l.points) == 0 || l.points[len(l.points)-1] != q {
+			l.points = append(l.points, q)
+		}
+	}
+	if ring && len(l.points) > 1 && l.points[0] != l.points[len(l.points)-1] {
+		l.points = append(l.points, l.points[0])
+	}
+	if len(l.points) == 1 {
+		l.points = append(l.points, l.points[0])
+	}
+	b.lines = append(b.lines, l)
+}
+
+// join finds the junctions of the lines: their ends, and the points where lines meet with different neighbours.
+func (b *builder) join() {
+	b.junctions = map[point]bool{}
+	neighbours := map[point][2]point{}
+	visit := func(p, prev, next point) {
+		n, ok := neighbours[p]
+		if !ok {
+			neighbours[p] = [2]point{prev, next}
+		} else if n != [2]point{prev, next} && n != [2]point{next, prev} {
+			b.junctions[p] = true
+		}
+	}
+	for _, l := range b.lines {
+		points := l.points
+		if len(points) == 0 {
+			continue
+		}
+		if l.ring {
+			// the points of a ring are visited cyclically, without its closing point.
+			m := len(points) - 1
+			for i := 0; i < m; i++ {
+				visit(points[i], points[(i+m-1)%m], points[(i+1)%m])
+			}
+			continue
+		}
+		b.junctions[points[0]] = true
+		b.junctions[points[len(points)-1]] = true
+		for i := 1; i+1 < len(points); i++ {
+			visit(points[i], points[i-1], points[i+1])
+		}
+	}
+}
+
+// cut cuts the lines into arcs at their junctions, and stores each arc once, or refers to it reversed.
+func (b *builder) cut() {
+	index := map[string]int{}
+	for _, l := range b.lines {
+		points := l.points
+		closed := false
+		if l.ring && len(points) > 2 {
+			points, closed = b.rotateRing(points)
+		}
+		start := 0
+		for i := 1; i < len(points); i++ {
+			if i == len(points)-1 || b.junctions[points[i]] {
+				l.arcs = append(l.arcs, b.arcIndex(points[start:i+1], closed, index))
+				start = i
+			}
+		}
+	}
+}
+
+// rotateRing returns the ring starting at its first junction, and else at its least point,
+// and true if it has no junction, so that a ring is the same arc whatever point it starts at.
+func (b *builder) rotateRing(ring []point) ([]point, bool) {
+	m := len(ring) - 1
+	start, closed := -1, false
+	for i := 0; i < m; i++ {
+		if b.junctions[ring[i]] {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		start, closed = leastPoint(ring[:m]), true
+	}
+	rotated := make([]point, 0, len(ring))
+	rotated = append(rotated, ring[start:m]...)
+	rotated = append(rotated, ring[:start+1]...)
+	return rotated, closed
+}
+
+// arcIndex returns the index of an arc, or the one's complement of the index of the arc reversed.
+func (b *builder) arcIndex(arc []point, closed bool, index map[string]int) int {
+	if i, ok := index[arcKey(arc)]; ok {
+		return i
+	}
+	reversed := make([]point, len(arc))
+	for i, p := range arc {
+		reversed[len(arc)-1-i] = p
+	}
+	if closed {
+		reversed, _ = b.rotateRing(reversed)
+	}
+	if i, ok := index[arcKey(reversed)]; ok {
+		return ^i
+	}
+	index[arcKey(arc)] = len(b.arcs)
+	b.arcs = append(b.arcs, arc)
+	return len(b.arcs) - 1
+}
+
+// leastPoint returns the index of the least point in the order of x, then y.
+func leastPoint(points []point) int {
+	least := 0
+	for i, p := range points {
+		if p[0] < points[least][0] || (p[0] == points[least][0] && p[1] < points[least][1]) {
+			least = i
+		}
+	}
+	return least
+}
+
+func arcKey(arc []point) string {
+	var sb strings.Builder
+	buf := make([]byte, 0, 24)
+	for _, p := range arc {
+		sb.Write(strconv.AppendFloat(buf, p[0], 'g', -1, 64))
+		sb.WriteByte(',')
+		sb.Write(strconv.AppendFloat(buf, p[1], 'g', -1, 64))
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
+
+// next returns the arcs of the next line, in the order they were collected.
+func (b *builder) next() []int {
+	l := b.lines[b.cursor]
+	b.cursor++
+	return l.arcs
+}
+
+// build returns the geometry of the topology of a geometry, the lines of which are the next ones collected.
+func (b *builder) build(geom space.Geometry) *Geometry {
+	switch g := geom.(type) {
+	case space.Point:
+		if g.IsEmpty() {
+			return &Geometry{}
+		}
+		p := b.quantize(g)
+		return &Geometry{Type: TypePoint, Point: p[:]}
+	case space.MultiPoint:
+		mp := make([][]float64, len(g))
+		for i, p := range g {
+			q := b.quantize(p)
+			mp[i] = q[:]
+		}
+		return &Geometry{Type: TypeMultiPoint, MultiPoint: mp}
+	case space.LineString:
+		return &Geometry{Type: TypeLineString, LineString: b.next()}
+	case space.MultiLineString:
+		mls := make([][]int, len(g))
+		for i := range g {
+			mls[i] = b.next()
+		}
+		return &Geometry{Type: TypeMultiLineString, MultiLineString: mls}
+	case space.Polygon:
+		return &Geometry{Type: TypePolygon, Polygon: b.polygon(len(g))}
+	case space.MultiPolygon:
+		mp := make([][][]int, len(g))
+		for i, polygon := range g {
+			mp[i] = b.polygon(len(polygon))
+		}
+		return &Geometry{Type: TypeMultiPolygon, MultiPolygon: mp}
+	case space.Collection:
+		collection := &Geometry{Type: TypeGeometryCollection, Geometries: make([]*Geometry, len(g))}
+		for i, member := range g {
+			collection.Geometries[i] = b.build(member)
+		}
+		return collection
+	}
+	return &Geometry{}
+}
+
+// polygon returns the arcs of the next rings of a polygon.
+func (b *builder) polygon(rings int) [][]int {
+	polygon := make([][]int, rings)
+	for i := range polygon {
+		polygon[i] = b.next()
+	}
+	return polygon
+}
+
+// encodeArcs returns the positions of the arcs, delta-encoded if they are quantized.
+func (b *builder) encodeArcs(delta bool) [][][]float64 {
+	arcs := make([][][]float64, len(b.arcs))
+	for i, arc := range b.arcs {
+		positions := make([][]float64, len(arc))
+		prev := point{}
+		for j, p := range arc {
+			if delta {
+				positions[j] = []float64{p[0] - prev[0], p[1] - prev[1]}
+				prev = p
+			} else {
+				positions[j] = []float64{p[0], p[1]}
+			}
+		}
+		arcs[i] = positions
+	}
+	return arcs
+}
@@ -0,0 +1,150 @@
+/*
+Package topojson is a library for converting GeoJSON features to TopoJSON topologies, and back.
+
+A topology stores the lines and the rings of the geometries as arcs, which are shared by the geometries
+where they are adjacent, like the borders of neighbouring administrative areas. Its coordinates may be
+quantized to a grid and delta-encoded, to make it smaller.
+See the specification at https://github.com/topojson/topojson-specification
+*/
+package topojson
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/spatial-go/geoos/geojson"
+)
+
+// TypeTopology is the type of a topology.
+const TypeTopology = "Topology"
+
+// Types of the geometries of a topology.
+const (
+	TypePoint              = "Point"
+	TypeMultiPoint         = "MultiPoint"
+	TypeLineString         = "LineString"
+	TypeMultiLineString    = "MultiLineString"
+	TypePolygon            = "Polygon"
+	TypeMultiPolygon       = "MultiPolygon"
+	TypeGeometryCollection = "GeometryCollection"
+)
+
+// ErrInvalidTopology is returned when a topology is not valid, like when a geometry refers to an arc it has not.
+var ErrInvalidTopology = errors.New("topojson: invalid topology")
+
+// Topology a TopoJSON topology
+type Topology struct {
+	Type      string               `json:"type"`
+	BBox      []float64            `json:"bbox,omitempty"`
+	Transform *Transform           `json:"transform,omitempty"`
+	Objects   map[string]*Geometry `json:"objects"`
+	// Arcs are the arcs of the topology, their positions quantized and delta-encoded if it has a transform.
+	Arcs [][][]float64 `json:"arcs"`
+}
+
+// Transform the transform of the quantized positions of a topology
+type Transform struct {
+	Scale     [2]float64 `json:"scale"`
+	Translate [2]float64 `json:"translate"`
+}
+
+// Geometry a geometry of a topology. Its arcs are indexes of the arcs of the topology,
+// the one's complement ~i of an index referring to the arc i reversed.
+// The type of a null geometry is empty.
+type Geometry struct {
+	Type       string
+	ID         interface{}
+	Properties geojson.Properties
+
+	Point           []float64
+	MultiPoint      [][]float64
+	LineString      []int
+	MultiLineString [][]int
+	Polygon         [][]int
+	MultiPolygon    [][][]int
+	Geometries      []*Geometry
+}
+
+// jsonGeometry is the JSON of a geometry of a topology.
+type jsonGeometry struct {
+	Type        *string            `json:"type"`
+	ID          interface{}        `json:"id,omitempty"`
+	Properties  geojson.Properties `json:"properties,omitempty"`
+	Coordinates interface{}        `json:"coordinates,omitempty"`
+	Arcs        interface{}        `json:"arcs,omitempty"`
+	Geometries  []*Geometry        `json:"geometries,omitempty"`
+}
+
+// MarshalJSON converts the geometry into its JSON, with coordinates for points and arcs for other geometries.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	jg := jsonGeometry{ID: g.ID, Properties: g.Properties}
+	if g.Type != "" {
+		jg.Type = &g.Type
+	}
+	switch g.Type {
+	case TypePoint:
+		jg.Coordinates = g.Point
+	case TypeMultiPoint:
+		jg.Coordinates = g.MultiPoint
+	case TypeLineString:
+		jg.Arcs = g.LineString
+	case TypeMultiLineString:
+		jg.Arcs = g.MultiLineString
+	case TypePolygon:
+		jg.Arcs = g.Polygon
+	case TypeMultiPolygon:
+		jg.Arcs = g.MultiPolygon
+	case TypeGeometryCollection:
+		jg.Geometries = g.Geometries
+		if jg.Geometries == nil {
+			jg.Geometries = []*Geometry{}
+		}
+	}
+	return json.Marshal(jg)
+}
+
+// UnmarshalJSON decodes the JSON of a geometry.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var jg struct {
+		Type        *string            `json:"type"`
+		ID          interface{}        `json:"id"`
+		Properties  geojson.Properties `json:"properties"`
+		Coordinates json.RawMessage    `json:"coordinates"`
+		Arcs        json.RawMessage    `json:"arcs"`
+		Geometries  []*Geometry        `json:"geometries"`
+	}
+	if err := json.Unmarshal(data, &jg); err != nil {
+		return err
+	}
+	*g = Geometry{ID: jg.ID, Properties: jg.Properties}
+	if jg.Type == nil {
+		return nil
+	}
+	g.Type = *jg.Type
+
+	var target interface{}
+	raw := jg.Arcs
+	switch g.Type {
+	case TypePoint:
+		target, raw = &g.Point, jg.Coordinates
+	case TypeMultiPoint:
+		target, raw = &g.MultiPoint, jg.Coordinates
+	case TypeLineString:
+		target = &g.LineString
+	case TypeMultiLineString:
+		target = &g.MultiLineString
+	case TypePolygon:
+		target = &g.Polygon
+	case TypeMultiPolygon:
+		target = &g.MultiPolygon
+	case TypeGeometryCollection:
+		g.Geometries = jg.Geometries
+		return nil
+	default:
+		return ErrInvalidTopology
+	}
+	if len(raw) == 0 {
+		return ErrInvalidTopology
+	}
+	return json.Unmarshal(raw, target)
+}
@@ -0,0 +1,219 @@
+package topojson
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/spatial-go/geoos/geojson"
+	"github.com/spatial-go/geoos/space"
+)
+
+func newCollection(geoms ...space.Geometry) *geojson.FeatureCollection {
+	fc := geojson.NewFeatureCollection()
+	for i, geom := range geoms {
+		feature := geojson.NewFeature(geojson.Geometry{})
+		if geom != nil {
+			feature.Geometry = *geojson.NewGeometry(geom)
+		}
+		feature.ID = float64(i)
+		feature.Properties = geojson.Properties{"name": string(rune('a' + i))}
+		fc.Append(feature)
+	}
+	return fc
+}
+
+// sameRing returns true if the rings have the same points from any starting point.
+func sameRing(a, b [][]float64) bool {
+	if len(a) != len(b) || len(a) == 0 {
+		return len(a) == len(b)
+	}
+	m := len(a) - 1
+	for start := 0; start < m; start++ {
+		same := true
+		for i := 0; i < m && same; i++ {
+			same = space.Point(a[i]).Equal(space.Point(b[(start+i)%m]))
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNewTopology_SharedArcs(t *testing.T) {
+	left := space.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}
+	right := space.Polygon{{{1, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 0}}}
+	island := space.Polygon{{{5, 5}, {6, 5}, {6, 6}, {5, 6}, {5, 5}}}
+	// the same ring as the island, from another point.
+	lake := space.MultiPolygon{
+		{{{4, 4}, {7, 4}, {7, 7}, {4, 7}, {4, 4}}, {{6, 6}, {5, 6}, {5, 5}, {6, 5}, {6, 6}}},
+	}
+	topology := NewTopology(map[string]*geojson.FeatureCollection{
+		"areas": newCollection(left, right, island, lake, nil, space.Point{3, 3}),
+	}, Options{})
+
+	// the edge between left and right is shared, and so is the ring of the island.
+	if len(topology.Arcs) != 5 {
+		t.Errorf("NewTopology() got %d arcs, want 5: %v", len(topology.Arcs), topology.Arcs)
+	}
+	areas := topology.Objects["areas"].Geometries
+	shared := 0
+	for _, arc := range areas[0].Polygon[0] {
+		for _, other := range areas[1].Polygon[0] {
+			if other == ^arc {
+				shared++
+			}
+		}
+	}
+	if shared != 1 {
+		t.Errorf("NewTopology() arcs got = %v and %v, want an arc shared reversed", areas[0].Polygon, areas[1].Polygon)
+	}
+	if island := areas[2].Polygon[0][0]; areas[3].MultiPolygon[0][1][0] != island {
+		t.Errorf("NewTopology() arcs of the lake got = %v, want the arc %d", areas[3].MultiPolygon, island)
+	}
+	if !reflect.DeepEqual(topology.BBox, []float64{0, 0, 7, 7}) {
+		t.Errorf("NewTopology() bbox got = %v", topology.BBox)
+	}
+
+	fc, err := topology.ToGeoJSON("areas")
+	if err != nil {
+		t.Fatalf("ToGeoJSON() error = %v", err)
+	}
+	if len(fc.Features) != 6 {
+		t.Fatalf("ToGeoJSON() got %d features", len(fc.Features))
+	}
+	for i, want := range []space.Polygon{left, right, island} {
+		got, ok := fc.Features[i].Geometry.Coordinates.(space.Polygon)
+		if !ok || !sameRing(got[0], want[0]) {
+			t.Errorf("feature %d got = %v, want %v", i, fc.Features[i].Geometry.Coordinates, want)
+		}
+	}
+	got, ok := fc.Features[3].Geometry.Coordinates.(space.MultiPolygon)
+	if !ok || len(got) != 1 || len(got[0]) != 2 || !sameRing(got[0][0], lake[0][0]) || !sameRing(got[0][1], lake[0][1]) {
+		t.Errorf("feature 3 got = %v, want %v", fc.Features[3].Geometry.Coordinates, lake)
+	}
+	if fc.Features[4].Geometry.Coordinates != nil || !fc.Features[5].Geometry.Coordinates.Equal(space.Point{3, 3}) {
+		t.Errorf("features 4 and 5 got = %v, %v", fc.Features[4].Geometry.Coordinates, fc.Features[5].Geometry.Coordinates)
+	}
+	if fc.Features[1].ID != 1.0 || fc.Features[1].Properties["name"] != "b" {
+		t.Errorf("feature 1 got id %v and properties %v", fc.Features[1].ID, fc.Features[1].Properties)
+	}
+	if _, err := topology.ToGeoJSON("missing"); err != ErrNoObject {
+		t.Errorf("ToGeoJSON() of a missing object error = %v", err)
+	}
+}
+
+func TestNewTopology_Lines(t *testing.T) {
+	// the lines share the segment from (1, 0) to (2, 0).
+	lines := newCollection(
+		space.LineString{{0, 0}, {1, 0}, {2, 0}, {3, 1}},
+		space.MultiLineString{{{1, 1}, {1, 0}, {2, 0}, {2, -1}}, {{9, 9}, {9, 9}}},
+	)
+	topology := NewTopology(map[string]*geojson.FeatureCollection{"lines": lines}, Options{})
+	if len(topology.Arcs) != 6 {
+		t.Errorf("NewTopology() got %d arcs, want 6: %v", len(topology.Arcs), topology.Arcs)
+	}
+	fc, err := topology.ToGeoJSON("lines")
+	if err != nil {
+		t.Fatalf("ToGeoJSON() error = %v", err)
+	}
+	for i, f := range fc.Features {
+		if !f.Geometry.Coordinates.Equal(lines.Features[i].Geometry.Coordinates) {
+			t.Errorf("feature %d got = %v, want %v", i, f.Geometry.Coordinates, lines.Features[i].Geometry.Coordinates)
+		}
+	}
+}
+
+func TestNewTopology_Quantization(t *testing.T) {
+	areas := newCollection(
+		space.Polygon{{{100, 30}, {100.5, 30}, {100.5, 30.5}, {100, 30.5}, {100, 30}}},
+		space.Polygon{{{100.5, 30}, {101, 30}, {101, 30.5}, {100.5, 30.5}, {100.5, 30}}},
+		space.Point{100.25, 30.25},
+	)
+	topology := NewTopology(map[string]*geojson.FeatureCollection{"areas": areas}, Options{Quantization: 1e4})
+	if topology.Transform == nil || topology.Transform.Translate != [2]float64{100, 30} {
+		t.Fatalf("NewTopology() transform got = %v", topology.Transform)
+	}
+	// positions are quantized, and arcs are delta-encoded from their first position.
+	if p := topology.Objects["areas"].Geometries[2].Point; !reflect.DeepEqual(p, []float64{2500, 5000}) {
+		t.Errorf("NewTopology() point got = %v", p)
+	}
+	for _, arc := range topology.Arcs {
+		for _, p := range arc[1:] {
+			if p[0] != 0 && p[1] != 0 {
+				t.Errorf("NewTopology() arc got = %v, want deltas along the axes", arc)
+			}
+		}
+	}
+
+	data, err := json.Marshal(topology)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	decoded := &Topology{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	fc, err := decoded.ToGeoJSON("areas")
+	if err != nil {
+		t.Fatalf("ToGeoJSON() error = %v", err)
+	}
+	tolerance := 1e-4
+	for i, f := range fc.Features[:2] {
+		got, want := f.Geometry.Coordinates.(space.Polygon), areas.Features[i].Geometry.Coordinates.(space.Polygon)
+		area, _ := got.Area()
+		wantArea, _ := want.Area()
+		if len(got[0]) != len(want[0]) || area-wantArea > tolerance || wantArea-area > tolerance {
+			t.Errorf("feature %d got = %v, want %v", i, got, want)
+		}
+	}
+	if p := fc.Features[2].Geometry.Coordinates.(space.Point); !p.EqualsExact(space.Point{100.25, 30.25}, tolerance) {
+		t.Errorf("feature 2 got = %v", p)
+	}
+}
+
+func TestGeometry_JSON(t *testing.T) {
+	data := `{"type":"Topology","objects":{"example":{"type":"GeometryCollection","geometries":[` +
+		`{"type":"Point","properties":{"prop0":"value0"},"coordinates":[102,0.5]},` +
+		`{"type":"LineString","properties":{"prop0":"value0"},"arcs":[0]},` +
+		`{"type":"Polygon","properties":{"prop0":"value0"},"arcs":[[-2]]},` +
+		`{"type":null}]}},` +
+		`"arcs":[[[102,0],[103,1],[104,0],[105,1]],[[100,0],[101,0],[101,1],[100,1],[100,0]]]}`
+	topology := &Topology{}
+	if err := json.Unmarshal([]byte(data), topology); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	fc, err := topology.ToGeoJSON("example")
+	if err != nil {
+		t.Fatalf("ToGeoJSON() error = %v", err)
+	}
+	wants := []space.Geometry{
+		space.Point{102, 0.5},
+		space.LineString{{102, 0}, {103, 1}, {104, 0}, {105, 1}},
+		space.Polygon{{{100, 0}, {100, 1}, {101, 1}, {101, 0}, {100, 0}}},
+	}
+	for i, want := range wants {
+		if !fc.Features[i].Geometry.Coordinates.Equal(want) {
+			t.Errorf("feature %d got = %v, want %v", i, fc.Features[i].Geometry.Coordinates, want)
+		}
+	}
+	if fc.Features[3].Geometry.Coordinates != nil {
+		t.Errorf("feature 3 got = %v, want null", fc.Features[3].Geometry.Coordinates)
+	}
+
+	encoded, err := json.Marshal(topology)
+	if err != nil || string(encoded) != data {
+		t.Errorf("Marshal() got = %s, %v, want %s", encoded, err, data)
+	}
+
+	for _, invalid := range []string{`{"type":"Polygon"}`, `{"type":"Curve","arcs":[0]}`} {
+		if err := json.Unmarshal([]byte(invalid), &Geometry{}); err != ErrInvalidTopology {
+			t.Errorf("Unmarshal(%s) error = %v", invalid, err)
+		}
+	}
+	topology.Objects["example"].Geometries[1].LineString = []int{7}
+	if _, err := topology.ToGeoJSON("example"); err != ErrInvalidTopology {
+		t.Errorf("ToGeoJSON() of a missing arc error = %v", err)
+	}
+}